@@ -0,0 +1,83 @@
+package sharedaction
+
+import (
+	"strings"
+	"time"
+
+	"github.com/SermoDigital/jose/jws"
+)
+
+// TokenInfo represents the claims of an OAuth access token.
+type TokenInfo struct {
+	Issuer    string
+	ClientID  string
+	UserName  string
+	UserID    string
+	GrantType string
+	Scopes    []string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// HasScope returns true if the token was granted the given scope.
+func (info TokenInfo) HasScope(scope string) bool {
+	for _, granted := range info.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// RemainingLifetime returns how long the token is valid for after now. It
+// returns 0 for expired tokens.
+func (info TokenInfo) RemainingLifetime(now time.Time) time.Duration {
+	if !info.ExpiresAt.After(now) {
+		return 0
+	}
+	return info.ExpiresAt.Sub(now)
+}
+
+// TokenInformation decodes the access token in the config. The token is not
+// verified or refreshed.
+func (_ Actor) TokenInformation(config Config) (TokenInfo, error) {
+	accessToken := config.AccessToken()
+	if accessToken == "" {
+		return TokenInfo{}, NotLoggedInError{
+			BinaryName: config.BinaryName(),
+		}
+	}
+
+	if fields := strings.Fields(accessToken); len(fields) == 2 && strings.EqualFold(fields[0], "bearer") {
+		accessToken = fields[1]
+	}
+
+	token, err := jws.ParseJWT([]byte(accessToken))
+	if err != nil {
+		return TokenInfo{}, err
+	}
+
+	claims := token.Claims()
+	info := TokenInfo{
+		ClientID:  stringClaim(claims.Get("client_id")),
+		UserName:  stringClaim(claims.Get("user_name")),
+		UserID:    stringClaim(claims.Get("user_id")),
+		GrantType: stringClaim(claims.Get("grant_type")),
+	}
+	info.Issuer, _ = claims.Issuer()
+	info.IssuedAt, _ = claims.IssuedAt()
+	info.ExpiresAt, _ = claims.Expiration()
+
+	if scopes, ok := claims.Get("scope").([]interface{}); ok {
+		for _, scope := range scopes {
+			info.Scopes = append(info.Scopes, stringClaim(scope))
+		}
+	}
+
+	return info, nil
+}
+
+func stringClaim(value interface{}) string {
+	str, _ := value.(string)
+	return str
+}
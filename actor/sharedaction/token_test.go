@@ -0,0 +1,102 @@
+package sharedaction_test
+
+import (
+	"time"
+
+	. "code.cloudfoundry.org/cli/actor/sharedaction"
+	"code.cloudfoundry.org/cli/actor/sharedaction/sharedactionfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TokenInformation", func() {
+	var (
+		actor      Actor
+		fakeConfig *sharedactionfakes.FakeConfig
+	)
+
+	BeforeEach(func() {
+		fakeConfig = new(sharedactionfakes.FakeConfig)
+		fakeConfig.BinaryNameReturns("faceman")
+		actor = NewActor()
+	})
+
+	Context("when the user is not logged in", func() {
+		It("returns a NotLoggedInError", func() {
+			_, err := actor.TokenInformation(fakeConfig)
+			Expect(err).To(MatchError(NotLoggedInError{BinaryName: "faceman"}))
+		})
+	})
+
+	Context("when the access token is a JWT", func() {
+		BeforeEach(func() {
+			fakeConfig.AccessTokenReturns("bearer eyJhbGciOiJSUzI1NiIsImtpZCI6ImxlZ2FjeS10b2tlbi1rZXkiLCJ0eXAiOiJKV1QifQ.eyJqdGkiOiI3YzZkMDA2MjA2OTI0NmViYWI0ZjBmZjY3NGQ3Zjk4OSIsInN1YiI6Ijk1MTliZTNlLTQ0ZDktNDBkMC1hYjlhLWY0YWNlMTFkZjE1OSIsInNjb3BlIjpbIm9wZW5pZCIsInJvdXRpbmcucm91dGVyX2dyb3Vwcy53cml0ZSIsInNjaW0ucmVhZCIsImNsb3VkX2NvbnRyb2xsZXIuYWRtaW4iLCJ1YWEudXNlciIsInJvdXRpbmcucm91dGVyX2dyb3Vwcy5yZWFkIiwiY2xvdWRfY29udHJvbGxlci5yZWFkIiwicGFzc3dvcmQud3JpdGUiLCJjbG91ZF9jb250cm9sbGVyLndyaXRlIiwiZG9wcGxlci5maXJlaG9zZSIsInNjaW0ud3JpdGUiXSwiY2xpZW50X2lkIjoiY2YiLCJjaWQiOiJjZiIsImF6cCI6ImNmIiwiZ3JhbnRfdHlwZSI6InBhc3N3b3JkIiwidXNlcl9pZCI6Ijk1MTliZTNlLTQ0ZDktNDBkMC1hYjlhLWY0YWNlMTFkZjE1OSIsIm9yaWdpbiI6InVhYSIsInVzZXJfbmFtZSI6ImFkbWluIiwiZW1haWwiOiJhZG1pbiIsImF1dGhfdGltZSI6MTQ3MzI4NDU3NywicmV2X3NpZyI6IjZiMjdkYTZjIiwiaWF0IjoxNDczMjg0NTc3LCJleHAiOjE0NzMyODUxNzcsImlzcyI6Imh0dHBzOi8vdWFhLmJvc2gtbGl0ZS5jb20vb2F1dGgvdG9rZW4iLCJ6aWQiOiJ1YWEiLCJhdWQiOlsiY2YiLCJvcGVuaWQiLCJyb3V0aW5nLnJvdXRlcl9ncm91cHMiLCJzY2ltIiwiY2xvdWRfY29udHJvbGxlciIsInVhYSIsInBhc3N3b3JkIiwiZG9wcGxlciJdfQ.OcH_w9yIKJkEcTZMThIs-qJAHk3G0JwNjG-aomVH9hKye4ciFO6IMQMLKmCBrrAQVc7ST1SZZwq7gv12Dq__6Jp-hai0a2_ADJK-Vc9YXyNZKgYTWIeVNGM1JGdHgFSrBR2Lz7IIrH9HqeN8plrKV5HzU8uI9LL4lyOCjbXJ9cM")
+		})
+
+		It("decodes the claims", func() {
+			info, err := actor.TokenInformation(fakeConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info).To(Equal(TokenInfo{
+				Issuer:    "https://uaa.bosh-lite.com/oauth/token",
+				ClientID:  "cf",
+				UserName:  "admin",
+				UserID:    "9519be3e-44d9-40d0-ab9a-f4ace11df159",
+				GrantType: "password",
+				Scopes: []string{
+					"openid",
+					"routing.router_groups.write",
+					"scim.read",
+					"cloud_controller.admin",
+					"uaa.user",
+					"routing.router_groups.read",
+					"cloud_controller.read",
+					"password.write",
+					"cloud_controller.write",
+					"doppler.firehose",
+					"scim.write",
+				},
+				IssuedAt:  time.Unix(1473284577, 0),
+				ExpiresAt: time.Unix(1473285177, 0),
+			}))
+		})
+	})
+
+	Context("when the access token is not a JWT", func() {
+		BeforeEach(func() {
+			fakeConfig.AccessTokenReturns("bearer not-a-jwt")
+		})
+
+		It("returns an error", func() {
+			_, err := actor.TokenInformation(fakeConfig)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("TokenInfo", func() {
+	var info TokenInfo
+
+	BeforeEach(func() {
+		info = TokenInfo{
+			Scopes:    []string{"openid", "cloud_controller.read"},
+			ExpiresAt: time.Unix(1000, 0),
+		}
+	})
+
+	Describe("HasScope", func() {
+		It("returns whether the scope was granted", func() {
+			Expect(info.HasScope("cloud_controller.read")).To(BeTrue())
+			Expect(info.HasScope("cloud_controller.admin")).To(BeFalse())
+		})
+	})
+
+	Describe("RemainingLifetime", func() {
+		It("returns the time until the token expires", func() {
+			Expect(info.RemainingLifetime(time.Unix(400, 0))).To(Equal(10 * time.Minute))
+		})
+
+		It("returns 0 once the token has expired", func() {
+			Expect(info.RemainingLifetime(time.Unix(1400, 0))).To(Equal(time.Duration(0)))
+		})
+	})
+})
@@ -1,6 +1,8 @@
 // Package v2action contains the business logic for the commands/v2 package
 package v2action
 
+import "code.cloudfoundry.org/cli/util/workpool"
+
 // Warnings is a list of warnings returned back from the cloud controller
 type Warnings []string
 
@@ -8,6 +10,10 @@ type Warnings []string
 type Actor struct {
 	CloudControllerClient CloudControllerClient
 	UAAClient             UAAClient
+
+	// Workers is the number of API requests made at the same time by bulk
+	// operations. It defaults to workpool.DefaultWorkers.
+	Workers int
 }
 
 // NewActor returns a new actor.
@@ -17,3 +23,10 @@ func NewActor(ccClient CloudControllerClient, uaaClient UAAClient) Actor {
 		UAAClient:             uaaClient,
 	}
 }
+
+func (actor Actor) workers() int {
+	if actor.Workers > 0 {
+		return actor.Workers
+	}
+	return workpool.DefaultWorkers
+}
@@ -243,6 +243,58 @@ var _ = Describe("Application Actions", func() {
 		})
 	})
 
+	Describe("GetApplicationsBySpace", func() {
+		Context("when the CC client returns no errors", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.GetApplicationsReturns(
+					[]ccv2.Application{
+						{
+							GUID: "some-app-guid-1",
+							Name: "some-app-1",
+						},
+						{
+							GUID: "some-app-guid-2",
+							Name: "some-app-2",
+						},
+					}, ccv2.Warnings{"applications-warning"}, nil)
+			})
+
+			It("returns the applications in the space and warnings", func() {
+				apps, warnings, err := actor.GetApplicationsBySpace("some-space-guid")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(warnings).To(ConsistOf("applications-warning"))
+				Expect(apps).To(ConsistOf(
+					Application{GUID: "some-app-guid-1", Name: "some-app-1"},
+					Application{GUID: "some-app-guid-2", Name: "some-app-2"},
+				))
+
+				Expect(fakeCloudControllerClient.GetApplicationsCallCount()).To(Equal(1))
+				Expect(fakeCloudControllerClient.GetApplicationsArgsForCall(0)).To(ConsistOf(ccv2.Query{
+					Filter:   ccv2.SpaceGUIDFilter,
+					Operator: ccv2.EqualOperator,
+					Value:    "some-space-guid",
+				}))
+			})
+		})
+
+		Context("when the CC client returns an error", func() {
+			var expectedError error
+
+			BeforeEach(func() {
+				expectedError = errors.New("some cc error")
+				fakeCloudControllerClient.GetApplicationsReturns(
+					[]ccv2.Application{}, ccv2.Warnings{"applications-warning"}, expectedError)
+			})
+
+			It("returns the error and warnings", func() {
+				apps, warnings, err := actor.GetApplicationsBySpace("some-space-guid")
+				Expect(err).To(MatchError(expectedError))
+				Expect(warnings).To(ConsistOf("applications-warning"))
+				Expect(apps).To(BeNil())
+			})
+		})
+	})
+
 	Describe("GetRouteApplications", func() {
 		Context("when the CC client returns no errors", func() {
 			BeforeEach(func() {
@@ -0,0 +1,32 @@
+package v2action
+
+import "code.cloudfoundry.org/cli/api/uaa"
+
+// Authenticate authenticates with the UAA using the given grant type and
+// stores the resulting tokens in the config. The targeted org and space are
+// cleared. When authenticating with client credentials, the client ID and
+// secret are kept in the config so the tokens can be refreshed later; the
+// config only saves the secret to a credential store.
+func (actor Actor) Authenticate(config Config, ID string, secret string, grantType uaa.GrantType) error {
+	config.UnsetOrganizationInformation()
+	config.UnsetSpaceInformation()
+	config.SetAccessToken("")
+	config.SetRefreshToken("")
+
+	accessToken, refreshToken, err := actor.UAAClient.Authenticate(ID, secret, grantType)
+	if err != nil {
+		return err
+	}
+
+	if grantType == uaa.GrantTypeClientCredentials {
+		config.SetUAAClientCredentials(ID, secret)
+		config.SetUAAGrantType(string(grantType))
+	} else {
+		config.SetUAAGrantType("")
+	}
+
+	config.SetAccessToken(accessToken)
+	config.SetRefreshToken(refreshToken)
+
+	return nil
+}
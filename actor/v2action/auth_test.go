@@ -0,0 +1,105 @@
+package v2action_test
+
+import (
+	"errors"
+
+	. "code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/actor/v2action/v2actionfakes"
+	"code.cloudfoundry.org/cli/api/uaa"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Auth Actions", func() {
+	var (
+		actor         Actor
+		fakeUAAClient *v2actionfakes.FakeUAAClient
+		fakeConfig    *v2actionfakes.FakeConfig
+	)
+
+	BeforeEach(func() {
+		fakeUAAClient = new(v2actionfakes.FakeUAAClient)
+		fakeConfig = new(v2actionfakes.FakeConfig)
+		actor = NewActor(nil, fakeUAAClient)
+	})
+
+	Describe("Authenticate", func() {
+		var (
+			grantType uaa.GrantType
+			actualErr error
+		)
+
+		JustBeforeEach(func() {
+			actualErr = actor.Authenticate(fakeConfig, "some-id", "some-secret", grantType)
+		})
+
+		Context("when the password grant succeeds", func() {
+			BeforeEach(func() {
+				grantType = uaa.GrantTypePassword
+				fakeUAAClient.AuthenticateReturns("some-access-token", "some-refresh-token", nil)
+			})
+
+			It("clears the target and stores the new tokens", func() {
+				Expect(actualErr).ToNot(HaveOccurred())
+
+				Expect(fakeConfig.UnsetOrganizationInformationCallCount()).To(Equal(1))
+				Expect(fakeConfig.UnsetSpaceInformationCallCount()).To(Equal(1))
+
+				Expect(fakeUAAClient.AuthenticateCallCount()).To(Equal(1))
+				ID, secret, passedGrantType := fakeUAAClient.AuthenticateArgsForCall(0)
+				Expect(ID).To(Equal("some-id"))
+				Expect(secret).To(Equal("some-secret"))
+				Expect(passedGrantType).To(Equal(uaa.GrantTypePassword))
+
+				Expect(fakeConfig.SetAccessTokenCallCount()).To(Equal(2))
+				Expect(fakeConfig.SetAccessTokenArgsForCall(1)).To(Equal("some-access-token"))
+				Expect(fakeConfig.SetRefreshTokenCallCount()).To(Equal(2))
+				Expect(fakeConfig.SetRefreshTokenArgsForCall(1)).To(Equal("some-refresh-token"))
+
+				Expect(fakeConfig.SetUAAClientCredentialsCallCount()).To(Equal(0))
+				Expect(fakeConfig.SetUAAGrantTypeCallCount()).To(Equal(1))
+				Expect(fakeConfig.SetUAAGrantTypeArgsForCall(0)).To(Equal(""))
+			})
+		})
+
+		Context("when the client credentials grant succeeds", func() {
+			BeforeEach(func() {
+				grantType = uaa.GrantTypeClientCredentials
+				fakeUAAClient.AuthenticateReturns("some-access-token", "", nil)
+			})
+
+			It("stores the client credentials and grant type", func() {
+				Expect(actualErr).ToNot(HaveOccurred())
+
+				Expect(fakeConfig.SetUAAClientCredentialsCallCount()).To(Equal(1))
+				client, clientSecret := fakeConfig.SetUAAClientCredentialsArgsForCall(0)
+				Expect(client).To(Equal("some-id"))
+				Expect(clientSecret).To(Equal("some-secret"))
+
+				Expect(fakeConfig.SetUAAGrantTypeCallCount()).To(Equal(1))
+				Expect(fakeConfig.SetUAAGrantTypeArgsForCall(0)).To(Equal("client_credentials"))
+
+				Expect(fakeConfig.SetAccessTokenArgsForCall(1)).To(Equal("some-access-token"))
+			})
+		})
+
+		Context("when authentication fails", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				grantType = uaa.GrantTypePassword
+				expectedErr = errors.New("some error")
+				fakeUAAClient.AuthenticateReturns("", "", expectedErr)
+			})
+
+			It("returns the error and leaves the tokens cleared", func() {
+				Expect(actualErr).To(MatchError(expectedErr))
+
+				Expect(fakeConfig.SetAccessTokenCallCount()).To(Equal(1))
+				Expect(fakeConfig.SetAccessTokenArgsForCall(0)).To(BeEmpty())
+				Expect(fakeConfig.SetRefreshTokenCallCount()).To(Equal(1))
+				Expect(fakeConfig.SetUAAGrantTypeCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
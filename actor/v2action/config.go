@@ -6,12 +6,17 @@ import "time"
 
 type Config interface {
 	PollingInterval() time.Duration
+	SetAccessToken(token string)
+	SetRefreshToken(token string)
 	SetTargetInformation(api string, apiVersion string, auth string, minCLIVersion string, doppler string, uaa string, routing string, skipSSLValidation bool)
 	SetTokenInformation(accessToken string, refreshToken string, sshOAuthClient string)
+	SetUAAClientCredentials(client string, clientSecret string)
+	SetUAAGrantType(uaaGrantType string)
 	SkipSSLValidation() bool
 	StagingTimeout() time.Duration
 	StartupTimeout() time.Duration
 	Target() string
+	UAAGrantType() string
 	UnsetOrganizationInformation()
 	UnsetSpaceInformation()
 }
@@ -61,6 +61,20 @@ func (actor Actor) GetOrganizationByName(orgName string) (Organization, Warnings
 	return Organization(orgs[0]), Warnings(warnings), nil
 }
 
+// GetOrganizations returns all organizations visible to the user.
+func (actor Actor) GetOrganizations() ([]Organization, Warnings, error) {
+	orgs, warnings, err := actor.CloudControllerClient.GetOrganizations(nil)
+	if err != nil {
+		return nil, Warnings(warnings), err
+	}
+
+	allOrgs := []Organization{}
+	for _, org := range orgs {
+		allOrgs = append(allOrgs, Organization(org))
+	}
+	return allOrgs, Warnings(warnings), nil
+}
+
 // DeleteOrganization deletes the Organization associated with the provided
 // GUID. Once the deletion request is sent, it polls the deletion job until
 // it's finished.
@@ -111,6 +111,54 @@ var _ = Describe("Org Actions", func() {
 		})
 	})
 
+	Describe("GetOrganizations", func() {
+		Context("when the CC client returns no errors", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.GetOrganizationsReturns(
+					[]ccv2.Organization{
+						{GUID: "some-org-guid-1", Name: "some-org-1"},
+						{GUID: "some-org-guid-2", Name: "some-org-2"},
+					},
+					ccv2.Warnings{"warning-1", "warning-2"},
+					nil,
+				)
+			})
+
+			It("returns all the organizations and warnings", func() {
+				orgs, warnings, err := actor.GetOrganizations()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(orgs).To(ConsistOf(
+					Organization{GUID: "some-org-guid-1", Name: "some-org-1"},
+					Organization{GUID: "some-org-guid-2", Name: "some-org-2"},
+				))
+				Expect(warnings).To(ConsistOf("warning-1", "warning-2"))
+
+				Expect(fakeCloudControllerClient.GetOrganizationsCallCount()).To(Equal(1))
+				Expect(fakeCloudControllerClient.GetOrganizationsArgsForCall(0)).To(BeNil())
+			})
+		})
+
+		Context("when the CC client returns an error", func() {
+			var expectedError error
+
+			BeforeEach(func() {
+				expectedError = errors.New("some cc error")
+				fakeCloudControllerClient.GetOrganizationsReturns(
+					[]ccv2.Organization{},
+					ccv2.Warnings{"warning-1", "warning-2"},
+					expectedError,
+				)
+			})
+
+			It("returns the error and warnings", func() {
+				orgs, warnings, err := actor.GetOrganizations()
+				Expect(err).To(MatchError(expectedError))
+				Expect(orgs).To(BeNil())
+				Expect(warnings).To(ConsistOf("warning-1", "warning-2"))
+			})
+		})
+	})
+
 	Describe("DeleteOrganization", func() {
 		var (
 			warnings     Warnings
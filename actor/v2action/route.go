@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/util/workpool"
 )
 
 // Route represents a CLI Route.
@@ -58,14 +59,25 @@ func (actor Actor) GetOrphanedRoutesBySpace(spaceGUID string) ([]Route, Warnings
 		return nil, allWarnings, err
 	}
 
-	for _, route := range routes {
-		apps, warnings, err := actor.GetRouteApplications(route.GUID, nil)
-		allWarnings = append(allWarnings, warnings...)
-		if err != nil {
-			return nil, allWarnings, err
+	appCounts := make([]int, len(routes))
+	jobs := make([]workpool.Job, len(routes))
+	for i := range routes {
+		index := i
+		jobs[index] = func() ([]string, error) {
+			apps, warnings, err := actor.GetRouteApplications(routes[index].GUID, nil)
+			appCounts[index] = len(apps)
+			return warnings, err
 		}
+	}
+
+	results := workpool.Run(actor.workers(), jobs)
+	allWarnings = append(allWarnings, results.Warnings()...)
+	if err := results.FirstError(); err != nil {
+		return nil, allWarnings, err
+	}
 
-		if len(apps) == 0 {
+	for i, route := range routes {
+		if appCounts[i] == 0 {
 			orphanedRoutes = append(orphanedRoutes, route)
 		}
 	}
@@ -111,6 +123,23 @@ func (actor Actor) DeleteRoute(routeGUID string) (Warnings, error) {
 	return Warnings(warnings), err
 }
 
+// DeleteRoutes deletes the provided routes concurrently. A failure to delete
+// one route does not stop the others from being deleted; when any fail a
+// workpool.PartialFailureError is returned whose failure indexes refer to
+// routes.
+func (actor Actor) DeleteRoutes(routes []Route) (Warnings, error) {
+	jobs := make([]workpool.Job, len(routes))
+	for i := range routes {
+		routeGUID := routes[i].GUID
+		jobs[i] = func() ([]string, error) {
+			return actor.DeleteRoute(routeGUID)
+		}
+	}
+
+	results := workpool.Run(actor.workers(), jobs)
+	return Warnings(results.Warnings()), results.Err()
+}
+
 func (actor Actor) applyDomain(ccv2Routes []ccv2.Route) ([]Route, Warnings, error) {
 	var routes []Route
 	var allWarnings Warnings
@@ -6,6 +6,7 @@ import (
 	. "code.cloudfoundry.org/cli/actor/v2action"
 	"code.cloudfoundry.org/cli/actor/v2action/v2actionfakes"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/util/workpool"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -100,17 +101,13 @@ var _ = Describe("Route Actions", func() {
 
 				Expect(fakeCloudControllerClient.GetRouteApplicationsCallCount()).To(Equal(3))
 
-				routeGUID, queries := fakeCloudControllerClient.GetRouteApplicationsArgsForCall(0)
-				Expect(routeGUID).To(Equal("orphaned-route-guid-1"))
-				Expect(queries).To(BeNil())
-
-				routeGUID, queries = fakeCloudControllerClient.GetRouteApplicationsArgsForCall(1)
-				Expect(routeGUID).To(Equal("orphaned-route-guid-2"))
-				Expect(queries).To(BeNil())
-
-				routeGUID, queries = fakeCloudControllerClient.GetRouteApplicationsArgsForCall(2)
-				Expect(routeGUID).To(Equal("not-orphaned-route-guid-3"))
-				Expect(queries).To(BeNil())
+				var routeGUIDs []string
+				for i := 0; i < 3; i++ {
+					routeGUID, queries := fakeCloudControllerClient.GetRouteApplicationsArgsForCall(i)
+					Expect(queries).To(BeNil())
+					routeGUIDs = append(routeGUIDs, routeGUID)
+				}
+				Expect(routeGUIDs).To(ConsistOf("orphaned-route-guid-1", "orphaned-route-guid-2", "not-orphaned-route-guid-3"))
 			})
 		})
 
@@ -193,6 +190,59 @@ var _ = Describe("Route Actions", func() {
 		})
 	})
 
+	Describe("DeleteRoutes", func() {
+		var routes []Route
+
+		BeforeEach(func() {
+			routes = []Route{
+				{GUID: "route-guid-1"},
+				{GUID: "route-guid-2"},
+				{GUID: "route-guid-3"},
+			}
+		})
+
+		Context("when all the routes are deleted", func() {
+			BeforeEach(func() {
+				fakeCloudControllerClient.DeleteRouteStub = func(routeGUID string) (ccv2.Warnings, error) {
+					return ccv2.Warnings{routeGUID + "-warning"}, nil
+				}
+			})
+
+			It("deletes every route and returns the warnings in order", func() {
+				warnings, err := actor.DeleteRoutes(routes)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(warnings).To(Equal(Warnings{"route-guid-1-warning", "route-guid-2-warning", "route-guid-3-warning"}))
+
+				Expect(fakeCloudControllerClient.DeleteRouteCallCount()).To(Equal(3))
+			})
+		})
+
+		Context("when some of the routes fail to delete", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("delete route error")
+				fakeCloudControllerClient.DeleteRouteStub = func(routeGUID string) (ccv2.Warnings, error) {
+					if routeGUID == "route-guid-2" {
+						return ccv2.Warnings{"delete-warning"}, expectedErr
+					}
+					return nil, nil
+				}
+			})
+
+			It("deletes the other routes and reports the failures", func() {
+				warnings, err := actor.DeleteRoutes(routes)
+				Expect(warnings).To(ConsistOf("delete-warning"))
+				Expect(err).To(Equal(workpool.PartialFailureError{
+					Total:    3,
+					Failures: []workpool.Failure{{Index: 1, Err: expectedErr}},
+				}))
+
+				Expect(fakeCloudControllerClient.DeleteRouteCallCount()).To(Equal(3))
+			})
+		})
+	})
+
 	Describe("DeleteRoute", func() {
 		Context("when the route exists", func() {
 			BeforeEach(func() {
@@ -41,3 +41,18 @@ func (actor Actor) GetServiceInstanceByNameAndSpace(name string, spaceGUID strin
 
 	return ServiceInstance(serviceInstances[0]), Warnings(warnings), nil
 }
+
+// GetServiceInstancesBySpace returns all service instances in the space,
+// including user provided service instances.
+func (actor Actor) GetServiceInstancesBySpace(spaceGUID string) ([]ServiceInstance, Warnings, error) {
+	serviceInstances, warnings, err := actor.CloudControllerClient.GetSpaceServiceInstances(spaceGUID, true, nil)
+	if err != nil {
+		return nil, Warnings(warnings), err
+	}
+
+	allServiceInstances := []ServiceInstance{}
+	for _, serviceInstance := range serviceInstances {
+		allServiceInstances = append(allServiceInstances, ServiceInstance(serviceInstance))
+	}
+	return allServiceInstances, Warnings(warnings), nil
+}
@@ -9,6 +9,18 @@ import (
 )
 
 type FakeUAAClient struct {
+	AuthenticateStub        func(ID string, secret string, grantType uaa.GrantType) (string, string, error)
+	authenticateMutex       sync.RWMutex
+	authenticateArgsForCall []struct {
+		ID        string
+		secret    string
+		grantType uaa.GrantType
+	}
+	authenticateReturns struct {
+		result1 string
+		result2 string
+		result3 error
+	}
 	NewUserStub        func(username string, password string, origin string) (uaa.User, error)
 	newUserMutex       sync.RWMutex
 	newUserArgsForCall []struct {
@@ -24,6 +36,43 @@ type FakeUAAClient struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeUAAClient) Authenticate(ID string, secret string, grantType uaa.GrantType) (string, string, error) {
+	fake.authenticateMutex.Lock()
+	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
+		ID        string
+		secret    string
+		grantType uaa.GrantType
+	}{ID, secret, grantType})
+	fake.recordInvocation("Authenticate", []interface{}{ID, secret, grantType})
+	fake.authenticateMutex.Unlock()
+	if fake.AuthenticateStub != nil {
+		return fake.AuthenticateStub(ID, secret, grantType)
+	} else {
+		return fake.authenticateReturns.result1, fake.authenticateReturns.result2, fake.authenticateReturns.result3
+	}
+}
+
+func (fake *FakeUAAClient) AuthenticateCallCount() int {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return len(fake.authenticateArgsForCall)
+}
+
+func (fake *FakeUAAClient) AuthenticateArgsForCall(i int) (string, string, uaa.GrantType) {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.authenticateArgsForCall[i].ID, fake.authenticateArgsForCall[i].secret, fake.authenticateArgsForCall[i].grantType
+}
+
+func (fake *FakeUAAClient) AuthenticateReturns(result1 string, result2 string, result3 error) {
+	fake.AuthenticateStub = nil
+	fake.authenticateReturns = struct {
+		result1 string
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeUAAClient) NewUser(username string, password string, origin string) (uaa.User, error) {
 	fake.newUserMutex.Lock()
 	fake.newUserArgsForCall = append(fake.newUserArgsForCall, struct {
@@ -63,6 +112,8 @@ func (fake *FakeUAAClient) NewUserReturns(result1 uaa.User, result2 error) {
 func (fake *FakeUAAClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
 	fake.newUserMutex.RLock()
 	defer fake.newUserMutex.RUnlock()
 	return fake.invocations
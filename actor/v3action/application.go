@@ -2,7 +2,6 @@ package v3action
 
 import (
 	"fmt"
-	"net/url"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
 )
@@ -23,9 +22,9 @@ func (e ApplicationNotFoundError) Error() string {
 // GetApplicationByNameAndSpace returns the application with the given
 // name in the given space.
 func (actor Actor) GetApplicationByNameAndSpace(appName string, spaceGUID string) (Application, Warnings, error) {
-	apps, warnings, err := actor.CloudControllerClient.GetApplications(url.Values{
-		"space_guids": []string{spaceGUID},
-		"names":       []string{appName},
+	apps, warnings, err := actor.CloudControllerClient.GetApplications([]ccv3.Query{
+		{Key: ccv3.SpaceGUIDFilter, Values: []string{spaceGUID}},
+		{Key: ccv3.NameFilter, Values: []string{appName}},
 	})
 	if err != nil {
 		return Application{}, Warnings(warnings), err
@@ -2,7 +2,6 @@ package v3action_test
 
 import (
 	"errors"
-	"net/url"
 
 	. "code.cloudfoundry.org/cli/actor/v3action"
 	"code.cloudfoundry.org/cli/actor/v3action/v3actionfakes"
@@ -48,9 +47,9 @@ var _ = Describe("Application Actions", func() {
 				Expect(warnings).To(Equal(Warnings{"some-warning"}))
 
 				Expect(fakeCloudControllerClient.GetApplicationsCallCount()).To(Equal(1))
-				expectedQuery := url.Values{
-					"names":       []string{"some-app-name"},
-					"space_guids": []string{"some-space-guid"},
+				expectedQuery := []ccv3.Query{
+					{Key: ccv3.SpaceGUIDFilter, Values: []string{"some-space-guid"}},
+					{Key: ccv3.NameFilter, Values: []string{"some-app-name"}},
 				}
 				query := fakeCloudControllerClient.GetApplicationsArgsForCall(0)
 				Expect(query).To(Equal(expectedQuery))
@@ -73,9 +72,9 @@ var _ = Describe("Application Actions", func() {
 				Expect(warnings).To(ConsistOf("some-warning"))
 				Expect(err).To(MatchError(expectedError))
 				Expect(fakeCloudControllerClient.GetApplicationsCallCount()).To(Equal(1))
-				expectedQuery := url.Values{
-					"names":       []string{"some-app-name"},
-					"space_guids": []string{"some-space-guid"},
+				expectedQuery := []ccv3.Query{
+					{Key: ccv3.SpaceGUIDFilter, Values: []string{"some-space-guid"}},
+					{Key: ccv3.NameFilter, Values: []string{"some-app-name"}},
 				}
 				query := fakeCloudControllerClient.GetApplicationsArgsForCall(0)
 				Expect(query).To(Equal(expectedQuery))
@@ -98,9 +97,9 @@ var _ = Describe("Application Actions", func() {
 			Expect(err).To(MatchError(
 				ApplicationNotFoundError{Name: "some-app-name"}))
 			Expect(fakeCloudControllerClient.GetApplicationsCallCount()).To(Equal(1))
-			expectedQuery := url.Values{
-				"names":       []string{"some-app-name"},
-				"space_guids": []string{"some-space-guid"},
+			expectedQuery := []ccv3.Query{
+				{Key: ccv3.SpaceGUIDFilter, Values: []string{"some-space-guid"}},
+				{Key: ccv3.NameFilter, Values: []string{"some-app-name"}},
 			}
 			query := fakeCloudControllerClient.GetApplicationsArgsForCall(0)
 			Expect(query).To(Equal(expectedQuery))
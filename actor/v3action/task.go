@@ -2,7 +2,6 @@ package v3action
 
 import (
 	"fmt"
-	"net/url"
 	"strconv"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
@@ -46,9 +45,9 @@ func (actor Actor) RunTask(appGUID string, command string, name string, memory u
 // GetApplicationTasks returns a list of tasks associated with the provided
 // appplication GUID.
 func (actor Actor) GetApplicationTasks(appGUID string, sortOrder SortOrder) ([]Task, Warnings, error) {
-	query := url.Values{}
+	var query []ccv3.Query
 	if sortOrder == Descending {
-		query.Add("order_by", "-created_at")
+		query = append(query, ccv3.OrderByQuery("created_at", true))
 	}
 
 	tasks, warnings, err := actor.CloudControllerClient.GetApplicationTasks(appGUID, query)
@@ -66,8 +65,8 @@ func (actor Actor) GetApplicationTasks(appGUID string, sortOrder SortOrder) ([]T
 }
 
 func (actor Actor) GetTaskBySequenceIDAndApplication(sequenceID int, appGUID string) (Task, Warnings, error) {
-	query := url.Values{
-		"sequence_ids": []string{strconv.Itoa(sequenceID)},
+	query := []ccv3.Query{
+		{Key: ccv3.SequenceIDFilter, Values: []string{strconv.Itoa(sequenceID)}},
 	}
 
 	tasks, warnings, err := actor.CloudControllerClient.GetApplicationTasks(appGUID, query)
@@ -2,7 +2,6 @@ package v3action_test
 
 import (
 	"errors"
-	"net/url"
 
 	. "code.cloudfoundry.org/cli/actor/v3action"
 	"code.cloudfoundry.org/cli/actor/v3action/v3actionfakes"
@@ -145,9 +144,7 @@ var _ = Describe("Task Actions", func() {
 					appGUID, query := fakeCloudControllerClient.GetApplicationTasksArgsForCall(0)
 					Expect(appGUID).To(Equal("some-app-guid"))
 					Expect(query).To(Equal(
-						url.Values{
-							"order_by": []string{"-created_at"},
-						},
+						[]ccv3.Query{ccv3.OrderByQuery("created_at", true)},
 					))
 				})
 			})
@@ -2,7 +2,6 @@
 package v3actionfakes
 
 import (
-	"net/url"
 	"sync"
 
 	"code.cloudfoundry.org/cli/actor/v3action"
@@ -16,21 +15,21 @@ type FakeCloudControllerClient struct {
 	cloudControllerAPIVersionReturns     struct {
 		result1 string
 	}
-	GetApplicationTasksStub        func(appGUID string, query url.Values) ([]ccv3.Task, ccv3.Warnings, error)
+	GetApplicationTasksStub        func(appGUID string, query []ccv3.Query) ([]ccv3.Task, ccv3.Warnings, error)
 	getApplicationTasksMutex       sync.RWMutex
 	getApplicationTasksArgsForCall []struct {
 		appGUID string
-		query   url.Values
+		query   []ccv3.Query
 	}
 	getApplicationTasksReturns struct {
 		result1 []ccv3.Task
 		result2 ccv3.Warnings
 		result3 error
 	}
-	GetApplicationsStub        func(query url.Values) ([]ccv3.Application, ccv3.Warnings, error)
+	GetApplicationsStub        func(query []ccv3.Query) ([]ccv3.Application, ccv3.Warnings, error)
 	getApplicationsMutex       sync.RWMutex
 	getApplicationsArgsForCall []struct {
-		query url.Values
+		query []ccv3.Query
 	}
 	getApplicationsReturns struct {
 		result1 []ccv3.Application
@@ -90,13 +89,18 @@ func (fake *FakeCloudControllerClient) CloudControllerAPIVersionReturns(result1
 	}{result1}
 }
 
-func (fake *FakeCloudControllerClient) GetApplicationTasks(appGUID string, query url.Values) ([]ccv3.Task, ccv3.Warnings, error) {
+func (fake *FakeCloudControllerClient) GetApplicationTasks(appGUID string, query []ccv3.Query) ([]ccv3.Task, ccv3.Warnings, error) {
+	var queryCopy []ccv3.Query
+	if query != nil {
+		queryCopy = make([]ccv3.Query, len(query))
+		copy(queryCopy, query)
+	}
 	fake.getApplicationTasksMutex.Lock()
 	fake.getApplicationTasksArgsForCall = append(fake.getApplicationTasksArgsForCall, struct {
 		appGUID string
-		query   url.Values
-	}{appGUID, query})
-	fake.recordInvocation("GetApplicationTasks", []interface{}{appGUID, query})
+		query   []ccv3.Query
+	}{appGUID, queryCopy})
+	fake.recordInvocation("GetApplicationTasks", []interface{}{appGUID, queryCopy})
 	fake.getApplicationTasksMutex.Unlock()
 	if fake.GetApplicationTasksStub != nil {
 		return fake.GetApplicationTasksStub(appGUID, query)
@@ -111,7 +115,7 @@ func (fake *FakeCloudControllerClient) GetApplicationTasksCallCount() int {
 	return len(fake.getApplicationTasksArgsForCall)
 }
 
-func (fake *FakeCloudControllerClient) GetApplicationTasksArgsForCall(i int) (string, url.Values) {
+func (fake *FakeCloudControllerClient) GetApplicationTasksArgsForCall(i int) (string, []ccv3.Query) {
 	fake.getApplicationTasksMutex.RLock()
 	defer fake.getApplicationTasksMutex.RUnlock()
 	return fake.getApplicationTasksArgsForCall[i].appGUID, fake.getApplicationTasksArgsForCall[i].query
@@ -126,12 +130,17 @@ func (fake *FakeCloudControllerClient) GetApplicationTasksReturns(result1 []ccv3
 	}{result1, result2, result3}
 }
 
-func (fake *FakeCloudControllerClient) GetApplications(query url.Values) ([]ccv3.Application, ccv3.Warnings, error) {
+func (fake *FakeCloudControllerClient) GetApplications(query []ccv3.Query) ([]ccv3.Application, ccv3.Warnings, error) {
+	var queryCopy []ccv3.Query
+	if query != nil {
+		queryCopy = make([]ccv3.Query, len(query))
+		copy(queryCopy, query)
+	}
 	fake.getApplicationsMutex.Lock()
 	fake.getApplicationsArgsForCall = append(fake.getApplicationsArgsForCall, struct {
-		query url.Values
-	}{query})
-	fake.recordInvocation("GetApplications", []interface{}{query})
+		query []ccv3.Query
+	}{queryCopy})
+	fake.recordInvocation("GetApplications", []interface{}{queryCopy})
 	fake.getApplicationsMutex.Unlock()
 	if fake.GetApplicationsStub != nil {
 		return fake.GetApplicationsStub(query)
@@ -146,7 +155,7 @@ func (fake *FakeCloudControllerClient) GetApplicationsCallCount() int {
 	return len(fake.getApplicationsArgsForCall)
 }
 
-func (fake *FakeCloudControllerClient) GetApplicationsArgsForCall(i int) url.Values {
+func (fake *FakeCloudControllerClient) GetApplicationsArgsForCall(i int) []ccv3.Query {
 	fake.getApplicationsMutex.RLock()
 	defer fake.getApplicationsMutex.RUnlock()
 	return fake.getApplicationsArgsForCall[i].query
@@ -2,11 +2,15 @@ package ccv2_test
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/wrapper"
+	"code.cloudfoundry.org/cli/util/httpcache"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -105,6 +109,52 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		Context("when responses are cached", func() {
+			var cacheDir string
+
+			BeforeEach(func() {
+				var err error
+				cacheDir, err = ioutil.TempDir("", "ccv2-job-cache")
+				Expect(err).ToNot(HaveOccurred())
+
+				client = NewTestClient(Config{JobPollingTimeout: time.Second})
+				client.WrapConnection(wrapper.NewHTTPCache(httpcache.New(cacheDir, server.URL(), "user:some-user-guid", time.Hour)))
+
+				for _, status := range []string{"queued", "finished"} {
+					server.AppendHandlers(
+						CombineHandlers(
+							VerifyRequest(http.MethodGet, "/v2/jobs/some-job-guid"),
+							RespondWith(http.StatusOK, fmt.Sprintf(`{
+								"metadata": {
+									"guid": "some-job-guid"
+								},
+								"entity": {
+									"guid": "some-job-guid",
+									"status": "%s"
+								}
+							}`, status)),
+						))
+				}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(cacheDir)
+			})
+
+			It("fetches the job from the server on every poll", func() {
+				_, err := client.PollJob(Job{GUID: "some-job-guid"})
+				Expect(err).ToNot(HaveOccurred())
+
+				polls := 0
+				for _, request := range server.ReceivedRequests() {
+					if request.URL.Path == "/v2/jobs/some-job-guid" {
+						polls++
+					}
+				}
+				Expect(polls).To(Equal(2))
+			})
+		})
+
 		Context("when the job starts queued and then fails", func() {
 			var jobFailureMessage string
 			BeforeEach(func() {
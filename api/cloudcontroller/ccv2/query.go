@@ -3,6 +3,8 @@ package ccv2
 import (
 	"fmt"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
 // QueryFilter is the type of filter a Query uses.
@@ -14,34 +16,111 @@ type QueryOperator string
 const (
 	// AppGUIDFilter is the name of the App GUID filter.
 	AppGUIDFilter QueryFilter = "app_guid"
+	// DomainGUIDFilter is the name of the domain GUID filter.
+	DomainGUIDFilter QueryFilter = "domain_guid"
+	// HostFilter is the name of the route host filter.
+	HostFilter QueryFilter = "host"
+	// LabelFilter is the name of the service label filter.
+	LabelFilter QueryFilter = "label"
 	// OrganizationGUIDFilter is the name of the organization GUID filter.
 	OrganizationGUIDFilter QueryFilter = "organization_guid"
+	// PathFilter is the name of the route path filter.
+	PathFilter QueryFilter = "path"
+	// PortFilter is the name of the route port filter.
+	PortFilter QueryFilter = "port"
 	// RouteGUIDFilter is the name of the route GUID filter.
 	RouteGUIDFilter QueryFilter = "route_guid"
+	// ServiceGUIDFilter is the name of the service GUID filter.
+	ServiceGUIDFilter QueryFilter = "service_guid"
 	// ServiceInstanceGUIDFilter is the name of the service instance GUID filter.
 	ServiceInstanceGUIDFilter QueryFilter = "service_instance_guid"
+	// ServicePlanGUIDFilter is the name of the service plan GUID filter.
+	ServicePlanGUIDFilter QueryFilter = "service_plan_guid"
 	// SpaceGUIDFilter is the name of the space GUID filter.
 	SpaceGUIDFilter QueryFilter = "space_guid"
+	// TimestampFilter is the name of the event timestamp filter.
+	TimestampFilter QueryFilter = "timestamp"
+	// TypeFilter is the name of the event type filter.
+	TypeFilter QueryFilter = "type"
 
 	// NameFilter is the name of the name filter.
 	NameFilter QueryFilter = "name"
+
+	// OrderByParameter is the request parameter for the field results are
+	// sorted by. It is used with ParameterOperator.
+	OrderByParameter QueryFilter = "order-by"
+	// OrderDirectionParameter is the request parameter for the sort direction,
+	// "asc" or "desc". It is used with ParameterOperator.
+	OrderDirectionParameter QueryFilter = "order-direction"
+	// ResultsPerPageParameter is the request parameter for the number of
+	// results on each page. It is used with ParameterOperator.
+	ResultsPerPageParameter QueryFilter = "results-per-page"
 )
 
 const (
 	// EqualOperator is the query equal operator.
 	EqualOperator QueryOperator = ":"
+	// GreaterThanOperator is the query greater than operator.
+	GreaterThanOperator QueryOperator = ">"
+	// GreaterThanOrEqualOperator is the query greater than or equal operator.
+	GreaterThanOrEqualOperator QueryOperator = ">="
+	// InOperator is the query operator for matching any of multiple values.
+	InOperator QueryOperator = " IN "
+	// LessThanOperator is the query less than operator.
+	LessThanOperator QueryOperator = "<"
+	// LessThanOrEqualOperator is the query less than or equal operator.
+	LessThanOrEqualOperator QueryOperator = "<="
+
+	// ParameterOperator sends a Query as a request parameter of its own, such
+	// as order-by or results-per-page, instead of as a q filter.
+	ParameterOperator QueryOperator = "="
 )
 
 // Query is a type of filter that can be passed to specific request to narrow
-// down the return set.
+// down the return set. Values is used instead of Value when it is set; the
+// values are comma separated, as expected by InOperator.
 type Query struct {
 	Filter   QueryFilter
 	Operator QueryOperator
 	Value    string
+	Values   []string
+}
+
+// InQuery returns a Query that matches any of values.
+func InQuery(filter QueryFilter, values ...string) Query {
+	return Query{Filter: filter, Operator: InOperator, Values: values}
+}
+
+// OrderByQuery returns a Query that sorts the results by filter.
+func OrderByQuery(filter QueryFilter) Query {
+	return Query{Filter: OrderByParameter, Operator: ParameterOperator, Value: string(filter)}
+}
+
+// OrderDirectionQuery returns a Query that sorts the results in descending
+// order if descending is true, and in ascending order otherwise.
+func OrderDirectionQuery(descending bool) Query {
+	direction := "asc"
+	if descending {
+		direction = "desc"
+	}
+	return Query{Filter: OrderDirectionParameter, Operator: ParameterOperator, Value: direction}
+}
+
+// ResultsPerPageQuery returns a Query that sets the number of results on each
+// page.
+func ResultsPerPageQuery(resultsPerPage int) Query {
+	return Query{Filter: ResultsPerPageParameter, Operator: ParameterOperator, Value: strconv.Itoa(resultsPerPage)}
+}
+
+func (query Query) value() string {
+	if len(query.Values) > 0 {
+		return strings.Join(query.Values, ",")
+	}
+	return query.Value
 }
 
 func (query Query) format() string {
-	return fmt.Sprintf("%s%s%s", query.Filter, query.Operator, query.Value)
+	return fmt.Sprintf("%s%s%s", query.Filter, query.Operator, query.value())
 }
 
 // FormatQueryParameters converts a Query object into a collection that
@@ -49,6 +128,10 @@ func (query Query) format() string {
 func FormatQueryParameters(queries []Query) url.Values {
 	params := url.Values{"q": []string{}}
 	for _, query := range queries {
+		if query.Operator == ParameterOperator {
+			params.Set(string(query.Filter), query.value())
+			continue
+		}
 		params["q"] = append(params["q"], query.format())
 	}
 
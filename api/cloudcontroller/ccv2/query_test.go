@@ -0,0 +1,48 @@
+package ccv2_test
+
+import (
+	"net/url"
+
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Query", func() {
+	Describe("FormatQueryParameters", func() {
+		It("formats each query as a q filter", func() {
+			params := FormatQueryParameters([]Query{
+				{Filter: NameFilter, Operator: EqualOperator, Value: "some-name"},
+				{Filter: TimestampFilter, Operator: GreaterThanOrEqualOperator, Value: "2017-01-01T00:00:00Z"},
+				{Filter: TimestampFilter, Operator: LessThanOperator, Value: "2017-02-01T00:00:00Z"},
+				InQuery(SpaceGUIDFilter, "space-guid-1", "space-guid-2"),
+			})
+
+			Expect(params).To(Equal(url.Values{
+				"q": []string{
+					"name:some-name",
+					"timestamp>=2017-01-01T00:00:00Z",
+					"timestamp<2017-02-01T00:00:00Z",
+					"space_guid IN space-guid-1,space-guid-2",
+				},
+			}))
+		})
+
+		It("formats order and paging queries as request parameters", func() {
+			params := FormatQueryParameters([]Query{
+				{Filter: TypeFilter, Operator: EqualOperator, Value: "audit.app.update"},
+				OrderByQuery(TimestampFilter),
+				OrderDirectionQuery(true),
+				ResultsPerPageQuery(50),
+			})
+
+			Expect(params).To(Equal(url.Values{
+				"q":                []string{"type:audit.app.update"},
+				"order-by":         []string{"timestamp"},
+				"order-direction":  []string{"desc"},
+				"results-per-page": []string{"50"},
+			}))
+		})
+	})
+})
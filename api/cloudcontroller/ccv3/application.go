@@ -1,8 +1,6 @@
 package ccv3
 
 import (
-	"net/url"
-
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/internal"
 )
@@ -14,10 +12,10 @@ type Application struct {
 }
 
 // GetApplications lists applications with optional filters.
-func (client *Client) GetApplications(query url.Values) ([]Application, Warnings, error) {
+func (client *Client) GetApplications(query []Query) ([]Application, Warnings, error) {
 	request, err := client.newHTTPRequest(requestOptions{
 		RequestName: internal.GetAppsRequest,
-		Query:       query,
+		Query:       FormatQueryParameters(query),
 	})
 	if err != nil {
 		return nil, nil, err
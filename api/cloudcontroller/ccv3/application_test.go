@@ -3,7 +3,6 @@ package ccv3_test
 import (
 	"fmt"
 	"net/http"
-	"net/url"
 
 	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
 	. "github.com/onsi/ginkgo"
@@ -64,9 +63,9 @@ var _ = Describe("Application", func() {
 			})
 
 			It("returns the queried applications and all warnings", func() {
-				apps, warnings, err := client.GetApplications(url.Values{
-					"space_guids": []string{"some-space-guid"},
-					"names":       []string{"some-app-name"},
+				apps, warnings, err := client.GetApplications([]Query{
+					{Key: SpaceGUIDFilter, Values: []string{"some-space-guid"}},
+					{Key: NameFilter, Values: []string{"some-app-name"}},
 				})
 				Expect(err).NotTo(HaveOccurred())
 
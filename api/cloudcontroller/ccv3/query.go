@@ -0,0 +1,114 @@
+package ccv3
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// QueryKey is the type of query that is being selected on.
+type QueryKey string
+
+const (
+	// AppGUIDFilter is a query parameter for listing objects by app GUID.
+	AppGUIDFilter QueryKey = "app_guids"
+	// GUIDFilter is a query parameter for listing objects by GUID.
+	GUIDFilter QueryKey = "guids"
+	// LabelSelectorFilter is a query parameter for listing objects by their
+	// labels. Its values are built with the Label* functions.
+	LabelSelectorFilter QueryKey = "label_selector"
+	// NameFilter is a query parameter for listing objects by name.
+	NameFilter QueryKey = "names"
+	// OrganizationGUIDFilter is a query parameter for listing objects by
+	// organization GUID.
+	OrganizationGUIDFilter QueryKey = "organization_guids"
+	// SequenceIDFilter is a query parameter for listing objects by sequence ID.
+	SequenceIDFilter QueryKey = "sequence_ids"
+	// SpaceGUIDFilter is a query parameter for listing objects by space GUID.
+	SpaceGUIDFilter QueryKey = "space_guids"
+	// StatesFilter is a query parameter for listing objects by state.
+	StatesFilter QueryKey = "states"
+
+	// OrderBy is a query parameter for sorting the returned objects.
+	OrderBy QueryKey = "order_by"
+	// PerPage is a query parameter for the number of objects on each page.
+	PerPage QueryKey = "per_page"
+)
+
+// Query is a single query parameter. A Query with multiple values matches
+// objects that match any of them, except for LabelSelectorFilter where every
+// requirement has to match.
+type Query struct {
+	Key    QueryKey
+	Values []string
+}
+
+// OrderByQuery returns a Query that sorts the returned objects by field, in
+// descending order if descending is true.
+func OrderByQuery(field string, descending bool) Query {
+	if descending {
+		field = "-" + field
+	}
+	return Query{Key: OrderBy, Values: []string{field}}
+}
+
+// PerPageQuery returns a Query that sets the number of objects on each page.
+func PerPageQuery(perPage int) Query {
+	return Query{Key: PerPage, Values: []string{strconv.Itoa(perPage)}}
+}
+
+// LabelSelectorQuery returns a Query that matches the objects whose labels
+// meet every requirement.
+func LabelSelectorQuery(requirements ...string) Query {
+	return Query{Key: LabelSelectorFilter, Values: requirements}
+}
+
+// LabelEquals is a label selector requirement for a label set to value.
+func LabelEquals(key string, value string) string {
+	return fmt.Sprintf("%s=%s", key, value)
+}
+
+// LabelNotEquals is a label selector requirement for a label that is not set
+// to value.
+func LabelNotEquals(key string, value string) string {
+	return fmt.Sprintf("%s!=%s", key, value)
+}
+
+// LabelIn is a label selector requirement for a label set to one of values.
+func LabelIn(key string, values ...string) string {
+	return fmt.Sprintf("%s in (%s)", key, strings.Join(values, ","))
+}
+
+// LabelNotIn is a label selector requirement for a label that is not set to
+// any of values.
+func LabelNotIn(key string, values ...string) string {
+	return fmt.Sprintf("%s notin (%s)", key, strings.Join(values, ","))
+}
+
+// LabelExists is a label selector requirement for a label that is set.
+func LabelExists(key string) string {
+	return key
+}
+
+// LabelNotExists is a label selector requirement for a label that is not set.
+func LabelNotExists(key string) string {
+	return "!" + key
+}
+
+// FormatQueryParameters converts a list of Query objects into a collection
+// that the request can accept. Multiple values for the same key are comma
+// separated.
+func FormatQueryParameters(queries []Query) url.Values {
+	params := url.Values{}
+	for _, query := range queries {
+		key := string(query.Key)
+		if existing := params.Get(key); existing != "" {
+			params.Set(key, existing+","+strings.Join(query.Values, ","))
+		} else {
+			params.Set(key, strings.Join(query.Values, ","))
+		}
+	}
+
+	return params
+}
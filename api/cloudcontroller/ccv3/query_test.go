@@ -0,0 +1,48 @@
+package ccv3_test
+
+import (
+	"net/url"
+
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Query", func() {
+	Describe("FormatQueryParameters", func() {
+		It("comma separates multiple values", func() {
+			params := FormatQueryParameters([]Query{
+				{Key: NameFilter, Values: []string{"app-1", "app-2"}},
+				{Key: SpaceGUIDFilter, Values: []string{"space-guid"}},
+				{Key: NameFilter, Values: []string{"app-3"}},
+			})
+
+			Expect(params).To(Equal(url.Values{
+				"names":       []string{"app-1,app-2,app-3"},
+				"space_guids": []string{"space-guid"},
+			}))
+		})
+
+		It("formats ordering, paging and label selectors", func() {
+			params := FormatQueryParameters([]Query{
+				OrderByQuery("created_at", true),
+				PerPageQuery(100),
+				LabelSelectorQuery(
+					LabelEquals("env", "prod"),
+					LabelNotEquals("tier", "frontend"),
+					LabelIn("region", "us", "eu"),
+					LabelNotIn("team", "a", "b"),
+					LabelExists("owner"),
+					LabelNotExists("deprecated"),
+				),
+			})
+
+			Expect(params).To(Equal(url.Values{
+				"order_by":       []string{"-created_at"},
+				"per_page":       []string{"100"},
+				"label_selector": []string{"env=prod,tier!=frontend,region in (us,eu),team notin (a,b),owner,!deprecated"},
+			}))
+		})
+	})
+})
@@ -1,6 +1,8 @@
 package ccv3
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
@@ -23,6 +25,14 @@ type TargetSettings struct {
 	// be used only for testing.
 	SkipSSLValidation bool
 
+	// RootCAs is the set of certificate authorities used to verify the
+	// server's certificate. If nil, the system roots are used.
+	RootCAs *x509.CertPool
+
+	// ClientCertificates are presented to servers that ask for a client
+	// certificate.
+	ClientCertificates []tls.Certificate
+
 	// URL is a fully qualified URL to the Cloud Controller API.
 	URL string
 }
@@ -33,8 +43,10 @@ func (client *Client) TargetCF(settings TargetSettings) (Warnings, error) {
 	client.cloudControllerURL = settings.URL
 
 	client.connection = cloudcontroller.NewConnection(cloudcontroller.Config{
-		DialTimeout:       settings.DialTimeout,
-		SkipSSLValidation: settings.SkipSSLValidation,
+		DialTimeout:        settings.DialTimeout,
+		SkipSSLValidation:  settings.SkipSSLValidation,
+		RootCAs:            settings.RootCAs,
+		ClientCertificates: settings.ClientCertificates,
 	})
 	client.WrapConnection(newErrorWrapper()) //Pretty Sneaky, Sis..
 
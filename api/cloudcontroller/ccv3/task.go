@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/url"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv3/internal"
@@ -69,14 +68,14 @@ func (client *Client) NewTask(appGUID string, command string, name string, memor
 }
 
 // GetApplicationTasks returns a list of tasks associated with the provided
-// application GUID. Results can be filtered by providing URL queries.
-func (client *Client) GetApplicationTasks(appGUID string, query url.Values) ([]Task, Warnings, error) {
+// application GUID. Results can be filtered by providing queries.
+func (client *Client) GetApplicationTasks(appGUID string, query []Query) ([]Task, Warnings, error) {
 	request, err := client.newHTTPRequest(requestOptions{
 		RequestName: internal.GetAppTasksRequest,
 		URIParams: internal.Params{
 			"guid": appGUID,
 		},
-		Query: query,
+		Query: FormatQueryParameters(query),
 	})
 	if err != nil {
 		return nil, nil, err
@@ -3,7 +3,6 @@ package ccv3_test
 import (
 	"fmt"
 	"net/http"
-	"net/url"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	. "code.cloudfoundry.org/cli/api/cloudcontroller/ccv3"
@@ -226,7 +225,7 @@ var _ = Describe("Task", func() {
 			})
 
 			It("returns a list of tasks associated with the application and all warnings", func() {
-				tasks, warnings, err := client.GetApplicationTasks("some-app-guid", url.Values{"per_page": []string{"2"}})
+				tasks, warnings, err := client.GetApplicationTasks("some-app-guid", []Query{PerPageQuery(2)})
 				Expect(err).ToNot(HaveOccurred())
 
 				Expect(tasks).To(ConsistOf(
@@ -22,8 +22,10 @@ type CloudControllerConnection struct {
 
 // Config is for configuring a CloudControllerConnection.
 type Config struct {
-	DialTimeout       time.Duration
-	SkipSSLValidation bool
+	DialTimeout        time.Duration
+	SkipSSLValidation  bool
+	RootCAs            *x509.CertPool
+	ClientCertificates []tls.Certificate
 }
 
 // NewConnection returns a new CloudControllerConnection with provided
@@ -32,6 +34,8 @@ func NewConnection(config Config) *CloudControllerConnection {
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: config.SkipSSLValidation,
+			RootCAs:            config.RootCAs,
+			Certificates:       config.ClientCertificates,
 		},
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
@@ -1,6 +1,7 @@
 package cloudcontroller_test
 
 import (
+	"crypto/x509"
 	"fmt"
 	"net/http"
 	"runtime"
@@ -169,6 +170,33 @@ var _ = Describe("Cloud Controller Connection", func() {
 						Expect(err).To(MatchError(UnverifiedServerError{URL: server.URL()}))
 					})
 				})
+
+				Context("when RootCAs contains the server's certificate", func() {
+					BeforeEach(func() {
+						server.AppendHandlers(
+							CombineHandlers(
+								VerifyRequest(http.MethodGet, "/v2/foo"),
+								RespondWith(http.StatusOK, "{}"),
+							),
+						)
+
+						cert, err := x509.ParseCertificate(server.HTTPTestServer.TLS.Certificates[0].Certificate[0])
+						Expect(err).ToNot(HaveOccurred())
+						rootCAs := x509.NewCertPool()
+						rootCAs.AddCert(cert)
+
+						connection = NewConnection(Config{RootCAs: rootCAs})
+					})
+
+					It("verifies the server", func() {
+						request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v2/foo", server.URL()), nil)
+						Expect(err).ToNot(HaveOccurred())
+
+						var response Response
+						err = connection.Make(request, &response)
+						Expect(err).ToNot(HaveOccurred())
+					})
+				})
 			})
 
 			Context("when the server's certificate does not match the hostname", func() {
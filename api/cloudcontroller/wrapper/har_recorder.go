@@ -0,0 +1,65 @@
+package wrapper
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+)
+
+//go:generate counterfeiter . HARRecorderOutput
+
+// HARRecorderOutput is the interface for recording requests in an HTTP
+// Archive
+type HARRecorderOutput interface {
+	Record(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte, started time.Time) error
+	HandleInternalError(err error)
+}
+
+// HARRecorder is the wrapper that records requests to and responses from the
+// Cloud Controller server in an HTTP Archive
+type HARRecorder struct {
+	connection cloudcontroller.Connection
+	output     HARRecorderOutput
+}
+
+// NewHARRecorder returns a pointer to a HARRecorder wrapper
+func NewHARRecorder(output HARRecorderOutput) *HARRecorder {
+	return &HARRecorder{
+		output: output,
+	}
+}
+
+// Wrap sets the connection on the HARRecorder and returns itself
+func (recorder *HARRecorder) Wrap(innerconnection cloudcontroller.Connection) cloudcontroller.Connection {
+	recorder.connection = innerconnection
+	return recorder
+}
+
+// Make records the request and the response once the response is received
+func (recorder *HARRecorder) Make(request *http.Request, passedResponse *cloudcontroller.Response) error {
+	var rawRequestBody []byte
+	if request.Body != nil {
+		var err error
+		rawRequestBody, err = ioutil.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return err
+		}
+		request.Body = ioutil.NopCloser(bytes.NewBuffer(rawRequestBody))
+	}
+
+	started := time.Now()
+	err := recorder.connection.Make(request, passedResponse)
+
+	if passedResponse.HTTPResponse != nil {
+		recordErr := recorder.output.Record(request, rawRequestBody, passedResponse.HTTPResponse, passedResponse.RawResponse, started)
+		if recordErr != nil {
+			recorder.output.HandleInternalError(recordErr)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,108 @@
+package wrapper_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/cloudcontrollerfakes"
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/wrapper"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/wrapper/wrapperfakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HAR Recorder", func() {
+	var (
+		fakeConnection *cloudcontrollerfakes.FakeConnection
+		fakeOutput     *wrapperfakes.FakeHARRecorderOutput
+
+		wrapper cloudcontroller.Connection
+
+		request  *http.Request
+		response *cloudcontroller.Response
+		makeErr  error
+	)
+
+	BeforeEach(func() {
+		fakeConnection = new(cloudcontrollerfakes.FakeConnection)
+		fakeOutput = new(wrapperfakes.FakeHARRecorderOutput)
+
+		wrapper = NewHARRecorder(fakeOutput).Wrap(fakeConnection)
+
+		var err error
+		request, err = http.NewRequest(http.MethodPost, "https://foo.bar.com/banana", bytes.NewBufferString("some-request-body"))
+		Expect(err).NotTo(HaveOccurred())
+
+		response = &cloudcontroller.Response{
+			RawResponse:  []byte("some-response-body"),
+			HTTPResponse: &http.Response{StatusCode: http.StatusCreated},
+		}
+	})
+
+	JustBeforeEach(func() {
+		makeErr = wrapper.Make(request, response)
+	})
+
+	It("passes the request body on to the connection", func() {
+		Expect(fakeConnection.MakeCallCount()).To(Equal(1))
+		passedRequest, _ := fakeConnection.MakeArgsForCall(0)
+		body, err := ioutil.ReadAll(passedRequest.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("some-request-body"))
+	})
+
+	It("records the request and response", func() {
+		Expect(makeErr).ToNot(HaveOccurred())
+		Expect(fakeOutput.RecordCallCount()).To(Equal(1))
+		passedRequest, requestBody, httpResponse, responseBody, started := fakeOutput.RecordArgsForCall(0)
+		Expect(passedRequest).To(Equal(request))
+		Expect(string(requestBody)).To(Equal("some-request-body"))
+		Expect(httpResponse).To(Equal(response.HTTPResponse))
+		Expect(string(responseBody)).To(Equal("some-response-body"))
+		Expect(started).To(BeTemporally("~", time.Now(), time.Second))
+	})
+
+	Context("when the connection returns an error", func() {
+		var expectedErr error
+
+		BeforeEach(func() {
+			expectedErr = errors.New("some-error")
+			fakeConnection.MakeReturns(expectedErr)
+		})
+
+		It("records the response and returns the error", func() {
+			Expect(makeErr).To(MatchError(expectedErr))
+			Expect(fakeOutput.RecordCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when there is no response", func() {
+		BeforeEach(func() {
+			response.HTTPResponse = nil
+		})
+
+		It("does not record anything", func() {
+			Expect(fakeOutput.RecordCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when recording fails", func() {
+		var recordErr error
+
+		BeforeEach(func() {
+			recordErr = errors.New("disk full")
+			fakeOutput.RecordReturns(recordErr)
+		})
+
+		It("handles the error without failing the request", func() {
+			Expect(makeErr).ToNot(HaveOccurred())
+			Expect(fakeOutput.HandleInternalErrorCallCount()).To(Equal(1))
+			Expect(fakeOutput.HandleInternalErrorArgsForCall(0)).To(MatchError(recordErr))
+		})
+	})
+})
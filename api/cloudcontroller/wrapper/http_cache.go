@@ -0,0 +1,103 @@
+package wrapper
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/util/httpcache"
+)
+
+//go:generate counterfeiter . ResponseCache
+
+// ResponseCache stores responses between CLI invocations
+type ResponseCache interface {
+	Get(url string) (httpcache.Entry, bool)
+	Put(entry httpcache.Entry) error
+	Clear() error
+	TTL() time.Duration
+}
+
+// HTTPCache is the wrapper that reuses stored responses to GET requests for
+// the lists in httpcache.Cacheable until they expire, and revalidates them
+// with conditional requests afterwards. Requests that may change resources
+// clear the cache. Failing to update the cache never fails a
+// request.
+type HTTPCache struct {
+	connection cloudcontroller.Connection
+	cache      ResponseCache
+}
+
+// NewHTTPCache returns a pointer to an HTTPCache wrapper
+func NewHTTPCache(cache ResponseCache) *HTTPCache {
+	return &HTTPCache{
+		cache: cache,
+	}
+}
+
+// Wrap sets the connection on the HTTPCache and returns itself
+func (wrapper *HTTPCache) Wrap(innerconnection cloudcontroller.Connection) cloudcontroller.Connection {
+	wrapper.connection = innerconnection
+	return wrapper
+}
+
+// Make returns the stored response if it is fresh, and otherwise makes the
+// request, conditionally if a stale response is stored.
+func (wrapper *HTTPCache) Make(request *http.Request, passedResponse *cloudcontroller.Response) error {
+	if httpcache.Invalidates(request) {
+		err := wrapper.connection.Make(request, passedResponse)
+		wrapper.cache.Clear()
+		return err
+	}
+	if !httpcache.Cacheable(request) {
+		return wrapper.connection.Make(request, passedResponse)
+	}
+
+	url := request.URL.String()
+	entry, found := wrapper.cache.Get(url)
+	if found && entry.Fresh(wrapper.cache.TTL(), time.Now()) {
+		passedResponse.HTTPResponse = entry.Response()
+		passedResponse.RawResponse = entry.Body
+		return decodeResult(passedResponse)
+	}
+	if found {
+		entry.SetConditionalHeaders(request.Header)
+	}
+
+	// The inner connection cannot decode the empty body of a 304 Not Modified
+	// response, so the result is decoded here instead.
+	innerResponse := cloudcontroller.Response{}
+	err := wrapper.connection.Make(request, &innerResponse)
+	passedResponse.HTTPResponse = innerResponse.HTTPResponse
+	passedResponse.RawResponse = innerResponse.RawResponse
+	passedResponse.Warnings = innerResponse.Warnings
+	if err != nil {
+		return err
+	}
+
+	switch innerResponse.HTTPResponse.StatusCode {
+	case http.StatusNotModified:
+		if found {
+			entry.StoredAt = time.Now()
+			wrapper.cache.Put(entry)
+			passedResponse.HTTPResponse = entry.Response()
+			passedResponse.RawResponse = entry.Body
+		}
+	case http.StatusOK:
+		wrapper.cache.Put(httpcache.NewEntry(url, innerResponse.HTTPResponse, innerResponse.RawResponse, time.Now()))
+	}
+
+	return decodeResult(passedResponse)
+}
+
+func decodeResult(passedResponse *cloudcontroller.Response) error {
+	if passedResponse.Result == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewBuffer(passedResponse.RawResponse))
+	decoder.UseNumber()
+	return decoder.Decode(passedResponse.Result)
+}
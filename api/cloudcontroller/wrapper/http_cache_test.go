@@ -0,0 +1,169 @@
+package wrapper_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/cloudcontrollerfakes"
+	. "code.cloudfoundry.org/cli/api/cloudcontroller/wrapper"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/wrapper/wrapperfakes"
+	"code.cloudfoundry.org/cli/util/httpcache"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HTTP Cache", func() {
+	type stack struct {
+		Name string `json:"name"`
+	}
+
+	var (
+		fakeConnection *cloudcontrollerfakes.FakeConnection
+		fakeCache      *wrapperfakes.FakeResponseCache
+
+		wrapper cloudcontroller.Connection
+
+		request  *http.Request
+		response *cloudcontroller.Response
+		result   stack
+		makeErr  error
+	)
+
+	respondWith := func(statusCode int, body string, header http.Header) {
+		fakeConnection.MakeStub = func(_ *http.Request, passedResponse *cloudcontroller.Response) error {
+			passedResponse.HTTPResponse = &http.Response{StatusCode: statusCode, Header: header}
+			passedResponse.RawResponse = []byte(body)
+			return nil
+		}
+	}
+
+	BeforeEach(func() {
+		fakeConnection = new(cloudcontrollerfakes.FakeConnection)
+		fakeCache = new(wrapperfakes.FakeResponseCache)
+		fakeCache.TTLReturns(time.Minute)
+
+		wrapper = NewHTTPCache(fakeCache).Wrap(fakeConnection)
+
+		var err error
+		request, err = http.NewRequest(http.MethodGet, "https://api.example.com/v2/stacks", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		result = stack{}
+		response = &cloudcontroller.Response{Result: &result}
+	})
+
+	JustBeforeEach(func() {
+		makeErr = wrapper.Make(request, response)
+	})
+
+	Context("when nothing is cached", func() {
+		BeforeEach(func() {
+			respondWith(http.StatusOK, `{"name":"cflinuxfs2"}`, http.Header{"Etag": {`"v1"`}})
+		})
+
+		It("makes the request and stores the response", func() {
+			Expect(makeErr).ToNot(HaveOccurred())
+			Expect(result.Name).To(Equal("cflinuxfs2"))
+
+			Expect(fakeCache.PutCallCount()).To(Equal(1))
+			entry := fakeCache.PutArgsForCall(0)
+			Expect(entry.URL).To(Equal("https://api.example.com/v2/stacks"))
+			Expect(string(entry.Body)).To(Equal(`{"name":"cflinuxfs2"}`))
+		})
+
+		It("does not pass the result to the inner connection", func() {
+			_, innerResponse := fakeConnection.MakeArgsForCall(0)
+			Expect(innerResponse.Result).To(BeNil())
+		})
+	})
+
+	Context("when a fresh response is cached", func() {
+		BeforeEach(func() {
+			fakeCache.GetReturns(httpcache.Entry{
+				URL:        "https://api.example.com/v2/stacks",
+				StoredAt:   time.Now(),
+				StatusCode: http.StatusOK,
+				Body:       []byte(`{"name":"cached"}`),
+			}, true)
+		})
+
+		It("returns it without making a request", func() {
+			Expect(makeErr).ToNot(HaveOccurred())
+			Expect(fakeConnection.MakeCallCount()).To(Equal(0))
+			Expect(result.Name).To(Equal("cached"))
+			Expect(response.HTTPResponse.StatusCode).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("when a stale response is cached", func() {
+		BeforeEach(func() {
+			fakeCache.GetReturns(httpcache.Entry{
+				URL:        "https://api.example.com/v2/stacks",
+				StoredAt:   time.Now().Add(-time.Hour),
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": {`"v1"`}},
+				Body:       []byte(`{"name":"cached"}`),
+			}, true)
+		})
+
+		Context("when the server responds with 304 Not Modified", func() {
+			BeforeEach(func() {
+				respondWith(http.StatusNotModified, "", http.Header{})
+			})
+
+			It("revalidates with If-None-Match and returns the cached response", func() {
+				Expect(makeErr).ToNot(HaveOccurred())
+				passedRequest, _ := fakeConnection.MakeArgsForCall(0)
+				Expect(passedRequest.Header.Get("If-None-Match")).To(Equal(`"v1"`))
+
+				Expect(result.Name).To(Equal("cached"))
+				Expect(response.HTTPResponse.StatusCode).To(Equal(http.StatusOK))
+
+				Expect(fakeCache.PutCallCount()).To(Equal(1))
+				Expect(fakeCache.PutArgsForCall(0).StoredAt).To(BeTemporally("~", time.Now(), time.Second))
+			})
+		})
+
+		Context("when the server responds with a new representation", func() {
+			BeforeEach(func() {
+				respondWith(http.StatusOK, `{"name":"updated"}`, http.Header{"Etag": {`"v2"`}})
+			})
+
+			It("returns and stores the new response", func() {
+				Expect(makeErr).ToNot(HaveOccurred())
+				Expect(result.Name).To(Equal("updated"))
+				Expect(fakeCache.PutArgsForCall(0).Header.Get("Etag")).To(Equal(`"v2"`))
+			})
+		})
+	})
+
+	Context("when the request fails", func() {
+		var expectedErr error
+
+		BeforeEach(func() {
+			expectedErr = errors.New("some-error")
+			fakeConnection.MakeReturns(expectedErr)
+		})
+
+		It("returns the error without storing anything", func() {
+			Expect(makeErr).To(MatchError(expectedErr))
+			Expect(fakeCache.PutCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the request is not a GET", func() {
+		BeforeEach(func() {
+			request.Method = http.MethodPut
+			response.Result = nil
+		})
+
+		It("makes the request and clears the cache", func() {
+			Expect(fakeConnection.MakeCallCount()).To(Equal(1))
+			Expect(fakeCache.GetCallCount()).To(Equal(0))
+			Expect(fakeCache.ClearCallCount()).To(Equal(1))
+		})
+	})
+})
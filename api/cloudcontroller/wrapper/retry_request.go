@@ -4,21 +4,25 @@ import (
 	"bytes"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
+	"code.cloudfoundry.org/cli/api/retry"
 )
 
-// RetryRequest is a wrapper that retries failed requests if they contain a 5XX
-// status code.
+// RetryRequest is a wrapper that retries failed requests according to a
+// retry.Policy, waiting between attempts.
 type RetryRequest struct {
-	maxRetries int
+	policy     retry.Policy
 	connection cloudcontroller.Connection
+	sleep      func(time.Duration)
 }
 
 // NewRetryRequest returns a pointer to a RetryRequest wrapper.
-func NewRetryRequest(maxRetries int) *RetryRequest {
+func NewRetryRequest(policy retry.Policy) *RetryRequest {
 	return &RetryRequest{
-		maxRetries: maxRetries,
+		policy: policy,
+		sleep:  time.Sleep,
 	}
 }
 
@@ -28,7 +32,7 @@ func (retry *RetryRequest) Wrap(innerconnection cloudcontroller.Connection) clou
 	return retry
 }
 
-// Make retries the request if it comes back with a 5XX status code.
+// Make retries the request if it comes back with a 5XX or 429 status code.
 func (retry *RetryRequest) Make(request *http.Request, passedResponse *cloudcontroller.Response) error {
 	var err error
 	var rawRequestBody []byte
@@ -41,7 +45,7 @@ func (retry *RetryRequest) Make(request *http.Request, passedResponse *cloudcont
 		}
 	}
 
-	for i := 0; i < retry.maxRetries+1; i += 1 {
+	for attempt := 1; ; attempt++ {
 		if rawRequestBody != nil {
 			request.Body = ioutil.NopCloser(bytes.NewBuffer(rawRequestBody))
 		}
@@ -50,14 +54,9 @@ func (retry *RetryRequest) Make(request *http.Request, passedResponse *cloudcont
 			return nil
 		}
 
-		if request.Method == http.MethodPost ||
-			passedResponse.HTTPResponse != nil &&
-				passedResponse.HTTPResponse.StatusCode != http.StatusInternalServerError &&
-				passedResponse.HTTPResponse.StatusCode != http.StatusBadGateway &&
-				passedResponse.HTTPResponse.StatusCode != http.StatusServiceUnavailable &&
-				passedResponse.HTTPResponse.StatusCode != http.StatusGatewayTimeout {
-			break
+		if !retry.policy.ShouldRetry(attempt, request.Method, passedResponse.HTTPResponse) {
+			return err
 		}
+		retry.sleep(retry.policy.Delay(attempt, passedResponse.HTTPResponse, time.Now()))
 	}
-	return err
 }
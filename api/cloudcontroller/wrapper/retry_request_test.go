@@ -8,6 +8,7 @@ import (
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/cloudcontrollerfakes"
 	. "code.cloudfoundry.org/cli/api/cloudcontroller/wrapper"
+	"code.cloudfoundry.org/cli/api/retry"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -40,7 +41,7 @@ var _ = Describe("Retry Request", func() {
 				return expectedErr
 			}
 
-			wrapper := NewRetryRequest(2).Wrap(fakeConnection)
+			wrapper := NewRetryRequest(retry.Policy{MaxRetries: 2}).Wrap(fakeConnection)
 			err = wrapper.Make(request, response)
 			Expect(err).To(MatchError(expectedErr))
 			Expect(fakeConnection.MakeCallCount()).To(Equal(expectedNumberOfRetries))
@@ -51,6 +52,9 @@ var _ = Describe("Retry Request", func() {
 		Entry("maxRetries for Non-Post (503) Service Unavailable", http.MethodGet, http.StatusServiceUnavailable, 3),
 		Entry("maxRetries for Non-Post (504) Gateway Timeout", http.MethodGet, http.StatusGatewayTimeout, 3),
 
+		Entry("maxRetries for Non-Post (429) Too Many Requests", http.MethodGet, http.StatusTooManyRequests, 3),
+		Entry("maxRetries for Post (429) Too Many Requests", http.MethodPost, http.StatusTooManyRequests, 3),
+
 		Entry("1 for Post (500) Internal Server Error", http.MethodPost, http.StatusInternalServerError, 1),
 		Entry("1 for Post (502) Bad Gateway", http.MethodPost, http.StatusBadGateway, 1),
 		Entry("1 for Post (503) Service Unavailable", http.MethodPost, http.StatusServiceUnavailable, 1),
@@ -69,7 +73,7 @@ var _ = Describe("Retry Request", func() {
 		}
 
 		fakeConnection := new(cloudcontrollerfakes.FakeConnection)
-		wrapper := NewRetryRequest(2).Wrap(fakeConnection)
+		wrapper := NewRetryRequest(retry.Policy{MaxRetries: 2}).Wrap(fakeConnection)
 
 		err = wrapper.Make(request, response)
 		Expect(err).ToNot(HaveOccurred())
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"io/ioutil"
 	"net/http"
+	"sync"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/api/uaa"
@@ -27,11 +28,13 @@ type TokenCache interface {
 }
 
 // UAAAuthentication wraps connections and adds authentication headers to all
-// requests
+// requests. It is safe to make requests from several goroutines; only one of
+// them refreshes an expired token.
 type UAAAuthentication struct {
 	connection cloudcontroller.Connection
 	client     UAAClient
 	cache      TokenCache
+	tokenLock  sync.RWMutex
 }
 
 // NewUAAAuthentication returns a pointer to a UAAAuthentication wrapper with
@@ -66,25 +69,49 @@ func (t *UAAAuthentication) Make(request *http.Request, passedResponse *cloudcon
 		request.Body = ioutil.NopCloser(bytes.NewBuffer(rawRequestBody))
 	}
 
-	request.Header.Set("Authorization", t.cache.AccessToken())
+	accessToken := t.accessToken()
+	request.Header.Set("Authorization", accessToken)
 
 	err = t.connection.Make(request, passedResponse)
 	if _, ok := err.(cloudcontroller.InvalidAuthTokenError); ok {
-		var token uaa.RefreshToken
-		token, err = t.client.RefreshAccessToken(t.cache.RefreshToken())
+		accessToken, err = t.refreshToken(accessToken)
 		if err != nil {
 			return err
 		}
 
-		t.cache.SetAccessToken(token.AuthorizationToken())
-		t.cache.SetRefreshToken(token.RefreshToken)
-
 		if rawRequestBody != nil {
 			request.Body = ioutil.NopCloser(bytes.NewBuffer(rawRequestBody))
 		}
-		request.Header.Set("Authorization", t.cache.AccessToken())
+		request.Header.Set("Authorization", accessToken)
 		err = t.connection.Make(request, passedResponse)
 	}
 
 	return err
 }
+
+func (t *UAAAuthentication) accessToken() string {
+	t.tokenLock.RLock()
+	defer t.tokenLock.RUnlock()
+	return t.cache.AccessToken()
+}
+
+// refreshToken returns a new access token to replace expiredToken. When
+// another request has already replaced it, that token is returned instead of
+// refreshing again.
+func (t *UAAAuthentication) refreshToken(expiredToken string) (string, error) {
+	t.tokenLock.Lock()
+	defer t.tokenLock.Unlock()
+
+	if accessToken := t.cache.AccessToken(); accessToken != expiredToken {
+		return accessToken, nil
+	}
+
+	token, err := t.client.RefreshAccessToken(t.cache.RefreshToken())
+	if err != nil {
+		return "", err
+	}
+
+	t.cache.SetAccessToken(token.AuthorizationToken())
+	t.cache.SetRefreshToken(token.RefreshToken)
+	return t.cache.AccessToken(), nil
+}
@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 
 	"code.cloudfoundry.org/cli/api/cloudcontroller"
 	"code.cloudfoundry.org/cli/api/cloudcontroller/cloudcontrollerfakes"
@@ -12,6 +13,7 @@ import (
 	"code.cloudfoundry.org/cli/api/cloudcontroller/wrapper/wrapperfakes"
 	"code.cloudfoundry.org/cli/api/uaa"
 	"code.cloudfoundry.org/cli/api/uaa/wrapper/util"
+	"code.cloudfoundry.org/cli/util/workpool"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -135,5 +137,54 @@ var _ = Describe("UAA Authentication", func() {
 				Expect(inMemoryCache.RefreshToken()).To(Equal("bananananananana"))
 			})
 		})
+
+		Context("when several requests are made at once with an expired token", func() {
+			var results workpool.Results
+
+			BeforeEach(func() {
+				inMemoryCache.SetAccessToken("expired")
+				inMemoryCache.SetRefreshToken("some-refresh-token")
+
+				jobs := make([]workpool.Job, 8)
+
+				// Hold back every rejected request until all of them have been
+				// rejected so that they all go on to refresh at the same time.
+				var rejected sync.WaitGroup
+				rejected.Add(len(jobs))
+				fakeConnection.MakeStub = func(request *http.Request, response *cloudcontroller.Response) error {
+					if request.Header.Get("Authorization") == "expired" {
+						rejected.Done()
+						rejected.Wait()
+						return cloudcontroller.InvalidAuthTokenError{}
+					}
+					return nil
+				}
+				fakeClient.RefreshAccessTokenReturns(
+					uaa.RefreshToken{
+						AccessToken:  "new-token",
+						RefreshToken: "new-refresh-token",
+						Type:         "bearer",
+					},
+					nil,
+				)
+
+				for i := range jobs {
+					jobs[i] = func() ([]string, error) {
+						return nil, wrapper.Make(&http.Request{Header: http.Header{}}, nil)
+					}
+				}
+				results = workpool.Run(len(jobs), jobs)
+			})
+
+			It("refreshes the token once and completes every request", func() {
+				for _, result := range results {
+					Expect(result.Err).ToNot(HaveOccurred())
+				}
+				Expect(fakeClient.RefreshAccessTokenCallCount()).To(Equal(1))
+				Expect(fakeClient.RefreshAccessTokenArgsForCall(0)).To(Equal("some-refresh-token"))
+				Expect(inMemoryCache.AccessToken()).To(Equal("bearer new-token"))
+				Expect(inMemoryCache.RefreshToken()).To(Equal("new-refresh-token"))
+			})
+		})
 	})
 })
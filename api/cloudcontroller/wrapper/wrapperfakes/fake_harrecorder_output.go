@@ -0,0 +1,127 @@
+// This file was generated by counterfeiter
+package wrapperfakes
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/cloudcontroller/wrapper"
+)
+
+type FakeHARRecorderOutput struct {
+	RecordStub        func(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte, started time.Time) error
+	recordMutex       sync.RWMutex
+	recordArgsForCall []struct {
+		request      *http.Request
+		requestBody  []byte
+		response     *http.Response
+		responseBody []byte
+		started      time.Time
+	}
+	recordReturns struct {
+		result1 error
+	}
+	HandleInternalErrorStub        func(err error)
+	handleInternalErrorMutex       sync.RWMutex
+	handleInternalErrorArgsForCall []struct {
+		err error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeHARRecorderOutput) Record(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte, started time.Time) error {
+	var requestBodyCopy []byte
+	if requestBody != nil {
+		requestBodyCopy = make([]byte, len(requestBody))
+		copy(requestBodyCopy, requestBody)
+	}
+	var responseBodyCopy []byte
+	if responseBody != nil {
+		responseBodyCopy = make([]byte, len(responseBody))
+		copy(responseBodyCopy, responseBody)
+	}
+	fake.recordMutex.Lock()
+	fake.recordArgsForCall = append(fake.recordArgsForCall, struct {
+		request      *http.Request
+		requestBody  []byte
+		response     *http.Response
+		responseBody []byte
+		started      time.Time
+	}{request, requestBodyCopy, response, responseBodyCopy, started})
+	fake.recordInvocation("Record", []interface{}{request, requestBodyCopy, response, responseBodyCopy, started})
+	fake.recordMutex.Unlock()
+	if fake.RecordStub != nil {
+		return fake.RecordStub(request, requestBody, response, responseBody, started)
+	} else {
+		return fake.recordReturns.result1
+	}
+}
+
+func (fake *FakeHARRecorderOutput) RecordCallCount() int {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return len(fake.recordArgsForCall)
+}
+
+func (fake *FakeHARRecorderOutput) RecordArgsForCall(i int) (*http.Request, []byte, *http.Response, []byte, time.Time) {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return fake.recordArgsForCall[i].request, fake.recordArgsForCall[i].requestBody, fake.recordArgsForCall[i].response, fake.recordArgsForCall[i].responseBody, fake.recordArgsForCall[i].started
+}
+
+func (fake *FakeHARRecorderOutput) RecordReturns(result1 error) {
+	fake.RecordStub = nil
+	fake.recordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHARRecorderOutput) HandleInternalError(err error) {
+	fake.handleInternalErrorMutex.Lock()
+	fake.handleInternalErrorArgsForCall = append(fake.handleInternalErrorArgsForCall, struct {
+		err error
+	}{err})
+	fake.recordInvocation("HandleInternalError", []interface{}{err})
+	fake.handleInternalErrorMutex.Unlock()
+	if fake.HandleInternalErrorStub != nil {
+		fake.HandleInternalErrorStub(err)
+	}
+}
+
+func (fake *FakeHARRecorderOutput) HandleInternalErrorCallCount() int {
+	fake.handleInternalErrorMutex.RLock()
+	defer fake.handleInternalErrorMutex.RUnlock()
+	return len(fake.handleInternalErrorArgsForCall)
+}
+
+func (fake *FakeHARRecorderOutput) HandleInternalErrorArgsForCall(i int) error {
+	fake.handleInternalErrorMutex.RLock()
+	defer fake.handleInternalErrorMutex.RUnlock()
+	return fake.handleInternalErrorArgsForCall[i].err
+}
+
+func (fake *FakeHARRecorderOutput) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	fake.handleInternalErrorMutex.RLock()
+	defer fake.handleInternalErrorMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeHARRecorderOutput) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ wrapper.HARRecorderOutput = new(FakeHARRecorderOutput)
@@ -0,0 +1,164 @@
+// Package retry decides when and how long to wait before retrying a failed
+// request to the Cloud Controller or UAA.
+package retry
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the default number of times a request is retried.
+	DefaultMaxRetries = 2
+
+	// DefaultBaseDelay is the default delay before the first retry. The delay
+	// doubles on every subsequent retry.
+	DefaultBaseDelay = 500 * time.Millisecond
+
+	// DefaultMaxDelay is the default upper bound for any single delay,
+	// including delays requested by the server.
+	DefaultMaxDelay = 30 * time.Second
+)
+
+// Policy describes how failed requests are retried.
+type Policy struct {
+	// MaxRetries is the number of times a request is retried after the
+	// initial attempt.
+	MaxRetries int
+
+	// BaseDelay is the delay before the first retry. Each retry doubles the
+	// previous delay, and a random jitter of up to half the delay is removed
+	// so that concurrent clients do not retry in lockstep.
+	BaseDelay time.Duration
+
+	// MaxDelay caps every delay, including ones requested through the
+	// Retry-After and X-RateLimit-Reset headers.
+	MaxDelay time.Duration
+}
+
+// DefaultPolicy returns a Policy with the default settings.
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxRetries: DefaultMaxRetries,
+		BaseDelay:  DefaultBaseDelay,
+		MaxDelay:   DefaultMaxDelay,
+	}
+}
+
+// ParsePolicy returns the default Policy overridden by the given settings.
+// maxRetries is a non-negative whole number; baseDelay and maxDelay are
+// durations such as "500ms" or a whole number of seconds. Empty or invalid
+// settings keep their defaults.
+func ParsePolicy(maxRetries string, baseDelay string, maxDelay string) Policy {
+	policy := DefaultPolicy()
+
+	if maxRetries != "" {
+		retries, err := strconv.Atoi(maxRetries)
+		if err == nil && retries >= 0 {
+			policy.MaxRetries = retries
+		}
+	}
+	if delay, ok := parseDelay(baseDelay); ok {
+		policy.BaseDelay = delay
+	}
+	if delay, ok := parseDelay(maxDelay); ok {
+		policy.MaxDelay = delay
+	}
+
+	return policy
+}
+
+// ShouldRetry returns true if a request that has been attempted attempt times
+// should be retried. A nil response represents a connection error.
+//
+// Rate limited (429) requests are always retried because the server did not
+// process them. Server errors (500, 502, 503 and 504) and connection errors
+// are only retried for requests other than POST, which are not idempotent.
+func (policy Policy) ShouldRetry(attempt int, method string, response *http.Response) bool {
+	if attempt > policy.MaxRetries {
+		return false
+	}
+
+	if response == nil {
+		return method != http.MethodPost
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return method != http.MethodPost
+	default:
+		return false
+	}
+}
+
+// Delay returns how long to wait before the retry that follows attempt. The
+// server's Retry-After header takes precedence, followed by
+// X-RateLimit-Reset when X-RateLimit-Remaining is 0. Otherwise the delay
+// grows exponentially from BaseDelay with jitter.
+func (policy Policy) Delay(attempt int, response *http.Response, now time.Time) time.Duration {
+	if response != nil {
+		if delay, ok := serverDelay(response.Header, now); ok {
+			return policy.capDelay(delay)
+		}
+	}
+
+	delay := policy.BaseDelay
+	for i := 1; i < attempt && delay < policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	delay = policy.capDelay(delay)
+
+	if delay > 1 {
+		delay -= time.Duration(rand.Int63n(int64(delay / 2)))
+	}
+	return delay
+}
+
+func (policy Policy) capDelay(delay time.Duration) time.Duration {
+	if delay < 0 {
+		return 0
+	}
+	if delay > policy.MaxDelay {
+		return policy.MaxDelay
+	}
+	return delay
+}
+
+func parseDelay(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if delay, err := time.ParseDuration(value); err == nil && delay >= 0 {
+		return delay, true
+	}
+	return 0, false
+}
+
+func serverDelay(header http.Header, now time.Time) (time.Duration, bool) {
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return date.Sub(now), true
+		}
+	}
+
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return time.Unix(reset, 0).Sub(now), true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,140 @@
+package retry_test
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	. "code.cloudfoundry.org/cli/api/retry"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Policy", func() {
+	var policy Policy
+
+	BeforeEach(func() {
+		policy = Policy{
+			MaxRetries: 2,
+			BaseDelay:  time.Second,
+			MaxDelay:   10 * time.Second,
+		}
+	})
+
+	Describe("ParsePolicy", func() {
+		It("returns the default policy when nothing is set", func() {
+			Expect(ParsePolicy("", "", "")).To(Equal(DefaultPolicy()))
+		})
+
+		It("overrides the defaults with valid settings", func() {
+			Expect(ParsePolicy("0", "250ms", "60")).To(Equal(Policy{
+				MaxRetries: 0,
+				BaseDelay:  250 * time.Millisecond,
+				MaxDelay:   time.Minute,
+			}))
+		})
+
+		It("ignores invalid settings", func() {
+			Expect(ParsePolicy("-1", "soon", "-5s")).To(Equal(DefaultPolicy()))
+		})
+	})
+
+	Describe("ShouldRetry", func() {
+		DescribeTable("retryable responses",
+			func(method string, statusCode int, expected bool) {
+				response := &http.Response{StatusCode: statusCode}
+				Expect(policy.ShouldRetry(1, method, response)).To(Equal(expected))
+			},
+
+			Entry("GET 429", http.MethodGet, http.StatusTooManyRequests, true),
+			Entry("POST 429", http.MethodPost, http.StatusTooManyRequests, true),
+			Entry("GET 500", http.MethodGet, http.StatusInternalServerError, true),
+			Entry("GET 502", http.MethodGet, http.StatusBadGateway, true),
+			Entry("GET 503", http.MethodGet, http.StatusServiceUnavailable, true),
+			Entry("GET 504", http.MethodGet, http.StatusGatewayTimeout, true),
+			Entry("POST 503", http.MethodPost, http.StatusServiceUnavailable, false),
+			Entry("GET 404", http.MethodGet, http.StatusNotFound, false),
+		)
+
+		It("retries connection errors for requests other than POST", func() {
+			Expect(policy.ShouldRetry(1, http.MethodGet, nil)).To(BeTrue())
+			Expect(policy.ShouldRetry(1, http.MethodPost, nil)).To(BeFalse())
+		})
+
+		It("stops after MaxRetries retries", func() {
+			response := &http.Response{StatusCode: http.StatusTooManyRequests}
+			Expect(policy.ShouldRetry(2, http.MethodGet, response)).To(BeTrue())
+			Expect(policy.ShouldRetry(3, http.MethodGet, response)).To(BeFalse())
+		})
+	})
+
+	Describe("Delay", func() {
+		var (
+			now      time.Time
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			now = time.Date(2017, time.March, 1, 12, 0, 0, 0, time.UTC)
+			response = &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{},
+			}
+		})
+
+		It("doubles the delay on every attempt, with jitter of up to half", func() {
+			for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+				delay := policy.Delay(attempt+1, response, now)
+				Expect(delay).To(BeNumerically(">", expected/2))
+				Expect(delay).To(BeNumerically("<=", expected))
+			}
+		})
+
+		It("never exceeds MaxDelay", func() {
+			delay := policy.Delay(20, nil, now)
+			Expect(delay).To(BeNumerically("<=", 10*time.Second))
+		})
+
+		Context("when the response has a Retry-After header in seconds", func() {
+			BeforeEach(func() {
+				response.Header.Set("Retry-After", "3")
+			})
+
+			It("waits that long", func() {
+				Expect(policy.Delay(1, response, now)).To(Equal(3 * time.Second))
+			})
+		})
+
+		Context("when the response has a Retry-After header with a date", func() {
+			BeforeEach(func() {
+				response.Header.Set("Retry-After", now.Add(5*time.Second).Format(http.TimeFormat))
+			})
+
+			It("waits until that date", func() {
+				Expect(policy.Delay(1, response, now)).To(Equal(5 * time.Second))
+			})
+		})
+
+		Context("when the Retry-After header exceeds MaxDelay", func() {
+			BeforeEach(func() {
+				response.Header.Set("Retry-After", "120")
+			})
+
+			It("waits MaxDelay", func() {
+				Expect(policy.Delay(1, response, now)).To(Equal(10 * time.Second))
+			})
+		})
+
+		Context("when the rate limit has been exhausted", func() {
+			BeforeEach(func() {
+				response.Header.Set("X-RateLimit-Remaining", "0")
+				response.Header.Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(7*time.Second).Unix(), 10))
+			})
+
+			It("waits until the rate limit resets", func() {
+				Expect(policy.Delay(1, response, now)).To(Equal(7 * time.Second))
+			})
+		})
+	})
+})
@@ -0,0 +1,71 @@
+package uaa
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/cli/api/uaa/internal"
+)
+
+// GrantType is the OAuth grant used to obtain tokens from the UAA.
+type GrantType string
+
+const (
+	// GrantTypeClientCredentials authenticates as a UAA client, for example a
+	// CI service account.
+	GrantTypeClientCredentials GrantType = "client_credentials"
+
+	// GrantTypePassword authenticates as a user with a username and password.
+	GrantTypePassword GrantType = "password"
+)
+
+// Authenticate requests new tokens from the UAA. For the password grant, ID
+// and secret are the username and password and the client's own credentials
+// identify the CLI. For the client credentials grant, ID and secret are the
+// client's credentials and no refresh token is returned.
+func (client *Client) Authenticate(ID string, secret string, grantType GrantType) (string, string, error) {
+	values := url.Values{
+		"grant_type": {string(grantType)},
+	}
+
+	switch grantType {
+	case GrantTypeClientCredentials:
+		values.Set("client_id", ID)
+		values.Set("client_secret", secret)
+	default:
+		values.Set("client_id", client.id)
+		values.Set("client_secret", client.secret)
+		values.Set("username", ID)
+		values.Set("password", secret)
+	}
+
+	return client.requestTokens(values)
+}
+
+// requestTokens posts the given form values to the token endpoint and returns
+// the access token, prefixed with its type, and the refresh token.
+func (client *Client) requestTokens(values url.Values) (string, string, error) {
+	request, err := client.newRequest(requestOptions{
+		RequestName: internal.PostOAuthTokenRequest,
+		Header: http.Header{
+			"Content-Type": {"application/x-www-form-urlencoded"},
+		},
+		Body: strings.NewReader(values.Encode()),
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	var token RefreshToken
+	response := Response{
+		Result: &token,
+	}
+
+	err = client.connection.Make(request, &response)
+	if err != nil {
+		return "", "", err
+	}
+
+	return token.AuthorizationToken(), token.RefreshToken, nil
+}
@@ -0,0 +1,103 @@
+package uaa_test
+
+import (
+	"net/http"
+
+	. "code.cloudfoundry.org/cli/api/uaa"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Auth", func() {
+	var (
+		client *Client
+	)
+
+	BeforeEach(func() {
+		client = NewTestUAAClientAndStore()
+	})
+
+	Describe("Authenticate", func() {
+		var (
+			grantType    GrantType
+			accessToken  string
+			refreshToken string
+			err          error
+		)
+
+		JustBeforeEach(func() {
+			accessToken, refreshToken, err = client.Authenticate("some-id", "some-secret", grantType)
+		})
+
+		Context("when using the password grant", func() {
+			BeforeEach(func() {
+				grantType = GrantTypePassword
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/oauth/token"),
+						VerifyHeaderKV("Accept", "application/json"),
+						VerifyHeaderKV("Content-Type", "application/x-www-form-urlencoded"),
+						VerifyBody([]byte("client_id=client-id&client_secret=client-secret&grant_type=password&password=some-secret&username=some-id")),
+						RespondWith(http.StatusOK, `{
+							"access_token": "some-access-token",
+							"token_type": "bearer",
+							"refresh_token": "some-refresh-token"
+						}`),
+					))
+			})
+
+			It("returns the access and refresh tokens", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(accessToken).To(Equal("bearer some-access-token"))
+				Expect(refreshToken).To(Equal("some-refresh-token"))
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when using the client credentials grant", func() {
+			BeforeEach(func() {
+				grantType = GrantTypeClientCredentials
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/oauth/token"),
+						VerifyHeaderKV("Content-Type", "application/x-www-form-urlencoded"),
+						VerifyBody([]byte("client_id=some-id&client_secret=some-secret&grant_type=client_credentials")),
+						RespondWith(http.StatusOK, `{
+							"access_token": "some-access-token",
+							"token_type": "bearer"
+						}`),
+					))
+			})
+
+			It("returns the access token without a refresh token", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(accessToken).To(Equal("bearer some-access-token"))
+				Expect(refreshToken).To(BeEmpty())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+
+		Context("when the credentials are rejected", func() {
+			BeforeEach(func() {
+				grantType = GrantTypePassword
+
+				server.AppendHandlers(
+					CombineHandlers(
+						VerifyRequest(http.MethodPost, "/oauth/token"),
+						RespondWith(http.StatusUnauthorized, `{
+							"error": "unauthorized",
+							"error_description": "Bad credentials"
+						}`),
+					))
+			})
+
+			It("returns a BadCredentialsError", func() {
+				Expect(err).To(MatchError(BadCredentialsError{Message: "Bad credentials"}))
+			})
+		})
+	})
+})
@@ -0,0 +1,82 @@
+package uaa
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"io"
+	"net/url"
+
+	"code.cloudfoundry.org/cli/api/uaa/internal"
+)
+
+// PKCE holds a Proof Key for Code Exchange (RFC 7636) pair. The Challenge is
+// sent with the authorization request and the Verifier with the code
+// exchange, so an intercepted code is useless to anyone else.
+type PKCE struct {
+	Verifier  string
+	Challenge string
+}
+
+// NewPKCE generates a random code verifier and its S256 code challenge.
+func NewPKCE() (PKCE, error) {
+	verifier, err := randomURLSafeString(32)
+	if err != nil {
+		return PKCE{}, err
+	}
+
+	sum := sha256.Sum256([]byte(verifier))
+	return PKCE{
+		Verifier:  verifier,
+		Challenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	}, nil
+}
+
+// NewState generates a random value for the OAuth state parameter.
+func NewState() (string, error) {
+	return randomURLSafeString(16)
+}
+
+// AuthorizationURL returns the UAA URL the user visits to log in with the
+// authorization code grant. After login, the UAA redirects the browser to
+// redirectURI with the code and the given state.
+func (client *Client) AuthorizationURL(redirectURI string, state string, pkce PKCE) (string, error) {
+	request, err := client.newRequest(requestOptions{
+		RequestName: internal.GetOAuthAuthorizeRequest,
+		Query: url.Values{
+			"client_id":             {client.id},
+			"code_challenge":        {pkce.Challenge},
+			"code_challenge_method": {"S256"},
+			"redirect_uri":          {redirectURI},
+			"response_type":         {"code"},
+			"state":                 {state},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return request.URL.String(), nil
+}
+
+// ExchangeAuthorizationCode trades an authorization code for tokens. The
+// redirectURI must match the one used to obtain the code.
+func (client *Client) ExchangeAuthorizationCode(code string, redirectURI string, pkce PKCE) (string, string, error) {
+	return client.requestTokens(url.Values{
+		"client_id":     {client.id},
+		"client_secret": {client.secret},
+		"code":          {code},
+		"code_verifier": {pkce.Verifier},
+		"grant_type":    {"authorization_code"},
+		"redirect_uri":  {redirectURI},
+	})
+}
+
+func randomURLSafeString(length int) (string, error) {
+	raw := make([]byte, length)
+	_, err := io.ReadFull(rand.Reader, raw)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
@@ -0,0 +1,105 @@
+package uaa
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const authorizationCodeCallbackPath = "/oauth/callback"
+
+const authorizationCodeResponsePage = `<!DOCTYPE html>
+<html>
+<head><title>Cloud Foundry CLI</title></head>
+<body><p>%s</p></body>
+</html>
+`
+
+// AuthorizationCodeListener is a loopback HTTP server that receives the
+// redirect from the UAA at the end of a browser login.
+type AuthorizationCodeListener struct {
+	listener net.Listener
+	state    string
+	results  chan authorizationCodeResult
+}
+
+type authorizationCodeResult struct {
+	code string
+	err  error
+}
+
+// NewAuthorizationCodeListener starts listening on a random port on the
+// loopback interface. Redirects that do not carry the expected state are
+// rejected.
+func NewAuthorizationCodeListener(state string) (*AuthorizationCodeListener, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	codeListener := &AuthorizationCodeListener{
+		listener: listener,
+		state:    state,
+		results:  make(chan authorizationCodeResult, 1),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(authorizationCodeCallbackPath, codeListener.handleCallback)
+	go http.Serve(listener, mux)
+
+	return codeListener, nil
+}
+
+// RedirectURI returns the URI the UAA should redirect the browser to.
+func (codeListener *AuthorizationCodeListener) RedirectURI() string {
+	return fmt.Sprintf("http://%s%s", codeListener.listener.Addr().String(), authorizationCodeCallbackPath)
+}
+
+// Wait blocks until an authorization code is received, the UAA reports an
+// error, or the timeout expires.
+func (codeListener *AuthorizationCodeListener) Wait(timeout time.Duration) (string, error) {
+	select {
+	case result := <-codeListener.results:
+		return result.code, result.err
+	case <-time.After(timeout):
+		return "", AuthorizationTimeoutError{}
+	}
+}
+
+// Close stops the listener.
+func (codeListener *AuthorizationCodeListener) Close() error {
+	return codeListener.listener.Close()
+}
+
+func (codeListener *AuthorizationCodeListener) handleCallback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("state") != codeListener.state {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, authorizationCodeResponsePage, "Invalid login request.")
+		return
+	}
+
+	var result authorizationCodeResult
+	switch {
+	case query.Get("error") != "":
+		result.err = AuthorizationDeniedError{
+			Type:        query.Get("error"),
+			Description: query.Get("error_description"),
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, authorizationCodeResponsePage, "Login failed. You can close this window and return to the CLI.")
+	case query.Get("code") == "":
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, authorizationCodeResponsePage, "Invalid login request.")
+		return
+	default:
+		result.code = query.Get("code")
+		fmt.Fprintf(w, authorizationCodeResponsePage, "Login successful. You can close this window and return to the CLI.")
+	}
+
+	select {
+	case codeListener.results <- result:
+	default:
+	}
+}
@@ -0,0 +1,157 @@
+package uaa_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	. "code.cloudfoundry.org/cli/api/uaa"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Authorization Code", func() {
+	var (
+		client *Client
+		pkce   PKCE
+	)
+
+	BeforeEach(func() {
+		client = NewTestUAAClientAndStore()
+
+		var err error
+		pkce, err = NewPKCE()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Describe("NewPKCE", func() {
+		It("derives the challenge from the verifier with S256", func() {
+			sum := sha256.Sum256([]byte(pkce.Verifier))
+			Expect(pkce.Challenge).To(Equal(base64.RawURLEncoding.EncodeToString(sum[:])))
+			Expect(len(pkce.Verifier)).To(BeNumerically(">=", 43))
+		})
+
+		It("generates a different verifier each time", func() {
+			other, err := NewPKCE()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(other.Verifier).ToNot(Equal(pkce.Verifier))
+		})
+	})
+
+	Describe("AuthorizationURL", func() {
+		It("returns the authorize endpoint with the PKCE challenge", func() {
+			rawURL, err := client.AuthorizationURL("http://127.0.0.1:1234/oauth/callback", "some-state", pkce)
+			Expect(err).ToNot(HaveOccurred())
+
+			authorizeURL, err := url.Parse(rawURL)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(authorizeURL.Scheme + "://" + authorizeURL.Host).To(Equal(server.URL()))
+			Expect(authorizeURL.Path).To(Equal("/oauth/authorize"))
+			Expect(authorizeURL.Query()).To(Equal(url.Values{
+				"client_id":             {"client-id"},
+				"code_challenge":        {pkce.Challenge},
+				"code_challenge_method": {"S256"},
+				"redirect_uri":          {"http://127.0.0.1:1234/oauth/callback"},
+				"response_type":         {"code"},
+				"state":                 {"some-state"},
+			}))
+		})
+	})
+
+	Describe("ExchangeAuthorizationCode", func() {
+		BeforeEach(func() {
+			server.AppendHandlers(
+				CombineHandlers(
+					VerifyRequest(http.MethodPost, "/oauth/token"),
+					VerifyHeaderKV("Content-Type", "application/x-www-form-urlencoded"),
+					VerifyForm(url.Values{
+						"client_id":     {"client-id"},
+						"client_secret": {"client-secret"},
+						"code":          {"some-code"},
+						"code_verifier": {pkce.Verifier},
+						"grant_type":    {"authorization_code"},
+						"redirect_uri":  {"http://127.0.0.1:1234/oauth/callback"},
+					}),
+					RespondWith(http.StatusOK, `{
+						"access_token": "some-access-token",
+						"token_type": "bearer",
+						"refresh_token": "some-refresh-token"
+					}`),
+				))
+		})
+
+		It("returns the tokens", func() {
+			accessToken, refreshToken, err := client.ExchangeAuthorizationCode("some-code", "http://127.0.0.1:1234/oauth/callback", pkce)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(accessToken).To(Equal("bearer some-access-token"))
+			Expect(refreshToken).To(Equal("some-refresh-token"))
+		})
+	})
+
+	Describe("AuthorizationCodeListener", func() {
+		var listener *AuthorizationCodeListener
+
+		BeforeEach(func() {
+			var err error
+			listener, err = NewAuthorizationCodeListener("some-state")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			listener.Close()
+		})
+
+		It("listens on the loopback interface", func() {
+			Expect(listener.RedirectURI()).To(MatchRegexp(`^http://127\.0\.0\.1:\d+/oauth/callback$`))
+		})
+
+		Context("when the redirect carries a code", func() {
+			It("returns the code", func() {
+				response, err := http.Get(listener.RedirectURI() + "?code=some-code&state=some-state")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				code, err := listener.Wait(time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(code).To(Equal("some-code"))
+			})
+		})
+
+		Context("when the redirect has the wrong state", func() {
+			It("rejects it and keeps waiting", func() {
+				response, err := http.Get(listener.RedirectURI() + "?code=bad-code&state=other-state")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+				_, err = http.Get(listener.RedirectURI() + "?code=some-code&state=some-state")
+				Expect(err).ToNot(HaveOccurred())
+
+				code, err := listener.Wait(time.Second)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(code).To(Equal("some-code"))
+			})
+		})
+
+		Context("when the redirect carries an error", func() {
+			It("returns an AuthorizationDeniedError", func() {
+				_, err := http.Get(fmt.Sprintf("%s?error=access_denied&error_description=%s&state=some-state", listener.RedirectURI(), url.QueryEscape("User denied access")))
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = listener.Wait(time.Second)
+				Expect(err).To(MatchError(AuthorizationDeniedError{Type: "access_denied", Description: "User denied access"}))
+			})
+		})
+
+		Context("when nothing is received", func() {
+			It("returns an AuthorizationTimeoutError", func() {
+				_, err := listener.Wait(10 * time.Millisecond)
+				Expect(err).To(MatchError(AuthorizationTimeoutError{}))
+			})
+		})
+	})
+})
@@ -6,6 +6,8 @@
 package uaa
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"runtime"
 	"time"
@@ -16,9 +18,10 @@ import (
 
 // Client is the UAA client
 type Client struct {
-	URL    string
-	id     string
-	secret string
+	URL       string
+	id        string
+	secret    string
+	grantType GrantType
 
 	connection Connection
 	router     *rata.RequestGenerator
@@ -43,6 +46,10 @@ type Config struct {
 	// ClientSecret is the UAA client secret the client will use.
 	ClientSecret string
 
+	// GrantType is the grant type the current tokens were obtained with. It
+	// determines how RefreshAccessToken gets a new access token.
+	GrantType GrantType
+
 	// SkipSSLValidation controls whether a client verifies the server's
 	// certificate chain and host name. If SkipSSLValidation is true, TLS accepts
 	// any certificate presented by the server and any host name in that
@@ -52,6 +59,14 @@ type Config struct {
 	// be used only for testing.
 	SkipSSLValidation bool
 
+	// RootCAs is the set of certificate authorities used to verify the
+	// server's certificate. If nil, the system roots are used.
+	RootCAs *x509.CertPool
+
+	// ClientCertificates are presented to servers that ask for a client
+	// certificate.
+	ClientCertificates []tls.Certificate
+
 	// URL is the api URL for the UAA target.
 	URL string
 }
@@ -67,12 +82,13 @@ func NewClient(config Config) *Client {
 	)
 
 	client := Client{
-		URL:    config.URL,
-		id:     config.ClientID,
-		secret: config.ClientSecret,
+		URL:       config.URL,
+		id:        config.ClientID,
+		secret:    config.ClientSecret,
+		grantType: config.GrantType,
 
 		router:     rata.NewRequestGenerator(config.URL, internal.Routes),
-		connection: NewConnection(config.SkipSSLValidation, config.DialTimeout),
+		connection: NewConnection(config),
 		userAgent:  userAgent,
 	}
 	client.WrapConnection(NewErrorWrapper())
@@ -3,6 +3,7 @@ package uaa
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 )
 
 // errorWrapper is the wrapper that converts responses with 4xx and 5xx status
@@ -53,10 +54,20 @@ func convert(rawHTTPStatusErr RawHTTPStatusError) error {
 		if uaaErrorResponse.Type == "invalid_token" {
 			return InvalidAuthTokenError{Message: uaaErrorResponse.Description}
 		}
+		if uaaErrorResponse.Type == "unauthorized" {
+			return BadCredentialsError{Message: uaaErrorResponse.Description}
+		}
 		return rawHTTPStatusErr
 	case http.StatusForbidden: // 403
 		if uaaErrorResponse.Type == "insufficient_scope" {
-			return InsufficientScopeError{Message: uaaErrorResponse.Description}
+			var requiredScopes []string
+			if uaaErrorResponse.Scope != "" {
+				requiredScopes = strings.Fields(uaaErrorResponse.Scope)
+			}
+			return InsufficientScopeError{
+				Message:        uaaErrorResponse.Description,
+				RequiredScopes: requiredScopes,
+			}
 		}
 		return rawHTTPStatusErr
 	case http.StatusConflict: // 409
@@ -118,6 +118,22 @@ var _ = Describe("Error Wrapper", func() {
 						Expect(makeErr).To(MatchError(InvalidAuthTokenError{Message: "your token is invalid!"}))
 					})
 				})
+
+				Context("bad credentials", func() {
+					BeforeEach(func() {
+						fakeConnectionErr.RawResponse = []byte(`{
+  "error": "unauthorized",
+  "error_description": "Bad credentials"
+}`)
+						fakeConnection.MakeReturns(fakeConnectionErr)
+					})
+
+					It("returns a BadCredentialsError", func() {
+						Expect(fakeConnection.MakeCallCount()).To(Equal(1))
+
+						Expect(makeErr).To(MatchError(BadCredentialsError{Message: "Bad credentials"}))
+					})
+				})
 			})
 
 			Context("(403) Forbidden", func() {
@@ -150,10 +166,13 @@ var _ = Describe("Error Wrapper", func() {
 						fakeConnection.MakeReturns(fakeConnectionErr)
 					})
 
-					It("returns an InsufficientScopeError", func() {
+					It("returns an InsufficientScopeError with the required scopes", func() {
 						Expect(fakeConnection.MakeCallCount()).To(Equal(1))
 
-						Expect(makeErr).To(MatchError(InsufficientScopeError{Message: "Insufficient scope for this resource"}))
+						Expect(makeErr).To(MatchError(InsufficientScopeError{
+							Message:        "Insufficient scope for this resource",
+							RequiredScopes: []string{"admin", "scim.write", "scim.create", "zones.admin"},
+						}))
 					})
 				})
 			})
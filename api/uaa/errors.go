@@ -16,6 +16,7 @@ func (r RawHTTPStatusError) Error() string {
 type UAAErrorResponse struct {
 	Type        string `json:"error"`
 	Description string `json:"error_description"`
+	Scope       string `json:"scope"`
 }
 
 func (e UAAErrorResponse) Error() string {
@@ -62,11 +63,24 @@ func (e InvalidAuthTokenError) Error() string {
 	return e.Message
 }
 
-// InsufficientScopeError is returned when the client has insufficient scope
-type InsufficientScopeError struct {
+// BadCredentialsError is returned when the UAA rejects the credentials
+// presented when requesting a token.
+type BadCredentialsError struct {
 	Message string
 }
 
+func (e BadCredentialsError) Error() string {
+	return e.Message
+}
+
+// InsufficientScopeError is returned when the client has insufficient scope.
+// RequiredScopes lists the scopes the UAA would have accepted, any one of
+// which grants access.
+type InsufficientScopeError struct {
+	Message        string
+	RequiredScopes []string
+}
+
 func (e InsufficientScopeError) Error() string {
 	return e.Message
 }
@@ -79,3 +93,26 @@ type InvalidSCIMResourceError struct {
 func (e InvalidSCIMResourceError) Error() string {
 	return e.Message
 }
+
+// AuthorizationDeniedError is returned when the UAA redirects back from the
+// authorization endpoint with an error instead of a code, for example when
+// the user declines the login.
+type AuthorizationDeniedError struct {
+	Type        string
+	Description string
+}
+
+func (e AuthorizationDeniedError) Error() string {
+	if e.Description == "" {
+		return e.Type
+	}
+	return e.Description
+}
+
+// AuthorizationTimeoutError is returned when no authorization code is
+// received before the timeout expires.
+type AuthorizationTimeoutError struct{}
+
+func (e AuthorizationTimeoutError) Error() string {
+	return "Timed out waiting for the browser login to complete"
+}
@@ -7,13 +7,15 @@ import (
 )
 
 const (
-	RefreshTokenRequest = "RefreshToken"
-	NewUserRequest      = "NewUser"
+	GetOAuthAuthorizeRequest = "GetOAuthAuthorize"
+	PostOAuthTokenRequest    = "PostOAuthToken"
+	NewUserRequest           = "NewUser"
 )
 
 // Routes is a list of routes used by the rata library to construct request
 // URLs.
 var Routes = rata.Routes{
-	{Path: "/oauth/token", Method: http.MethodPost, Name: RefreshTokenRequest},
+	{Path: "/oauth/authorize", Method: http.MethodGet, Name: GetOAuthAuthorizeRequest},
+	{Path: "/oauth/token", Method: http.MethodPost, Name: PostOAuthTokenRequest},
 	{Path: "/Users", Method: http.MethodPost, Name: NewUserRequest},
 }
@@ -21,17 +21,25 @@ func (refreshTokenResponse RefreshToken) AuthorizationToken() string {
 	return fmt.Sprintf("%s %s", refreshTokenResponse.Type, refreshTokenResponse.AccessToken)
 }
 
-// RefreshAccessToken refreshes the current access token.
+// RefreshAccessToken refreshes the current access token. The UAA does not
+// issue refresh tokens for the client credentials grant, so when the client
+// was configured with that grant type a new token is requested with the
+// client's credentials and refreshToken is ignored.
 func (client *Client) RefreshAccessToken(refreshToken string) (RefreshToken, error) {
-	body := strings.NewReader(url.Values{
+	values := url.Values{
 		"client_id":     {client.id},
 		"client_secret": {client.secret},
-		"grant_type":    {"refresh_token"},
-		"refresh_token": {refreshToken},
-	}.Encode())
+	}
+	if client.grantType == GrantTypeClientCredentials {
+		values.Set("grant_type", string(GrantTypeClientCredentials))
+	} else {
+		values.Set("grant_type", "refresh_token")
+		values.Set("refresh_token", refreshToken)
+	}
+	body := strings.NewReader(values.Encode())
 
 	request, err := client.newRequest(requestOptions{
-		RequestName: internal.RefreshTokenRequest,
+		RequestName: internal.PostOAuthTokenRequest,
 		Header: http.Header{
 			"Content-Type": {"application/x-www-form-urlencoded"},
 		},
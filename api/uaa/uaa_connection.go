@@ -17,16 +17,19 @@ type UAAConnection struct {
 	HTTPClient *http.Client
 }
 
-// NewConnection returns a pointer to a new UAA Connection
-func NewConnection(skipSSLValidation bool, dialTimeout time.Duration) *UAAConnection {
+// NewConnection returns a pointer to a new UAA Connection using the
+// connection settings in config.
+func NewConnection(config Config) *UAAConnection {
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{
-			InsecureSkipVerify: skipSSLValidation,
+			InsecureSkipVerify: config.SkipSSLValidation,
+			RootCAs:            config.RootCAs,
+			Certificates:       config.ClientCertificates,
 		},
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
 			KeepAlive: 30 * time.Second,
-			Timeout:   dialTimeout,
+			Timeout:   config.DialTimeout,
 		}).DialContext,
 	}
 
@@ -64,10 +64,10 @@ func (t *UAAAuthentication) Make(request *http.Request, passedResponse *uaa.Resp
 
 		request.Body = ioutil.NopCloser(bytes.NewBuffer(rawRequestBody))
 
-		// The authentication header is not added to the token refresh request.
+		// The authentication header is not added to token requests; they carry
+		// the client credentials in the body instead.
 		if strings.Contains(request.URL.String(), "/oauth/token") &&
-			request.Method == http.MethodPost &&
-			strings.Contains(string(rawRequestBody), "grant_type=refresh_token") {
+			request.Method == http.MethodPost {
 			return t.connection.Make(request, passedResponse)
 		}
 	}
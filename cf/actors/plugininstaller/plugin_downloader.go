@@ -1,6 +1,7 @@
 package plugininstaller
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,13 +17,13 @@ type PluginDownloader struct {
 	UI             terminal.UI
 	FileDownloader downloader.Downloader
 }
-type downloadFromPath func(string, downloader.Downloader) string
+type downloadFromPath func(string, downloader.Downloader) (string, error)
 
-func (downloader *PluginDownloader) downloadFromPath(pluginSourceFilepath string) string {
+func (downloader *PluginDownloader) downloadFromPath(pluginSourceFilepath string) (string, error) {
 	size, filename, err := downloader.FileDownloader.DownloadFile(pluginSourceFilepath)
 
 	if err != nil {
-		downloader.UI.Failed(fmt.Sprintf(T("Download attempt failed: {{.Error}}\n\nUnable to install, plugin is not available from the given url.", map[string]interface{}{"Error": err.Error()})))
+		return "", errors.New(fmt.Sprintf(T("Download attempt failed: {{.Error}}\n\nUnable to install, plugin is not available from the given url.", map[string]interface{}{"Error": err.Error()})))
 	}
 
 	downloader.UI.Say(fmt.Sprintf("%d "+T("bytes downloaded")+"...", size))
@@ -30,53 +31,52 @@ func (downloader *PluginDownloader) downloadFromPath(pluginSourceFilepath string
 	executablePath := filepath.Join(downloader.FileDownloader.SavePath(), filename)
 	err = os.Chmod(executablePath, 0700)
 	if err != nil {
-		downloader.UI.Failed(fmt.Sprintf(T("Failed to make plugin executable: {{.Error}}", map[string]interface{}{"Error": err.Error()})))
+		return "", errors.New(fmt.Sprintf(T("Failed to make plugin executable: {{.Error}}", map[string]interface{}{"Error": err.Error()})))
 	}
 
-	return executablePath
+	return executablePath, nil
 }
 
-func (downloader *PluginDownloader) downloadFromPlugin(plugin clipr.Plugin) (string, string) {
+func (downloader *PluginDownloader) downloadFromPlugin(plugin clipr.Plugin) (string, clipr.Binary, error) {
+	binary, ok := getBinary(plugin, binaryPlatform())
+	if !ok {
+		return "", clipr.Binary{}, binaryNotAvailableError()
+	}
+
+	executablePath, err := downloader.downloadFromPath(binary.Url)
+	return executablePath, binary, err
+}
+
+func binaryPlatform() string {
 	arch := runtime.GOARCH
 
 	switch runtime.GOOS {
 	case "darwin":
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "osx")), downloader.getBinaryChecksum(plugin, "osx")
+		return "osx"
 	case "linux":
 		if arch == "386" {
-			return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "linux32")), downloader.getBinaryChecksum(plugin, "linux32")
+			return "linux32"
 		}
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "linux64")), downloader.getBinaryChecksum(plugin, "linux64")
+		return "linux64"
 	case "windows":
 		if arch == "386" {
-			return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "win32")), downloader.getBinaryChecksum(plugin, "win32")
+			return "win32"
 		}
-		return downloader.downloadFromPath(downloader.getBinaryURL(plugin, "win64")), downloader.getBinaryChecksum(plugin, "win64")
+		return "win64"
 	default:
-		downloader.binaryNotAvailable()
-	}
-	return "", ""
-}
-
-func (downloader *PluginDownloader) getBinaryURL(plugin clipr.Plugin, os string) string {
-	for _, binary := range plugin.Binaries {
-		if binary.Platform == os {
-			return binary.Url
-		}
+		return ""
 	}
-	downloader.binaryNotAvailable()
-	return ""
 }
 
-func (downloader *PluginDownloader) getBinaryChecksum(plugin clipr.Plugin, os string) string {
+func getBinary(plugin clipr.Plugin, platform string) (clipr.Binary, bool) {
 	for _, binary := range plugin.Binaries {
-		if binary.Platform == os {
-			return binary.Checksum
+		if binary.Platform == platform {
+			return binary, true
 		}
 	}
-	return ""
+	return clipr.Binary{}, false
 }
 
-func (downloader *PluginDownloader) binaryNotAvailable() {
-	downloader.UI.Failed(T("Plugin requested has no binary available for your OS: ") + runtime.GOOS + ", " + runtime.GOARCH)
+func binaryNotAvailableError() error {
+	return errors.New(T("Plugin requested has no binary available for your OS: ") + runtime.GOOS + ", " + runtime.GOARCH)
 }
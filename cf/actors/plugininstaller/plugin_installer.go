@@ -11,16 +11,17 @@ import (
 //go:generate counterfeiter . PluginInstaller
 
 type PluginInstaller interface {
-	Install(inputSourceFilepath string) string
+	Install(inputSourceFilepath string) (string, error)
 }
 
 type Context struct {
-	Checksummer    util.Sha1Checksum
+	Checksummer    util.Checksum
 	FileDownloader downloader.Downloader
 	GetPluginRepos pluginReposFetcher
 	PluginRepo     pluginrepo.PluginRepo
 	RepoName       string
 	UI             terminal.UI
+	Verifier       SignatureVerifier
 }
 
 type pluginReposFetcher func() []models.PluginRepo
@@ -34,6 +35,7 @@ func NewPluginInstaller(context *Context) PluginInstaller {
 			UI:               context.UI,
 			PluginDownloader: pluginDownloader,
 			RepoName:         context.RepoName,
+			Verifier:         context.Verifier,
 		}
 	} else {
 		installer = &pluginInstallerWithRepo{
@@ -43,6 +45,7 @@ func NewPluginInstaller(context *Context) PluginInstaller {
 			Checksummer:      context.Checksummer,
 			PluginRepo:       context.PluginRepo,
 			GetPluginRepos:   context.GetPluginRepos,
+			Verifier:         context.Verifier,
 		}
 	}
 	return installer
@@ -1,6 +1,7 @@
 package plugininstaller
 
 import (
+	"crypto/sha256"
 	"errors"
 	"strings"
 
@@ -17,47 +18,53 @@ type pluginInstallerWithRepo struct {
 	PluginDownloader *PluginDownloader
 	DownloadFromPath downloadFromPath
 	RepoName         string
-	Checksummer      util.Sha1Checksum
+	Checksummer      util.Checksum
 	PluginRepo       pluginrepo.PluginRepo
 	GetPluginRepos   pluginReposFetcher
+	Verifier         SignatureVerifier
 }
 
-func (installer *pluginInstallerWithRepo) Install(inputSourceFilepath string) string {
-	var outputSourceFilepath string
-
+func (installer *pluginInstallerWithRepo) Install(inputSourceFilepath string) (string, error) {
 	targetPluginName := strings.ToLower(inputSourceFilepath)
 
 	installer.UI.Say(T("Looking up '{{.filePath}}' from repository '{{.repoName}}'", map[string]interface{}{"filePath": inputSourceFilepath, "repoName": installer.RepoName}))
 
 	repoModel, err := installer.getRepoFromConfig(installer.RepoName)
 	if err != nil {
-		installer.UI.Failed(err.Error() + "\n" + T("Tip: use 'add-plugin-repo' to register the repo"))
+		return "", errors.New(err.Error() + "\n" + T("Tip: use 'add-plugin-repo' to register the repo"))
 	}
 
 	pluginList, repoAry := installer.PluginRepo.GetPlugins([]models.PluginRepo{repoModel})
 	if len(repoAry) != 0 {
-		installer.UI.Failed(T("Error getting plugin metadata from repo: ") + repoAry[0])
+		return "", errors.New(T("Error getting plugin metadata from repo: ") + repoAry[0])
 	}
 
-	found := false
-	sha1 := ""
 	for _, plugin := range findRepoCaseInsensity(pluginList, installer.RepoName) {
 		if strings.ToLower(plugin.Name) == targetPluginName {
-			found = true
-			outputSourceFilepath, sha1 = installer.PluginDownloader.downloadFromPlugin(plugin)
+			outputSourceFilepath, binary, err := installer.PluginDownloader.downloadFromPlugin(plugin)
+			if err != nil {
+				return "", err
+			}
 
 			installer.Checksummer.SetFilePath(outputSourceFilepath)
-			if !installer.Checksummer.CheckSha1(sha1) {
-				installer.UI.Failed(T("Downloaded plugin binary's checksum does not match repo metadata"))
+			if !installer.checkChecksum(binary.Checksum) {
+				return "", errors.New(T("Downloaded plugin binary's checksum does not match repo metadata"))
 			}
-		}
 
+			return outputSourceFilepath, verifySignature(installer.UI, installer.Verifier, outputSourceFilepath, binary.Url)
+		}
 	}
-	if !found {
-		installer.UI.Failed(inputSourceFilepath + T(" is not available in repo '") + installer.RepoName + "'")
-	}
 
-	return outputSourceFilepath
+	return "", errors.New(inputSourceFilepath + T(" is not available in repo '") + installer.RepoName + "'")
+}
+
+// checkChecksum accepts either a SHA256 or, for older repositories, a SHA1
+// checksum.
+func (installer *pluginInstallerWithRepo) checkChecksum(checksum string) bool {
+	if len(checksum) == sha256.Size*2 {
+		return installer.Checksummer.CheckSha256(checksum)
+	}
+	return installer.Checksummer.CheckSha1(checksum)
 }
 
 func (installer *pluginInstallerWithRepo) getRepoFromConfig(repoName string) (models.PluginRepo, error) {
@@ -1,6 +1,7 @@
 package plugininstaller
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,9 +15,11 @@ type pluginInstallerWithoutRepo struct {
 	PluginDownloader *PluginDownloader
 	DownloadFromPath downloadFromPath
 	RepoName         string
+	Verifier         SignatureVerifier
 }
 
-func (installer *pluginInstallerWithoutRepo) Install(inputSourceFilepath string) (outputSourceFilepath string) {
+func (installer *pluginInstallerWithoutRepo) Install(inputSourceFilepath string) (string, error) {
+	var outputSourceFilepath string
 	if filepath.Dir(inputSourceFilepath) == "." {
 		outputSourceFilepath = "./" + filepath.Clean(inputSourceFilepath)
 	} else {
@@ -27,12 +30,16 @@ func (installer *pluginInstallerWithoutRepo) Install(inputSourceFilepath string)
 	if strings.HasPrefix(outputSourceFilepath, "https://") || strings.HasPrefix(outputSourceFilepath, "http://") ||
 		strings.HasPrefix(outputSourceFilepath, "ftp://") || strings.HasPrefix(outputSourceFilepath, "ftps://") {
 		installer.UI.Say(T("Attempting to download binary file from internet address..."))
-		return installer.PluginDownloader.downloadFromPath(outputSourceFilepath)
+		downloadedFilepath, err := installer.PluginDownloader.downloadFromPath(outputSourceFilepath)
+		if err != nil {
+			return "", err
+		}
+		return downloadedFilepath, verifySignature(installer.UI, installer.Verifier, downloadedFilepath, outputSourceFilepath)
 	} else if !installer.ensureCandidatePluginBinaryExistsAtGivenPath(outputSourceFilepath) {
-		installer.UI.Failed(T("File not found locally, make sure the file exists at given path {{.filepath}}", map[string]interface{}{"filepath": outputSourceFilepath}))
+		return "", errors.New(T("File not found locally, make sure the file exists at given path {{.filepath}}", map[string]interface{}{"filepath": outputSourceFilepath}))
 	}
 
-	return outputSourceFilepath
+	return outputSourceFilepath, verifySignature(installer.UI, installer.Verifier, outputSourceFilepath, outputSourceFilepath)
 }
 
 func (installer *pluginInstallerWithoutRepo) ensureCandidatePluginBinaryExistsAtGivenPath(pluginSourceFilepath string) bool {
@@ -0,0 +1,184 @@
+package plugininstaller
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"golang.org/x/crypto/ed25519"
+)
+
+// SignatureSuffix is appended to the path of a plugin binary to find its
+// signature. A signature is the base64 encoded ed25519 signature of the
+// binary.
+const SignatureSuffix = ".sig"
+
+// SignatureVerifier checks plugin binaries against the signatures published
+// alongside them.
+type SignatureVerifier struct {
+	TrustedKeys   []ed25519.PublicKey
+	RequireSigned bool
+	Client        *http.Client
+}
+
+// LoadTrustedKeys reads base64 encoded ed25519 public keys from path, one per
+// line. Blank lines and lines starting with '#' are ignored.
+func LoadTrustedKeys(path string) ([]ed25519.PublicKey, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []ed25519.PublicKey{}
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		key, err := base64.StdEncoding.DecodeString(text)
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, errors.New(T("Invalid ed25519 public key on line {{.Line}} of {{.Path}}", map[string]interface{}{
+				"Line": line,
+				"Path": path,
+			}))
+		}
+		keys = append(keys, ed25519.PublicKey(key))
+	}
+
+	return keys, scanner.Err()
+}
+
+// Verify checks the plugin binary at binaryPath against the signature found
+// at source with SignatureSuffix appended to its path, where source is the
+// URL or local path the binary came from. It returns true when a trusted key signed the binary. A
+// signature that does not match any trusted key is always an error; a
+// missing signature is only an error when RequireSigned is set.
+func (v SignatureVerifier) Verify(binaryPath string, source string) (bool, error) {
+	signature, found, err := v.readSignature(signatureLocation(source))
+	if err != nil {
+		return false, err
+	}
+
+	if !found {
+		if v.RequireSigned {
+			return false, errors.New(T("Plugin binary is not signed and only signed plugins may be installed"))
+		}
+		return false, nil
+	}
+
+	if len(v.TrustedKeys) == 0 {
+		if v.RequireSigned {
+			return false, errors.New(T("Plugin binary is signed, but no trusted keys are configured to verify it"))
+		}
+		return false, nil
+	}
+
+	binary, err := ioutil.ReadFile(binaryPath)
+	if err != nil {
+		return false, err
+	}
+
+	for _, key := range v.TrustedKeys {
+		if ed25519.Verify(key, binary, signature) {
+			return true, nil
+		}
+	}
+
+	return false, errors.New(T("Plugin binary signature does not match any trusted key"))
+}
+
+// signatureLocation returns where the signature of the binary at source is
+// published. For URLs the suffix goes on the path, before any query or
+// fragment.
+func signatureLocation(source string) string {
+	if !isURL(source) {
+		return source + SignatureSuffix
+	}
+
+	u, err := url.Parse(source)
+	if err != nil {
+		return source + SignatureSuffix
+	}
+	u.Path += SignatureSuffix
+	if u.RawPath != "" {
+		u.RawPath += SignatureSuffix
+	}
+	return u.String()
+}
+
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://")
+}
+
+func (v SignatureVerifier) readSignature(location string) ([]byte, bool, error) {
+	var encoded []byte
+	if isURL(location) {
+		client := v.Client
+		if client == nil {
+			client = http.DefaultClient
+		}
+
+		response, err := client.Get(location)
+		if err != nil {
+			return nil, false, err
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode == http.StatusNotFound {
+			return nil, false, nil
+		}
+		if response.StatusCode != http.StatusOK {
+			return nil, false, errors.New(T("Unable to download plugin signature from {{.URL}}: {{.Status}}", map[string]interface{}{
+				"URL":    location,
+				"Status": response.Status,
+			}))
+		}
+
+		encoded, err = ioutil.ReadAll(response.Body)
+		if err != nil {
+			return nil, false, err
+		}
+	} else {
+		var err error
+		encoded, err = ioutil.ReadFile(location)
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil || len(signature) != ed25519.SignatureSize {
+		return nil, false, errors.New(T("Invalid plugin signature at {{.Location}}", map[string]interface{}{
+			"Location": location,
+		}))
+	}
+
+	return signature, true, nil
+}
+
+func verifySignature(ui terminal.UI, verifier SignatureVerifier, binaryPath string, source string) error {
+	verified, err := verifier.Verify(binaryPath, source)
+	if err != nil {
+		return err
+	}
+
+	if verified {
+		ui.Say(T("Plugin signature verified with a trusted key."))
+	} else if len(verifier.TrustedKeys) > 0 {
+		ui.Warn(T("Plugin binary is not signed by a trusted key."))
+	}
+	return nil
+}
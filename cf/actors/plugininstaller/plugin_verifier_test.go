@@ -0,0 +1,168 @@
+package plugininstaller_test
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/cf/actors/plugininstaller"
+	"golang.org/x/crypto/ed25519"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SignatureVerifier", func() {
+	var (
+		dir        string
+		binaryPath string
+		publicKey  ed25519.PublicKey
+		privateKey ed25519.PrivateKey
+		verifier   plugininstaller.SignatureVerifier
+	)
+
+	writeSignature := func(key ed25519.PrivateKey) {
+		signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte("plugin binary")))
+		Expect(ioutil.WriteFile(binaryPath+plugininstaller.SignatureSuffix, []byte(signature+"\n"), 0600)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "plugin-verifier")
+		Expect(err).ToNot(HaveOccurred())
+
+		binaryPath = filepath.Join(dir, "plugin")
+		Expect(ioutil.WriteFile(binaryPath, []byte("plugin binary"), 0700)).To(Succeed())
+
+		publicKey, privateKey, err = ed25519.GenerateKey(rand.Reader)
+		Expect(err).ToNot(HaveOccurred())
+
+		verifier = plugininstaller.SignatureVerifier{TrustedKeys: []ed25519.PublicKey{publicKey}}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("LoadTrustedKeys", func() {
+		It("reads one base64 key per line, skipping blank lines and comments", func() {
+			keysPath := filepath.Join(dir, "keys")
+			contents := fmt.Sprintf("# release key\n%s\n\n", base64.StdEncoding.EncodeToString(publicKey))
+			Expect(ioutil.WriteFile(keysPath, []byte(contents), 0600)).To(Succeed())
+
+			keys, err := plugininstaller.LoadTrustedKeys(keysPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keys).To(Equal([]ed25519.PublicKey{publicKey}))
+		})
+
+		It("returns an error for a key that is not an ed25519 public key", func() {
+			keysPath := filepath.Join(dir, "keys")
+			Expect(ioutil.WriteFile(keysPath, []byte("bm90IGEga2V5\n"), 0600)).To(Succeed())
+
+			_, err := plugininstaller.LoadTrustedKeys(keysPath)
+			Expect(err).To(MatchError(ContainSubstring("Invalid ed25519 public key on line 1")))
+		})
+	})
+
+	Describe("Verify", func() {
+		It("verifies a local binary against the signature next to it", func() {
+			writeSignature(privateKey)
+
+			verified, err := verifier.Verify(binaryPath, binaryPath)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(verified).To(BeTrue())
+		})
+
+		It("returns an error when the signature does not match a trusted key", func() {
+			_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			writeSignature(otherKey)
+
+			_, err = verifier.Verify(binaryPath, binaryPath)
+			Expect(err).To(MatchError("Plugin binary signature does not match any trusted key"))
+		})
+
+		It("returns an error when the signature cannot be decoded", func() {
+			Expect(ioutil.WriteFile(binaryPath+plugininstaller.SignatureSuffix, []byte("garbage"), 0600)).To(Succeed())
+
+			_, err := verifier.Verify(binaryPath, binaryPath)
+			Expect(err).To(MatchError(ContainSubstring("Invalid plugin signature")))
+		})
+
+		Context("when the binary is not signed", func() {
+			It("allows the binary unless signatures are required", func() {
+				verified, err := verifier.Verify(binaryPath, binaryPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(verified).To(BeFalse())
+
+				verifier.RequireSigned = true
+				_, err = verifier.Verify(binaryPath, binaryPath)
+				Expect(err).To(MatchError("Plugin binary is not signed and only signed plugins may be installed"))
+			})
+		})
+
+		Context("when no trusted keys are configured", func() {
+			BeforeEach(func() {
+				verifier.TrustedKeys = nil
+				writeSignature(privateKey)
+			})
+
+			It("does not verify the signature unless signatures are required", func() {
+				verified, err := verifier.Verify(binaryPath, binaryPath)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(verified).To(BeFalse())
+
+				verifier.RequireSigned = true
+				_, err = verifier.Verify(binaryPath, binaryPath)
+				Expect(err).To(MatchError("Plugin binary is signed, but no trusted keys are configured to verify it"))
+			})
+		})
+
+		Context("when the binary was downloaded", func() {
+			var (
+				server    *httptest.Server
+				signature string
+			)
+
+			BeforeEach(func() {
+				signature = base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte("plugin binary")))
+				server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.URL.Path == "/plugin.sig" && signature != "" {
+						fmt.Fprint(w, signature)
+						return
+					}
+					w.WriteHeader(http.StatusNotFound)
+				}))
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("downloads the signature from the binary's URL", func() {
+				verified, err := verifier.Verify(binaryPath, server.URL+"/plugin")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(verified).To(BeTrue())
+			})
+
+			It("appends the suffix to the path of a URL with a query or fragment", func() {
+				verified, err := verifier.Verify(binaryPath, server.URL+"/plugin?version=1.0#download")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(verified).To(BeTrue())
+			})
+
+			It("treats a missing signature as unsigned", func() {
+				signature = ""
+
+				verified, err := verifier.Verify(binaryPath, server.URL+"/plugin")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(verified).To(BeFalse())
+			})
+		})
+	})
+})
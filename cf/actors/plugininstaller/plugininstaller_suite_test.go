@@ -0,0 +1,18 @@
+package plugininstaller_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/util/testhelpers/configuration"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPluginInstaller(t *testing.T) {
+	config := configuration.NewRepositoryWithDefaults()
+	i18n.T = i18n.Init(config)
+
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PluginInstaller Suite")
+}
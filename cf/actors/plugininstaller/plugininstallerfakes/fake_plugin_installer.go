@@ -8,19 +8,20 @@ import (
 )
 
 type FakePluginInstaller struct {
-	InstallStub        func(inputSourceFilepath string) string
+	InstallStub        func(inputSourceFilepath string) (string, error)
 	installMutex       sync.RWMutex
 	installArgsForCall []struct {
 		inputSourceFilepath string
 	}
 	installReturns struct {
 		result1 string
+		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakePluginInstaller) Install(inputSourceFilepath string) string {
+func (fake *FakePluginInstaller) Install(inputSourceFilepath string) (string, error) {
 	fake.installMutex.Lock()
 	fake.installArgsForCall = append(fake.installArgsForCall, struct {
 		inputSourceFilepath string
@@ -30,7 +31,7 @@ func (fake *FakePluginInstaller) Install(inputSourceFilepath string) string {
 	if fake.InstallStub != nil {
 		return fake.InstallStub(inputSourceFilepath)
 	} else {
-		return fake.installReturns.result1
+		return fake.installReturns.result1, fake.installReturns.result2
 	}
 }
 
@@ -46,11 +47,12 @@ func (fake *FakePluginInstaller) InstallArgsForCall(i int) string {
 	return fake.installArgsForCall[i].inputSourceFilepath
 }
 
-func (fake *FakePluginInstaller) InstallReturns(result1 string) {
+func (fake *FakePluginInstaller) InstallReturns(result1 string, result2 error) {
 	fake.InstallStub = nil
 	fake.installReturns = struct {
 		result1 string
-	}{result1}
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakePluginInstaller) Invocations() map[string][][]interface{} {
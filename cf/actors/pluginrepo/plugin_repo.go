@@ -1,6 +1,7 @@
 package pluginrepo
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -19,10 +20,21 @@ type PluginRepo interface {
 	GetPlugins([]models.PluginRepo) (map[string][]clipr.Plugin, []string)
 }
 
-type pluginRepo struct{}
+type pluginRepo struct {
+	client *http.Client
+}
 
-func NewPluginRepo() PluginRepo {
-	return pluginRepo{}
+// NewPluginRepo returns a PluginRepo that requests plugin lists using
+// tlsConfig. If tlsConfig is nil the default TLS configuration is used.
+func NewPluginRepo(tlsConfig *tls.Config) PluginRepo {
+	return pluginRepo{
+		client: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+		},
+	}
 }
 
 func (r pluginRepo) GetPlugins(repos []models.PluginRepo) (map[string][]clipr.Plugin, []string) {
@@ -31,7 +43,7 @@ func (r pluginRepo) GetPlugins(repos []models.PluginRepo) (map[string][]clipr.Pl
 	repoPlugins := make(map[string][]clipr.Plugin)
 
 	for _, repo := range repos {
-		resp, err := http.Get(getListEndpoint(repo.URL))
+		resp, err := r.client.Get(getListEndpoint(repo.URL))
 		if err != nil {
 			repoError = append(repoError, fmt.Sprintf(T("Error requesting from")+" '%s' - %s", repo.Name, err.Error()))
 			continue
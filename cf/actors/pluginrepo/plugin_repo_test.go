@@ -23,7 +23,7 @@ var _ = Describe("PluginRepo", func() {
 	)
 
 	BeforeEach(func() {
-		repoActor = NewPluginRepo()
+		repoActor = NewPluginRepo(nil)
 	})
 
 	Context("request data from all repos", func() {
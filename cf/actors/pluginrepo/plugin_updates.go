@@ -0,0 +1,86 @@
+package pluginrepo
+
+import (
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/plugin"
+	"github.com/blang/semver"
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
+)
+
+// PluginUpdate is a newer version of an installed plugin that is available
+// from a plugin repository.
+type PluginUpdate struct {
+	Name           string
+	CurrentVersion semver.Version
+	LatestVersion  semver.Version
+	RepoName       string
+	Plugin         clipr.Plugin
+}
+
+// FindPluginUpdates compares the installed plugins against the plugins
+// listed by each repository and returns the installed plugins with a newer
+// version available, sorted by name. When more than one repository has a
+// newer version, the highest version wins. Installed plugins that do not
+// report a version and repository entries with an invalid version are
+// ignored.
+func FindPluginUpdates(installed map[string]pluginconfig.PluginMetadata, repoPlugins map[string][]clipr.Plugin) []PluginUpdate {
+	repoNames := []string{}
+	for repoName := range repoPlugins {
+		repoNames = append(repoNames, repoName)
+	}
+	sort.Strings(repoNames)
+
+	updates := []PluginUpdate{}
+	for name, metadata := range installed {
+		currentVersion, ok := installedVersion(metadata.Version)
+		if !ok {
+			continue
+		}
+
+		update := PluginUpdate{Name: name, CurrentVersion: currentVersion, LatestVersion: currentVersion}
+		for _, repoName := range repoNames {
+			for _, repoPlugin := range repoPlugins[repoName] {
+				if !strings.EqualFold(repoPlugin.Name, name) {
+					continue
+				}
+
+				version, err := semver.ParseTolerant(repoPlugin.Version)
+				if err != nil || !version.GT(update.LatestVersion) {
+					continue
+				}
+
+				update.LatestVersion = version
+				update.RepoName = repoName
+				update.Plugin = repoPlugin
+			}
+		}
+
+		if update.RepoName != "" {
+			updates = append(updates, update)
+		}
+	}
+
+	sort.Sort(pluginUpdatesByName(updates))
+	return updates
+}
+
+func installedVersion(version plugin.VersionType) (semver.Version, bool) {
+	if version.Major == 0 && version.Minor == 0 && version.Build == 0 {
+		return semver.Version{}, false
+	}
+
+	return semver.Version{
+		Major: uint64(version.Major),
+		Minor: uint64(version.Minor),
+		Patch: uint64(version.Build),
+	}, true
+}
+
+type pluginUpdatesByName []PluginUpdate
+
+func (u pluginUpdatesByName) Len() int           { return len(u) }
+func (u pluginUpdatesByName) Swap(i, j int)      { u[i], u[j] = u[j], u[i] }
+func (u pluginUpdatesByName) Less(i, j int) bool { return u[i].Name < u[j].Name }
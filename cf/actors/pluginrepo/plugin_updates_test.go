@@ -0,0 +1,67 @@
+package pluginrepo_test
+
+import (
+	. "code.cloudfoundry.org/cli/cf/actors/pluginrepo"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/plugin"
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FindPluginUpdates", func() {
+	var installed map[string]pluginconfig.PluginMetadata
+
+	BeforeEach(func() {
+		installed = map[string]pluginconfig.PluginMetadata{
+			"plugin-b":   {Version: plugin.VersionType{Major: 1, Minor: 2, Build: 3}},
+			"plugin-a":   {Version: plugin.VersionType{Major: 0, Minor: 1, Build: 0}},
+			"no-version": {},
+		}
+	})
+
+	It("returns the installed plugins with a newer version in a repo, sorted by name", func() {
+		updates := FindPluginUpdates(installed, map[string][]clipr.Plugin{
+			"repo1": {
+				{Name: "plugin-b", Version: "1.3.0"},
+				{Name: "PLUGIN-A", Version: "0.2.0"},
+				{Name: "no-version", Version: "9.9.9"},
+				{Name: "not-installed", Version: "1.0.0"},
+			},
+		})
+
+		Expect(updates).To(HaveLen(2))
+		Expect(updates[0].Name).To(Equal("plugin-a"))
+		Expect(updates[0].CurrentVersion.String()).To(Equal("0.1.0"))
+		Expect(updates[0].LatestVersion.String()).To(Equal("0.2.0"))
+		Expect(updates[0].RepoName).To(Equal("repo1"))
+		Expect(updates[0].Plugin.Name).To(Equal("PLUGIN-A"))
+		Expect(updates[1].Name).To(Equal("plugin-b"))
+		Expect(updates[1].LatestVersion.String()).To(Equal("1.3.0"))
+	})
+
+	It("ignores repo versions that are not newer or cannot be parsed", func() {
+		updates := FindPluginUpdates(installed, map[string][]clipr.Plugin{
+			"repo1": {
+				{Name: "plugin-b", Version: "1.2.3"},
+				{Name: "plugin-a", Version: "0.0.9"},
+				{Name: "plugin-a", Version: "not-a-version"},
+			},
+		})
+
+		Expect(updates).To(BeEmpty())
+	})
+
+	It("picks the highest version across all repos", func() {
+		updates := FindPluginUpdates(installed, map[string][]clipr.Plugin{
+			"repo1": {{Name: "plugin-b", Version: "2.0.0"}},
+			"repo2": {{Name: "plugin-b", Version: "2.1"}},
+			"repo3": {{Name: "plugin-b", Version: "1.9.0"}},
+		})
+
+		Expect(updates).To(HaveLen(1))
+		Expect(updates[0].LatestVersion.String()).To(Equal("2.1.0"))
+		Expect(updates[0].RepoName).To(Equal("repo2"))
+	})
+})
@@ -0,0 +1,87 @@
+// This file was generated by counterfeiter
+package apifakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/api"
+)
+
+type FakeRawRequestRepository struct {
+	RequestStub        func(method, path string, body []byte) (api.RawResponse, error)
+	requestMutex       sync.RWMutex
+	requestArgsForCall []struct {
+		method string
+		path   string
+		body   []byte
+	}
+	requestReturns struct {
+		result1 api.RawResponse
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeRawRequestRepository) Request(method string, path string, body []byte) (api.RawResponse, error) {
+	var bodyCopy []byte
+	if body != nil {
+		bodyCopy = make([]byte, len(body))
+		copy(bodyCopy, body)
+	}
+	fake.requestMutex.Lock()
+	fake.requestArgsForCall = append(fake.requestArgsForCall, struct {
+		method string
+		path   string
+		body   []byte
+	}{method, path, bodyCopy})
+	fake.recordInvocation("Request", []interface{}{method, path, bodyCopy})
+	fake.requestMutex.Unlock()
+	if fake.RequestStub != nil {
+		return fake.RequestStub(method, path, body)
+	} else {
+		return fake.requestReturns.result1, fake.requestReturns.result2
+	}
+}
+
+func (fake *FakeRawRequestRepository) RequestCallCount() int {
+	fake.requestMutex.RLock()
+	defer fake.requestMutex.RUnlock()
+	return len(fake.requestArgsForCall)
+}
+
+func (fake *FakeRawRequestRepository) RequestArgsForCall(i int) (string, string, []byte) {
+	fake.requestMutex.RLock()
+	defer fake.requestMutex.RUnlock()
+	return fake.requestArgsForCall[i].method, fake.requestArgsForCall[i].path, fake.requestArgsForCall[i].body
+}
+
+func (fake *FakeRawRequestRepository) RequestReturns(result1 api.RawResponse, result2 error) {
+	fake.RequestStub = nil
+	fake.requestReturns = struct {
+		result1 api.RawResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeRawRequestRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.requestMutex.RLock()
+	defer fake.requestMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeRawRequestRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ api.RawRequestRepository = new(FakeRawRequestRepository)
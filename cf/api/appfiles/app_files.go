@@ -2,8 +2,10 @@ package appfiles
 
 import (
 	"fmt"
+	"net/http"
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
 	"code.cloudfoundry.org/cli/cf/net"
 )
 
@@ -11,6 +13,7 @@ import (
 
 type Repository interface {
 	ListFiles(appGUID string, instance int, path string) (files string, apiErr error)
+	GetFileFromOffset(appGUID string, instance int, path string, offset int64) (contents string, apiErr error)
 }
 
 type CloudControllerAppFilesRepository struct {
@@ -25,8 +28,7 @@ func NewCloudControllerAppFilesRepository(config coreconfig.Reader, gateway net.
 }
 
 func (repo CloudControllerAppFilesRepository) ListFiles(appGUID string, instance int, path string) (files string, apiErr error) {
-	url := fmt.Sprintf("%s/v2/apps/%s/instances/%d/files/%s", repo.config.APIEndpoint(), appGUID, instance, path)
-	request, apiErr := repo.gateway.NewRequest("GET", url, repo.config.AccessToken(), nil)
+	request, apiErr := repo.newFilesRequest(appGUID, instance, path)
 	if apiErr != nil {
 		return
 	}
@@ -34,3 +36,59 @@ func (repo CloudControllerAppFilesRepository) ListFiles(appGUID string, instance
 	files, _, apiErr = repo.gateway.PerformRequestForTextResponse(request)
 	return
 }
+
+// FileTruncatedError is returned by GetFileFromOffset when the file has
+// become shorter than the offset, for instance because it was truncated or
+// rotated.
+type FileTruncatedError struct {
+	Path string
+}
+
+func (err *FileTruncatedError) Error() string {
+	return fmt.Sprintf("%s was truncated", err.Path)
+}
+
+// GetFileFromOffset returns the contents of the file at path starting at the
+// given byte offset. An empty string is returned when the file has not grown
+// past offset, and a *FileTruncatedError when it has shrunk below it.
+func (repo CloudControllerAppFilesRepository) GetFileFromOffset(appGUID string, instance int, path string, offset int64) (contents string, apiErr error) {
+	request, apiErr := repo.newFilesRequest(appGUID, instance, path)
+	if apiErr != nil {
+		return
+	}
+
+	// Ask for the byte before the offset as well, so that a file which has
+	// not grown can be told apart from one which is now shorter than offset.
+	rangeStart := offset
+	if offset > 0 {
+		rangeStart = offset - 1
+	}
+	request.HTTPReq.Header.Set("Range", fmt.Sprintf("bytes=%d-", rangeStart))
+
+	contents, headers, apiErr := repo.gateway.PerformRequestForTextResponse(request)
+	if httpErr, ok := apiErr.(errors.HTTPError); ok && httpErr.StatusCode() == http.StatusRequestedRangeNotSatisfiable {
+		if offset > 0 {
+			return "", &FileTruncatedError{Path: path}
+		}
+		return "", nil
+	}
+	if apiErr != nil {
+		return
+	}
+
+	// Servers that ignore the Range header send back the whole file.
+	if headers.Get("Content-Range") == "" {
+		rangeStart = 0
+	}
+
+	skip := offset - rangeStart
+	if int64(len(contents)) < skip {
+		return "", &FileTruncatedError{Path: path}
+	}
+	return contents[skip:], nil
+}
+
+func (repo CloudControllerAppFilesRepository) newFilesRequest(appGUID string, instance int, path string) (*net.Request, error) {
+	url := fmt.Sprintf("%s/v2/apps/%s/instances/%d/files/%s", repo.config.APIEndpoint(), appGUID, instance, path)
+	return repo.gateway.NewRequest("GET", url, repo.config.AccessToken(), nil)
+}
@@ -67,4 +67,123 @@ var _ = Describe("AppFilesRepository", func() {
 		Expect(err).ToNot(HaveOccurred())
 		Expect(list).To(Equal(expectedResponse))
 	})
+
+	Describe("GetFileFromOffset", func() {
+		var (
+			fileServer *httptest.Server
+			rangeSeen  string
+			status     int
+			headers    http.Header
+			body       string
+			repo       CloudControllerAppFilesRepository
+		)
+
+		BeforeEach(func() {
+			status = http.StatusPartialContent
+			headers = http.Header{"Content-Range": {"bytes 5-11/12"}}
+			body = " world\n"
+
+			fileServer = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				rangeSeen = request.Header.Get("Range")
+				for key, values := range headers {
+					writer.Header()[key] = values
+				}
+				writer.WriteHeader(status)
+				fmt.Fprint(writer, body)
+			}))
+
+			configRepo := testconfig.NewRepositoryWithDefaults()
+			configRepo.SetAPIEndpoint(fileServer.URL)
+
+			gateway := net.NewCloudControllerGateway(configRepo, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+			repo = NewCloudControllerAppFilesRepository(configRepo, gateway)
+		})
+
+		AfterEach(func() {
+			fileServer.Close()
+		})
+
+		It("requests the file from the given offset", func() {
+			contents, err := repo.GetFileFromOffset("my-app-guid", 1, "logs/stdout.log", 6)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rangeSeen).To(Equal("bytes=5-"))
+			Expect(contents).To(Equal("world\n"))
+		})
+
+		Context("when the offset is 0", func() {
+			BeforeEach(func() {
+				headers = http.Header{"Content-Range": {"bytes 0-11/12"}}
+				body = "hello world\n"
+			})
+
+			It("requests the whole file", func() {
+				contents, err := repo.GetFileFromOffset("my-app-guid", 1, "logs/stdout.log", 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(rangeSeen).To(Equal("bytes=0-"))
+				Expect(contents).To(Equal("hello world\n"))
+			})
+
+			Context("when the file is empty", func() {
+				BeforeEach(func() {
+					status = http.StatusRequestedRangeNotSatisfiable
+					headers = http.Header{}
+					body = ""
+				})
+
+				It("returns no contents and no error", func() {
+					contents, err := repo.GetFileFromOffset("my-app-guid", 1, "logs/stdout.log", 0)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(contents).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("when the file has not grown past the offset", func() {
+			BeforeEach(func() {
+				headers = http.Header{"Content-Range": {"bytes 11-11/12"}}
+				body = "\n"
+			})
+
+			It("returns no contents and no error", func() {
+				contents, err := repo.GetFileFromOffset("my-app-guid", 1, "logs/stdout.log", 12)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(contents).To(BeEmpty())
+			})
+		})
+
+		Context("when the file is now shorter than the offset", func() {
+			BeforeEach(func() {
+				status = http.StatusRequestedRangeNotSatisfiable
+				headers = http.Header{"Content-Range": {"bytes */4"}}
+				body = ""
+			})
+
+			It("returns a FileTruncatedError", func() {
+				_, err := repo.GetFileFromOffset("my-app-guid", 1, "logs/stdout.log", 12)
+				Expect(err).To(BeAssignableToTypeOf(&FileTruncatedError{}))
+				Expect(err).To(MatchError("logs/stdout.log was truncated"))
+			})
+		})
+
+		Context("when the server ignores the range", func() {
+			BeforeEach(func() {
+				status = http.StatusOK
+				headers = http.Header{}
+				body = "hello world\n"
+			})
+
+			It("returns the contents after the offset", func() {
+				contents, err := repo.GetFileFromOffset("my-app-guid", 1, "logs/stdout.log", 6)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(contents).To(Equal("world\n"))
+			})
+
+			Context("when the file is now shorter than the offset", func() {
+				It("returns a FileTruncatedError", func() {
+					_, err := repo.GetFileFromOffset("my-app-guid", 1, "logs/stdout.log", 20)
+					Expect(err).To(BeAssignableToTypeOf(&FileTruncatedError{}))
+				})
+			})
+		})
+	})
 })
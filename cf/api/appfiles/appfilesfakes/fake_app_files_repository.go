@@ -19,6 +19,20 @@ type FakeAppFilesRepository struct {
 		result1 string
 		result2 error
 	}
+	GetFileFromOffsetStub        func(appGUID string, instance int, path string, offset int64) (contents string, apiErr error)
+	getFileFromOffsetMutex       sync.RWMutex
+	getFileFromOffsetArgsForCall []struct {
+		appGUID  string
+		instance int
+		path     string
+		offset   int64
+	}
+	getFileFromOffsetReturns struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
 }
 
 func (fake *FakeAppFilesRepository) ListFiles(appGUID string, instance int, path string) (files string, apiErr error) {
@@ -28,6 +42,7 @@ func (fake *FakeAppFilesRepository) ListFiles(appGUID string, instance int, path
 		instance int
 		path     string
 	}{appGUID, instance, path})
+	fake.recordInvocation("ListFiles", []interface{}{appGUID, instance, path})
 	fake.listFilesMutex.Unlock()
 	if fake.ListFilesStub != nil {
 		return fake.ListFilesStub(appGUID, instance, path)
@@ -56,4 +71,63 @@ func (fake *FakeAppFilesRepository) ListFilesReturns(result1 string, result2 err
 	}{result1, result2}
 }
 
+func (fake *FakeAppFilesRepository) GetFileFromOffset(appGUID string, instance int, path string, offset int64) (contents string, apiErr error) {
+	fake.getFileFromOffsetMutex.Lock()
+	fake.getFileFromOffsetArgsForCall = append(fake.getFileFromOffsetArgsForCall, struct {
+		appGUID  string
+		instance int
+		path     string
+		offset   int64
+	}{appGUID, instance, path, offset})
+	fake.recordInvocation("GetFileFromOffset", []interface{}{appGUID, instance, path, offset})
+	fake.getFileFromOffsetMutex.Unlock()
+	if fake.GetFileFromOffsetStub != nil {
+		return fake.GetFileFromOffsetStub(appGUID, instance, path, offset)
+	} else {
+		return fake.getFileFromOffsetReturns.result1, fake.getFileFromOffsetReturns.result2
+	}
+}
+
+func (fake *FakeAppFilesRepository) GetFileFromOffsetCallCount() int {
+	fake.getFileFromOffsetMutex.RLock()
+	defer fake.getFileFromOffsetMutex.RUnlock()
+	return len(fake.getFileFromOffsetArgsForCall)
+}
+
+func (fake *FakeAppFilesRepository) GetFileFromOffsetArgsForCall(i int) (string, int, string, int64) {
+	fake.getFileFromOffsetMutex.RLock()
+	defer fake.getFileFromOffsetMutex.RUnlock()
+	return fake.getFileFromOffsetArgsForCall[i].appGUID, fake.getFileFromOffsetArgsForCall[i].instance, fake.getFileFromOffsetArgsForCall[i].path, fake.getFileFromOffsetArgsForCall[i].offset
+}
+
+func (fake *FakeAppFilesRepository) GetFileFromOffsetReturns(result1 string, result2 error) {
+	fake.GetFileFromOffsetStub = nil
+	fake.getFileFromOffsetReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAppFilesRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listFilesMutex.RLock()
+	defer fake.listFilesMutex.RUnlock()
+	fake.getFileFromOffsetMutex.RLock()
+	defer fake.getFileFromOffsetMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAppFilesRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
 var _ appfiles.Repository = new(FakeAppFilesRepository)
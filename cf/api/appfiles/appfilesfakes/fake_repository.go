@@ -19,6 +19,18 @@ type FakeRepository struct {
 		result1 string
 		result2 error
 	}
+	GetFileFromOffsetStub        func(appGUID string, instance int, path string, offset int64) (contents string, apiErr error)
+	getFileFromOffsetMutex       sync.RWMutex
+	getFileFromOffsetArgsForCall []struct {
+		appGUID  string
+		instance int
+		path     string
+		offset   int64
+	}
+	getFileFromOffsetReturns struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -59,11 +71,50 @@ func (fake *FakeRepository) ListFilesReturns(result1 string, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) GetFileFromOffset(appGUID string, instance int, path string, offset int64) (contents string, apiErr error) {
+	fake.getFileFromOffsetMutex.Lock()
+	fake.getFileFromOffsetArgsForCall = append(fake.getFileFromOffsetArgsForCall, struct {
+		appGUID  string
+		instance int
+		path     string
+		offset   int64
+	}{appGUID, instance, path, offset})
+	fake.recordInvocation("GetFileFromOffset", []interface{}{appGUID, instance, path, offset})
+	fake.getFileFromOffsetMutex.Unlock()
+	if fake.GetFileFromOffsetStub != nil {
+		return fake.GetFileFromOffsetStub(appGUID, instance, path, offset)
+	} else {
+		return fake.getFileFromOffsetReturns.result1, fake.getFileFromOffsetReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetFileFromOffsetCallCount() int {
+	fake.getFileFromOffsetMutex.RLock()
+	defer fake.getFileFromOffsetMutex.RUnlock()
+	return len(fake.getFileFromOffsetArgsForCall)
+}
+
+func (fake *FakeRepository) GetFileFromOffsetArgsForCall(i int) (string, int, string, int64) {
+	fake.getFileFromOffsetMutex.RLock()
+	defer fake.getFileFromOffsetMutex.RUnlock()
+	return fake.getFileFromOffsetArgsForCall[i].appGUID, fake.getFileFromOffsetArgsForCall[i].instance, fake.getFileFromOffsetArgsForCall[i].path, fake.getFileFromOffsetArgsForCall[i].offset
+}
+
+func (fake *FakeRepository) GetFileFromOffsetReturns(result1 string, result2 error) {
+	fake.GetFileFromOffsetStub = nil
+	fake.getFileFromOffsetReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.listFilesMutex.RLock()
 	defer fake.listFilesMutex.RUnlock()
+	fake.getFileFromOffsetMutex.RLock()
+	defer fake.getFileFromOffsetMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -1,7 +1,6 @@
 package authentication
 
 import (
-	"crypto/tls"
 	"encoding/base64"
 	"fmt"
 	"net/http"
@@ -9,10 +8,13 @@ import (
 	"strings"
 	"time"
 
+	uaaclient "code.cloudfoundry.org/cli/api/uaa"
+	"code.cloudfoundry.org/cli/cf"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/errors"
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/cli/version"
 )
 
 //go:generate counterfeiter . TokenRefresher
@@ -28,6 +30,7 @@ type Repository interface {
 
 	RefreshAuthToken() (updatedToken string, apiErr error)
 	Authenticate(credentials map[string]string) (apiErr error)
+	AuthenticateWithBrowser(openBrowser func(url string) error) error
 	Authorize(token string) (string, error)
 	GetLoginPromptsAndSaveUAAServerURL() (map[string]coreconfig.AuthPrompt, error)
 }
@@ -40,6 +43,20 @@ type UAARepository struct {
 
 var ErrPreventRedirect = errors.New("prevent-redirect")
 
+// BrowserLoginTimeout is how long AuthenticateWithBrowser waits for the user
+// to finish logging in.
+var BrowserLoginTimeout = 5 * time.Minute
+
+// BrowserUnavailableError is returned by AuthenticateWithBrowser when the
+// login page could not be opened or the login was not completed in time.
+type BrowserUnavailableError struct {
+	Err error
+}
+
+func (e BrowserUnavailableError) Error() string {
+	return e.Err.Error()
+}
+
 func NewUAARepository(gateway net.Gateway, config coreconfig.ReadWriter, dumper net.RequestDumper) UAARepository {
 	return UAARepository{
 		config:  config,
@@ -56,10 +73,8 @@ func (uaa UAARepository) Authorize(token string) (string, error) {
 		},
 		Timeout: 30 * time.Second,
 		Transport: &http.Transport{
-			DisableKeepAlives: true,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: uaa.config.IsSSLDisabled(),
-			},
+			DisableKeepAlives:   true,
+			TLSClientConfig:     uaa.gateway.TLSConfig(),
 			Proxy:               http.ProxyFromEnvironment,
 			TLSHandshakeTimeout: 10 * time.Second,
 		},
@@ -137,6 +152,74 @@ func (uaa UAARepository) Authenticate(credentials map[string]string) error {
 	return nil
 }
 
+// AuthenticateWithBrowser logs in with the authorization code grant. It
+// listens on a loopback port, passes the UAA authorize URL to openBrowser and
+// exchanges the code the UAA redirects back with for tokens. PKCE ties the
+// code to this process.
+func (uaa UAARepository) AuthenticateWithBrowser(openBrowser func(url string) error) error {
+	client := uaa.newUAAClient()
+
+	pkce, err := uaaclient.NewPKCE()
+	if err != nil {
+		return err
+	}
+	state, err := uaaclient.NewState()
+	if err != nil {
+		return err
+	}
+
+	listener, err := uaaclient.NewAuthorizationCodeListener(state)
+	if err != nil {
+		return BrowserUnavailableError{Err: err}
+	}
+	defer listener.Close()
+
+	authorizationURL, err := client.AuthorizationURL(listener.RedirectURI(), state, pkce)
+	if err != nil {
+		return err
+	}
+
+	err = openBrowser(authorizationURL)
+	if err != nil {
+		return BrowserUnavailableError{Err: err}
+	}
+
+	code, err := listener.Wait(BrowserLoginTimeout)
+	if _, ok := err.(uaaclient.AuthorizationTimeoutError); ok {
+		return BrowserUnavailableError{Err: err}
+	}
+	if err != nil {
+		return err
+	}
+
+	err = uaa.requestTokens(uaa.config.UaaEndpoint(), url.Values{
+		"code":          {code},
+		"code_verifier": {pkce.Verifier},
+		"grant_type":    {"authorization_code"},
+		"redirect_uri":  {listener.RedirectURI()},
+	})
+	if httpError, ok := err.(errors.HTTPError); ok && httpError.StatusCode() == http.StatusUnauthorized {
+		return errors.New(T("Credentials were rejected, please try again."))
+	}
+	return err
+}
+
+// newUAAClient returns a client that builds the authorize URL. It does not
+// send requests; those go through the gateway, so they are traced like the
+// others.
+func (uaa UAARepository) newUAAClient() *uaaclient.Client {
+	return uaaclient.NewClient(uaaclient.Config{
+		AppName:            cf.Name,
+		AppVersion:         version.VersionString(),
+		ClientID:           uaa.config.UAAOAuthClient(),
+		ClientSecret:       uaa.config.UAAOAuthClientSecret(),
+		SkipSSLValidation:  uaa.config.IsSSLDisabled(),
+		RootCAs:            uaa.gateway.Certificates().RootCAs,
+		ClientCertificates: uaa.gateway.Certificates().ClientCertificates,
+		URL:                uaa.config.UaaEndpoint(),
+	})
+}
+
 func (uaa UAARepository) DumpRequest(req *http.Request) {
 	uaa.dumper.DumpRequest(req)
 }
@@ -187,6 +270,14 @@ func (uaa UAARepository) RefreshAuthToken() (string, error) {
 		"scope":         {""},
 	}
 
+	// Tokens obtained with client credentials have no refresh token; new ones
+	// are requested with the stored client credentials instead.
+	if uaa.config.UAAGrantType() == "client_credentials" {
+		data = url.Values{
+			"grant_type": {"client_credentials"},
+		}
+	}
+
 	apiErr := uaa.getAuthToken(data)
 	updatedToken := uaa.config.AccessToken()
 
@@ -194,6 +285,12 @@ func (uaa UAARepository) RefreshAuthToken() (string, error) {
 }
 
 func (uaa UAARepository) getAuthToken(data url.Values) error {
+	return uaa.requestTokens(uaa.config.AuthenticationEndpoint(), data)
+}
+
+// requestTokens requests tokens from the token endpoint of the given
+// authorization server and saves them to the config.
+func (uaa UAARepository) requestTokens(endpoint string, data url.Values) error {
 	type uaaErrorResponse struct {
 		Code        string `json:"error"`
 		Description string `json:"error_description"`
@@ -206,7 +303,7 @@ func (uaa UAARepository) getAuthToken(data url.Values) error {
 		Error        uaaErrorResponse `json:"error"`
 	}
 
-	path := fmt.Sprintf("%s/oauth/token", uaa.config.AuthenticationEndpoint())
+	path := fmt.Sprintf("%s/oauth/token", endpoint)
 	accessToken := "Basic " + base64.StdEncoding.EncodeToString([]byte(uaa.config.UAAOAuthClient()+":"+uaa.config.UAAOAuthClientSecret()))
 	request, err := uaa.gateway.NewRequest("POST", path, accessToken, strings.NewReader(data.Encode()))
 	if err != nil {
@@ -1,10 +1,14 @@
 package authentication_test
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/net"
@@ -221,6 +225,21 @@ var _ = Describe("AuthenticationRepository", func() {
 					Expect(apiErr).NotTo(BeNil())
 				})
 			})
+
+			Context("when logged in with client credentials", func() {
+				BeforeEach(func() {
+					setupTestServer(clientCredentialsRefreshRequest)
+					config.SetUAAOAuthClient("some-client")
+					config.SetUAAOAuthClientSecret("some-secret")
+					config.SetUAAGrantType("client_credentials")
+				})
+
+				It("requests a new token with the client credentials", func() {
+					Expect(apiErr).NotTo(HaveOccurred())
+					Expect(handler).To(HaveAllRequestsCalled())
+					Expect(config.AccessToken()).To(Equal("BEARER my_access_token"))
+				})
+			})
 		})
 	})
 
@@ -323,6 +342,132 @@ var _ = Describe("AuthenticationRepository", func() {
 			})
 		})
 	})
+
+	Describe("AuthenticateWithBrowser", func() {
+		var (
+			uaaServer   *ghttp.Server
+			config      coreconfig.ReadWriter
+			authRepo    Repository
+			openBrowser func(string) error
+			challenge   string
+			fakePrinter *tracefakes.FakePrinter
+			err         error
+		)
+
+		BeforeEach(func() {
+			uaaServer = ghttp.NewServer()
+			config = testconfig.NewRepository()
+			config.SetUaaEndpoint(uaaServer.URL())
+			config.SetUAAOAuthClient("cf")
+
+			fakePrinter = new(tracefakes.FakePrinter)
+			gateway := net.NewUAAGateway(config, new(terminalfakes.FakeUI), fakePrinter, "")
+			authRepo = NewUAARepository(gateway, config, net.NewRequestDumper(fakePrinter))
+
+			// Stands in for the browser: the user logs in and the UAA redirects
+			// back to the CLI with a code.
+			openBrowser = func(rawURL string) error {
+				authorizeURL, parseErr := url.Parse(rawURL)
+				Expect(parseErr).NotTo(HaveOccurred())
+				Expect(authorizeURL.Path).To(Equal("/oauth/authorize"))
+
+				query := authorizeURL.Query()
+				Expect(query.Get("client_id")).To(Equal("cf"))
+				Expect(query.Get("code_challenge_method")).To(Equal("S256"))
+				challenge = query.Get("code_challenge")
+
+				go func() {
+					defer GinkgoRecover()
+					response, getErr := http.Get(query.Get("redirect_uri") + "?code=the-code&state=" + url.QueryEscape(query.Get("state")))
+					Expect(getErr).NotTo(HaveOccurred())
+					response.Body.Close()
+				}()
+				return nil
+			}
+
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/oauth/token"),
+					func(w http.ResponseWriter, req *http.Request) {
+						Expect(req.ParseForm()).To(Succeed())
+						Expect(req.Form.Get("grant_type")).To(Equal("authorization_code"))
+						Expect(req.Form.Get("code")).To(Equal("the-code"))
+
+						sum := sha256.Sum256([]byte(req.Form.Get("code_verifier")))
+						Expect(base64.RawURLEncoding.EncodeToString(sum[:])).To(Equal(challenge))
+					},
+					ghttp.RespondWith(http.StatusOK, `{
+						"access_token": "my_access_token",
+						"token_type": "bearer",
+						"refresh_token": "my_refresh_token"
+					}`),
+				),
+			)
+		})
+
+		AfterEach(func() {
+			uaaServer.Close()
+		})
+
+		JustBeforeEach(func() {
+			err = authRepo.AuthenticateWithBrowser(openBrowser)
+		})
+
+		It("exchanges the code from the redirect for tokens", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uaaServer.ReceivedRequests()).To(HaveLen(1))
+			Expect(config.AccessToken()).To(Equal("bearer my_access_token"))
+			Expect(config.RefreshToken()).To(Equal("my_refresh_token"))
+		})
+
+		It("traces the code exchange like other requests", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			traced := []string{}
+			for i := 0; i < fakePrinter.PrintfCallCount(); i++ {
+				format, args := fakePrinter.PrintfArgsForCall(i)
+				traced = append(traced, fmt.Sprintf(format, args...))
+			}
+			Expect(traced).To(ContainElement(ContainSubstring("POST /oauth/token")))
+		})
+
+		Context("when the login is not completed in time", func() {
+			var originalTimeout time.Duration
+
+			BeforeEach(func() {
+				originalTimeout = BrowserLoginTimeout
+				BrowserLoginTimeout = 10 * time.Millisecond
+
+				openBrowser = func(string) error {
+					return nil
+				}
+			})
+
+			AfterEach(func() {
+				BrowserLoginTimeout = originalTimeout
+			})
+
+			It("returns a BrowserUnavailableError", func() {
+				Expect(err).To(BeAssignableToTypeOf(BrowserUnavailableError{}))
+				Expect(uaaServer.ReceivedRequests()).To(BeEmpty())
+				Expect(config.AccessToken()).To(BeEmpty())
+			})
+		})
+
+		Context("when the browser cannot be opened", func() {
+			BeforeEach(func() {
+				openBrowser = func(string) error {
+					return errors.New("no browser available")
+				}
+			})
+
+			It("returns a BrowserUnavailableError", func() {
+				Expect(err).To(BeAssignableToTypeOf(BrowserUnavailableError{}))
+				Expect(uaaServer.ReceivedRequests()).To(BeEmpty())
+				Expect(config.AccessToken()).To(BeEmpty())
+			})
+		})
+	})
 })
 
 var authHeaders = http.Header{
@@ -361,6 +506,34 @@ var successfulLoginMatcher = func(request *http.Request) {
 	Expect(request.Form.Get("scope")).To(Equal(""))
 }
 
+var clientCredentialsRefreshRequest = testnet.TestRequest{
+	Method: "POST",
+	Path:   "/oauth/token",
+	Header: http.Header{
+		"accept":        {"application/json"},
+		"content-type":  {"application/x-www-form-urlencoded"},
+		"authorization": {"Basic " + base64.StdEncoding.EncodeToString([]byte("some-client:some-secret"))},
+	},
+	Matcher: func(request *http.Request) {
+		err := request.ParseForm()
+		if err != nil {
+			Fail(fmt.Sprintf("Failed to parse form: %s", err))
+			return
+		}
+
+		Expect(request.Form.Get("grant_type")).To(Equal("client_credentials"))
+		Expect(request.Form).ToNot(HaveKey("refresh_token"))
+	},
+	Response: testnet.TestResponse{
+		Status: http.StatusOK,
+		Body: `
+{
+  "access_token": "my_access_token",
+  "token_type": "BEARER",
+  "expires_in": 98765
+} `},
+}
+
 var unsuccessfulLoginRequest = testnet.TestRequest{
 	Method: "POST",
 	Path:   "/oauth/token",
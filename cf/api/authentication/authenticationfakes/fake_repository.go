@@ -35,6 +35,14 @@ type FakeRepository struct {
 	authenticateReturns struct {
 		result1 error
 	}
+	AuthenticateWithBrowserStub        func(openBrowser func(url string) error) error
+	authenticateWithBrowserMutex       sync.RWMutex
+	authenticateWithBrowserArgsForCall []struct {
+		openBrowser func(url string) error
+	}
+	authenticateWithBrowserReturns struct {
+		result1 error
+	}
 	AuthorizeStub        func(token string) (string, error)
 	authorizeMutex       sync.RWMutex
 	authorizeArgsForCall []struct {
@@ -162,6 +170,39 @@ func (fake *FakeRepository) AuthenticateReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeRepository) AuthenticateWithBrowser(openBrowser func(url string) error) error {
+	fake.authenticateWithBrowserMutex.Lock()
+	fake.authenticateWithBrowserArgsForCall = append(fake.authenticateWithBrowserArgsForCall, struct {
+		openBrowser func(url string) error
+	}{openBrowser})
+	fake.recordInvocation("AuthenticateWithBrowser", []interface{}{openBrowser})
+	fake.authenticateWithBrowserMutex.Unlock()
+	if fake.AuthenticateWithBrowserStub != nil {
+		return fake.AuthenticateWithBrowserStub(openBrowser)
+	} else {
+		return fake.authenticateWithBrowserReturns.result1
+	}
+}
+
+func (fake *FakeRepository) AuthenticateWithBrowserCallCount() int {
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
+	return len(fake.authenticateWithBrowserArgsForCall)
+}
+
+func (fake *FakeRepository) AuthenticateWithBrowserArgsForCall(i int) func(url string) error {
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
+	return fake.authenticateWithBrowserArgsForCall[i].openBrowser
+}
+
+func (fake *FakeRepository) AuthenticateWithBrowserReturns(result1 error) {
+	fake.AuthenticateWithBrowserStub = nil
+	fake.authenticateWithBrowserReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeRepository) Authorize(token string) (string, error) {
 	fake.authorizeMutex.Lock()
 	fake.authorizeArgsForCall = append(fake.authorizeArgsForCall, struct {
@@ -233,6 +274,8 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.refreshAuthTokenMutex.RUnlock()
 	fake.authenticateMutex.RLock()
 	defer fake.authenticateMutex.RUnlock()
+	fake.authenticateWithBrowserMutex.RLock()
+	defer fake.authenticateWithBrowserMutex.RUnlock()
 	fake.authorizeMutex.RLock()
 	defer fake.authorizeMutex.RUnlock()
 	fake.getLoginPromptsAndSaveUAAServerURLMutex.RLock()
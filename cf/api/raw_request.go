@@ -0,0 +1,107 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/retry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/errors"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+//go:generate counterfeiter . RawRequestRepository
+
+// RawRequestRepository sends arbitrary requests to an API on behalf of
+// plugins. Requests go through the CLI's gateway, so they share its TLS,
+// proxy, tracing and token refresh behavior.
+type RawRequestRepository interface {
+	Request(method, path string, body []byte) (RawResponse, error)
+}
+
+// RawResponse is the unprocessed response to a RawRequestRepository request.
+// Error statuses are returned as responses rather than errors.
+type RawResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+type GatewayRawRequestRepository struct {
+	endpoint func() string
+	config   coreconfig.Reader
+	gateway  net.Gateway
+	policy   retry.Policy
+}
+
+func NewCloudControllerRawRequestRepository(config coreconfig.Reader, gateway net.Gateway, policy retry.Policy) GatewayRawRequestRepository {
+	return GatewayRawRequestRepository{
+		endpoint: config.APIEndpoint,
+		config:   config,
+		gateway:  gateway,
+		policy:   policy,
+	}
+}
+
+func NewUAARawRequestRepository(config coreconfig.Reader, gateway net.Gateway, policy retry.Policy) GatewayRawRequestRepository {
+	return GatewayRawRequestRepository{
+		endpoint: config.UaaEndpoint,
+		config:   config,
+		gateway:  gateway,
+		policy:   policy,
+	}
+}
+
+func (repo GatewayRawRequestRepository) Request(method, path string, body []byte) (RawResponse, error) {
+	if method == "" {
+		method = "GET"
+	}
+	url := fmt.Sprintf("%s/%s", strings.TrimRight(repo.endpoint(), "/"), strings.TrimLeft(path, "/"))
+
+	for attempt := 1; ; attempt++ {
+		request, err := repo.gateway.NewRequest(method, url, repo.config.AccessToken(), bytes.NewReader(body))
+		if err != nil {
+			return RawResponse{}, err
+		}
+
+		response, err := repo.gateway.PerformRequest(request)
+		switch err.(type) {
+		case nil, errors.HTTPError, *errors.InvalidTokenError:
+			if response != nil {
+				err = nil
+			}
+		}
+
+		if !repo.policy.ShouldRetry(attempt, method, response) {
+			if err != nil {
+				return RawResponse{}, err
+			}
+			return readRawResponse(response)
+		}
+
+		if response != nil {
+			response.Body.Close()
+		}
+		time.Sleep(repo.policy.Delay(attempt, response, time.Now()))
+	}
+}
+
+func readRawResponse(response *http.Response) (RawResponse, error) {
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return RawResponse{}, fmt.Errorf("%s: %s", T("Error reading response"), err.Error())
+	}
+
+	return RawResponse{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       body,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package api_test
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/api/retry"
+	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
+	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	testconfig "code.cloudfoundry.org/cli/util/testhelpers/configuration"
+
+	. "code.cloudfoundry.org/cli/cf/api"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("GatewayRawRequestRepository", func() {
+	var (
+		server *ghttp.Server
+		config coreconfig.ReadWriter
+		policy retry.Policy
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		config = testconfig.NewRepository()
+		config.SetAccessToken("bearer my-access-token")
+		config.SetAPIEndpoint(server.URL())
+		policy = retry.Policy{MaxRetries: 2}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("NewCloudControllerRawRequestRepository", func() {
+		var (
+			authRepo *authenticationfakes.FakeRepository
+			repo     RawRequestRepository
+		)
+
+		BeforeEach(func() {
+			authRepo = new(authenticationfakes.FakeRepository)
+			gateway := net.NewCloudControllerGateway(config, time.Now, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+			gateway.SetTokenRefresher(authRepo)
+			repo = NewCloudControllerRawRequestRepository(config, gateway, policy)
+		})
+
+		It("sends the request to the API with the access token", func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/v3/apps/some-guid"),
+					ghttp.VerifyHeaderKV("Authorization", "bearer my-access-token"),
+					ghttp.VerifyBody([]byte(`{"name":"new-name"}`)),
+					ghttp.RespondWith(http.StatusOK, `{"guid":"some-guid"}`, http.Header{"X-Some-Header": {"some-value"}}),
+				),
+			)
+
+			response, err := repo.Request("PUT", "/v3/apps/some-guid", []byte(`{"name":"new-name"}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Header.Get("X-Some-Header")).To(Equal("some-value"))
+			Expect(response.Body).To(MatchJSON(`{"guid":"some-guid"}`))
+		})
+
+		It("uses GET when no method is given", func() {
+			server.AppendHandlers(ghttp.VerifyRequest("GET", "/v2/info"))
+
+			_, err := repo.Request("", "v2/info", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns error statuses as responses", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusNotFound, `{"code":10000,"description":"Unknown request"}`),
+			)
+
+			response, err := repo.Request("GET", "/v2/nowhere", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			Expect(response.Body).To(MatchJSON(`{"code":10000,"description":"Unknown request"}`))
+		})
+
+		It("retries server errors according to the retry policy", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, `{}`),
+				ghttp.RespondWith(http.StatusServiceUnavailable, `{}`),
+				ghttp.RespondWith(http.StatusOK, `{"ok":true}`),
+			)
+
+			response, err := repo.Request("GET", "/v2/info", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(server.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("does not retry server errors for POST requests", func() {
+			server.AppendHandlers(
+				ghttp.RespondWith(http.StatusServiceUnavailable, `{}`),
+			)
+
+			response, err := repo.Request("POST", "/v2/apps", []byte(`{}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusServiceUnavailable))
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("refreshes an expired access token and sends the request again", func() {
+			authRepo.RefreshAuthTokenReturns("bearer new-access-token", nil)
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyHeaderKV("Authorization", "bearer my-access-token"),
+					ghttp.RespondWith(http.StatusUnauthorized, `{"code":1000,"description":"Invalid Auth Token"}`),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyHeaderKV("Authorization", "bearer new-access-token"),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				),
+			)
+
+			response, err := repo.Request("GET", "/v2/apps", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(authRepo.RefreshAuthTokenCallCount()).To(Equal(1))
+		})
+	})
+
+	Describe("NewUAARawRequestRepository", func() {
+		It("sends the request to the UAA endpoint", func() {
+			uaaServer := ghttp.NewServer()
+			defer uaaServer.Close()
+			config.SetUaaEndpoint(uaaServer.URL())
+
+			uaaServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/Users"),
+					ghttp.VerifyHeaderKV("Authorization", "bearer my-access-token"),
+					ghttp.RespondWith(http.StatusOK, `{"resources":[]}`),
+				),
+			)
+
+			gateway := net.NewUAAGateway(config, new(terminalfakes.FakeUI), new(tracefakes.FakePrinter), "")
+			repo := NewUAARawRequestRepository(config, gateway, policy)
+
+			response, err := repo.Request("GET", "/Users", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Body).To(MatchJSON(`{"resources":[]}`))
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
@@ -3,9 +3,11 @@ package api
 import (
 	"crypto/tls"
 	"net/http"
+	"os"
 	"strconv"
 	"time"
 
+	"code.cloudfoundry.org/cli/api/retry"
 	"code.cloudfoundry.org/cli/cf/api/appevents"
 	api_appfiles "code.cloudfoundry.org/cli/cf/api/appfiles"
 	"code.cloudfoundry.org/cli/cf/api/appinstances"
@@ -39,6 +41,8 @@ import (
 type RepositoryLocator struct {
 	authRepo                        authentication.Repository
 	curlRepo                        CurlRepository
+	ccRawRequestRepo                RawRequestRepository
+	uaaRawRequestRepo               RawRequestRepository
 	endpointRepo                    coreconfig.EndpointRepository
 	organizationRepo                organizations.OrganizationRepository
 	quotaRepo                       quotas.QuotaRepository
@@ -100,10 +104,15 @@ func NewRepositoryLocator(config coreconfig.ReadWriter, gatewaysByName map[strin
 	loc.appInstancesRepo = appinstances.NewCloudControllerAppInstancesRepository(config, cloudControllerGateway)
 	loc.authTokenRepo = NewCloudControllerServiceAuthTokenRepository(config, cloudControllerGateway)
 	loc.curlRepo = NewCloudControllerCurlRepository(config, cloudControllerGateway)
+
+	retryPolicy := retry.ParsePolicy(os.Getenv("CF_RETRY_MAX"), os.Getenv("CF_RETRY_BASE_DELAY"), os.Getenv("CF_RETRY_MAX_DELAY"))
+	loc.ccRawRequestRepo = NewCloudControllerRawRequestRepository(config, cloudControllerGateway, retryPolicy)
+	loc.uaaRawRequestRepo = NewUAARawRequestRepository(config, uaaGateway, retryPolicy)
+
 	loc.domainRepo = NewCloudControllerDomainRepository(config, cloudControllerGateway)
 	loc.endpointRepo = NewEndpointRepository(cloudControllerGateway)
 
-	tlsConfig := net.NewTLSConfig([]tls.Certificate{}, config.IsSSLDisabled())
+	tlsConfig := net.NewTLSConfig([]tls.Certificate{}, cloudControllerGateway.Certificates(), config.IsSSLDisabled())
 
 	var noaaRetryTimeout time.Duration
 	convertedTime, err := strconv.Atoi(envDialTimeout)
@@ -169,6 +178,24 @@ func (locator RepositoryLocator) GetCurlRepository() CurlRepository {
 	return locator.curlRepo
 }
 
+func (locator RepositoryLocator) SetCloudControllerRawRequestRepository(repo RawRequestRepository) RepositoryLocator {
+	locator.ccRawRequestRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetCloudControllerRawRequestRepository() RawRequestRepository {
+	return locator.ccRawRequestRepo
+}
+
+func (locator RepositoryLocator) SetUAARawRequestRepository(repo RawRequestRepository) RepositoryLocator {
+	locator.uaaRawRequestRepo = repo
+	return locator
+}
+
+func (locator RepositoryLocator) GetUAARawRequestRepository() RawRequestRepository {
+	return locator.uaaRawRequestRepo
+}
+
 func (locator RepositoryLocator) GetEndpointRepository() coreconfig.EndpointRepository {
 	return locator.endpointRepo
 }
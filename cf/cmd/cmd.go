@@ -47,34 +47,9 @@ func Main(traceEnv string, args []string) {
 	newArgs, isVerbose := handleVerbose(args)
 	args = newArgs
 
-	errFunc := func(err error) {
-		if err != nil {
-			ui := terminal.NewUI(
-				os.Stdin,
-				Writer,
-				terminal.NewTeePrinter(Writer),
-				trace.NewLogger(Writer, isVerbose, traceEnv, ""),
-			)
-			ui.Failed(fmt.Sprintf("Config error: %s", err))
-			os.Exit(1)
-		}
-	}
-
-	// Only used to get Trace, so our errorHandler doesn't matter, since it's not used
-	configPath, err := confighelpers.DefaultFilePath()
-	if err != nil {
-		errFunc(err)
-	}
-	config := coreconfig.NewRepositoryFromFilepath(configPath, errFunc)
-	defer config.Close()
-
-	traceConfigVal := config.Trace()
-
-	// Writer is assigned in writer_unix.go/writer_windows.go
-	traceLogger := trace.NewLogger(Writer, isVerbose, traceEnv, traceConfigVal)
-
-	deps := commandregistry.NewDependency(Writer, traceLogger, os.Getenv("CF_DIAL_TIMEOUT"))
+	deps := newDependency(traceEnv, isVerbose)
 	defer deps.Config.Close()
+	getDeps := func() commandregistry.Dependency { return deps }
 
 	warningProducers := []net.WarningProducer{}
 	for _, warningProducer := range deps.Gateways {
@@ -94,7 +69,7 @@ func Main(traceEnv string, args []string) {
 		flagContext.SkipFlagParsing(meta.SkipFlagParsing)
 
 		cmdArgs := args[2:]
-		err = flagContext.Parse(cmdArgs...)
+		err := flagContext.Parse(cmdArgs...)
 		if err != nil {
 			usage := cmdRegistry.CommandUsage(cmdName)
 			deps.UI.Failed(T("Incorrect Usage") + "\n\n" + err.Error() + "\n\n" + usage)
@@ -103,9 +78,16 @@ func Main(traceEnv string, args []string) {
 		cmd = cmd.SetDependency(deps, false)
 		cmdRegistry.SetCommand(cmd)
 
+		err = runCommandHooks(getDeps, preHookEvent(meta.Name, cmdArgs))
+		if err != nil {
+			deps.UI.Failed(err.Error())
+			os.Exit(1)
+		}
+
 		requirementsFactory := requirements.NewFactory(deps.Config, deps.RepoLocator)
 		reqs, reqErr := cmd.Requirements(requirementsFactory, flagContext)
 		if reqErr != nil {
+			runPostCommandHooks(getDeps, meta.Name, cmdArgs, reqErr)
 			os.Exit(1)
 		}
 
@@ -113,6 +95,7 @@ func Main(traceEnv string, args []string) {
 			err = req.Execute()
 			if err != nil {
 				deps.UI.Failed(err.Error())
+				runPostCommandHooks(getDeps, meta.Name, cmdArgs, err)
 				os.Exit(1)
 			}
 		}
@@ -120,8 +103,10 @@ func Main(traceEnv string, args []string) {
 		err = cmd.Execute(flagContext)
 		if err != nil {
 			deps.UI.Failed(err.Error())
+			runPostCommandHooks(getDeps, meta.Name, cmdArgs, err)
 			os.Exit(1)
 		}
+		runPostCommandHooks(getDeps, meta.Name, cmdArgs, nil)
 
 		err = warningsCollector.PrintWarnings()
 		if err != nil {
@@ -140,14 +125,7 @@ func Main(traceEnv string, args []string) {
 		os.Exit(1)
 	}
 
-	pluginPath := filepath.Join(confighelpers.PluginRepoDir(), ".cf", "plugins")
-	pluginConfig := pluginconfig.NewPluginConfig(
-		func(err error) {
-			deps.UI.Failed(fmt.Sprintf("Error read/writing plugin config: %s, ", err.Error()))
-		},
-		configuration.NewDiskPersistor(filepath.Join(pluginPath, "config.json")),
-		pluginPath,
-	)
+	pluginConfig := newPluginConfig(func() terminal.UI { return deps.UI })
 	pluginList := pluginConfig.Plugins()
 
 	ran := rpc.RunMethodIfExists(rpcService, args[1:], pluginList)
@@ -158,6 +136,47 @@ func Main(traceEnv string, args []string) {
 	}
 }
 
+func newDependency(traceEnv string, isVerbose bool) commandregistry.Dependency {
+	errFunc := func(err error) {
+		if err != nil {
+			ui := terminal.NewUI(
+				os.Stdin,
+				Writer,
+				terminal.NewTeePrinter(Writer),
+				trace.NewLogger(Writer, isVerbose, traceEnv, ""),
+			)
+			ui.Failed(fmt.Sprintf("Config error: %s", err))
+			os.Exit(1)
+		}
+	}
+
+	// Only used to get Trace, so our errorHandler doesn't matter, since it's not used
+	configPath, err := confighelpers.DefaultFilePath()
+	if err != nil {
+		errFunc(err)
+	}
+	config := coreconfig.NewRepositoryFromFilepath(configPath, errFunc)
+	defer config.Close()
+
+	traceConfigVal := config.Trace()
+
+	// Writer is assigned in writer_unix.go/writer_windows.go
+	traceLogger := trace.NewLogger(Writer, isVerbose, traceEnv, traceConfigVal)
+
+	return commandregistry.NewDependency(Writer, traceLogger, os.Getenv("CF_DIAL_TIMEOUT"))
+}
+
+func newPluginConfig(ui func() terminal.UI) *pluginconfig.PluginConfig {
+	pluginPath := filepath.Join(confighelpers.PluginRepoDir(), ".cf", "plugins")
+	return pluginconfig.NewPluginConfig(
+		func(err error) {
+			ui().Failed(fmt.Sprintf("Error read/writing plugin config: %s, ", err.Error()))
+		},
+		configuration.NewDiskPersistor(filepath.Join(pluginPath, "config.json")),
+		pluginPath,
+	)
+}
+
 func suggestCommands(cmdName string, ui terminal.UI, cmdsList []string) {
 	cmdSuggester := spellcheck.NewCommandSuggester(cmdsList)
 	recommendedCmds := cmdSuggester.Recommend(cmdName)
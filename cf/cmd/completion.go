@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"io/ioutil"
+
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/plugin/rpc"
+
+	netrpc "net/rpc"
+)
+
+// CompletePluginFlag returns the values offered for a flag of a plugin
+// command. The plugin binary at location is only started when the flag asks
+// for its values to be completed by the plugin.
+func CompletePluginFlag(traceEnv string, location string, flag plugin.Flag, request plugin.CompletionRequest) ([]string, error) {
+	if !flag.Complete {
+		return rpc.CompleteFlagValues(nil, location, flag, request)
+	}
+
+	deps := newDependency(traceEnv, false)
+	rpcService, err := rpc.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, rpc.NewCommandRunner(), deps.Logger, ioutil.Discard, netrpc.NewServer())
+	if err != nil {
+		return nil, err
+	}
+
+	return rpc.CompleteFlagValues(rpcService, location, flag, request)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/plugin/rpc"
+
+	netrpc "net/rpc"
+)
+
+// hooksRan records the hooks that already ran in this process, by stage and
+// command. Most commands are handed to Main after their hooks have been
+// considered and must not run them a second time.
+var hooksRan = map[hookRun]bool{}
+
+type hookRun struct {
+	stage   plugin.HookStage
+	command string
+}
+
+// RunPreCommandHooks runs the pre hooks that plugins registered for a core
+// command. It returns an error if a hook stopped the command or failed.
+func RunPreCommandHooks(traceEnv string, command string, args []string) error {
+	args, isVerbose := handleVerbose(args)
+	return runCommandHooks(lazyDependency(traceEnv, isVerbose), preHookEvent(command, args))
+}
+
+// RunPostCommandHooks runs the post hooks that plugins registered for a core
+// command, passing on the error the command returned. Hook failures are only
+// reported, since the command has already run.
+func RunPostCommandHooks(traceEnv string, command string, args []string, cmdErr error) {
+	args, isVerbose := handleVerbose(args)
+	runPostCommandHooks(lazyDependency(traceEnv, isVerbose), command, args, cmdErr)
+}
+
+func runPostCommandHooks(getDeps func() commandregistry.Dependency, command string, args []string, cmdErr error) {
+	err := runCommandHooks(getDeps, postHookEvent(command, args, cmdErr))
+	if err != nil {
+		getDeps().UI.Warn(err.Error())
+	}
+}
+
+func preHookEvent(command string, args []string) plugin.HookEvent {
+	return plugin.HookEvent{
+		Stage:   plugin.PreHook,
+		Command: command,
+		Args:    args,
+	}
+}
+
+func postHookEvent(command string, args []string, cmdErr error) plugin.HookEvent {
+	event := plugin.HookEvent{
+		Stage:     plugin.PostHook,
+		Command:   command,
+		Args:      args,
+		Succeeded: cmdErr == nil,
+	}
+	if cmdErr != nil {
+		event.Error = cmdErr.Error()
+	}
+	return event
+}
+
+// runCommandHooks runs the hooks for event at most once per process. The
+// dependency is only built when a plugin registered a matching hook.
+func runCommandHooks(getDeps func() commandregistry.Dependency, event plugin.HookEvent) error {
+	run := hookRun{stage: event.Stage, command: event.Command}
+	if hooksRan[run] {
+		return nil
+	}
+	hooksRan[run] = true
+
+	pluginList := newPluginConfig(func() terminal.UI { return getDeps().UI }).Plugins()
+	if len(rpc.PluginsWithHook(pluginList, event.Stage, event.Command)) == 0 {
+		return nil
+	}
+
+	deps := getDeps()
+	rpcService, err := rpc.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, rpc.NewCommandRunner(), deps.Logger, Writer, netrpc.NewServer())
+	if err != nil {
+		return err
+	}
+
+	return rpc.RunHooks(rpcService, event, pluginList)
+}
+
+func lazyDependency(traceEnv string, isVerbose bool) func() commandregistry.Dependency {
+	var deps *commandregistry.Dependency
+	return func() commandregistry.Dependency {
+		if deps == nil {
+			d := newDependency(traceEnv, isVerbose)
+			deps = &d
+		}
+		return *deps
+	}
+}
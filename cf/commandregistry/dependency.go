@@ -23,8 +23,11 @@ import (
 	"code.cloudfoundry.org/cli/cf/net"
 	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/cf/trace"
+	"code.cloudfoundry.org/cli/command"
 	"code.cloudfoundry.org/cli/plugin/models"
 	"code.cloudfoundry.org/cli/util"
+	"code.cloudfoundry.org/cli/util/httpcache"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 	"code.cloudfoundry.org/cli/util/words/generator"
 )
 
@@ -36,6 +39,7 @@ type Dependency struct {
 	ManifestRepo       manifest.Repository
 	AppManifest        manifest.App
 	Gateways           map[string]net.Gateway
+	Certificates       tlsconfig.Certificates
 	TeePrinter         *terminal.TeePrinter
 	PluginRepo         pluginrepo.PluginRepo
 	PluginModels       *PluginModels
@@ -49,7 +53,7 @@ type Dependency struct {
 	AppFiles           appfiles.AppFiles
 	PushActor          actors.PushActor
 	RouteActor         actors.RouteActor
-	ChecksumUtil       util.Sha1Checksum
+	ChecksumUtil       util.Checksum
 	WildcardDependency interface{} //use for injecting fakes
 	Logger             trace.Printer
 }
@@ -98,10 +102,34 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 	terminal.UserAskedForColors = deps.Config.ColorEnabled()
 	terminal.InitColorSupport()
 
+	deps.Certificates, err = tlsconfig.Load(deps.Config.TLSFiles())
+	if err != nil {
+		errorHandler(err)
+	}
+
+	cloudControllerGateway := net.NewCloudControllerGateway(deps.Config, time.Now, deps.UI, logger, envDialTimeout)
+	uaaGateway := net.NewUAAGateway(deps.Config, deps.UI, logger, envDialTimeout)
+	routingAPIGateway := net.NewRoutingAPIGateway(deps.Config, time.Now, deps.UI, logger, envDialTimeout)
+	var harRecorders []net.HARRecorder
+	for _, path := range trace.HARPaths(os.Getenv("CF_TRACE"), deps.Config.Trace()) {
+		harRecorders = append(harRecorders, command.NewHARRecorder(path))
+	}
+
+	for _, gateway := range []*net.Gateway{&cloudControllerGateway, &uaaGateway, &routingAPIGateway} {
+		gateway.SetCertificates(deps.Certificates)
+		gateway.SetHARRecorders(harRecorders)
+	}
+
+	ttl := httpcache.ParseTTL(os.Getenv(httpcache.TTLEnvVar))
+	if identity := httpcache.Identity(deps.Config.AccessToken()); identity != "" && ttl > 0 {
+		cacheDir := filepath.Join(filepath.Dir(configPath), "cache")
+		cloudControllerGateway.SetResponseCache(httpcache.New(cacheDir, deps.Config.APIEndpoint(), identity, ttl))
+	}
+
 	deps.Gateways = map[string]net.Gateway{
-		"cloud-controller": net.NewCloudControllerGateway(deps.Config, time.Now, deps.UI, logger, envDialTimeout),
-		"uaa":              net.NewUAAGateway(deps.Config, deps.UI, logger, envDialTimeout),
-		"routing-api":      net.NewRoutingAPIGateway(deps.Config, time.Now, deps.UI, logger, envDialTimeout),
+		"cloud-controller": cloudControllerGateway,
+		"uaa":              uaaGateway,
+		"routing-api":      routingAPIGateway,
 	}
 	deps.RepoLocator = api.NewRepositoryLocator(deps.Config, deps.Gateways, logger, envDialTimeout)
 
@@ -123,7 +151,7 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 		deps.ServiceBuilder,
 	)
 
-	deps.PluginRepo = pluginrepo.NewPluginRepo()
+	deps.PluginRepo = pluginrepo.NewPluginRepo(deps.Certificates.TLSConfig(false))
 
 	deps.ServiceHandler = actors.NewServiceHandler(
 		deps.RepoLocator.GetOrganizationRepository(),
@@ -147,7 +175,7 @@ func NewDependency(writer io.Writer, logger trace.Printer, envDialTimeout string
 	deps.RouteActor = actors.NewRouteActor(deps.UI, deps.RepoLocator.GetRouteRepository(), deps.RepoLocator.GetDomainRepository())
 	deps.PushActor = actors.NewPushActor(deps.RepoLocator.GetApplicationBitsRepository(), deps.AppZipper, deps.AppFiles, deps.RouteActor)
 
-	deps.ChecksumUtil = util.NewSha1Checksum("")
+	deps.ChecksumUtil = util.NewChecksum("")
 
 	deps.Logger = logger
 
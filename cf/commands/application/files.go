@@ -3,6 +3,13 @@ package application
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/api/appfiles"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -13,11 +20,22 @@ import (
 	"code.cloudfoundry.org/cli/cf/terminal"
 )
 
+const DefaultTailInterval = 2 * time.Second
+
+var fileListingEntryRegexp = regexp.MustCompile(`^(.+?)\s+(\S+)$`)
+
 type Files struct {
 	ui           terminal.UI
 	config       coreconfig.Reader
 	appFilesRepo appfiles.Repository
 	appReq       requirements.DEAApplicationRequirement
+	TailInterval time.Duration
+}
+
+type fileEntry struct {
+	Path  string
+	Size  string
+	IsDir bool
 }
 
 func init() {
@@ -27,13 +45,17 @@ func init() {
 func (cmd *Files) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["i"] = &flags.IntFlag{ShortName: "i", Usage: T("Instance")}
+	fs["recursive"] = &flags.BoolFlag{Name: "recursive", ShortName: "r", Usage: T("List the contents of the directory and all of its subdirectories")}
+	fs["download"] = &flags.StringFlag{Name: "download", Usage: T("Download the directory and all of its subdirectories into the given local directory")}
+	fs["tail"] = &flags.StringFlag{Name: "tail", Usage: T("Print the contents of the given file and keep printing new contents as the file grows")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "files",
 		ShortName:   "f",
 		Description: T("Print out a list of files in a directory or the contents of a specific file of an app running on the DEA backend"),
 		Usage: []string{
-			T(`CF_NAME files APP_NAME [PATH] [-i INSTANCE]
+			T(`CF_NAME files APP_NAME [PATH] [-i INSTANCE] [-r | --download DIR]
+   CF_NAME files APP_NAME --tail PATH [-i INSTANCE]
 			
 TIP:
   To list and inspect files of an app running on the Diego backend, use 'CF_NAME ssh'`),
@@ -48,6 +70,11 @@ func (cmd *Files) Requirements(requirementsFactory requirements.Factory, c flags
 		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(c.Args()), 1)
 	}
 
+	if c.String("tail") != "" && (len(c.Args()) > 1 || c.Bool("recursive") || c.String("download") != "") {
+		cmd.ui.Failed(T("Incorrect Usage. --tail cannot be used with PATH, --recursive or --download\n\n") + commandregistry.Commands.CommandUsage("files"))
+		return nil, fmt.Errorf("Incorrect usage: --tail cannot be combined with other options")
+	}
+
 	cmd.appReq = requirementsFactory.NewDEAApplicationRequirement(c.Args()[0])
 
 	reqs := []requirements.Requirement{
@@ -63,6 +90,7 @@ func (cmd *Files) SetDependency(deps commandregistry.Dependency, pluginCall bool
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.appFilesRepo = deps.RepoLocator.GetAppFilesRepository()
+	cmd.TailInterval = DefaultTailInterval
 	return cmd
 }
 
@@ -94,11 +122,25 @@ func (cmd *Files) Execute(c flags.FlagContext) error {
 			"SpaceName": terminal.EntityNameColor(cmd.config.SpaceFields().Name),
 			"Username":  terminal.EntityNameColor(cmd.config.Username())}))
 
+	if c.String("tail") != "" {
+		cmd.ui.Ok()
+		cmd.ui.Say("")
+		return cmd.tailFile(app.GUID, instance, c.String("tail"))
+	}
+
 	path := "/"
 	if len(c.Args()) > 1 {
 		path = c.Args()[1]
 	}
 
+	if c.String("download") != "" {
+		return cmd.downloadFiles(app.GUID, instance, path, c.String("download"))
+	}
+
+	if c.Bool("recursive") {
+		return cmd.listFilesRecursively(app.GUID, instance, path)
+	}
+
 	list, err := cmd.appFilesRepo.ListFiles(app.GUID, instance, path)
 	if err != nil {
 		return err
@@ -114,3 +156,188 @@ func (cmd *Files) Execute(c flags.FlagContext) error {
 	}
 	return nil
 }
+
+func (cmd *Files) listFilesRecursively(appGUID string, instance int, dir string) error {
+	table := cmd.ui.Table([]string{T("path"), T("size")})
+	err := cmd.walkFiles(appGUID, instance, dir, func(entry fileEntry) error {
+		name := entry.Path
+		if entry.IsDir {
+			name += "/"
+		}
+		table.Add(name, entry.Size)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	return table.Print()
+}
+
+func (cmd *Files) downloadFiles(appGUID string, instance int, dir string, localDir string) error {
+	count := 0
+	err := cmd.walkFiles(appGUID, instance, dir, func(entry fileEntry) error {
+		localPath, err := localFilePath(localDir, entry.Path)
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir {
+			return os.MkdirAll(localPath, 0755)
+		}
+
+		contents, err := cmd.appFilesRepo.ListFiles(appGUID, instance, remoteFilePath(dir, entry.Path))
+		if err != nil {
+			return err
+		}
+
+		err = os.MkdirAll(filepath.Dir(localPath), 0755)
+		if err != nil {
+			return err
+		}
+
+		count++
+		return ioutil.WriteFile(localPath, []byte(contents), 0644)
+	})
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+	if count == 1 {
+		cmd.ui.Say(T("Downloaded 1 file to {{.Dir}}",
+			map[string]interface{}{
+				"Dir": terminal.EntityNameColor(localDir),
+			}))
+	} else {
+		cmd.ui.Say(T("Downloaded {{.Count}} files to {{.Dir}}",
+			map[string]interface{}{
+				"Count": count,
+				"Dir":   terminal.EntityNameColor(localDir),
+			}))
+	}
+	return nil
+}
+
+// walkFiles visits every entry below dir, depth first, in the order the
+// files endpoint lists them. Entry paths are relative to dir.
+func (cmd *Files) walkFiles(appGUID string, instance int, dir string, visit func(fileEntry) error) error {
+	var walk func(relativeDir string) error
+	walk = func(relativeDir string) error {
+		list, err := cmd.appFilesRepo.ListFiles(appGUID, instance, remoteFilePath(dir, relativeDir)+"/")
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range parseFileListing(list) {
+			if !isPlainFileName(entry.Path) {
+				return errors.New(T("Invalid path in file listing: {{.Path}}",
+					map[string]interface{}{
+						"Path": entry.Path,
+					}))
+			}
+			entry.Path = path.Join(relativeDir, entry.Path)
+
+			err = visit(entry)
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir {
+				err = walk(entry.Path)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	return walk("")
+}
+
+func (cmd *Files) tailFile(appGUID string, instance int, filePath string) error {
+	var offset int64
+	for {
+		contents, err := cmd.appFilesRepo.GetFileFromOffset(appGUID, instance, filePath, offset)
+		if _, ok := err.(*appfiles.FileTruncatedError); ok {
+			// The file was truncated or rotated, so follow it from the start.
+			offset = 0
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if contents != "" {
+			cmd.ui.PrintCapturingNoOutput("%s", contents)
+			offset += int64(len(contents))
+		}
+
+		time.Sleep(cmd.TailInterval)
+	}
+}
+
+func remoteFilePath(dir string, relativePath string) string {
+	return strings.TrimPrefix(path.Join(strings.Trim(dir, "/"), relativePath), "/")
+}
+
+// isPlainFileName reports whether name, from a directory listing, names an
+// entry inside the listed directory.
+func isPlainFileName(name string) bool {
+	if name == "" || path.IsAbs(name) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return false
+	}
+
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// localFilePath returns where the entry at relativePath is downloaded to. It
+// fails for paths that would end up outside localDir.
+func localFilePath(localDir string, relativePath string) (string, error) {
+	localPath := filepath.Join(localDir, filepath.FromSlash(relativePath))
+
+	rel, err := filepath.Rel(filepath.Clean(localDir), localPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || filepath.IsAbs(rel) {
+		return "", errors.New(T("Refusing to download {{.Path}}: the path is outside the download directory",
+			map[string]interface{}{
+				"Path": relativePath,
+			}))
+	}
+	return localPath, nil
+}
+
+// parseFileListing parses a directory listing from the files endpoint, in
+// which every line holds a name followed by a size and directory names end
+// with a slash.
+func parseFileListing(list string) []fileEntry {
+	entries := []fileEntry{}
+	for _, line := range strings.Split(list, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		entry := fileEntry{Path: line}
+		if matches := fileListingEntryRegexp.FindStringSubmatch(line); matches != nil {
+			entry.Path = matches[1]
+			entry.Size = matches[2]
+		}
+
+		if strings.HasSuffix(entry.Path, "/") {
+			entry.IsDir = true
+			entry.Path = strings.TrimSuffix(entry.Path, "/")
+		}
+
+		entries = append(entries, entry)
+	}
+	return entries
+}
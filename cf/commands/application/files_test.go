@@ -2,6 +2,10 @@ package application_test
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
 
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commands/application"
@@ -11,6 +15,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
 
+	"code.cloudfoundry.org/cli/cf/api/appfiles"
 	"code.cloudfoundry.org/cli/cf/api/appfiles/appfilesfakes"
 	testconfig "code.cloudfoundry.org/cli/util/testhelpers/configuration"
 	testterm "code.cloudfoundry.org/cli/util/testhelpers/terminal"
@@ -87,6 +92,21 @@ var _ = Describe("Files", func() {
 			})
 		})
 
+		Context("when --tail is combined with a path", func() {
+			BeforeEach(func() {
+				flagContext.Parse("app-name", "the-path", "--tail", "logs/stdout.log")
+			})
+
+			It("fails with usage", func() {
+				_, err := cmd.Requirements(factory, flagContext)
+				Expect(err).To(HaveOccurred())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Incorrect Usage. --tail cannot be used with PATH, --recursive or --download"},
+				))
+			})
+		})
+
 		Context("when provided exactly one arg", func() {
 			BeforeEach(func() {
 				flagContext.Parse("app-name")
@@ -260,5 +280,226 @@ var _ = Describe("Files", func() {
 				Expect(path).To(Equal("the-path"))
 			})
 		})
+
+		Context("when --recursive is provided", func() {
+			BeforeEach(func() {
+				args = []string{"app-name", "app", "--recursive"}
+				appFilesRepo.ListFilesStub = func(appGUID string, instance int, path string) (string, error) {
+					switch path {
+					case "app/":
+						return "lib/                                      -\nmy file.txt                              12B\n", nil
+					case "app/lib/":
+						return "lib.rb                                  1.2K\n", nil
+					}
+					return "", errors.New("unexpected path " + path)
+				}
+			})
+
+			It("lists every file below the path", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(appFilesRepo.ListFilesCallCount()).To(Equal(2))
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"OK"},
+					[]string{"path", "size"},
+					[]string{"lib/", "-"},
+					[]string{"lib/lib.rb", "1.2K"},
+					[]string{"my file.txt", "12B"},
+				))
+			})
+
+			Context("when listing a subdirectory fails", func() {
+				BeforeEach(func() {
+					appFilesRepo.ListFilesStub = func(appGUID string, instance int, path string) (string, error) {
+						if path == "app/" {
+							return "lib/                                      -\n", nil
+						}
+						return "", errors.New("list-files-err")
+					}
+				})
+
+				It("returns the error", func() {
+					Expect(err).To(MatchError("list-files-err"))
+					Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"OK"}))
+				})
+			})
+
+			Context("when the listing contains an entry outside the directory", func() {
+				BeforeEach(func() {
+					appFilesRepo.ListFilesStub = func(appGUID string, instance int, path string) (string, error) {
+						return "../secret.txt                            14B\n", nil
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(err).To(MatchError("Invalid path in file listing: ../secret.txt"))
+					Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"OK"}))
+				})
+			})
+		})
+
+		Context("when --download is provided", func() {
+			var downloadDir string
+
+			BeforeEach(func() {
+				var tempErr error
+				downloadDir, tempErr = ioutil.TempDir("", "files-download")
+				Expect(tempErr).NotTo(HaveOccurred())
+
+				args = []string{"app-name", "--download", downloadDir}
+				appFilesRepo.ListFilesStub = func(appGUID string, instance int, path string) (string, error) {
+					switch path {
+					case "/":
+						return "app/                                      -\nempty/                                    -\n", nil
+					case "app/":
+						return "config.yml                              14B\n", nil
+					case "empty/":
+						return "", nil
+					case "app/config.yml":
+						return "key: value\n", nil
+					}
+					return "", errors.New("unexpected path " + path)
+				}
+			})
+
+			AfterEach(func() {
+				os.RemoveAll(downloadDir)
+			})
+
+			It("mirrors the directory tree into the local directory", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				contents, readErr := ioutil.ReadFile(filepath.Join(downloadDir, "app", "config.yml"))
+				Expect(readErr).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal("key: value\n"))
+
+				Expect(filepath.Join(downloadDir, "empty")).To(BeADirectory())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"OK"},
+					[]string{"Downloaded 1 file to", downloadDir},
+				))
+			})
+
+			Context("when several files are downloaded", func() {
+				BeforeEach(func() {
+					appFilesRepo.ListFilesStub = func(appGUID string, instance int, path string) (string, error) {
+						switch path {
+						case "/":
+							return "a.txt                                    2B\nb.txt                                    2B\n", nil
+						case "a.txt", "b.txt":
+							return "a\n", nil
+						}
+						return "", errors.New("unexpected path " + path)
+					}
+				})
+
+				It("reports the number of files", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(ui.Outputs()).To(ContainSubstrings([]string{"Downloaded 2 files to", downloadDir}))
+				})
+			})
+
+			Context("when the listing contains an entry outside the directory", func() {
+				BeforeEach(func() {
+					appFilesRepo.ListFilesStub = func(appGUID string, instance int, path string) (string, error) {
+						switch path {
+						case "/":
+							return "app/                                      -\n", nil
+						case "app/":
+							return "../../escaped.txt                        14B\n", nil
+						}
+						return "pwned\n", nil
+					}
+				})
+
+				It("returns an error and does not write the file", func() {
+					Expect(err).To(MatchError("Invalid path in file listing: ../../escaped.txt"))
+
+					_, statErr := os.Stat(filepath.Join(filepath.Dir(downloadDir), "escaped.txt"))
+					Expect(os.IsNotExist(statErr)).To(BeTrue())
+					Expect(appFilesRepo.ListFilesCallCount()).To(Equal(2))
+				})
+			})
+
+			Context("when the listing contains an absolute path", func() {
+				BeforeEach(func() {
+					appFilesRepo.ListFilesStub = func(appGUID string, instance int, path string) (string, error) {
+						return "/etc/escaped.txt                         14B\n", nil
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(err).To(MatchError("Invalid path in file listing: /etc/escaped.txt"))
+					Expect(appFilesRepo.ListFilesCallCount()).To(Equal(1))
+				})
+			})
+		})
+
+		Context("when --tail is provided", func() {
+			BeforeEach(func() {
+				args = []string{"app-name", "--tail", "logs/stdout.log"}
+				cmd.(*application.Files).TailInterval = time.Millisecond
+
+				chunks := []string{"first line\n", "", "second line\n"}
+				appFilesRepo.GetFileFromOffsetStub = func(appGUID string, instance int, path string, offset int64) (string, error) {
+					call := appFilesRepo.GetFileFromOffsetCallCount() - 1
+					if call < len(chunks) {
+						return chunks[call], nil
+					}
+					return "", errors.New("tail-err")
+				}
+			})
+
+			It("follows the file from the last offset it printed", func() {
+				Expect(err).To(MatchError("tail-err"))
+				Expect(appFilesRepo.GetFileFromOffsetCallCount()).To(Equal(4))
+
+				offsets := []int64{}
+				for i := 0; i < 4; i++ {
+					appGUID, _, path, offset := appFilesRepo.GetFileFromOffsetArgsForCall(i)
+					Expect(appGUID).To(Equal("app-guid"))
+					Expect(path).To(Equal("logs/stdout.log"))
+					offsets = append(offsets, offset)
+				}
+				Expect(offsets).To(Equal([]int64{0, 11, 11, 23}))
+
+				Expect(ui.UncapturedOutput()).To(ContainSubstrings(
+					[]string{"first line"},
+					[]string{"second line"},
+				))
+			})
+
+			Context("when the file is truncated", func() {
+				BeforeEach(func() {
+					appFilesRepo.GetFileFromOffsetStub = func(appGUID string, instance int, path string, offset int64) (string, error) {
+						switch appFilesRepo.GetFileFromOffsetCallCount() {
+						case 1:
+							return "first line\n", nil
+						case 2:
+							return "", &appfiles.FileTruncatedError{Path: path}
+						case 3:
+							return "new line\n", nil
+						}
+						return "", errors.New("tail-err")
+					}
+				})
+
+				It("follows the file from the start again", func() {
+					Expect(err).To(MatchError("tail-err"))
+					Expect(appFilesRepo.GetFileFromOffsetCallCount()).To(Equal(4))
+
+					offsets := []int64{}
+					for i := 0; i < 4; i++ {
+						_, _, _, offset := appFilesRepo.GetFileFromOffsetArgsForCall(i)
+						offsets = append(offsets, offset)
+					}
+					Expect(offsets).To(Equal([]int64{0, 11, 0, 9}))
+
+					Expect(ui.UncapturedOutput()).To(ContainSubstrings(
+						[]string{"first line"},
+						[]string{"new line"},
+					))
+				})
+			})
+		})
 	})
 })
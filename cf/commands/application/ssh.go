@@ -4,15 +4,19 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"golang.org/x/crypto/ssh"
 
+	"code.cloudfoundry.org/cli/cf/api/appinstances"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commands"
+	"code.cloudfoundry.org/cli/cf/configuration/confighelpers"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/net"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	sshCmd "code.cloudfoundry.org/cli/cf/ssh"
@@ -22,13 +26,14 @@ import (
 )
 
 type SSH struct {
-	ui            terminal.UI
-	config        coreconfig.Reader
-	gateway       net.Gateway
-	appReq        requirements.ApplicationRequirement
-	sshCodeGetter commands.SSHCodeGetter
-	opts          *options.SSHOptions
-	secureShell   sshCmd.SecureShell
+	ui               terminal.UI
+	config           coreconfig.Reader
+	gateway          net.Gateway
+	appInstancesRepo appinstances.Repository
+	appReq           requirements.ApplicationRequirement
+	sshCodeGetter    commands.SSHCodeGetter
+	opts             *options.SSHOptions
+	secureShell      sshCmd.SecureShell
 }
 
 type sshInfo struct {
@@ -43,6 +48,8 @@ func init() {
 func (cmd *SSH) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["L"] = &flags.StringSliceFlag{ShortName: "L", Usage: T("Local port forward specification. This flag can be defined more than once.")}
+	fs["R"] = &flags.StringSliceFlag{ShortName: "R", Usage: T("Remote port forward specification. This flag can be defined more than once.")}
+	fs["D"] = &flags.StringSliceFlag{ShortName: "D", Usage: T("Dynamic SOCKS5 port forward specification. This flag can be defined more than once.")}
 	fs["command"] = &flags.StringSliceFlag{Name: "command", ShortName: "c", Usage: T("Command to run. This flag can be defined more than once.")}
 	fs["app-instance-index"] = &flags.IntFlag{Name: "app-instance-index", ShortName: "i", Usage: T("Application instance index")}
 	fs["skip-host-validation"] = &flags.BoolFlag{Name: "skip-host-validation", ShortName: "k", Usage: T("Skip host key validation")}
@@ -50,12 +57,17 @@ func (cmd *SSH) MetaData() commandregistry.CommandMetadata {
 	fs["request-pseudo-tty"] = &flags.BoolFlag{Name: "request-pseudo-tty", ShortName: "t", Usage: T("Request pseudo-tty allocation")}
 	fs["force-pseudo-tty"] = &flags.BoolFlag{Name: "force-pseudo-tty", ShortName: "tt", Usage: T("Force pseudo-tty allocation")}
 	fs["disable-pseudo-tty"] = &flags.BoolFlag{Name: "disable-pseudo-tty", ShortName: "T", Usage: T("Disable pseudo-tty allocation")}
+	fs["strict-host-key-checking"] = &flags.BoolFlag{Name: "strict-host-key-checking", Usage: T("Refuse to connect if the host key differs from the one pinned in the known hosts file")}
+	fs["all-instances"] = &flags.BoolFlag{Name: "all-instances", Usage: T("Run the command on every running instance concurrently")}
+	fs["max-in-flight"] = &flags.IntFlag{Name: "max-in-flight", Usage: T("Maximum number of instances to run the command on at the same time when using --all-instances")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "ssh",
 		Description: T("SSH to an application container instance"),
 		Usage: []string{
-			T("CF_NAME ssh APP_NAME [-i app-instance-index] [-c command] [-L [bind_address:]port:host:hostport] [--skip-host-validation] [--skip-remote-execution] [--request-pseudo-tty] [--force-pseudo-tty] [--disable-pseudo-tty]"),
+			T("CF_NAME ssh APP_NAME [-i app-instance-index] [-c command] [-L [bind_address:]port:host:hostport] [-R [bind_address:]port:host:hostport] [-D [bind_address:]port] [--skip-host-validation] [--skip-remote-execution] [--request-pseudo-tty] [--force-pseudo-tty] [--disable-pseudo-tty] [--strict-host-key-checking]"),
+			"\n   ",
+			T("CF_NAME ssh APP_NAME --all-instances -c command [--max-in-flight number]"),
 		},
 		Flags: fs,
 	}
@@ -80,6 +92,22 @@ func (cmd *SSH) Requirements(requirementsFactory requirements.Factory, fc flags.
 		return nil, err
 	}
 
+	if cmd.opts.HostKeyChecking == options.HostKeyCheckingOff && !cmd.opts.SkipHostValidation {
+		cmd.opts.HostKeyChecking, err = options.ParseHostKeyChecking(os.Getenv("CF_SSH_HOST_KEY_CHECKING"))
+		if err != nil {
+			cmd.ui.Failed(err.Error())
+			return nil, err
+		}
+	}
+
+	if cmd.opts.HostKeyChecking != options.HostKeyCheckingOff {
+		cmd.opts.KnownHostsFile, err = confighelpers.DefaultKnownHostsFilePath()
+		if err != nil {
+			cmd.ui.Failed(err.Error())
+			return nil, err
+		}
+	}
+
 	cmd.appReq = requirementsFactory.NewApplicationRequirement(cmd.opts.AppName)
 
 	reqs := []requirements.Requirement{
@@ -95,6 +123,7 @@ func (cmd *SSH) SetDependency(deps commandregistry.Dependency, pluginCall bool)
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
 	cmd.gateway = deps.Gateways["cloud-controller"]
+	cmd.appInstancesRepo = deps.RepoLocator.GetAppInstancesRepository()
 
 	if deps.WildcardDependency != nil {
 		cmd.secureShell = deps.WildcardDependency.(sshCmd.SecureShell)
@@ -115,40 +144,42 @@ func (cmd *SSH) Execute(fc flags.FlagContext) error {
 		return errors.New(T("Error getting SSH info:") + err.Error())
 	}
 
+	if cmd.opts.AllInstances {
+		return cmd.executeOnAllInstances(app, info)
+	}
+
 	sshAuthCode, err := cmd.sshCodeGetter.Get()
 	if err != nil {
 		return errors.New(T("Error getting one time auth code: ") + err.Error())
 	}
 
-	//init secureShell if it is not already set by SetDependency() with fakes
-	if cmd.secureShell == nil {
-		cmd.secureShell = sshCmd.NewSecureShell(
-			sshCmd.DefaultSecureDialer(),
-			sshTerminal.DefaultHelper(),
-			sshCmd.DefaultListenerFactory(),
-			30*time.Second,
-			app,
-			info.SSHEndpointFingerprint,
-			info.SSHEndpoint,
-			sshAuthCode,
-		)
-	}
+	secureShell := cmd.newSecureShell(app, info, sshAuthCode, sshTerminal.DefaultHelper())
 
-	err = cmd.secureShell.Connect(cmd.opts)
+	err = secureShell.Connect(cmd.opts)
 	if err != nil {
 		return errors.New(T("Error opening SSH connection: ") + err.Error())
 	}
-	defer cmd.secureShell.Close()
+	defer secureShell.Close()
 
-	err = cmd.secureShell.LocalPortForward()
+	err = secureShell.LocalPortForward()
+	if err != nil {
+		return errors.New(T("Error forwarding port: ") + err.Error())
+	}
+
+	err = secureShell.RemotePortForward()
+	if err != nil {
+		return errors.New(T("Error forwarding port: ") + err.Error())
+	}
+
+	err = secureShell.DynamicPortForward()
 	if err != nil {
 		return errors.New(T("Error forwarding port: ") + err.Error())
 	}
 
 	if cmd.opts.SkipRemoteExecution {
-		err = cmd.secureShell.Wait()
+		err = secureShell.Wait()
 	} else {
-		err = cmd.secureShell.InteractiveSession()
+		err = secureShell.InteractiveSession()
 	}
 
 	if err != nil {
@@ -168,6 +199,90 @@ func (cmd *SSH) Execute(fc flags.FlagContext) error {
 	return nil
 }
 
+func (cmd *SSH) executeOnAllInstances(app models.Application, info sshInfo) error {
+	instances, err := cmd.appInstancesRepo.GetInstances(app.GUID)
+	if err != nil {
+		return errors.New(T("Error getting application instances: ") + err.Error())
+	}
+
+	var indexes []uint
+	for index, instance := range instances {
+		if instance.State == models.InstanceRunning {
+			indexes = append(indexes, uint(index))
+		}
+	}
+
+	if len(indexes) == 0 {
+		return errors.New(T("Application {{.AppName}} has no running instances", map[string]interface{}{
+			"AppName": app.Name,
+		}))
+	}
+
+	// one time auth codes are single use, so every instance needs its own
+	codeMutex := &sync.Mutex{}
+	newSecureShell := func(terminalHelper sshTerminal.TerminalHelper) (sshCmd.SecureShell, error) {
+		codeMutex.Lock()
+		sshAuthCode, err := cmd.sshCodeGetter.Get()
+		codeMutex.Unlock()
+		if err != nil {
+			return nil, errors.New(T("Error getting one time auth code: ") + err.Error())
+		}
+
+		return cmd.newSecureShell(app, info, sshAuthCode, terminalHelper), nil
+	}
+
+	_, stdout, stderr := sshTerminal.DefaultHelper().StdStreams()
+	results := sshCmd.RunOnAllInstances(cmd.opts, indexes, cmd.opts.MaxInFlight, stdout, stderr, newSecureShell)
+
+	cmd.ui.Say("")
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			cmd.ui.Say(T("Instance {{.Index}}: exited with status {{.ExitStatus}} ({{.Error}})", map[string]interface{}{
+				"Index":      result.Index,
+				"ExitStatus": result.ExitStatus,
+				"Error":      result.Err.Error(),
+			}))
+		} else {
+			cmd.ui.Say(T("Instance {{.Index}}: exited with status {{.ExitStatus}}", map[string]interface{}{
+				"Index":      result.Index,
+				"ExitStatus": result.ExitStatus,
+			}))
+		}
+
+		if result.Err != nil || result.ExitStatus != 0 {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.New(T("Command failed on {{.Failed}} of {{.Total}} instances", map[string]interface{}{
+			"Failed": failed,
+			"Total":  len(results),
+		}))
+	}
+
+	return nil
+}
+
+func (cmd *SSH) newSecureShell(app models.Application, info sshInfo, sshAuthCode string, terminalHelper sshTerminal.TerminalHelper) sshCmd.SecureShell {
+	// secureShell is only set by SetDependency() with fakes
+	if cmd.secureShell != nil {
+		return cmd.secureShell
+	}
+
+	return sshCmd.NewSecureShell(
+		sshCmd.DefaultSecureDialer(),
+		terminalHelper,
+		sshCmd.DefaultListenerFactory(),
+		30*time.Second,
+		app,
+		info.SSHEndpointFingerprint,
+		info.SSHEndpoint,
+		sshAuthCode,
+	)
+}
+
 func (cmd *SSH) getSSHEndpointInfo() (sshInfo, error) {
 	info := sshInfo{}
 	err := cmd.gateway.GetResource(cmd.config.APIEndpoint()+"/v2/info", &info)
@@ -4,9 +4,11 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/appinstances/appinstancesfakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commands/commandsfakes"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
@@ -110,6 +112,25 @@ var _ = Describe("SSH command", func() {
 			Expect(runCommand("my-app")).To(BeFalse())
 		})
 
+		Context("when CF_SSH_HOST_KEY_CHECKING is not a valid mode", func() {
+			BeforeEach(func() {
+				os.Setenv("CF_SSH_HOST_KEY_CHECKING", "sometimes")
+			})
+
+			AfterEach(func() {
+				os.Unsetenv("CF_SSH_HOST_KEY_CHECKING")
+			})
+
+			It("fails with an error", func() {
+				requirementsFactory.NewLoginRequirementReturns(requirements.Passing{})
+				Expect(runCommand("my-app")).To(BeFalse())
+				Expect(ui.Outputs()).To(ContainSubstrings(
+					[]string{"FAILED"},
+					[]string{"Invalid host key checking mode"},
+				))
+			})
+		})
+
 		Describe("Flag options", func() {
 			var args []string
 
@@ -296,6 +317,30 @@ var _ = Describe("SSH command", func() {
 				})
 			})
 
+			Context("Error port forwarding when -R is provided", func() {
+				It("notifies users", func() {
+					fakeSecureShell.RemotePortForwardReturns(errors.New("tcpip-forward request denied"))
+
+					runCommand("my-app", "-R", "8000:localhost:8000")
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Error forwarding port", "tcpip-forward request denied"},
+					))
+				})
+			})
+
+			Context("Error port forwarding when -D is provided", func() {
+				It("notifies users", func() {
+					fakeSecureShell.DynamicPortForwardReturns(errors.New("listen error"))
+
+					runCommand("my-app", "-D", "1080")
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Error forwarding port", "listen error"},
+					))
+				})
+			})
+
 			Context("when -N is provided", func() {
 				It("calls secureShell.Wait()", func() {
 					fakeSecureShell.ConnectReturns(nil)
@@ -318,6 +363,89 @@ var _ = Describe("SSH command", func() {
 				})
 			})
 
+			Context("when --all-instances is provided", func() {
+				var appInstancesRepo *appinstancesfakes.FakeAppInstancesRepository
+
+				BeforeEach(func() {
+					appInstancesRepo = new(appinstancesfakes.FakeAppInstancesRepository)
+					appInstancesRepo.GetInstancesReturns([]models.AppInstanceFields{
+						{State: models.InstanceRunning},
+						{State: models.InstanceCrashed},
+						{State: models.InstanceRunning},
+					}, nil)
+					deps.RepoLocator = deps.RepoLocator.SetAppInstancesRepository(appInstancesRepo)
+
+					sshCodeGetter.GetReturns("some-code", nil)
+				})
+
+				It("runs the command on every running instance", func() {
+					runCommand("my-app", "--all-instances", "-c", "hostname")
+
+					Expect(appInstancesRepo.GetInstancesArgsForCall(0)).To(Equal("my-app-guid"))
+					Expect(sshCodeGetter.GetCallCount()).To(Equal(2))
+					Expect(fakeSecureShell.ConnectCallCount()).To(Equal(2))
+					Expect(fakeSecureShell.InteractiveSessionCallCount()).To(Equal(2))
+
+					indexes := []uint{
+						fakeSecureShell.ConnectArgsForCall(0).Index,
+						fakeSecureShell.ConnectArgsForCall(1).Index,
+					}
+					Expect(indexes).To(ConsistOf(uint(0), uint(2)))
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Instance 0: exited with status 0"},
+						[]string{"Instance 2: exited with status 0"},
+					))
+				})
+
+				Context("when the command fails on an instance", func() {
+					BeforeEach(func() {
+						fakeSecureShell.InteractiveSessionReturns(errors.New("session broke"))
+					})
+
+					It("reports the failed instances", func() {
+						Expect(runCommand("my-app", "--all-instances", "-c", "hostname")).To(BeFalse())
+
+						Expect(ui.Outputs()).To(ContainSubstrings(
+							[]string{"Instance 0: exited with status 255 (session broke)"},
+							[]string{"Instance 2: exited with status 255 (session broke)"},
+							[]string{"Command failed on 2 of 2 instances"},
+						))
+					})
+				})
+
+				Context("when no instances are running", func() {
+					BeforeEach(func() {
+						appInstancesRepo.GetInstancesReturns([]models.AppInstanceFields{
+							{State: models.InstanceCrashed},
+						}, nil)
+					})
+
+					It("notifies users", func() {
+						Expect(runCommand("my-app", "--all-instances", "-c", "hostname")).To(BeFalse())
+
+						Expect(ui.Outputs()).To(ContainSubstrings(
+							[]string{"Application my-app has no running instances"},
+						))
+						Expect(fakeSecureShell.ConnectCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("when getting the instances fails", func() {
+					BeforeEach(func() {
+						appInstancesRepo.GetInstancesReturns(nil, errors.New("instances error"))
+					})
+
+					It("notifies users", func() {
+						Expect(runCommand("my-app", "--all-instances", "-c", "hostname")).To(BeFalse())
+
+						Expect(ui.Outputs()).To(ContainSubstrings(
+							[]string{"Error getting application instances", "instances error"},
+						))
+					})
+				})
+			})
+
 			Context("when Wait() or InteractiveSession() returns error", func() {
 
 				It("notifities users", func() {
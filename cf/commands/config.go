@@ -2,6 +2,8 @@ package commands
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"sort"
 
 	"code.cloudfoundry.org/cli/cf/commandregistry"
@@ -28,12 +30,17 @@ func (cmd *ConfigCommands) MetaData() commandregistry.CommandMetadata {
 	fs["trace"] = &flags.StringFlag{Name: "trace", Usage: T("Trace HTTP requests")}
 	fs["color"] = &flags.StringFlag{Name: "color", Usage: T("Enable or disable color")}
 	fs["locale"] = &flags.StringFlag{Name: "locale", Usage: T("Set default locale. If LOCALE is 'CLEAR', previous locale is deleted.")}
+	fs["ca-cert"] = &flags.StringFlag{Name: "ca-cert", Usage: T("PEM bundle of additional certificate authorities to trust. If PATH is 'CLEAR', the previous bundle is deleted.")}
+	fs["client-cert"] = &flags.StringFlag{Name: "client-cert", Usage: T("PEM client certificate presented to servers that request one. If PATH is 'CLEAR', the previous certificate is deleted.")}
+	fs["client-key"] = &flags.StringFlag{Name: "client-key", Usage: T("PEM private key for the client certificate. If PATH is 'CLEAR', the previous key is deleted.")}
+	fs["plugin-trusted-keys"] = &flags.StringFlag{Name: "plugin-trusted-keys", Usage: T("File of base64 encoded ed25519 public keys, one per line, that plugin signatures are verified with. If PATH is 'CLEAR', the previous file is deleted.")}
+	fs["require-signed-plugins"] = &flags.StringFlag{Name: "require-signed-plugins", Usage: T("Only install plugins signed by a trusted key")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "config",
 		Description: T("Write default values to the config"),
 		Usage: []string{
-			T("CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)]"),
+			T("CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)] [--ca-cert (PATH | CLEAR)] [--client-cert (PATH | CLEAR)] [--client-key (PATH | CLEAR)] [--plugin-trusted-keys (PATH | CLEAR)] [--require-signed-plugins (true | false)]"),
 		},
 		Flags: fs,
 	}
@@ -51,7 +58,9 @@ func (cmd *ConfigCommands) SetDependency(deps commandregistry.Dependency, plugin
 }
 
 func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
-	if !context.IsSet("trace") && !context.IsSet("async-timeout") && !context.IsSet("color") && !context.IsSet("locale") {
+	if !context.IsSet("trace") && !context.IsSet("async-timeout") && !context.IsSet("color") && !context.IsSet("locale") &&
+		!context.IsSet("ca-cert") && !context.IsSet("client-cert") && !context.IsSet("client-key") &&
+		!context.IsSet("plugin-trusted-keys") && !context.IsSet("require-signed-plugins") {
 		return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
 	}
 
@@ -80,6 +89,38 @@ func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
 		}
 	}
 
+	if context.IsSet("require-signed-plugins") {
+		switch context.String("require-signed-plugins") {
+		case "true":
+			cmd.config.SetPluginRequireSigned(true)
+		case "false":
+			cmd.config.SetPluginRequireSigned(false)
+		default:
+			return errors.New(T("Incorrect Usage") + "\n\n" + commandregistry.Commands.CommandUsage("config"))
+		}
+	}
+
+	fileFlags := []struct {
+		name string
+		set  func(string)
+	}{
+		{"ca-cert", cmd.config.SetCACertFile},
+		{"client-cert", cmd.config.SetClientCertFile},
+		{"client-key", cmd.config.SetClientKeyFile},
+		{"plugin-trusted-keys", cmd.config.SetPluginTrustedKeysFile},
+	}
+	for _, fileFlag := range fileFlags {
+		if !context.IsSet(fileFlag.name) {
+			continue
+		}
+
+		path, err := configFilePath(context.String(fileFlag.name))
+		if err != nil {
+			return err
+		}
+		fileFlag.set(path)
+	}
+
 	if context.IsSet("locale") {
 		locale := context.String("locale")
 
@@ -106,3 +147,22 @@ func (cmd *ConfigCommands) Execute(context flags.FlagContext) error {
 	}
 	return nil
 }
+
+func configFilePath(path string) (string, error) {
+	if path == "CLEAR" {
+		return "", nil
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := os.Stat(absPath); err != nil {
+		return "", errors.New(T("File not found: {{.Path}}", map[string]interface{}{
+			"Path": absPath,
+		}))
+	}
+
+	return absPath, nil
+}
@@ -1,6 +1,10 @@
 package commands_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
@@ -125,4 +129,89 @@ var _ = Describe("config command", func() {
 			})
 		})
 	})
+
+	Context("--ca-cert flag", func() {
+		var certPath string
+
+		BeforeEach(func() {
+			file, err := ioutil.TempFile("", "ca-cert")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+			certPath = file.Name()
+		})
+
+		AfterEach(func() {
+			Expect(os.Remove(certPath)).To(Succeed())
+		})
+
+		It("stores the absolute path of the bundle", func() {
+			runCommand("--ca-cert", certPath)
+			absPath, err := filepath.Abs(certPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configRepo.TLSFiles().CACert).To(Equal(absPath))
+		})
+
+		It("fails when the file does not exist", func() {
+			runCommand("--ca-cert", certPath+"-missing")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"FAILED"},
+				[]string{"File not found:"},
+			))
+			Expect(configRepo.TLSFiles().CACert).To(BeEmpty())
+		})
+
+		Context("when the client certificate is already set", func() {
+			BeforeEach(func() {
+				configRepo.SetClientCertFile(certPath)
+				configRepo.SetClientKeyFile(certPath)
+			})
+
+			It("clears the paths when CLEAR is provided", func() {
+				runCommand("--client-cert", "CLEAR", "--client-key", "CLEAR")
+				Expect(configRepo.TLSFiles().ClientCert).To(BeEmpty())
+				Expect(configRepo.TLSFiles().ClientKey).To(BeEmpty())
+			})
+		})
+	})
+	Context("--plugin-trusted-keys flag", func() {
+		var keysPath string
+
+		BeforeEach(func() {
+			file, err := ioutil.TempFile("", "plugin-keys")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(file.Close()).To(Succeed())
+			keysPath = file.Name()
+		})
+
+		AfterEach(func() {
+			Expect(os.Remove(keysPath)).To(Succeed())
+		})
+
+		It("stores the absolute path of the keys file", func() {
+			runCommand("--plugin-trusted-keys", keysPath)
+			absPath, err := filepath.Abs(keysPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configRepo.PluginTrustedKeysFile()).To(Equal(absPath))
+
+			runCommand("--plugin-trusted-keys", "CLEAR")
+			Expect(configRepo.PluginTrustedKeysFile()).To(BeEmpty())
+		})
+	})
+
+	Context("--require-signed-plugins flag", func() {
+		It("stores whether signed plugins are required", func() {
+			runCommand("--require-signed-plugins", "true")
+			Expect(configRepo.PluginRequireSigned()).To(BeTrue())
+
+			runCommand("--require-signed-plugins", "false")
+			Expect(configRepo.PluginRequireSigned()).To(BeFalse())
+		})
+
+		It("fails with usage when a non-bool value is provided", func() {
+			runCommand("--require-signed-plugins", "sometimes")
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Incorrect Usage"},
+			))
+		})
+	})
 })
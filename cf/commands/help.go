@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/help"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/plugin"
 
 	. "code.cloudfoundry.org/cli/cf/i18n"
 )
@@ -69,7 +70,10 @@ func (cmd *Help) Execute(c flags.FlagContext) error {
 						output += "\n" + T("USAGE:") + "\n"
 						output += "   " + c.UsageDetails.Usage + "\n"
 
-						if len(c.UsageDetails.Options) > 0 {
+						if len(c.UsageDetails.Flags) > 0 {
+							output += "\n" + T("OPTIONS:") + "\n"
+							output += flags.NewFlagContext(pluginFlagSets(c.UsageDetails.Flags)).ShowUsage(3) + "\n"
+						} else if len(c.UsageDetails.Options) > 0 {
 							output += "\n" + T("OPTIONS:") + "\n"
 
 							//find longest name length
@@ -99,3 +103,28 @@ func (cmd *Help) Execute(c flags.FlagContext) error {
 	}
 	return nil
 }
+
+func pluginFlagSets(pluginFlags []plugin.Flag) map[string]flags.FlagSet {
+	fs := make(map[string]flags.FlagSet)
+	for _, f := range pluginFlags {
+		usage := f.Usage
+		if f.Default != "" {
+			usage += T(" (Default: {{.DefaultValue}})", map[string]interface{}{"DefaultValue": f.Default})
+		}
+
+		key := f.Name
+		if key == "" {
+			key = f.ShortName
+		}
+
+		switch f.Type {
+		case plugin.StringFlag:
+			fs[key] = &flags.StringFlag{Name: f.Name, ShortName: f.ShortName, Usage: usage}
+		case plugin.IntFlag:
+			fs[key] = &flags.IntFlag{Name: f.Name, ShortName: f.ShortName, Usage: usage}
+		default:
+			fs[key] = &flags.BoolFlag{Name: f.Name, ShortName: f.ShortName, Usage: usage}
+		}
+	}
+	return fs
+}
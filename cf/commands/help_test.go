@@ -176,6 +176,43 @@ var _ = Describe("Help", func() {
 			})
 		})
 
+		Context("command declares structured flags", func() {
+			BeforeEach(func() {
+				m := make(map[string]pluginconfig.PluginMetadata)
+				m["fakePlugin"] = pluginconfig.PluginMetadata{
+					Commands: []plugin.Command{
+						{
+							Name:     "deploy",
+							HelpText: "Deploys an app",
+							UsageDetails: plugin.Usage{
+								Usage: "cf deploy [--app APP] [--env ENV] [-f]",
+								Options: map[string]string{
+									"ignored": "only shown when there are no flags",
+								},
+								Flags: []plugin.Flag{
+									{Name: "app", ShortName: "a", Type: plugin.StringFlag, Usage: "App to deploy"},
+									{Name: "env", Type: plugin.StringFlag, Default: "dev", Usage: "Environment to deploy to"},
+									{ShortName: "f", Usage: "Deploy without confirmation"},
+								},
+							},
+						},
+					},
+				}
+
+				fakeConfig.PluginsReturns(m)
+			})
+
+			It("prints the flags like those of core commands", func() {
+				flagContext.Parse("deploy")
+				err := cmd.Execute(flagContext)
+				Expect(err).NotTo(HaveOccurred())
+
+				output, _ := fakeUI.SayArgsForCall(0)
+				Expect(output).To(ContainSubstring("OPTIONS:\n   --app, -a      App to deploy\n   --env          Environment to deploy to (Default: dev)\n   -f             Deploy without confirmation\n"))
+				Expect(output).NotTo(ContainSubstring("ignored"))
+			})
+		})
+
 		Context("command is a plugin command alias", func() {
 			It("prints the usage help for the command alias", func() {
 				flagContext.Parse("fpc1")
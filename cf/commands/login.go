@@ -15,6 +15,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/util/browser"
 )
 
 const maxLoginTries = 3
@@ -27,6 +28,7 @@ type Login struct {
 	endpointRepo  coreconfig.EndpointRepository
 	orgRepo       organizations.OrganizationRepository
 	spaceRepo     spaces.SpaceRepository
+	openURL       func(url string) error
 }
 
 func init() {
@@ -40,7 +42,7 @@ func (cmd *Login) MetaData() commandregistry.CommandMetadata {
 	fs["p"] = &flags.StringFlag{ShortName: "p", Usage: T("Password")}
 	fs["o"] = &flags.StringFlag{ShortName: "o", Usage: T("Org")}
 	fs["s"] = &flags.StringFlag{ShortName: "s", Usage: T("Space")}
-	fs["sso"] = &flags.BoolFlag{Name: "sso", Usage: T("Prompt for a one-time passcode to login")}
+	fs["sso"] = &flags.BoolFlag{Name: "sso", Usage: T("Log in through a browser, or with a one-time passcode if no browser is available")}
 	fs["sso-passcode"] = &flags.StringFlag{Name: "sso-passcode", Usage: T("One-time passcode")}
 	fs["skip-ssl-validation"] = &flags.BoolFlag{Name: "skip-ssl-validation", Usage: T("Skip verification of the API endpoint. Not recommended!")}
 
@@ -57,7 +59,7 @@ func (cmd *Login) MetaData() commandregistry.CommandMetadata {
 			T("CF_NAME login -u name@example.com -p pa55woRD (specify username and password as arguments)"),
 			T("CF_NAME login -u name@example.com -p \"my password\" (use quotes for passwords with a space)"),
 			T("CF_NAME login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)"),
-			T("CF_NAME login --sso (CF_NAME will provide a url to obtain a one-time passcode to login)"),
+			T("CF_NAME login --sso (CF_NAME will open a browser to log in, or provide a url to obtain a one-time passcode)"),
 		},
 		Flags: fs,
 	}
@@ -75,6 +77,7 @@ func (cmd *Login) SetDependency(deps commandregistry.Dependency, pluginCall bool
 	cmd.endpointRepo = deps.RepoLocator.GetEndpointRepository()
 	cmd.orgRepo = deps.RepoLocator.GetOrganizationRepository()
 	cmd.spaceRepo = deps.RepoLocator.GetSpaceRepository()
+	cmd.openURL = browser.Open
 	return cmd
 }
 
@@ -161,6 +164,20 @@ func (cmd Login) authenticateSSO(c flags.FlagContext) error {
 		return err
 	}
 
+	if !c.IsSet("sso-passcode") {
+		err = cmd.authenticator.AuthenticateWithBrowser(cmd.openBrowser)
+		switch err.(type) {
+		case nil:
+			cmd.ui.Ok()
+			cmd.ui.Say("")
+			return nil
+		case authentication.BrowserUnavailableError:
+			cmd.ui.Say(T("Unable to log in with a browser: {{.Error}}", map[string]interface{}{"Error": err.Error()}))
+		default:
+			return err
+		}
+	}
+
 	credentials := make(map[string]string)
 	passcode := prompts["passcode"]
 
@@ -189,6 +206,21 @@ func (cmd Login) authenticateSSO(c flags.FlagContext) error {
 	return nil
 }
 
+func (cmd Login) openBrowser(url string) error {
+	if cmd.openURL == nil {
+		return browser.ErrNoBrowser
+	}
+
+	err := cmd.openURL(url)
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Log in with the browser window that opened. If it did not open, visit:\n{{.URL}}", map[string]interface{}{"URL": url}))
+	cmd.ui.Say(T("Authenticating..."))
+	return nil
+}
+
 func (cmd Login) authenticate(c flags.FlagContext) error {
 	usernameFlagValue := c.String("u")
 	passwordFlagValue := c.String("p")
@@ -3,6 +3,7 @@ package commands_test
 import (
 	"strconv"
 
+	"code.cloudfoundry.org/cli/cf/api/authentication"
 	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
 	"code.cloudfoundry.org/cli/cf/api/organizations/organizationsfakes"
 	"code.cloudfoundry.org/cli/cf/api/spaces/spacesfakes"
@@ -429,18 +430,51 @@ var _ = Describe("Login Command", func() {
 			})
 
 			Context("when the user does provide the --sso flag", func() {
-				It("only prompts the user for the passcode type prompts", func() {
+				BeforeEach(func() {
 					Flags = []string{"--sso", "-a", "api.example.com"}
-					ui.Inputs = []string{"the-one-time-code"}
+				})
 
+				It("logs in through the browser without prompting", func() {
 					testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
 
+					Expect(authRepo.AuthenticateWithBrowserCallCount()).To(Equal(1))
 					Expect(ui.Prompts).To(BeEmpty())
-					Expect(ui.PasswordPrompts).To(ContainSubstrings([]string{"passcode"}))
-					Expect(authRepo.AuthenticateCallCount()).To(Equal(1))
-					Expect(authRepo.AuthenticateArgsForCall(0)).To(Equal(map[string]string{
-						"passcode": "the-one-time-code",
-					}))
+					Expect(ui.PasswordPrompts).To(BeEmpty())
+					Expect(authRepo.AuthenticateCallCount()).To(Equal(0))
+				})
+
+				Context("when no browser is available", func() {
+					BeforeEach(func() {
+						authRepo.AuthenticateWithBrowserReturns(authentication.BrowserUnavailableError{Err: errors.New("no browser available")})
+					})
+
+					It("only prompts the user for the passcode type prompts", func() {
+						ui.Inputs = []string{"the-one-time-code"}
+
+						testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+
+						Expect(ui.Outputs()).To(ContainSubstrings([]string{"Unable to log in with a browser: no browser available"}))
+						Expect(ui.Prompts).To(BeEmpty())
+						Expect(ui.PasswordPrompts).To(ContainSubstrings([]string{"passcode"}))
+						Expect(authRepo.AuthenticateCallCount()).To(Equal(1))
+						Expect(authRepo.AuthenticateArgsForCall(0)).To(Equal(map[string]string{
+							"passcode": "the-one-time-code",
+						}))
+					})
+				})
+
+				Context("when the browser login fails", func() {
+					BeforeEach(func() {
+						authRepo.AuthenticateWithBrowserReturns(errors.New("access denied"))
+					})
+
+					It("fails without prompting for a passcode", func() {
+						execution := testcmd.RunCLICommand("login", Flags, nil, updateCommandDependency, false, ui)
+						Expect(execution).To(BeFalse())
+
+						Expect(ui.PasswordPrompts).To(BeEmpty())
+						Expect(authRepo.AuthenticateCallCount()).To(Equal(0))
+					})
 				})
 			})
 
@@ -3,6 +3,7 @@ package plugin
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"net/rpc"
 	"os"
 	"os/exec"
@@ -20,6 +21,7 @@ import (
 	"code.cloudfoundry.org/cli/plugin"
 	"code.cloudfoundry.org/cli/util"
 	"code.cloudfoundry.org/cli/util/downloader"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 	"code.cloudfoundry.org/gofileutils/fileutils"
 
 	pluginRPCService "code.cloudfoundry.org/cli/plugin/rpc"
@@ -30,7 +32,8 @@ type PluginInstall struct {
 	config       coreconfig.Reader
 	pluginConfig pluginconfig.PluginConfiguration
 	pluginRepo   pluginrepo.PluginRepo
-	checksum     util.Sha1Checksum
+	checksum     util.Checksum
+	certificates tlsconfig.Certificates
 	rpcService   *pluginRPCService.CliRpcService
 }
 
@@ -42,14 +45,18 @@ func (cmd *PluginInstall) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["r"] = &flags.StringFlag{ShortName: "r", Usage: T("Name of a registered repository where the specified plugin is located")}
 	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force install of plugin without confirmation")}
+	fs["require-signed"] = &flags.BoolFlag{Name: "require-signed", Usage: T("Only install the plugin if it is signed by a trusted key")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "install-plugin",
 		Description: T("Install CLI plugin"),
 		Usage: []string{
-			T(`CF_NAME install-plugin (LOCAL-PATH/TO/PLUGIN | URL | -r REPO_NAME PLUGIN_NAME) [-f]
+			T(`CF_NAME install-plugin (LOCAL-PATH/TO/PLUGIN | URL | -r REPO_NAME PLUGIN_NAME) [-f] [--require-signed]
 
-   Prompts for confirmation unless '-f' is provided.`),
+   Prompts for confirmation unless '-f' is provided.
+
+   Plugin signatures are read from the binary's location with '.sig' appended and verified
+   with the keys set by 'CF_NAME config --plugin-trusted-keys'.`),
 		},
 		Examples: []string{
 			"CF_NAME install-plugin ~/Downloads/plugin-foobar",
@@ -77,6 +84,7 @@ func (cmd *PluginInstall) SetDependency(deps commandregistry.Dependency, pluginC
 	cmd.pluginConfig = deps.PluginConfig
 	cmd.pluginRepo = deps.PluginRepo
 	cmd.checksum = deps.ChecksumUtil
+	cmd.certificates = deps.Certificates
 
 	//reset rpc registration in case there is other running instance,
 	//each service can only be registered once
@@ -103,7 +111,7 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 		return errors.New(T("Plugin installation cancelled"))
 	}
 
-	fileDownloader := downloader.NewDownloader(os.TempDir())
+	fileDownloader := downloader.NewDownloader(os.TempDir(), cmd.certificates.TLSConfig(false))
 
 	removeTmpFile := func() {
 		err := fileDownloader.RemoveFile()
@@ -113,6 +121,11 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 	}
 	defer removeTmpFile()
 
+	verifier, err := newSignatureVerifier(cmd.config, cmd.certificates, c.Bool("require-signed"))
+	if err != nil {
+		return err
+	}
+
 	deps := &plugininstaller.Context{
 		Checksummer:    cmd.checksum,
 		GetPluginRepos: cmd.config.PluginRepos,
@@ -120,9 +133,13 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 		PluginRepo:     cmd.pluginRepo,
 		RepoName:       c.String("r"),
 		UI:             cmd.ui,
+		Verifier:       verifier,
 	}
 	installer := plugininstaller.NewPluginInstaller(deps)
-	pluginSourceFilepath := installer.Install(c.Args()[0])
+	pluginSourceFilepath, err := installer.Install(c.Args()[0])
+	if err != nil {
+		return err
+	}
 
 	_, pluginExecutableName := filepath.Split(pluginSourceFilepath)
 
@@ -135,7 +152,7 @@ func (cmd *PluginInstall) Execute(c flags.FlagContext) error {
 
 	pluginDestinationFilepath := filepath.Join(cmd.pluginConfig.GetPluginPath(), pluginExecutableName)
 
-	err := cmd.ensurePluginBinaryWithSameFileNameDoesNotAlreadyExist(pluginDestinationFilepath, pluginExecutableName)
+	err = cmd.ensurePluginBinaryWithSameFileNameDoesNotAlreadyExist(pluginDestinationFilepath, pluginExecutableName)
 	if err != nil {
 		return err
 	}
@@ -210,7 +227,14 @@ func (cmd *PluginInstall) ensurePluginIsSafeForInstallation(pluginMetadata *plug
 		)
 	}
 
-	if pluginMetadata.Commands == nil {
+	return validatePluginMetadata(pluginMetadata, plugins, pluginSourceFilepath)
+}
+
+// validatePluginMetadata makes sure the plugin provides commands or hooks that
+// are valid and that its commands do not conflict with core commands or with
+// the commands of installedPlugins.
+func validatePluginMetadata(pluginMetadata *plugin.PluginMetadata, installedPlugins map[string]pluginconfig.PluginMetadata, pluginSourceFilepath string) error {
+	if pluginMetadata.Commands == nil && pluginMetadata.Hooks == nil {
 		return errors.New(T(
 			"Error getting command list from plugin {{.FilePath}}",
 			map[string]interface{}{
@@ -240,7 +264,7 @@ func (cmd *PluginInstall) ensurePluginIsSafeForInstallation(pluginMetadata *plug
 			)
 		}
 
-		for installedPluginName, installedPlugin := range plugins {
+		for installedPluginName, installedPlugin := range installedPlugins {
 			for _, installedPluginCmd := range installedPlugin.Commands {
 
 				//check for command conflicting other plugin commands/alias
@@ -267,6 +291,18 @@ func (cmd *PluginInstall) ensurePluginIsSafeForInstallation(pluginMetadata *plug
 			}
 		}
 	}
+
+	for _, hook := range pluginMetadata.Hooks {
+		if hook.Command == "" || (hook.Stage != plugin.PreHook && hook.Stage != plugin.PostHook) {
+			return errors.New(T(
+				"Hook `{{.Stage}} {{.Command}}` in the plugin being installed is invalid. Hooks need a core command name and a stage of `pre` or `post`.",
+				map[string]interface{}{
+					"Stage":   hook.Stage,
+					"Command": hook.Command,
+				}),
+			)
+		}
+	}
 	return nil
 }
 
@@ -285,6 +321,7 @@ func (cmd *PluginInstall) installPlugin(pluginMetadata *plugin.PluginMetadata, p
 		Location: pluginDestinationFilepath,
 		Version:  pluginMetadata.Version,
 		Commands: pluginMetadata.Commands,
+		Hooks:    pluginMetadata.Hooks,
 	}
 
 	cmd.pluginConfig.SetPlugin(pluginMetadata.Name, configMetadata)
@@ -292,24 +329,28 @@ func (cmd *PluginInstall) installPlugin(pluginMetadata *plugin.PluginMetadata, p
 }
 
 func (cmd *PluginInstall) runBinaryAndObtainPluginMetadata(pluginSourceFilepath string) (*plugin.PluginMetadata, error) {
-	err := cmd.rpcService.Start()
+	return obtainPluginMetadata(cmd.rpcService, pluginSourceFilepath)
+}
+
+func obtainPluginMetadata(rpcService *pluginRPCService.CliRpcService, pluginSourceFilepath string) (*plugin.PluginMetadata, error) {
+	err := rpcService.Start()
 	if err != nil {
 		return nil, err
 	}
-	defer cmd.rpcService.Stop()
+	defer rpcService.Stop()
 
-	err = cmd.runPluginBinary(pluginSourceFilepath, cmd.rpcService.Port())
+	err = runPluginBinary(pluginSourceFilepath, rpcService.Port())
 	if err != nil {
 		return nil, err
 	}
 
-	c := cmd.rpcService.RpcCmd
+	c := rpcService.RpcCmd
 	c.MetadataMutex.RLock()
 	defer c.MetadataMutex.RUnlock()
 	return c.PluginMetadata, nil
 }
 
-func (cmd *PluginInstall) runPluginBinary(location string, servicePort string) error {
+func runPluginBinary(location string, servicePort string) error {
 	pluginInvocation := exec.Command(location, servicePort, "SendMetadata")
 
 	err := pluginInvocation.Run()
@@ -318,3 +359,30 @@ func (cmd *PluginInstall) runPluginBinary(location string, servicePort string) e
 	}
 	return nil
 }
+
+// newSignatureVerifier returns a verifier that uses the configured trusted
+// keys. Signed plugins are required when either requireSigned or the config
+// asks for it.
+func newSignatureVerifier(config coreconfig.Reader, certificates tlsconfig.Certificates, requireSigned bool) (plugininstaller.SignatureVerifier, error) {
+	verifier := plugininstaller.SignatureVerifier{
+		RequireSigned: requireSigned || config.PluginRequireSigned(),
+		Client: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: certificates.TLSConfig(false),
+			},
+		},
+	}
+
+	if path := config.PluginTrustedKeysFile(); path != "" {
+		keys, err := plugininstaller.LoadTrustedKeys(path)
+		if err != nil {
+			return plugininstaller.SignatureVerifier{}, errors.New(T("Could not load trusted plugin keys: {{.Error}}", map[string]interface{}{
+				"Error": err.Error(),
+			}))
+		}
+		verifier.TrustedKeys = keys
+	}
+
+	return verifier, nil
+}
@@ -8,7 +8,9 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
+	"code.cloudfoundry.org/cli/cf/actors/plugininstaller"
 	"code.cloudfoundry.org/cli/cf/actors/pluginrepo/pluginrepofakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/commandregistry/commandregistryfakes"
@@ -39,7 +41,7 @@ var _ = Describe("Install", func() {
 		config              coreconfig.Repository
 		pluginConfig        *pluginconfigfakes.FakePluginConfiguration
 		fakePluginRepo      *pluginrepofakes.FakePluginRepo
-		fakeChecksum        *utilfakes.FakeSha1Checksum
+		fakeChecksum        *utilfakes.FakeChecksum
 
 		pluginFile *os.File
 		homeDir    string
@@ -71,7 +73,7 @@ var _ = Describe("Install", func() {
 		pluginConfig = new(pluginconfigfakes.FakePluginConfiguration)
 		config = testconfig.NewRepositoryWithDefaults()
 		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
-		fakeChecksum = new(utilfakes.FakeSha1Checksum)
+		fakeChecksum = new(utilfakes.FakeChecksum)
 
 		dir, err := os.Getwd()
 		if err != nil {
@@ -203,6 +205,10 @@ var _ = Describe("Install", func() {
 
 					BeforeEach(func() {
 						h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+							if strings.HasSuffix(r.URL.Path, plugininstaller.SignatureSuffix) {
+								http.NotFound(w, r)
+								return
+							}
 							fmt.Fprintln(w, "abc")
 						})
 
@@ -298,6 +304,10 @@ var _ = Describe("Install", func() {
 
 				It("downloads and installs binary when it is available", func() {
 					h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						if strings.HasSuffix(r.URL.Path, plugininstaller.SignatureSuffix) {
+							http.NotFound(w, r)
+							return
+						}
 						fmt.Fprintln(w, "hi")
 					})
 
@@ -333,6 +343,24 @@ var _ = Describe("Install", func() {
 						},
 					))
 				})
+
+				It("refuses an unsigned plugin when --require-signed is provided", func() {
+					runCommand("./install_plugin.go", "-f", "--require-signed")
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"FAILED"},
+						[]string{"Plugin binary is not signed and only signed plugins may be installed"},
+					))
+				})
+
+				It("refuses an unsigned plugin when the config requires signed plugins", func() {
+					config.SetPluginRequireSigned(true)
+					runCommand("./install_plugin.go", "-f")
+
+					Expect(ui.Outputs()).To(ContainSubstrings(
+						[]string{"Plugin binary is not signed and only signed plugins may be installed"},
+					))
+				})
 			})
 		})
 
@@ -3,8 +3,12 @@ package plugin
 import (
 	"fmt"
 	"sort"
+	"strings"
 
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
 	"code.cloudfoundry.org/cli/cf/flags"
 	. "code.cloudfoundry.org/cli/cf/i18n"
@@ -15,8 +19,10 @@ import (
 )
 
 type Plugins struct {
-	ui     terminal.UI
-	config pluginconfig.PluginConfiguration
+	ui         terminal.UI
+	config     pluginconfig.PluginConfiguration
+	coreConfig coreconfig.Reader
+	pluginRepo pluginrepo.PluginRepo
 }
 
 func init() {
@@ -26,12 +32,13 @@ func init() {
 func (cmd *Plugins) MetaData() commandregistry.CommandMetadata {
 	fs := make(map[string]flags.FlagSet)
 	fs["checksum"] = &flags.BoolFlag{Name: "checksum", Usage: T("Compute and show the sha1 value of the plugin binary file")}
+	fs["outdated"] = &flags.BoolFlag{Name: "outdated", Usage: T("Search the plugin repositories for new versions of installed plugins")}
 
 	return commandregistry.CommandMetadata{
 		Name:        "plugins",
 		Description: T("List all available plugin commands"),
 		Usage: []string{
-			T("CF_NAME plugins"),
+			T("CF_NAME plugins [--checksum | --outdated]"),
 		},
 		Flags: fs,
 	}
@@ -54,10 +61,16 @@ func (cmd *Plugins) Requirements(requirementsFactory requirements.Factory, fc fl
 func (cmd *Plugins) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
 	cmd.ui = deps.UI
 	cmd.config = deps.PluginConfig
+	cmd.coreConfig = deps.Config
+	cmd.pluginRepo = deps.PluginRepo
 	return cmd
 }
 
 func (cmd *Plugins) Execute(c flags.FlagContext) error {
+	if c.Bool("outdated") {
+		return cmd.listOutdatedPlugins()
+	}
+
 	var version string
 
 	cmd.ui.Say(T("Listing Installed Plugins..."))
@@ -96,7 +109,7 @@ func (cmd *Plugins) Execute(c flags.FlagContext) error {
 			}
 
 			if c.Bool("checksum") {
-				checksum := util.NewSha1Checksum(metadata.Location)
+				checksum := util.NewChecksum(metadata.Location)
 				sha1, err := checksum.ComputeFileSha1()
 				if err != nil {
 					args = append(args, "n/a")
@@ -119,3 +132,51 @@ func (cmd *Plugins) Execute(c flags.FlagContext) error {
 	}
 	return nil
 }
+
+func (cmd *Plugins) listOutdatedPlugins() error {
+	repos := cmd.coreConfig.PluginRepos()
+	if len(repos) == 0 {
+		cmd.ui.Say(T("No plugin repositories registered to search for plugin updates."))
+		return nil
+	}
+
+	repoNames := []string{}
+	for _, repo := range repos {
+		repoNames = append(repoNames, repo.Name)
+	}
+	cmd.ui.Say(T("Searching {{.RepoNames}} for newer versions of installed plugins...", map[string]interface{}{
+		"RepoNames": strings.Join(repoNames, ", "),
+	}))
+
+	repoPlugins, repoErrors := cmd.pluginRepo.GetPlugins(repos)
+	for _, repoError := range repoErrors {
+		cmd.ui.Warn(repoError)
+	}
+
+	updates := pluginrepo.FindPluginUpdates(cmd.config.Plugins(), repoPlugins)
+
+	cmd.ui.Ok()
+	cmd.ui.Say("")
+
+	if len(updates) == 0 {
+		cmd.ui.Say(T("All installed plugins are up to date."))
+		return nil
+	}
+
+	table := cmd.ui.Table([]string{T("Plugin Name"), T("Version"), T("Latest Version"), T("Repository")})
+	for _, update := range updates {
+		table.Add(update.Name, update.CurrentVersion.String(), update.LatestVersion.String(), update.RepoName)
+	}
+
+	err := table.Print()
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Use '{{.UpdateCommand}}' to update a plugin, or '{{.UpdateAllCommand}}' to update all of them.", map[string]interface{}{
+		"UpdateCommand":    terminal.CommandColor(cf.Name + " update-plugin PLUGIN_NAME"),
+		"UpdateAllCommand": terminal.CommandColor(cf.Name + " update-plugin --all"),
+	}))
+	return nil
+}
@@ -3,16 +3,21 @@ package plugin_test
 import (
 	"net/rpc"
 
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo/pluginrepofakes"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	plugincmd "code.cloudfoundry.org/cli/cf/commands/plugin"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
 	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig/pluginconfigfakes"
 	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
 	"code.cloudfoundry.org/cli/plugin"
 	testcmd "code.cloudfoundry.org/cli/util/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/util/testhelpers/configuration"
 	. "code.cloudfoundry.org/cli/util/testhelpers/matchers"
 	testterm "code.cloudfoundry.org/cli/util/testhelpers/terminal"
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -23,12 +28,16 @@ var _ = Describe("Plugins", func() {
 		ui                  *testterm.FakeUI
 		requirementsFactory *requirementsfakes.FakeFactory
 		config              *pluginconfigfakes.FakePluginConfiguration
+		coreConfig          coreconfig.Repository
+		fakePluginRepo      *pluginrepofakes.FakePluginRepo
 		deps                commandregistry.Dependency
 	)
 
 	updateCommandDependency := func(pluginCall bool) {
 		deps.UI = ui
 		deps.PluginConfig = config
+		deps.Config = coreConfig
+		deps.PluginRepo = fakePluginRepo
 		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("plugins").SetDependency(deps, pluginCall))
 	}
 
@@ -36,6 +45,8 @@ var _ = Describe("Plugins", func() {
 		ui = &testterm.FakeUI{}
 		requirementsFactory = new(requirementsfakes.FakeFactory)
 		config = new(pluginconfigfakes.FakePluginConfiguration)
+		coreConfig = testconfig.NewRepositoryWithDefaults()
+		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
 
 		rpc.DefaultServer = rpc.NewServer()
 	})
@@ -64,6 +75,49 @@ var _ = Describe("Plugins", func() {
 		})
 	})
 
+	Context("If --outdated flag is provided", func() {
+		BeforeEach(func() {
+			coreConfig.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: "http://repo1.example.com"})
+			coreConfig.SetPluginRepo(models.PluginRepo{Name: "repo2", URL: "http://repo2.example.com"})
+
+			config.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+				"Test1": {Location: "path/to/plugin1", Version: plugin.VersionType{Major: 1, Minor: 2, Build: 3}},
+				"Test2": {Location: "path/to/plugin2", Version: plugin.VersionType{Major: 2, Minor: 0, Build: 0}},
+			})
+		})
+
+		It("lists the installed plugins that have a newer version in a registered repo", func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+				"repo1": {{Name: "Test1", Version: "1.3.0"}, {Name: "Test2", Version: "2.0.0"}},
+				"repo2": {{Name: "Test1", Version: "1.2.9"}},
+			}, []string{"repo2 is unreachable"})
+
+			runCommand("--outdated")
+
+			repos := fakePluginRepo.GetPluginsArgsForCall(0)
+			Expect(repos).To(HaveLen(2))
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Searching repo1, repo2 for newer versions of installed plugins..."},
+				[]string{"repo2 is unreachable"},
+				[]string{"OK"},
+				[]string{"Plugin Name", "Version", "Latest Version", "Repository"},
+				[]string{"Test1", "1.2.3", "1.3.0", "repo1"},
+				[]string{"update-plugin --all"},
+			))
+			Expect(ui.Outputs()).NotTo(ContainSubstrings([]string{"Test2"}))
+		})
+
+		It("says so when all plugins are up to date", func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+				"repo1": {{Name: "Test1", Version: "1.2.3"}},
+			}, nil)
+
+			runCommand("--outdated")
+
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"All installed plugins are up to date."}))
+		})
+	})
+
 	Context("when arguments are provided", func() {
 		var cmd commandregistry.Command
 		var flagContext flags.FlagContext
@@ -0,0 +1,317 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"net/rpc"
+	"os"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/actors/plugininstaller"
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/cf/flags"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/util"
+	"code.cloudfoundry.org/cli/util/downloader"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
+	"code.cloudfoundry.org/gofileutils/fileutils"
+
+	pluginRPCService "code.cloudfoundry.org/cli/plugin/rpc"
+)
+
+type PluginUpdate struct {
+	ui           terminal.UI
+	config       coreconfig.Reader
+	pluginConfig pluginconfig.PluginConfiguration
+	pluginRepo   pluginrepo.PluginRepo
+	checksum     util.Checksum
+	certificates tlsconfig.Certificates
+	rpcService   *pluginRPCService.CliRpcService
+}
+
+func init() {
+	commandregistry.Register(&PluginUpdate{})
+}
+
+func (cmd *PluginUpdate) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["all"] = &flags.BoolFlag{Name: "all", Usage: T("Update all installed plugins that have a newer version in a registered repository")}
+	fs["f"] = &flags.BoolFlag{ShortName: "f", Usage: T("Force update of plugins without confirmation")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "update-plugin",
+		Description: T("Update installed plugins to the latest version in the registered repositories"),
+		Usage: []string{
+			T(`CF_NAME update-plugin (PLUGIN_NAME | --all) [-f]
+
+   Prompts for confirmation unless '-f' is provided.
+
+   The replaced binary of each plugin is kept in the plugin directory with a '.rollback'
+   suffix. To go back to it, move that file out of the plugin directory, run
+   'CF_NAME uninstall-plugin PLUGIN_NAME' and install the moved file with 'CF_NAME install-plugin'.`),
+		},
+		Examples: []string{
+			"CF_NAME update-plugin plugin-echo",
+			"CF_NAME update-plugin --all -f",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *PluginUpdate) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) > 1 || (len(fc.Args()) == 1) == fc.Bool("all") {
+		cmd.ui.Failed(T("Incorrect Usage. Requires either a plugin name or the --all flag\n\n") + commandregistry.Commands.CommandUsage("update-plugin"))
+		return nil, fmt.Errorf("Incorrect usage: requires either a plugin name or --all")
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs, nil
+}
+
+func (cmd *PluginUpdate) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	cmd.config = deps.Config
+	cmd.pluginConfig = deps.PluginConfig
+	cmd.pluginRepo = deps.PluginRepo
+	cmd.checksum = deps.ChecksumUtil
+	cmd.certificates = deps.Certificates
+
+	//reset rpc registration in case there is other running instance,
+	//each service can only be registered once
+	server := rpc.NewServer()
+
+	rpcService, err := pluginRPCService.NewRpcService(deps.TeePrinter, deps.TeePrinter, deps.Config, deps.RepoLocator, pluginRPCService.NewCommandRunner(), deps.Logger, cmd.ui.Writer(), server)
+	if err != nil {
+		cmd.ui.Failed("Error initializing RPC service: " + err.Error())
+	}
+
+	cmd.rpcService = rpcService
+
+	return cmd
+}
+
+func (cmd *PluginUpdate) Execute(c flags.FlagContext) error {
+	installed := cmd.pluginConfig.Plugins()
+
+	if !c.Bool("all") {
+		pluginName := c.Args()[0]
+		metadata, ok := installed[pluginName]
+		if !ok {
+			return errors.New(T("Plugin name {{.PluginName}} does not exist", map[string]interface{}{"PluginName": pluginName}))
+		}
+		installed = map[string]pluginconfig.PluginMetadata{pluginName: metadata}
+	}
+
+	cmd.ui.Say(T("Searching for plugin updates..."))
+
+	repoPlugins, repoErrors := cmd.pluginRepo.GetPlugins(cmd.config.PluginRepos())
+	for _, repoError := range repoErrors {
+		cmd.ui.Warn(repoError)
+	}
+
+	updates := pluginrepo.FindPluginUpdates(installed, repoPlugins)
+	if len(updates) == 0 {
+		cmd.ui.Ok()
+		cmd.ui.Say(T("All plugins are up to date."))
+		return nil
+	}
+
+	names := []string{}
+	for _, update := range updates {
+		names = append(names, fmt.Sprintf("%s v%s", update.Name, update.LatestVersion))
+	}
+
+	if !c.Bool("f") && !cmd.ui.Confirm(T("**Attention: Plugins are binaries written by potentially untrusted authors. Install and use plugins at your own risk.**\n\nDo you want to update to {{.Plugins}}?",
+		map[string]interface{}{
+			"Plugins": strings.Join(names, ", "),
+		}),
+	) {
+		return errors.New(T("Plugin update cancelled"))
+	}
+
+	failed := []string{}
+	for _, update := range updates {
+		err := cmd.updatePlugin(update, installed[update.Name])
+		if err != nil {
+			cmd.ui.Warn(err.Error())
+			failed = append(failed, update.Name)
+			continue
+		}
+
+		cmd.ui.Say(T("Plugin {{.PluginName}} successfully updated to v{{.Version}}.", map[string]interface{}{
+			"PluginName": update.Name,
+			"Version":    update.LatestVersion.String(),
+		}))
+	}
+
+	if len(failed) > 0 {
+		return errors.New(T("Failed to update plugins: {{.PluginNames}}", map[string]interface{}{
+			"PluginNames": strings.Join(failed, ", "),
+		}))
+	}
+
+	cmd.ui.Ok()
+	return nil
+}
+
+func (cmd *PluginUpdate) updatePlugin(update pluginrepo.PluginUpdate, current pluginconfig.PluginMetadata) error {
+	cmd.ui.Say(T("Updating plugin {{.PluginName}} from v{{.CurrentVersion}} to v{{.LatestVersion}} using repository {{.RepoName}}...", map[string]interface{}{
+		"PluginName":     update.Name,
+		"CurrentVersion": update.CurrentVersion.String(),
+		"LatestVersion":  update.LatestVersion.String(),
+		"RepoName":       update.RepoName,
+	}))
+
+	fileDownloader := downloader.NewDownloader(os.TempDir(), cmd.certificates.TLSConfig(false))
+	defer func() {
+		err := fileDownloader.RemoveFile()
+		if err != nil {
+			cmd.ui.Say(T("Problem removing downloaded binary in temp directory: ") + err.Error())
+		}
+	}()
+
+	verifier, err := newSignatureVerifier(cmd.config, cmd.certificates, false)
+	if err != nil {
+		return err
+	}
+
+	installer := plugininstaller.NewPluginInstaller(&plugininstaller.Context{
+		Checksummer:    cmd.checksum,
+		GetPluginRepos: cmd.config.PluginRepos,
+		FileDownloader: fileDownloader,
+		PluginRepo:     cmd.pluginRepo,
+		RepoName:       update.RepoName,
+		UI:             cmd.ui,
+		Verifier:       verifier,
+	})
+	pluginSourceFilepath, err := installer.Install(update.Plugin.Name)
+	if err != nil {
+		return err
+	}
+
+	pluginMetadata, err := obtainPluginMetadata(cmd.rpcService, pluginSourceFilepath)
+	if err != nil {
+		return err
+	}
+
+	err = cmd.ensurePluginIsSafeForUpdate(update.Name, pluginMetadata, pluginSourceFilepath)
+	if err != nil {
+		return err
+	}
+
+	return cmd.replacePlugin(update.Name, current, pluginMetadata, pluginSourceFilepath)
+}
+
+func (cmd *PluginUpdate) ensurePluginIsSafeForUpdate(pluginName string, pluginMetadata *plugin.PluginMetadata, pluginSourceFilepath string) error {
+	if pluginMetadata.Name != pluginName {
+		return errors.New(T(
+			"Plugin binary from the repository reports the name {{.ActualName}} instead of {{.PluginName}}",
+			map[string]interface{}{
+				"ActualName": pluginMetadata.Name,
+				"PluginName": pluginName,
+			}),
+		)
+	}
+
+	if minCliVersion := plugin.MinCliVersionStr(pluginMetadata.MinCliVersion); minCliVersion != "" {
+		var ok bool
+		err := cmd.rpcService.RpcCmd.IsMinCliVersion(minCliVersion, &ok)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New(T(
+				"Plugin {{.PluginName}} was not updated because the new version requires CLI version {{.MinCliVersion}} or later",
+				map[string]interface{}{
+					"PluginName":    pluginName,
+					"MinCliVersion": minCliVersion,
+				}),
+			)
+		}
+	}
+
+	otherPlugins := map[string]pluginconfig.PluginMetadata{}
+	for name, metadata := range cmd.pluginConfig.Plugins() {
+		if name != pluginName {
+			otherPlugins[name] = metadata
+		}
+	}
+
+	return validatePluginMetadata(pluginMetadata, otherPlugins, pluginSourceFilepath)
+}
+
+// replacePlugin swaps the installed binary for the new one. The new binary is
+// copied next to the installed one first so that the swap is a pair of
+// renames. The installed binary is kept as a rollback copy, which replaces a
+// rollback copy left by an earlier update.
+func (cmd *PluginUpdate) replacePlugin(pluginName string, current pluginconfig.PluginMetadata, pluginMetadata *plugin.PluginMetadata, pluginSourceFilepath string) error {
+	pluginDestinationFilepath := current.Location
+	newFilepath := pluginDestinationFilepath + ".new"
+	rollbackFilepath := pluginDestinationFilepath + ".rollback"
+
+	err := fileutils.CopyPathToPath(pluginSourceFilepath, newFilepath)
+	if err != nil {
+		os.Remove(newFilepath)
+		return errors.New(T(
+			"Could not copy plugin binary: \n{{.Error}}",
+			map[string]interface{}{
+				"Error": err.Error(),
+			}),
+		)
+	}
+
+	os.Remove(rollbackFilepath)
+	err = os.Rename(pluginDestinationFilepath, rollbackFilepath)
+	if err != nil {
+		os.Remove(newFilepath)
+		return errors.New(T(
+			"Could not keep a rollback copy of plugin {{.PluginName}}: \n{{.Error}}",
+			map[string]interface{}{
+				"PluginName": pluginName,
+				"Error":      err.Error(),
+			}),
+		)
+	}
+
+	err = os.Rename(newFilepath, pluginDestinationFilepath)
+	if err != nil {
+		os.Remove(newFilepath)
+		rollbackErr := os.Rename(rollbackFilepath, pluginDestinationFilepath)
+		if rollbackErr != nil {
+			return errors.New(T(
+				"Could not restore plugin {{.PluginName}} after a failed update, the previous binary is at {{.RollbackPath}}: \n{{.Error}}",
+				map[string]interface{}{
+					"PluginName":   pluginName,
+					"RollbackPath": rollbackFilepath,
+					"Error":        rollbackErr.Error(),
+				}),
+			)
+		}
+		return errors.New(T(
+			"Could not install the new binary of plugin {{.PluginName}}, the previous version was restored: \n{{.Error}}",
+			map[string]interface{}{
+				"PluginName": pluginName,
+				"Error":      err.Error(),
+			}),
+		)
+	}
+
+	cmd.pluginConfig.SetPlugin(pluginName, pluginconfig.PluginMetadata{
+		Location: pluginDestinationFilepath,
+		Version:  pluginMetadata.Version,
+		Commands: pluginMetadata.Commands,
+		Hooks:    pluginMetadata.Hooks,
+	})
+
+	cmd.ui.Say(T("The previous binary of plugin {{.PluginName}} is kept at {{.RollbackPath}}.", map[string]interface{}{
+		"PluginName":   pluginName,
+		"RollbackPath": rollbackFilepath,
+	}))
+	return nil
+}
@@ -0,0 +1,280 @@
+package plugin_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf/actors/plugininstaller"
+	"code.cloudfoundry.org/cli/cf/actors/pluginrepo/pluginrepofakes"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig/pluginconfigfakes"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+	"code.cloudfoundry.org/cli/plugin"
+	testcmd "code.cloudfoundry.org/cli/util/testhelpers/commands"
+	testconfig "code.cloudfoundry.org/cli/util/testhelpers/configuration"
+	testterm "code.cloudfoundry.org/cli/util/testhelpers/terminal"
+	"code.cloudfoundry.org/cli/util/utilfakes"
+
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
+
+	. "code.cloudfoundry.org/cli/util/testhelpers/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("update-plugin", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		config              coreconfig.Repository
+		pluginConfig        *pluginconfigfakes.FakePluginConfiguration
+		fakePluginRepo      *pluginrepofakes.FakePluginRepo
+		fakeChecksum        *utilfakes.FakeChecksum
+		deps                commandregistry.Dependency
+
+		testServer      *httptest.Server
+		homeDir         string
+		installedBinary string
+		newBinary       []byte
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		deps.Config = config
+		deps.PluginConfig = pluginConfig
+		deps.PluginRepo = fakePluginRepo
+		deps.ChecksumUtil = fakeChecksum
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("update-plugin").SetDependency(deps, pluginCall))
+	}
+
+	runCommand := func(args ...string) bool {
+		return testcmd.RunCLICommand("update-plugin", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	repoPlugin := func(name, version string) clipr.Plugin {
+		binaries := []clipr.Binary{}
+		for _, platform := range []string{"osx", "linux32", "linux64", "win32", "win64"} {
+			binaries = append(binaries, clipr.Binary{Platform: platform, Url: testServer.URL + "/test_1.exe"})
+		}
+		return clipr.Plugin{Name: name, Version: version, Binaries: binaries}
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+		pluginConfig = new(pluginconfigfakes.FakePluginConfiguration)
+		config = testconfig.NewRepositoryWithDefaults()
+		config.SetPluginRepo(models.PluginRepo{Name: "repo1", URL: "http://repo1.example.com"})
+		fakePluginRepo = new(pluginrepofakes.FakePluginRepo)
+		fakeChecksum = new(utilfakes.FakeChecksum)
+		fakeChecksum.CheckSha1Returns(true)
+
+		var err error
+		newBinary, err = ioutil.ReadFile(filepath.Join("..", "..", "..", "fixtures", "plugins", "test_1.exe"))
+		Expect(err).ToNot(HaveOccurred())
+
+		testServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, plugininstaller.SignatureSuffix) {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write(newBinary)
+		}))
+
+		homeDir, err = ioutil.TempDir("", "update-plugin")
+		Expect(err).ToNot(HaveOccurred())
+		pluginDir := filepath.Join(homeDir, ".cf", "plugins")
+		Expect(os.MkdirAll(pluginDir, 0700)).To(Succeed())
+		pluginConfig.GetPluginPathReturns(pluginDir)
+
+		installedBinary = filepath.Join(pluginDir, "test_1.exe")
+		Expect(ioutil.WriteFile(installedBinary, []byte("old binary"), 0700)).To(Succeed())
+
+		pluginConfig.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+			"Test1": {
+				Location: installedBinary,
+				Version:  plugin.VersionType{Major: 1, Minor: 2, Build: 3},
+				Commands: []plugin.Command{{Name: "test_1_cmd1"}},
+			},
+		})
+	})
+
+	AfterEach(func() {
+		testServer.Close()
+		os.RemoveAll(homeDir)
+	})
+
+	Describe("requirements", func() {
+		It("fails with usage when neither a plugin name nor --all is provided", func() {
+			Expect(runCommand()).ToNot(HavePassedRequirements())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage"}))
+		})
+
+		It("fails with usage when both a plugin name and --all are provided", func() {
+			Expect(runCommand("Test1", "--all")).ToNot(HavePassedRequirements())
+		})
+	})
+
+	It("fails when the plugin is not installed", func() {
+		runCommand("not-installed", "-f")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Plugin name not-installed does not exist"},
+		))
+		Expect(fakePluginRepo.GetPluginsCallCount()).To(Equal(0))
+	})
+
+	It("says so when the plugin is up to date", func() {
+		fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+			"repo1": {repoPlugin("Test1", "1.2.3")},
+		}, nil)
+
+		runCommand("Test1", "-f")
+
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"All plugins are up to date."}))
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+	})
+
+	It("does not update the plugin when the user does not confirm", func() {
+		fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+			"repo1": {repoPlugin("Test1", "1.2.4")},
+		}, nil)
+		ui.Inputs = []string{"n"}
+
+		runCommand("--all")
+
+		Expect(ui.Prompts).To(ContainSubstrings([]string{"Do you want to update to Test1 v1.2.4?"}))
+		Expect(ui.Outputs()).To(ContainSubstrings([]string{"Plugin update cancelled"}))
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+	})
+
+	It("replaces the installed binary with the newer version and keeps the rollback copy", func() {
+		fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+			"repo1": {repoPlugin("Test1", "1.2.4")},
+		}, nil)
+
+		runCommand("Test1", "-f")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"Updating plugin Test1 from v1.2.3 to v1.2.4 using repository repo1..."},
+			[]string{"The previous binary of plugin Test1 is kept at " + installedBinary + ".rollback."},
+			[]string{"Plugin Test1 successfully updated to v1.2.4."},
+			[]string{"OK"},
+		))
+
+		contents, err := ioutil.ReadFile(installedBinary)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contents).To(Equal(newBinary))
+		rollback, err := ioutil.ReadFile(installedBinary + ".rollback")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(rollback)).To(Equal("old binary"))
+		Expect(installedBinary + ".new").ToNot(BeAnExistingFile())
+
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(1))
+		name, metadata := pluginConfig.SetPluginArgsForCall(0)
+		Expect(name).To(Equal("Test1"))
+		Expect(metadata.Location).To(Equal(installedBinary))
+		Expect(metadata.Version).To(Equal(plugin.VersionType{Major: 1, Minor: 2, Build: 4}))
+		Expect(metadata.Commands).ToNot(BeEmpty())
+	})
+
+	It("keeps the installed binary when the new binary reports a different name", func() {
+		pluginConfig.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+			"Renamed": {
+				Location: installedBinary,
+				Version:  plugin.VersionType{Major: 1},
+			},
+		})
+		fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+			"repo1": {repoPlugin("Renamed", "2.0.0")},
+		}, nil)
+
+		runCommand("--all", "-f")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"reports the name Test1 instead of Renamed"},
+			[]string{"FAILED"},
+			[]string{"Failed to update plugins: Renamed"},
+		))
+
+		contents, err := ioutil.ReadFile(installedBinary)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(contents)).To(Equal("old binary"))
+		Expect(pluginConfig.SetPluginCallCount()).To(Equal(0))
+	})
+
+	Context("when updating all plugins", func() {
+		var brokenBinary string
+
+		BeforeEach(func() {
+			brokenBinary = filepath.Join(homeDir, ".cf", "plugins", "broken.exe")
+			Expect(ioutil.WriteFile(brokenBinary, []byte("broken binary"), 0700)).To(Succeed())
+
+			pluginConfig.PluginsReturns(map[string]pluginconfig.PluginMetadata{
+				"Broken": {
+					Location: brokenBinary,
+					Version:  plugin.VersionType{Major: 1},
+				},
+				"Test1": {
+					Location: installedBinary,
+					Version:  plugin.VersionType{Major: 1, Minor: 2, Build: 3},
+					Commands: []plugin.Command{{Name: "test_1_cmd1"}},
+				},
+			})
+		})
+
+		It("updates the other plugins when a plugin has no binary for this platform", func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+				"repo1": {
+					{Name: "Broken", Version: "2.0.0"},
+					repoPlugin("Test1", "1.2.4"),
+				},
+			}, nil)
+
+			runCommand("--all", "-f")
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"Plugin requested has no binary available"},
+				[]string{"Plugin Test1 successfully updated to v1.2.4."},
+				[]string{"FAILED"},
+				[]string{"Failed to update plugins: Broken"},
+			))
+			Expect(pluginConfig.SetPluginCallCount()).To(Equal(1))
+			name, _ := pluginConfig.SetPluginArgsForCall(0)
+			Expect(name).To(Equal("Test1"))
+		})
+
+		It("updates the other plugins when a downloaded binary does not match its checksum", func() {
+			fakePluginRepo.GetPluginsReturns(map[string][]clipr.Plugin{
+				"repo1": {
+					repoPlugin("Broken", "2.0.0"),
+					repoPlugin("Test1", "1.2.4"),
+				},
+			}, nil)
+			fakeChecksum.CheckSha1Stub = func(string) bool {
+				return fakeChecksum.CheckSha1CallCount() > 1
+			}
+
+			runCommand("--all", "-f")
+
+			Expect(ui.Outputs()).To(ContainSubstrings(
+				[]string{"checksum does not match"},
+				[]string{"Plugin Test1 successfully updated to v1.2.4."},
+				[]string{"FAILED"},
+				[]string{"Failed to update plugins: Broken"},
+			))
+			contents, err := ioutil.ReadFile(brokenBinary)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(contents)).To(Equal("broken binary"))
+			Expect(pluginConfig.SetPluginCallCount()).To(Equal(1))
+		})
+	})
+})
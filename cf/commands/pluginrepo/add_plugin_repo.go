@@ -16,6 +16,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 
 	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
 
@@ -23,8 +24,9 @@ import (
 )
 
 type AddPluginRepo struct {
-	ui     terminal.UI
-	config coreconfig.ReadWriter
+	ui           terminal.UI
+	config       coreconfig.ReadWriter
+	certificates tlsconfig.Certificates
 }
 
 func init() {
@@ -58,6 +60,7 @@ func (cmd *AddPluginRepo) Requirements(requirementsFactory requirements.Factory,
 func (cmd *AddPluginRepo) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
 	cmd.ui = deps.UI
 	cmd.config = deps.Config
+	cmd.certificates = deps.Certificates
 	return cmd
 }
 
@@ -76,7 +79,13 @@ func (cmd *AddPluginRepo) Execute(c flags.FlagContext) error {
 		return err
 	}
 
-	resp, err := http.Get(repoURL)
+	client := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: cmd.certificates.TLSConfig(false),
+		},
+	}
+	resp, err := client.Get(repoURL)
 	if err != nil {
 		if urlErr, ok := err.(*url.Error); ok {
 			if opErr, opErrOk := urlErr.Err.(*net.OpError); opErrOk {
@@ -0,0 +1,148 @@
+package pluginrepo
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/cli/cf"
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/util/pluginreposerver"
+
+	. "code.cloudfoundry.org/cli/cf/i18n"
+)
+
+const (
+	defaultServePort    = 8080
+	defaultServeAddress = "127.0.0.1"
+)
+
+type ServePluginRepo struct {
+	ui terminal.UI
+}
+
+func init() {
+	commandregistry.Register(&ServePluginRepo{})
+}
+
+func (cmd *ServePluginRepo) MetaData() commandregistry.CommandMetadata {
+	fs := make(map[string]flags.FlagSet)
+	fs["port"] = &flags.IntFlag{Name: "port", Usage: T("Port to listen on (Default: 8080)")}
+	fs["address"] = &flags.StringFlag{Name: "address", Usage: T("Address to listen on, such as 0.0.0.0 for every interface (Default: 127.0.0.1)")}
+
+	return commandregistry.CommandMetadata{
+		Name:        "plugin-repo",
+		Description: T("Serve a directory of plugin binaries as a plugin repository"),
+		Usage: []string{
+			T(`CF_NAME plugin-repo serve DIR [--port PORT] [--address ADDRESS]
+
+   Binaries in DIR are named NAME_VERSION_PLATFORM, where PLATFORM is osx, linux32, linux64,
+   win32, win64 or a GOOS_GOARCH pair such as linux_amd64. Windows binaries may end in '.exe'.
+   Signatures named after the binary with '.sig' appended are served alongside it.
+
+   The repository is only reachable from this machine unless another ADDRESS is given.`),
+		},
+		Examples: []string{
+			"CF_NAME plugin-repo serve /srv/cf-plugins --address 0.0.0.0 --port 8080",
+			"CF_NAME add-plugin-repo internal http://plugins.example.com:8080",
+		},
+		Flags: fs,
+	}
+}
+
+func (cmd *ServePluginRepo) Requirements(requirementsFactory requirements.Factory, fc flags.FlagContext) ([]requirements.Requirement, error) {
+	if len(fc.Args()) != 2 || fc.Args()[0] != "serve" {
+		cmd.ui.Failed(T("Incorrect Usage. Requires 'serve' and a directory as arguments\n\n") + commandregistry.Commands.CommandUsage("plugin-repo"))
+		return nil, fmt.Errorf("Incorrect usage: %d arguments of %d required", len(fc.Args()), 2)
+	}
+
+	reqs := []requirements.Requirement{}
+	return reqs, nil
+}
+
+func (cmd *ServePluginRepo) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	return cmd
+}
+
+func (cmd *ServePluginRepo) Execute(c flags.FlagContext) error {
+	dir, err := filepath.Abs(c.Args()[1])
+	if err != nil {
+		return err
+	}
+
+	if info, statErr := os.Stat(dir); statErr != nil || !info.IsDir() {
+		return errors.New(T("Directory not found: {{.Dir}}", map[string]interface{}{"Dir": dir}))
+	}
+
+	port := defaultServePort
+	if c.IsSet("port") {
+		port = c.Int("port")
+	}
+
+	address := defaultServeAddress
+	if c.IsSet("address") {
+		address = c.String("address")
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(port)))
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	listenPort := listener.Addr().(*net.TCPAddr).Port
+	server := pluginreposerver.NewServer(dir)
+
+	plugins, skipped, err := server.Index(fmt.Sprintf("http://localhost:%d", listenPort))
+	if err != nil {
+		return err
+	}
+
+	cmd.ui.Say(T("Serving plugin repository from {{.Dir}} on {{.Address}}...", map[string]interface{}{
+		"Dir":     terminal.EntityNameColor(dir),
+		"Address": listener.Addr().String(),
+	}))
+	cmd.ui.Say("")
+
+	table := cmd.ui.Table([]string{T("Plugin Name"), T("Version"), T("Platforms")})
+	for _, plugin := range plugins {
+		platforms := []string{}
+		for _, binary := range plugin.Binaries {
+			platforms = append(platforms, binary.Platform)
+		}
+		table.Add(plugin.Name, plugin.Version, strings.Join(platforms, ", "))
+	}
+	err = table.Print()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range skipped {
+		cmd.ui.Warn(T("Skipping {{.File}}: file name is not NAME_VERSION_PLATFORM", map[string]interface{}{"File": file}))
+	}
+
+	cmd.ui.Say("")
+	cmd.ui.Say(T("Add the repository with '{{.Command}}'. Press Ctrl-C to stop.", map[string]interface{}{
+		"Command": terminal.CommandColor(fmt.Sprintf("%s add-plugin-repo REPO_NAME http://%s", cf.Name, net.JoinHostPort(repoHost(address), strconv.Itoa(listenPort)))),
+	}))
+
+	return http.Serve(listener, server)
+}
+
+// repoHost returns the host to suggest in the repository URL. A server
+// listening on every interface is suggested with a placeholder.
+func repoHost(address string) string {
+	if ip := net.ParseIP(address); address == "" || (ip != nil && ip.IsUnspecified()) {
+		return "HOSTNAME"
+	}
+	return address
+}
@@ -0,0 +1,57 @@
+package pluginrepo_test
+
+import (
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/requirements/requirementsfakes"
+
+	testcmd "code.cloudfoundry.org/cli/util/testhelpers/commands"
+	testterm "code.cloudfoundry.org/cli/util/testhelpers/terminal"
+
+	. "code.cloudfoundry.org/cli/util/testhelpers/matchers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("plugin-repo", func() {
+	var (
+		ui                  *testterm.FakeUI
+		requirementsFactory *requirementsfakes.FakeFactory
+		deps                commandregistry.Dependency
+	)
+
+	updateCommandDependency := func(pluginCall bool) {
+		deps.UI = ui
+		commandregistry.Commands.SetCommand(commandregistry.Commands.FindCommand("plugin-repo").SetDependency(deps, pluginCall))
+	}
+
+	BeforeEach(func() {
+		ui = &testterm.FakeUI{}
+		requirementsFactory = new(requirementsfakes.FakeFactory)
+	})
+
+	var callServePluginRepo = func(args ...string) bool {
+		return testcmd.RunCLICommand("plugin-repo", args, requirementsFactory, updateCommandDependency, false, ui)
+	}
+
+	Describe("requirements", func() {
+		It("fails with usage when a directory is not provided", func() {
+			Expect(callServePluginRepo("serve")).ToNot(HavePassedRequirements())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage"}))
+		})
+
+		It("fails with usage when the subcommand is not serve", func() {
+			Expect(callServePluginRepo("stop", ".")).ToNot(HavePassedRequirements())
+			Expect(ui.Outputs()).To(ContainSubstrings([]string{"Incorrect Usage"}))
+		})
+	})
+
+	It("fails when the directory does not exist", func() {
+		callServePluginRepo("serve", "/no/such/plugin/dir")
+
+		Expect(ui.Outputs()).To(ContainSubstrings(
+			[]string{"FAILED"},
+			[]string{"Directory not found:", "/no/such/plugin/dir"},
+		))
+	})
+})
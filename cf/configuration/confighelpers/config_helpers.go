@@ -23,6 +23,15 @@ func DefaultFilePath() (string, error) {
 	return filepath.Join(homeDir, ".cf", "config.json"), nil
 }
 
+func DefaultKnownHostsFilePath() (string, error) {
+	configFilePath, err := DefaultFilePath()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(configFilePath), "known_hosts"), nil
+}
+
 // See: http://stackoverflow.com/questions/7922270/obtain-users-home-directory
 // we can't cross compile using cgo and use user.Current()
 var userHomeDir = func() string {
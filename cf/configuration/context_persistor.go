@@ -0,0 +1,76 @@
+package configuration
+
+import "code.cloudfoundry.org/cli/util/contexts"
+
+// ContextData is implemented by config data that holds target information.
+type ContextData interface {
+	Context() contexts.Context
+	SetContext(contexts.Context)
+}
+
+// ContextPersistor replaces the target information of ContextData with a
+// named context while the config is in use. Changes, such as refreshed
+// tokens, are saved to the context and the wrapped Persistor keeps its own
+// target information.
+type ContextPersistor struct {
+	persistor  Persistor
+	store      *contexts.Store
+	name       string
+	unselected contexts.Context
+}
+
+func NewContextPersistor(persistor Persistor, store *contexts.Store, name string) *ContextPersistor {
+	return &ContextPersistor{
+		persistor: persistor,
+		store:     store,
+		name:      name,
+	}
+}
+
+func (p *ContextPersistor) Delete() {
+	p.persistor.Delete()
+}
+
+func (p *ContextPersistor) Exists() bool {
+	return p.persistor.Exists()
+}
+
+func (p *ContextPersistor) Load(data DataInterface) error {
+	err := p.persistor.Load(data)
+	if err != nil {
+		return err
+	}
+
+	contextData, ok := data.(ContextData)
+	if !ok {
+		return nil
+	}
+
+	context, err := p.store.Get(p.name)
+	if err != nil {
+		return err
+	}
+
+	p.unselected = contextData.Context()
+	contextData.SetContext(context)
+	return nil
+}
+
+func (p *ContextPersistor) Save(data DataInterface) error {
+	contextData, ok := data.(ContextData)
+	if !ok {
+		return p.persistor.Save(data)
+	}
+
+	context := contextData.Context()
+	p.store.Set(p.name, context)
+	err := p.store.Save()
+	if err != nil {
+		return err
+	}
+
+	contextData.SetContext(p.unselected)
+	defer contextData.SetContext(context)
+
+	return p.persistor.Save(data)
+}
@@ -0,0 +1,97 @@
+package configuration_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/cf/configuration"
+	"code.cloudfoundry.org/cli/cf/configuration/configurationfakes"
+	"code.cloudfoundry.org/cli/util/contexts"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContextPersistor", func() {
+	var (
+		dir           string
+		store         *contexts.Store
+		fakePersistor *configurationfakes.FakePersistor
+		persistor     *ContextPersistor
+		d             *contextData
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "context-persistor")
+		Expect(err).NotTo(HaveOccurred())
+
+		store = contexts.NewStore(contexts.FilePath(dir), nil)
+		store.Set("prod", contexts.Context{Target: "https://api.prod.example.com", AccessToken: "bearer prod"})
+
+		fakePersistor = new(configurationfakes.FakePersistor)
+		fakePersistor.LoadStub = func(data DataInterface) error {
+			data.(*contextData).context = contexts.Context{Target: "https://api.dev.example.com"}
+			return nil
+		}
+		persistor = NewContextPersistor(fakePersistor, store, "prod")
+		d = &contextData{}
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("Load", func() {
+		It("replaces the target information with the context", func() {
+			Expect(persistor.Load(d)).To(Succeed())
+			Expect(d.context.Target).To(Equal("https://api.prod.example.com"))
+			Expect(d.context.AccessToken).To(Equal("bearer prod"))
+		})
+
+		It("returns an error when the context does not exist", func() {
+			persistor = NewContextPersistor(fakePersistor, store, "missing")
+			Expect(persistor.Load(d)).To(MatchError(contexts.NotFoundError{Name: "missing"}))
+		})
+	})
+
+	Describe("Save", func() {
+		var savedContext contexts.Context
+
+		BeforeEach(func() {
+			fakePersistor.SaveStub = func(data DataInterface) error {
+				savedContext = data.(*contextData).context
+				return nil
+			}
+			Expect(persistor.Load(d)).To(Succeed())
+			d.context.AccessToken = "bearer refreshed"
+		})
+
+		It("saves the context and keeps the config's own target information", func() {
+			Expect(persistor.Save(d)).To(Succeed())
+
+			Expect(savedContext.Target).To(Equal("https://api.dev.example.com"))
+			Expect(d.context.AccessToken).To(Equal("bearer refreshed"))
+
+			loaded := contexts.NewStore(contexts.FilePath(dir), nil)
+			Expect(loaded.Load()).To(Succeed())
+			context, err := loaded.Get("prod")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(context.AccessToken).To(Equal("bearer refreshed"))
+			Expect(filepath.Join(dir, "contexts.json")).To(BeARegularFile())
+		})
+	})
+})
+
+type contextData struct {
+	data
+	context contexts.Context
+}
+
+func (d *contextData) Context() contexts.Context {
+	return d.context
+}
+
+func (d *contextData) SetContext(context contexts.Context) {
+	d.context = context
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 
 	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/util/contexts"
+	"code.cloudfoundry.org/cli/util/credentials"
 )
 
 type AuthPromptType string
@@ -29,6 +31,7 @@ type Data struct {
 	AccessToken              string
 	UAAOAuthClient           string
 	UAAOAuthClientSecret     string
+	UAAGrantType             string
 	SSHOAuthClient           string
 	RefreshToken             string
 	OrganizationFields       models.OrganizationFields
@@ -41,6 +44,11 @@ type Data struct {
 	PluginRepos              []models.PluginRepo
 	MinCLIVersion            string
 	MinRecommendedCLIVersion string
+	CACertFile               string `json:",omitempty"`
+	ClientCertFile           string `json:",omitempty"`
+	ClientKeyFile            string `json:",omitempty"`
+	PluginTrustedKeysFile    string `json:",omitempty"`
+	PluginRequireSigned      bool   `json:",omitempty"`
 }
 
 func NewData() *Data {
@@ -54,7 +62,14 @@ func NewData() *Data {
 
 func (d *Data) JSONMarshalV3() ([]byte, error) {
 	d.ConfigVersion = 3
-	return json.MarshalIndent(d, "", "  ")
+
+	// A secret given to auth --client-credentials is only ever saved in a
+	// credential store.
+	data := *d
+	if data.UAAGrantType == "client_credentials" {
+		data.UAAOAuthClientSecret = ""
+	}
+	return json.MarshalIndent(data, "", "  ")
 }
 
 func (d *Data) JSONUnmarshalV3(input []byte) error {
@@ -70,3 +85,69 @@ func (d *Data) JSONUnmarshalV3(input []byte) error {
 
 	return nil
 }
+
+func (d *Data) Credentials() credentials.Credentials {
+	return credentials.Credentials{
+		AccessToken:  d.AccessToken,
+		RefreshToken: d.RefreshToken,
+		ClientSecret: d.UAAOAuthClientSecret,
+	}
+}
+
+func (d *Data) SetCredentials(creds credentials.Credentials) {
+	d.AccessToken = creds.AccessToken
+	d.RefreshToken = creds.RefreshToken
+	d.UAAOAuthClientSecret = creds.ClientSecret
+}
+
+func (d *Data) Context() contexts.Context {
+	return contexts.Context{
+		Target:                   d.Target,
+		APIVersion:               d.APIVersion,
+		AuthorizationEndpoint:    d.AuthorizationEndpoint,
+		DopplerEndpoint:          d.DopplerEndPoint,
+		UAAEndpoint:              d.UaaEndpoint,
+		RoutingEndpoint:          d.RoutingAPIEndpoint,
+		SkipSSLValidation:        d.SSLDisabled,
+		MinCLIVersion:            d.MinCLIVersion,
+		MinRecommendedCLIVersion: d.MinRecommendedCLIVersion,
+		AccessToken:              d.AccessToken,
+		RefreshToken:             d.RefreshToken,
+		SSHOAuthClient:           d.SSHOAuthClient,
+		UAAOAuthClient:           d.UAAOAuthClient,
+		UAAOAuthClientSecret:     d.UAAOAuthClientSecret,
+		UAAGrantType:             d.UAAGrantType,
+		OrganizationGUID:         d.OrganizationFields.GUID,
+		OrganizationName:         d.OrganizationFields.Name,
+		SpaceGUID:                d.SpaceFields.GUID,
+		SpaceName:                d.SpaceFields.Name,
+		SpaceAllowSSH:            d.SpaceFields.AllowSSH,
+	}
+}
+
+func (d *Data) SetContext(context contexts.Context) {
+	d.Target = context.Target
+	d.APIVersion = context.APIVersion
+	d.AuthorizationEndpoint = context.AuthorizationEndpoint
+	d.DopplerEndPoint = context.DopplerEndpoint
+	d.UaaEndpoint = context.UAAEndpoint
+	d.RoutingAPIEndpoint = context.RoutingEndpoint
+	d.SSLDisabled = context.SkipSSLValidation
+	d.MinCLIVersion = context.MinCLIVersion
+	d.MinRecommendedCLIVersion = context.MinRecommendedCLIVersion
+	d.AccessToken = context.AccessToken
+	d.RefreshToken = context.RefreshToken
+	d.SSHOAuthClient = context.SSHOAuthClient
+	d.UAAOAuthClient = context.UAAOAuthClient
+	d.UAAOAuthClientSecret = context.UAAOAuthClientSecret
+	d.UAAGrantType = context.UAAGrantType
+	d.OrganizationFields = models.OrganizationFields{
+		GUID: context.OrganizationGUID,
+		Name: context.OrganizationName,
+	}
+	d.SpaceFields = models.SpaceFields{
+		GUID:     context.SpaceGUID,
+		Name:     context.SpaceName,
+		AllowSSH: context.SpaceAllowSSH,
+	}
+}
@@ -21,6 +21,7 @@ var _ = Describe("V3 Config files", func() {
 		"AccessToken": "the-access-token",
 		"UAAOAuthClient": "cf-oauth-client-id",
 		"UAAOAuthClientSecret": "cf-oauth-client-secret",
+		"UAAGrantType": "",
 		"SSHOAuthClient": "ssh-oauth-client-id",
 		"RefreshToken": "the-refresh-token",
 		"OrganizationFields": {
@@ -154,6 +155,19 @@ var _ = Describe("V3 Config files", func() {
 
 			Expect(jsonData).To(MatchJSON(exampleV3JSON))
 		})
+
+		It("leaves out a client secret given to auth --client-credentials", func() {
+			data := coreconfig.NewData()
+			data.UAAOAuthClient = "ci-client"
+			data.UAAOAuthClientSecret = "ci-secret"
+			data.UAAGrantType = "client_credentials"
+
+			jsonData, err := data.JSONMarshalV3()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(jsonData)).To(ContainSubstring("ci-client"))
+			Expect(string(jsonData)).NotTo(ContainSubstring("ci-secret"))
+			Expect(data.UAAOAuthClientSecret).To(Equal("ci-secret"))
+		})
 	})
 
 	Describe("JSONUnmarshalV3", func() {
@@ -1,10 +1,14 @@
 package coreconfig
 
 import (
+	"path/filepath"
 	"sync"
 
 	"code.cloudfoundry.org/cli/cf/configuration"
 	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/util/contexts"
+	"code.cloudfoundry.org/cli/util/credentials"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 	"code.cloudfoundry.org/cli/version"
 	"github.com/blang/semver"
 )
@@ -28,11 +32,31 @@ type CCInfo struct {
 	RoutingAPIEndpoint       string `json:"routing_endpoint"`
 }
 
-func NewRepositoryFromFilepath(filepath string, errorHandler func(error)) Repository {
+func NewRepositoryFromFilepath(filePath string, errorHandler func(error)) Repository {
 	if errorHandler == nil {
 		return nil
 	}
-	return NewRepositoryFromPersistor(configuration.NewDiskPersistor(filepath), errorHandler)
+
+	var persistor configuration.Persistor = configuration.NewDiskPersistor(filePath)
+
+	store, err := credentials.NewStoreFromEnv(filepath.Dir(filePath))
+	if err != nil {
+		errorHandler(err)
+	} else if store != nil {
+		persistor = configuration.NewCredentialStorePersistor(persistor, store, filePath)
+	}
+
+	if name := contexts.Selected(); name != "" {
+		contextStore := contexts.NewStore(contexts.FilePath(filepath.Dir(filePath)), store)
+		err = contextStore.Load()
+		if err != nil {
+			errorHandler(err)
+		} else if contextStore.Current() != name {
+			persistor = configuration.NewContextPersistor(persistor, contextStore, name)
+		}
+	}
+
+	return NewRepositoryFromPersistor(persistor, errorHandler)
 }
 
 func NewRepositoryFromPersistor(persistor configuration.Persistor, errorHandler func(error)) Repository {
@@ -66,6 +90,7 @@ type Reader interface {
 	AccessToken() string
 	UAAOAuthClient() string
 	UAAOAuthClientSecret() string
+	UAAGrantType() string
 	SSHOAuthClient() string
 	RefreshToken() string
 
@@ -80,6 +105,7 @@ type Reader interface {
 	UserEmail() string
 	IsLoggedIn() bool
 	IsSSLDisabled() bool
+	TLSFiles() tlsconfig.Files
 	IsMinAPIVersion(semver.Version) bool
 	IsMinCLIVersion(string) bool
 	MinCLIVersion() string
@@ -94,6 +120,8 @@ type Reader interface {
 	Locale() string
 
 	PluginRepos() []models.PluginRepo
+	PluginTrustedKeysFile() string
+	PluginRequireSigned() bool
 }
 
 //go:generate counterfeiter . ReadWriter
@@ -112,17 +140,23 @@ type ReadWriter interface {
 	SetAccessToken(string)
 	SetUAAOAuthClient(string)
 	SetUAAOAuthClientSecret(string)
+	SetUAAGrantType(string)
 	SetSSHOAuthClient(string)
 	SetRefreshToken(string)
 	SetOrganizationFields(models.OrganizationFields)
 	SetSpaceFields(models.SpaceFields)
 	SetSSLDisabled(bool)
+	SetCACertFile(string)
+	SetClientCertFile(string)
+	SetClientKeyFile(string)
 	SetAsyncTimeout(uint)
 	SetTrace(string)
 	SetColorEnabled(string)
 	SetLocale(string)
 	SetPluginRepo(models.PluginRepo)
 	UnSetPluginRepo(int)
+	SetPluginTrustedKeysFile(string)
+	SetPluginRequireSigned(bool)
 	SetCLIVersion(string)
 }
 
@@ -246,6 +280,13 @@ func (c *ConfigRepository) UAAOAuthClientSecret() (clientID string) {
 	return
 }
 
+func (c *ConfigRepository) UAAGrantType() (grantType string) {
+	c.read(func() {
+		grantType = c.data.UAAGrantType
+	})
+	return
+}
+
 func (c *ConfigRepository) SSHOAuthClient() (clientID string) {
 	c.read(func() {
 		clientID = c.data.SSHOAuthClient
@@ -414,6 +455,37 @@ func (c *ConfigRepository) Locale() (locale string) {
 	return
 }
 
+// TLSFiles returns the configured CA bundle and client certificate. Use
+// tlsconfig.Load to apply the environment overrides.
+func (c *ConfigRepository) TLSFiles() (files tlsconfig.Files) {
+	c.read(func() {
+		files = tlsconfig.Files{
+			CACert:     c.data.CACertFile,
+			ClientCert: c.data.ClientCertFile,
+			ClientKey:  c.data.ClientKeyFile,
+		}
+	})
+	return
+}
+
+// PluginTrustedKeysFile returns the path to the file of public keys that
+// plugin signatures are verified with.
+func (c *ConfigRepository) PluginTrustedKeysFile() (path string) {
+	c.read(func() {
+		path = c.data.PluginTrustedKeysFile
+	})
+	return
+}
+
+// PluginRequireSigned returns whether plugins must have a signature from a
+// trusted key to be installed.
+func (c *ConfigRepository) PluginRequireSigned() (requireSigned bool) {
+	c.read(func() {
+		requireSigned = c.data.PluginRequireSigned
+	})
+	return
+}
+
 func (c *ConfigRepository) PluginRepos() (repos []models.PluginRepo) {
 	c.read(func() {
 		repos = c.data.PluginRepos
@@ -429,6 +501,14 @@ func (c *ConfigRepository) ClearSession() {
 		c.data.RefreshToken = ""
 		c.data.OrganizationFields = models.OrganizationFields{}
 		c.data.SpaceFields = models.SpaceFields{}
+
+		// Client credentials belong to the service account that logged in,
+		// so they are dropped along with its tokens.
+		if c.data.UAAGrantType == "client_credentials" {
+			c.data.UAAOAuthClient = "cf"
+			c.data.UAAOAuthClientSecret = ""
+		}
+		c.data.UAAGrantType = ""
 	})
 }
 
@@ -498,6 +578,12 @@ func (c *ConfigRepository) SetUAAOAuthClientSecret(clientID string) {
 	})
 }
 
+func (c *ConfigRepository) SetUAAGrantType(grantType string) {
+	c.write(func() {
+		c.data.UAAGrantType = grantType
+	})
+}
+
 func (c *ConfigRepository) SetSSHOAuthClient(clientID string) {
 	c.write(func() {
 		c.data.SSHOAuthClient = clientID
@@ -552,6 +638,24 @@ func (c *ConfigRepository) SetLocale(locale string) {
 	})
 }
 
+func (c *ConfigRepository) SetCACertFile(path string) {
+	c.write(func() {
+		c.data.CACertFile = path
+	})
+}
+
+func (c *ConfigRepository) SetClientCertFile(path string) {
+	c.write(func() {
+		c.data.ClientCertFile = path
+	})
+}
+
+func (c *ConfigRepository) SetClientKeyFile(path string) {
+	c.write(func() {
+		c.data.ClientKeyFile = path
+	})
+}
+
 func (c *ConfigRepository) SetPluginRepo(repo models.PluginRepo) {
 	c.write(func() {
 		c.data.PluginRepos = append(c.data.PluginRepos, repo)
@@ -563,3 +667,15 @@ func (c *ConfigRepository) UnSetPluginRepo(index int) {
 		c.data.PluginRepos = append(c.data.PluginRepos[:index], c.data.PluginRepos[index+1:]...)
 	})
 }
+
+func (c *ConfigRepository) SetPluginTrustedKeysFile(path string) {
+	c.write(func() {
+		c.data.PluginTrustedKeysFile = path
+	})
+}
+
+func (c *ConfigRepository) SetPluginRequireSigned(requireSigned bool) {
+	c.write(func() {
+		c.data.PluginRequireSigned = requireSigned
+	})
+}
@@ -83,6 +83,9 @@ var _ = Describe("Configuration Repository", func() {
 		config.SetUAAOAuthClientSecret("cf-oauth-client-secret")
 		Expect(config.UAAOAuthClientSecret()).To(Equal("cf-oauth-client-secret"))
 
+		config.SetUAAGrantType("client_credentials")
+		Expect(config.UAAGrantType()).To(Equal("client_credentials"))
+
 		config.SetSSHOAuthClient("oauth-client-id")
 		Expect(config.SSHOAuthClient()).To(Equal("oauth-client-id"))
 
@@ -120,6 +123,36 @@ var _ = Describe("Configuration Repository", func() {
 		Expect(config.MinRecommendedCLIVersion()).To(Equal("6.9.0"))
 	})
 
+	Describe("ClearSession", func() {
+		BeforeEach(func() {
+			config.SetAccessToken("the-token")
+			config.SetRefreshToken("the-refresh-token")
+			config.SetUAAOAuthClient("some-client")
+			config.SetUAAOAuthClientSecret("some-secret")
+		})
+
+		It("clears the tokens and keeps the client credentials", func() {
+			config.ClearSession()
+			Expect(config.AccessToken()).To(BeEmpty())
+			Expect(config.RefreshToken()).To(BeEmpty())
+			Expect(config.UAAOAuthClient()).To(Equal("some-client"))
+			Expect(config.UAAOAuthClientSecret()).To(Equal("some-secret"))
+		})
+
+		Context("when logged in with client credentials", func() {
+			BeforeEach(func() {
+				config.SetUAAGrantType("client_credentials")
+			})
+
+			It("resets the client credentials and grant type", func() {
+				config.ClearSession()
+				Expect(config.UAAOAuthClient()).To(Equal("cf"))
+				Expect(config.UAAOAuthClientSecret()).To(BeEmpty())
+				Expect(config.UAAGrantType()).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("HasAPIEndpoint", func() {
 		Context("when both endpoint and version are set", func() {
 			BeforeEach(func() {
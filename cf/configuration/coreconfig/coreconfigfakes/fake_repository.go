@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 	"github.com/blang/semver"
 )
 
@@ -70,6 +71,12 @@ type FakeRepository struct {
 	uAAOAuthClientSecretReturns     struct {
 		result1 string
 	}
+	UAAGrantTypeStub        func() string
+	uAAGrantTypeMutex       sync.RWMutex
+	uAAGrantTypeArgsForCall []struct{}
+	uAAGrantTypeReturns     struct {
+		result1 string
+	}
 	SSHOAuthClientStub        func() string
 	sSHOAuthClientMutex       sync.RWMutex
 	sSHOAuthClientArgsForCall []struct{}
@@ -136,6 +143,12 @@ type FakeRepository struct {
 	isSSLDisabledReturns     struct {
 		result1 bool
 	}
+	PluginRequireSignedStub        func() bool
+	pluginRequireSignedMutex       sync.RWMutex
+	pluginRequireSignedArgsForCall []struct{}
+	pluginRequireSignedReturns     struct {
+		result1 bool
+	}
 	IsMinAPIVersionStub        func(semver.Version) bool
 	isMinAPIVersionMutex       sync.RWMutex
 	isMinAPIVersionArgsForCall []struct {
@@ -194,6 +207,18 @@ type FakeRepository struct {
 	localeReturns     struct {
 		result1 string
 	}
+	PluginTrustedKeysFileStub        func() string
+	pluginTrustedKeysFileMutex       sync.RWMutex
+	pluginTrustedKeysFileArgsForCall []struct{}
+	pluginTrustedKeysFileReturns     struct {
+		result1 string
+	}
+	TLSFilesStub        func() tlsconfig.Files
+	tLSFilesMutex       sync.RWMutex
+	tLSFilesArgsForCall []struct{}
+	tLSFilesReturns     struct {
+		result1 tlsconfig.Files
+	}
 	PluginReposStub        func() []models.PluginRepo
 	pluginReposMutex       sync.RWMutex
 	pluginReposArgsForCall []struct{}
@@ -258,6 +283,11 @@ type FakeRepository struct {
 	setUAAOAuthClientSecretArgsForCall []struct {
 		arg1 string
 	}
+	SetUAAGrantTypeStub        func(string)
+	setUAAGrantTypeMutex       sync.RWMutex
+	setUAAGrantTypeArgsForCall []struct {
+		arg1 string
+	}
 	SetSSHOAuthClientStub        func(string)
 	setSSHOAuthClientMutex       sync.RWMutex
 	setSSHOAuthClientArgsForCall []struct {
@@ -283,6 +313,11 @@ type FakeRepository struct {
 	setSSLDisabledArgsForCall []struct {
 		arg1 bool
 	}
+	SetPluginRequireSignedStub        func(bool)
+	setPluginRequireSignedMutex       sync.RWMutex
+	setPluginRequireSignedArgsForCall []struct {
+		arg1 bool
+	}
 	SetAsyncTimeoutStub        func(uint)
 	setAsyncTimeoutMutex       sync.RWMutex
 	setAsyncTimeoutArgsForCall []struct {
@@ -303,6 +338,26 @@ type FakeRepository struct {
 	setLocaleArgsForCall []struct {
 		arg1 string
 	}
+	SetClientKeyFileStub        func(string)
+	setClientKeyFileMutex       sync.RWMutex
+	setClientKeyFileArgsForCall []struct {
+		arg1 string
+	}
+	SetClientCertFileStub        func(string)
+	setClientCertFileMutex       sync.RWMutex
+	setClientCertFileArgsForCall []struct {
+		arg1 string
+	}
+	SetCACertFileStub        func(string)
+	setCACertFileMutex       sync.RWMutex
+	setCACertFileArgsForCall []struct {
+		arg1 string
+	}
+	SetPluginTrustedKeysFileStub        func(string)
+	setPluginTrustedKeysFileMutex       sync.RWMutex
+	setPluginTrustedKeysFileArgsForCall []struct {
+		arg1 string
+	}
 	SetPluginRepoStub        func(models.PluginRepo)
 	setPluginRepoMutex       sync.RWMutex
 	setPluginRepoArgsForCall []struct {
@@ -562,12 +617,30 @@ func (fake *FakeRepository) UAAOAuthClientSecret() string {
 	}
 }
 
+func (fake *FakeRepository) UAAGrantType() string {
+	fake.uAAGrantTypeMutex.Lock()
+	fake.uAAGrantTypeArgsForCall = append(fake.uAAGrantTypeArgsForCall, struct{}{})
+	fake.recordInvocation("UAAGrantType", []interface{}{})
+	fake.uAAGrantTypeMutex.Unlock()
+	if fake.UAAGrantTypeStub != nil {
+		return fake.UAAGrantTypeStub()
+	} else {
+		return fake.uAAGrantTypeReturns.result1
+	}
+}
+
 func (fake *FakeRepository) UAAOAuthClientSecretCallCount() int {
 	fake.uAAOAuthClientSecretMutex.RLock()
 	defer fake.uAAOAuthClientSecretMutex.RUnlock()
 	return len(fake.uAAOAuthClientSecretArgsForCall)
 }
 
+func (fake *FakeRepository) UAAGrantTypeCallCount() int {
+	fake.uAAGrantTypeMutex.RLock()
+	defer fake.uAAGrantTypeMutex.RUnlock()
+	return len(fake.uAAGrantTypeArgsForCall)
+}
+
 func (fake *FakeRepository) UAAOAuthClientSecretReturns(result1 string) {
 	fake.UAAOAuthClientSecretStub = nil
 	fake.uAAOAuthClientSecretReturns = struct {
@@ -575,6 +648,13 @@ func (fake *FakeRepository) UAAOAuthClientSecretReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeRepository) UAAGrantTypeReturns(result1 string) {
+	fake.UAAGrantTypeStub = nil
+	fake.uAAGrantTypeReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeRepository) SSHOAuthClient() string {
 	fake.sSHOAuthClientMutex.Lock()
 	fake.sSHOAuthClientArgsForCall = append(fake.sSHOAuthClientArgsForCall, struct{}{})
@@ -850,6 +930,31 @@ func (fake *FakeRepository) IsSSLDisabledReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeRepository) PluginRequireSigned() bool {
+	fake.pluginRequireSignedMutex.Lock()
+	fake.pluginRequireSignedArgsForCall = append(fake.pluginRequireSignedArgsForCall, struct{}{})
+	fake.recordInvocation("PluginRequireSigned", []interface{}{})
+	fake.pluginRequireSignedMutex.Unlock()
+	if fake.PluginRequireSignedStub != nil {
+		return fake.PluginRequireSignedStub()
+	} else {
+		return fake.pluginRequireSignedReturns.result1
+	}
+}
+
+func (fake *FakeRepository) PluginRequireSignedCallCount() int {
+	fake.pluginRequireSignedMutex.RLock()
+	defer fake.pluginRequireSignedMutex.RUnlock()
+	return len(fake.pluginRequireSignedArgsForCall)
+}
+
+func (fake *FakeRepository) PluginRequireSignedReturns(result1 bool) {
+	fake.PluginRequireSignedStub = nil
+	fake.pluginRequireSignedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeRepository) IsMinAPIVersion(arg1 semver.Version) bool {
 	fake.isMinAPIVersionMutex.Lock()
 	fake.isMinAPIVersionArgsForCall = append(fake.isMinAPIVersionArgsForCall, struct {
@@ -1091,6 +1196,56 @@ func (fake *FakeRepository) LocaleReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeRepository) PluginTrustedKeysFile() string {
+	fake.pluginTrustedKeysFileMutex.Lock()
+	fake.pluginTrustedKeysFileArgsForCall = append(fake.pluginTrustedKeysFileArgsForCall, struct{}{})
+	fake.recordInvocation("PluginTrustedKeysFile", []interface{}{})
+	fake.pluginTrustedKeysFileMutex.Unlock()
+	if fake.PluginTrustedKeysFileStub != nil {
+		return fake.PluginTrustedKeysFileStub()
+	} else {
+		return fake.pluginTrustedKeysFileReturns.result1
+	}
+}
+
+func (fake *FakeRepository) PluginTrustedKeysFileCallCount() int {
+	fake.pluginTrustedKeysFileMutex.RLock()
+	defer fake.pluginTrustedKeysFileMutex.RUnlock()
+	return len(fake.pluginTrustedKeysFileArgsForCall)
+}
+
+func (fake *FakeRepository) PluginTrustedKeysFileReturns(result1 string) {
+	fake.PluginTrustedKeysFileStub = nil
+	fake.pluginTrustedKeysFileReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeRepository) TLSFiles() tlsconfig.Files {
+	fake.tLSFilesMutex.Lock()
+	fake.tLSFilesArgsForCall = append(fake.tLSFilesArgsForCall, struct{}{})
+	fake.recordInvocation("TLSFiles", []interface{}{})
+	fake.tLSFilesMutex.Unlock()
+	if fake.TLSFilesStub != nil {
+		return fake.TLSFilesStub()
+	} else {
+		return fake.tLSFilesReturns.result1
+	}
+}
+
+func (fake *FakeRepository) TLSFilesCallCount() int {
+	fake.tLSFilesMutex.RLock()
+	defer fake.tLSFilesMutex.RUnlock()
+	return len(fake.tLSFilesArgsForCall)
+}
+
+func (fake *FakeRepository) TLSFilesReturns(result1 tlsconfig.Files) {
+	fake.TLSFilesStub = nil
+	fake.tLSFilesReturns = struct {
+		result1 tlsconfig.Files
+	}{result1}
+}
+
 func (fake *FakeRepository) PluginRepos() []models.PluginRepo {
 	fake.pluginReposMutex.Lock()
 	fake.pluginReposArgsForCall = append(fake.pluginReposArgsForCall, struct{}{})
@@ -1384,18 +1539,42 @@ func (fake *FakeRepository) SetUAAOAuthClientSecret(arg1 string) {
 	}
 }
 
+func (fake *FakeRepository) SetUAAGrantType(arg1 string) {
+	fake.setUAAGrantTypeMutex.Lock()
+	fake.setUAAGrantTypeArgsForCall = append(fake.setUAAGrantTypeArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetUAAGrantType", []interface{}{arg1})
+	fake.setUAAGrantTypeMutex.Unlock()
+	if fake.SetUAAGrantTypeStub != nil {
+		fake.SetUAAGrantTypeStub(arg1)
+	}
+}
+
 func (fake *FakeRepository) SetUAAOAuthClientSecretCallCount() int {
 	fake.setUAAOAuthClientSecretMutex.RLock()
 	defer fake.setUAAOAuthClientSecretMutex.RUnlock()
 	return len(fake.setUAAOAuthClientSecretArgsForCall)
 }
 
+func (fake *FakeRepository) SetUAAGrantTypeCallCount() int {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return len(fake.setUAAGrantTypeArgsForCall)
+}
+
 func (fake *FakeRepository) SetUAAOAuthClientSecretArgsForCall(i int) string {
 	fake.setUAAOAuthClientSecretMutex.RLock()
 	defer fake.setUAAOAuthClientSecretMutex.RUnlock()
 	return fake.setUAAOAuthClientSecretArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetUAAGrantTypeArgsForCall(i int) string {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return fake.setUAAGrantTypeArgsForCall[i].arg1
+}
+
 func (fake *FakeRepository) SetSSHOAuthClient(arg1 string) {
 	fake.setSSHOAuthClientMutex.Lock()
 	fake.setSSHOAuthClientArgsForCall = append(fake.setSSHOAuthClientArgsForCall, struct {
@@ -1516,6 +1695,30 @@ func (fake *FakeRepository) SetSSLDisabledArgsForCall(i int) bool {
 	return fake.setSSLDisabledArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetPluginRequireSigned(arg1 bool) {
+	fake.setPluginRequireSignedMutex.Lock()
+	fake.setPluginRequireSignedArgsForCall = append(fake.setPluginRequireSignedArgsForCall, struct {
+		arg1 bool
+	}{arg1})
+	fake.recordInvocation("SetPluginRequireSigned", []interface{}{arg1})
+	fake.setPluginRequireSignedMutex.Unlock()
+	if fake.SetPluginRequireSignedStub != nil {
+		fake.SetPluginRequireSignedStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetPluginRequireSignedCallCount() int {
+	fake.setPluginRequireSignedMutex.RLock()
+	defer fake.setPluginRequireSignedMutex.RUnlock()
+	return len(fake.setPluginRequireSignedArgsForCall)
+}
+
+func (fake *FakeRepository) SetPluginRequireSignedArgsForCall(i int) bool {
+	fake.setPluginRequireSignedMutex.RLock()
+	defer fake.setPluginRequireSignedMutex.RUnlock()
+	return fake.setPluginRequireSignedArgsForCall[i].arg1
+}
+
 func (fake *FakeRepository) SetAsyncTimeout(arg1 uint) {
 	fake.setAsyncTimeoutMutex.Lock()
 	fake.setAsyncTimeoutArgsForCall = append(fake.setAsyncTimeoutArgsForCall, struct {
@@ -1612,6 +1815,102 @@ func (fake *FakeRepository) SetLocaleArgsForCall(i int) string {
 	return fake.setLocaleArgsForCall[i].arg1
 }
 
+func (fake *FakeRepository) SetClientKeyFile(arg1 string) {
+	fake.setClientKeyFileMutex.Lock()
+	fake.setClientKeyFileArgsForCall = append(fake.setClientKeyFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetClientKeyFile", []interface{}{arg1})
+	fake.setClientKeyFileMutex.Unlock()
+	if fake.SetClientKeyFileStub != nil {
+		fake.SetClientKeyFileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetClientKeyFileCallCount() int {
+	fake.setClientKeyFileMutex.RLock()
+	defer fake.setClientKeyFileMutex.RUnlock()
+	return len(fake.setClientKeyFileArgsForCall)
+}
+
+func (fake *FakeRepository) SetClientKeyFileArgsForCall(i int) string {
+	fake.setClientKeyFileMutex.RLock()
+	defer fake.setClientKeyFileMutex.RUnlock()
+	return fake.setClientKeyFileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetClientCertFile(arg1 string) {
+	fake.setClientCertFileMutex.Lock()
+	fake.setClientCertFileArgsForCall = append(fake.setClientCertFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetClientCertFile", []interface{}{arg1})
+	fake.setClientCertFileMutex.Unlock()
+	if fake.SetClientCertFileStub != nil {
+		fake.SetClientCertFileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetClientCertFileCallCount() int {
+	fake.setClientCertFileMutex.RLock()
+	defer fake.setClientCertFileMutex.RUnlock()
+	return len(fake.setClientCertFileArgsForCall)
+}
+
+func (fake *FakeRepository) SetClientCertFileArgsForCall(i int) string {
+	fake.setClientCertFileMutex.RLock()
+	defer fake.setClientCertFileMutex.RUnlock()
+	return fake.setClientCertFileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetCACertFile(arg1 string) {
+	fake.setCACertFileMutex.Lock()
+	fake.setCACertFileArgsForCall = append(fake.setCACertFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetCACertFile", []interface{}{arg1})
+	fake.setCACertFileMutex.Unlock()
+	if fake.SetCACertFileStub != nil {
+		fake.SetCACertFileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetCACertFileCallCount() int {
+	fake.setCACertFileMutex.RLock()
+	defer fake.setCACertFileMutex.RUnlock()
+	return len(fake.setCACertFileArgsForCall)
+}
+
+func (fake *FakeRepository) SetCACertFileArgsForCall(i int) string {
+	fake.setCACertFileMutex.RLock()
+	defer fake.setCACertFileMutex.RUnlock()
+	return fake.setCACertFileArgsForCall[i].arg1
+}
+
+func (fake *FakeRepository) SetPluginTrustedKeysFile(arg1 string) {
+	fake.setPluginTrustedKeysFileMutex.Lock()
+	fake.setPluginTrustedKeysFileArgsForCall = append(fake.setPluginTrustedKeysFileArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetPluginTrustedKeysFile", []interface{}{arg1})
+	fake.setPluginTrustedKeysFileMutex.Unlock()
+	if fake.SetPluginTrustedKeysFileStub != nil {
+		fake.SetPluginTrustedKeysFileStub(arg1)
+	}
+}
+
+func (fake *FakeRepository) SetPluginTrustedKeysFileCallCount() int {
+	fake.setPluginTrustedKeysFileMutex.RLock()
+	defer fake.setPluginTrustedKeysFileMutex.RUnlock()
+	return len(fake.setPluginTrustedKeysFileArgsForCall)
+}
+
+func (fake *FakeRepository) SetPluginTrustedKeysFileArgsForCall(i int) string {
+	fake.setPluginTrustedKeysFileMutex.RLock()
+	defer fake.setPluginTrustedKeysFileMutex.RUnlock()
+	return fake.setPluginTrustedKeysFileArgsForCall[i].arg1
+}
+
 func (fake *FakeRepository) SetPluginRepo(arg1 models.PluginRepo) {
 	fake.setPluginRepoMutex.Lock()
 	fake.setPluginRepoArgsForCall = append(fake.setPluginRepoArgsForCall, struct {
@@ -1723,6 +2022,8 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.uAAOAuthClientMutex.RUnlock()
 	fake.uAAOAuthClientSecretMutex.RLock()
 	defer fake.uAAOAuthClientSecretMutex.RUnlock()
+	fake.uAAGrantTypeMutex.RLock()
+	defer fake.uAAGrantTypeMutex.RUnlock()
 	fake.sSHOAuthClientMutex.RLock()
 	defer fake.sSHOAuthClientMutex.RUnlock()
 	fake.refreshTokenMutex.RLock()
@@ -1745,6 +2046,8 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.isLoggedInMutex.RUnlock()
 	fake.isSSLDisabledMutex.RLock()
 	defer fake.isSSLDisabledMutex.RUnlock()
+	fake.pluginRequireSignedMutex.RLock()
+	defer fake.pluginRequireSignedMutex.RUnlock()
 	fake.isMinAPIVersionMutex.RLock()
 	defer fake.isMinAPIVersionMutex.RUnlock()
 	fake.isMinCLIVersionMutex.RLock()
@@ -1763,6 +2066,10 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.colorEnabledMutex.RUnlock()
 	fake.localeMutex.RLock()
 	defer fake.localeMutex.RUnlock()
+	fake.pluginTrustedKeysFileMutex.RLock()
+	defer fake.pluginTrustedKeysFileMutex.RUnlock()
+	fake.tLSFilesMutex.RLock()
+	defer fake.tLSFilesMutex.RUnlock()
 	fake.pluginReposMutex.RLock()
 	defer fake.pluginReposMutex.RUnlock()
 	fake.clearSessionMutex.RLock()
@@ -1789,6 +2096,8 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.setUAAOAuthClientMutex.RUnlock()
 	fake.setUAAOAuthClientSecretMutex.RLock()
 	defer fake.setUAAOAuthClientSecretMutex.RUnlock()
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
 	fake.setSSHOAuthClientMutex.RLock()
 	defer fake.setSSHOAuthClientMutex.RUnlock()
 	fake.setRefreshTokenMutex.RLock()
@@ -1799,6 +2108,8 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.setSpaceFieldsMutex.RUnlock()
 	fake.setSSLDisabledMutex.RLock()
 	defer fake.setSSLDisabledMutex.RUnlock()
+	fake.setPluginRequireSignedMutex.RLock()
+	defer fake.setPluginRequireSignedMutex.RUnlock()
 	fake.setAsyncTimeoutMutex.RLock()
 	defer fake.setAsyncTimeoutMutex.RUnlock()
 	fake.setTraceMutex.RLock()
@@ -1807,6 +2118,14 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.setColorEnabledMutex.RUnlock()
 	fake.setLocaleMutex.RLock()
 	defer fake.setLocaleMutex.RUnlock()
+	fake.setClientKeyFileMutex.RLock()
+	defer fake.setClientKeyFileMutex.RUnlock()
+	fake.setClientCertFileMutex.RLock()
+	defer fake.setClientCertFileMutex.RUnlock()
+	fake.setCACertFileMutex.RLock()
+	defer fake.setCACertFileMutex.RUnlock()
+	fake.setPluginTrustedKeysFileMutex.RLock()
+	defer fake.setPluginTrustedKeysFileMutex.RUnlock()
 	fake.setPluginRepoMutex.RLock()
 	defer fake.setPluginRepoMutex.RUnlock()
 	fake.unSetPluginRepoMutex.RLock()
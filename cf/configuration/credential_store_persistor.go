@@ -0,0 +1,88 @@
+package configuration
+
+import "code.cloudfoundry.org/cli/util/credentials"
+
+// CredentialsData is implemented by config data that holds OAuth tokens.
+type CredentialsData interface {
+	Credentials() credentials.Credentials
+	SetCredentials(credentials.Credentials)
+}
+
+// CredentialStorePersistor keeps the tokens and client secret of
+// CredentialsData in a credentials.Store and everything else in the wrapped
+// Persistor.
+type CredentialStorePersistor struct {
+	persistor Persistor
+	store     credentials.Store
+	key       string
+	stored    credentials.Credentials
+}
+
+func NewCredentialStorePersistor(persistor Persistor, store credentials.Store, key string) *CredentialStorePersistor {
+	return &CredentialStorePersistor{
+		persistor: persistor,
+		store:     store,
+		key:       key,
+	}
+}
+
+func (p *CredentialStorePersistor) Delete() {
+	p.persistor.Delete()
+	_ = p.store.Erase(p.key)
+}
+
+func (p *CredentialStorePersistor) Exists() bool {
+	return p.persistor.Exists()
+}
+
+func (p *CredentialStorePersistor) Load(data DataInterface) error {
+	err := p.persistor.Load(data)
+	if err != nil {
+		return err
+	}
+
+	credsData, ok := data.(CredentialsData)
+	if !ok {
+		return nil
+	}
+
+	creds, err := p.store.Get(p.key)
+	if err != nil {
+		return err
+	}
+
+	p.stored = creds
+	if !creds.IsEmpty() {
+		if creds.ClientSecret == "" {
+			creds.ClientSecret = credsData.Credentials().ClientSecret
+		}
+		credsData.SetCredentials(creds)
+	}
+	return nil
+}
+
+func (p *CredentialStorePersistor) Save(data DataInterface) error {
+	credsData, ok := data.(CredentialsData)
+	if !ok {
+		return p.persistor.Save(data)
+	}
+
+	creds := credsData.Credentials()
+	if creds != p.stored {
+		var err error
+		if creds.IsEmpty() {
+			err = p.store.Erase(p.key)
+		} else {
+			err = p.store.Set(p.key, creds)
+		}
+		if err != nil {
+			return err
+		}
+		p.stored = creds
+	}
+
+	credsData.SetCredentials(credentials.Credentials{})
+	defer credsData.SetCredentials(creds)
+
+	return p.persistor.Save(data)
+}
@@ -0,0 +1,161 @@
+package configuration_test
+
+import (
+	"errors"
+
+	. "code.cloudfoundry.org/cli/cf/configuration"
+	"code.cloudfoundry.org/cli/cf/configuration/configurationfakes"
+	"code.cloudfoundry.org/cli/util/credentials"
+	"code.cloudfoundry.org/cli/util/credentials/credentialsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CredentialStorePersistor", func() {
+	var (
+		fakePersistor *configurationfakes.FakePersistor
+		fakeStore     *credentialsfakes.FakeStore
+		persistor     *CredentialStorePersistor
+		d             *credentialsData
+	)
+
+	BeforeEach(func() {
+		fakePersistor = new(configurationfakes.FakePersistor)
+		fakeStore = new(credentialsfakes.FakeStore)
+		persistor = NewCredentialStorePersistor(fakePersistor, fakeStore, "/home/user/.cf/config.json")
+		d = &credentialsData{}
+	})
+
+	Describe("Load", func() {
+		Context("when the store has credentials", func() {
+			BeforeEach(func() {
+				fakeStore.GetReturns(credentials.Credentials{AccessToken: "bearer stored", RefreshToken: "stored-refresh"}, nil)
+			})
+
+			It("loads the data and replaces the tokens with the stored ones", func() {
+				Expect(persistor.Load(d)).To(Succeed())
+				Expect(fakePersistor.LoadCallCount()).To(Equal(1))
+				Expect(fakeStore.GetArgsForCall(0)).To(Equal("/home/user/.cf/config.json"))
+				Expect(d.creds).To(Equal(credentials.Credentials{AccessToken: "bearer stored", RefreshToken: "stored-refresh"}))
+			})
+		})
+
+		Context("when the store has no credentials", func() {
+			BeforeEach(func() {
+				fakePersistor.LoadStub = func(data DataInterface) error {
+					data.(*credentialsData).creds = credentials.Credentials{AccessToken: "bearer plaintext"}
+					return nil
+				}
+			})
+
+			It("keeps the tokens from the config file", func() {
+				Expect(persistor.Load(d)).To(Succeed())
+				Expect(d.creds.AccessToken).To(Equal("bearer plaintext"))
+			})
+		})
+
+		Context("when the store has tokens but no client secret", func() {
+			BeforeEach(func() {
+				fakeStore.GetReturns(credentials.Credentials{AccessToken: "bearer stored"}, nil)
+				fakePersistor.LoadStub = func(data DataInterface) error {
+					data.(*credentialsData).creds = credentials.Credentials{ClientSecret: "plaintext-secret"}
+					return nil
+				}
+			})
+
+			It("keeps the client secret from the config file", func() {
+				Expect(persistor.Load(d)).To(Succeed())
+				Expect(d.creds).To(Equal(credentials.Credentials{AccessToken: "bearer stored", ClientSecret: "plaintext-secret"}))
+			})
+		})
+
+		Context("when the store returns an error", func() {
+			BeforeEach(func() {
+				fakeStore.GetReturns(credentials.Credentials{}, errors.New("locked"))
+			})
+
+			It("returns the error", func() {
+				Expect(persistor.Load(d)).To(MatchError("locked"))
+			})
+		})
+	})
+
+	Describe("Save", func() {
+		var savedCreds credentials.Credentials
+
+		BeforeEach(func() {
+			fakePersistor.SaveStub = func(data DataInterface) error {
+				savedCreds = data.(*credentialsData).creds
+				return nil
+			}
+			d.creds = credentials.Credentials{AccessToken: "bearer new", RefreshToken: "new-refresh"}
+		})
+
+		It("saves the tokens to the store and the rest without them", func() {
+			Expect(persistor.Save(d)).To(Succeed())
+
+			Expect(fakeStore.SetCallCount()).To(Equal(1))
+			key, creds := fakeStore.SetArgsForCall(0)
+			Expect(key).To(Equal("/home/user/.cf/config.json"))
+			Expect(creds).To(Equal(credentials.Credentials{AccessToken: "bearer new", RefreshToken: "new-refresh"}))
+
+			Expect(savedCreds.IsEmpty()).To(BeTrue())
+			Expect(d.creds.AccessToken).To(Equal("bearer new"))
+		})
+
+		It("saves the client secret to the store and the rest without it", func() {
+			d.creds.ClientSecret = "some-secret"
+			Expect(persistor.Save(d)).To(Succeed())
+
+			_, creds := fakeStore.SetArgsForCall(0)
+			Expect(creds.ClientSecret).To(Equal("some-secret"))
+			Expect(savedCreds.ClientSecret).To(BeEmpty())
+			Expect(d.creds.ClientSecret).To(Equal("some-secret"))
+		})
+
+		It("does not write unchanged tokens to the store again", func() {
+			Expect(persistor.Save(d)).To(Succeed())
+			Expect(persistor.Save(d)).To(Succeed())
+			Expect(fakeStore.SetCallCount()).To(Equal(1))
+			Expect(fakePersistor.SaveCallCount()).To(Equal(2))
+		})
+
+		Context("when the tokens have been cleared", func() {
+			BeforeEach(func() {
+				fakeStore.GetReturns(credentials.Credentials{AccessToken: "bearer old"}, nil)
+				Expect(persistor.Load(d)).To(Succeed())
+				d.creds = credentials.Credentials{}
+			})
+
+			It("erases them from the store", func() {
+				Expect(persistor.Save(d)).To(Succeed())
+				Expect(fakeStore.EraseCallCount()).To(Equal(1))
+				Expect(fakeStore.SetCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the store returns an error", func() {
+			BeforeEach(func() {
+				fakeStore.SetReturns(errors.New("locked"))
+			})
+
+			It("does not save the config", func() {
+				Expect(persistor.Save(d)).To(MatchError("locked"))
+				Expect(fakePersistor.SaveCallCount()).To(Equal(0))
+			})
+		})
+	})
+})
+
+type credentialsData struct {
+	data
+	creds credentials.Credentials
+}
+
+func (d *credentialsData) Credentials() credentials.Credentials {
+	return d.creds
+}
+
+func (d *credentialsData) SetCredentials(creds credentials.Credentials) {
+	d.creds = creds
+}
@@ -386,6 +386,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("remove-plugin-repo"),
 					presentCommand("list-plugin-repos"),
 					presentCommand("repo-plugins"),
+					presentCommand("plugin-repo"),
 				},
 			},
 		}, {
@@ -395,6 +396,7 @@ func newAppPresenter() appPresenter {
 					presentCommand("plugins"),
 					presentCommand("install-plugin"),
 					presentCommand("uninstall-plugin"),
+					presentCommand("update-plugin"),
 				},
 			},
 		}, {
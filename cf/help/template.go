@@ -16,18 +16,33 @@ func GetHelpTemplate() string {
 {{range .}}   {{.Name}} {{.Description}}
 {{end}}{{end}}{{end}}
 {{.Title "` + T("ENVIRONMENT VARIABLES:") + `"}}
+   CF_CA_CERT=path/to/ca.pem          ` + T("Trust the certificate authorities in this PEM bundle in addition to the system ones") + `
+   CF_CACHE_TTL=60                    ` + T("Reuse API responses for this many seconds, then revalidate them with the server") + `
+   CF_CLIENT_CERT=path/to/cert.pem    ` + T("Present this PEM client certificate to servers that request one") + `
+   CF_CLIENT_KEY=path/to/key.pem      ` + T("Private key for CF_CLIENT_CERT") + `
    CF_COLOR=false                     ` + T("Do not colorize output") + `
+   CF_CONCURRENCY=4                   ` + T("Max number of API requests made at the same time by bulk operations") + `
+   CF_CONTEXT=prod                    ` + T("Run commands against a saved context (see 'cf context')") + `
+   CF_CREDENTIAL_STORE=secret-service ` + T("Keep tokens in secret-service (with the secret-tool command), encrypted-file (with CF_CREDENTIAL_PASSPHRASE) or a credential helper instead of the config file") + `
    CF_HOME=path/to/dir/               ` + T("Override path to default config directory") + `
    CF_DIAL_TIMEOUT=5                  ` + T("Max wait time to establish a connection, including name resolution, in seconds") + `
    CF_PLUGIN_HOME=path/to/dir/        ` + T("Override path to default plugin config directory") + `
+   CF_RETRY_BASE_DELAY=500ms          ` + T("Initial wait before retrying a failed API request; doubles on each retry") + `
+   CF_RETRY_MAX=2                     ` + T("Max number of times to retry a failed or rate limited API request") + `
+   CF_RETRY_MAX_DELAY=30s             ` + T("Max wait between retries, including waits requested by the server") + `
+   CF_SSH_HOST_KEY_CHECKING=strict    ` + T("Check 'cf ssh' host keys against the known hosts file: off, warn or strict") + `
    CF_STAGING_TIMEOUT=15              ` + T("Max wait time for buildpack staging, in minutes") + `
    CF_STARTUP_TIMEOUT=5               ` + T("Max wait time for app instance startup, in minutes") + `
    CF_TRACE=true                      ` + T("Print API request diagnostics to stdout") + `
    CF_TRACE=path/to/trace.log         ` + T("Append API request diagnostics to a log file") + `
+   CF_TRACE_FORMAT=har                ` + T("Write CF_TRACE files as HTTP Archives (HAR) instead of text") + `
    https_proxy=proxy.example.com:8080 ` + T("Enable HTTP proxying for API requests") + `
 
 {{.Title "` + T("GLOBAL OPTIONS:") + `"}}
+   --context NAME                     ` + T("Run the command against a saved context") + `
    --help, -h                         ` + T("Show help") + `
+   --no-cache                         ` + T("Do not reuse cached API responses (see CF_CACHE_TTL)") + `
+   --trace-har FILE                   ` + T("Record API requests and responses in an HTTP Archive (HAR) file") + `
    -v                                 ` + T("Print API request diagnostics to stdout") + `
 `
 }
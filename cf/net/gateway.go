@@ -21,6 +21,8 @@ import (
 	. "code.cloudfoundry.org/cli/cf/i18n"
 	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/cf/trace"
+	"code.cloudfoundry.org/cli/util/httpcache"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 	"code.cloudfoundry.org/cli/version"
 )
 
@@ -57,12 +59,33 @@ type Request struct {
 	SeekableBody io.ReadSeeker
 }
 
+//go:generate counterfeiter . HARRecorder
+
+// HARRecorder records requests and responses in an HTTP Archive.
+type HARRecorder interface {
+	Record(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte, started time.Time) error
+}
+
+//go:generate counterfeiter . ResponseCache
+
+// ResponseCache stores responses to GET requests between CLI invocations.
+type ResponseCache interface {
+	Get(url string) (httpcache.Entry, bool)
+	Put(entry httpcache.Entry) error
+	Clear() error
+	Identity() string
+	TTL() time.Duration
+}
+
 type Gateway struct {
 	authenticator   tokenRefresher
 	errHandler      apiErrorHandler
 	PollingEnabled  bool
 	PollingThrottle time.Duration
 	trustedCerts    []tls.Certificate
+	certificates    tlsconfig.Certificates
+	harRecorders    []HARRecorder
+	cache           ResponseCache
 	config          coreconfig.Reader
 	warnings        *[]string
 	Clock           func() time.Time
@@ -416,10 +439,23 @@ func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error)
 		makeHTTPTransport(&gateway)
 	}
 
+	entry, cached, fresh := gateway.lookupCache(request)
+	if fresh {
+		return entry.Response(), nil
+	}
+
 	httpClient := NewHTTPClient(gateway.transport, NewRequestDumper(gateway.logger))
 
 	httpClient.DumpRequest(request)
 
+	var requestBody []byte
+	if len(gateway.harRecorders) > 0 && request.Body != nil && isTextual(request.Header.Get("Content-Type")) {
+		requestBody, _ = ioutil.ReadAll(request.Body)
+		request.Body.Close()
+		request.Body = ioutil.NopCloser(bytes.NewReader(requestBody))
+	}
+	started := time.Now()
+
 	for i := 0; i < 3; i++ {
 		response, err = httpClient.Do(request)
 		if response == nil && err != nil {
@@ -434,6 +470,8 @@ func (gateway Gateway) doRequest(request *http.Request) (*http.Response, error)
 	}
 
 	httpClient.DumpResponse(response)
+	gateway.recordHAR(request, requestBody, response, started)
+	response = gateway.storeInCache(request, response, entry, cached)
 
 	header := http.CanonicalHeaderKey("X-Cf-Warnings")
 	rawWarnings := response.Header[header]
@@ -451,7 +489,7 @@ func makeHTTPTransport(gateway *Gateway) {
 			KeepAlive: 30 * time.Second,
 			Timeout:   gateway.DialTimeout,
 		}).Dial,
-		TLSClientConfig: NewTLSConfig(gateway.trustedCerts, gateway.config.IsSSLDisabled()),
+		TLSClientConfig: gateway.TLSConfig(),
 		Proxy:           http.ProxyFromEnvironment,
 	}
 }
@@ -468,3 +506,124 @@ func (gateway *Gateway) SetTrustedCerts(certificates []tls.Certificate) {
 	gateway.trustedCerts = certificates
 	makeHTTPTransport(gateway)
 }
+
+// SetCertificates sets the CA bundle and client certificate used for every
+// request made by the gateway.
+func (gateway *Gateway) SetCertificates(certificates tlsconfig.Certificates) {
+	gateway.certificates = certificates
+	makeHTTPTransport(gateway)
+}
+
+// SetHARRecorders records every request made by the gateway, and its
+// response, with each of the recorders.
+func (gateway *Gateway) SetHARRecorders(recorders []HARRecorder) {
+	gateway.harRecorders = recorders
+}
+
+// SetResponseCache reuses responses to GET requests for the lists in
+// httpcache.Cacheable from cache until they expire. Requests that may change
+// resources clear the cache.
+func (gateway *Gateway) SetResponseCache(cache ResponseCache) {
+	gateway.cache = cache
+}
+
+// lookupCache returns the stored response to request, if there is one, and
+// whether it is still fresh. Stale responses are revalidated by adding
+// conditional headers to the request.
+func (gateway Gateway) lookupCache(request *http.Request) (httpcache.Entry, bool, bool) {
+	if gateway.cache == nil {
+		return httpcache.Entry{}, false, false
+	}
+
+	if httpcache.Invalidates(request) {
+		gateway.cache.Clear()
+		return httpcache.Entry{}, false, false
+	}
+	if !httpcache.Cacheable(request) || !gateway.cacheMatches(request) {
+		return httpcache.Entry{}, false, false
+	}
+
+	entry, cached := gateway.cache.Get(request.URL.String())
+	if !cached {
+		return entry, false, false
+	}
+	if entry.Fresh(gateway.cache.TTL(), time.Now()) {
+		return entry, true, true
+	}
+
+	entry.SetConditionalHeaders(request.Header)
+	return entry, true, false
+}
+
+// storeInCache stores successful responses to cacheable requests and replaces a
+// 304 Not Modified response with the stored response it revalidated.
+func (gateway Gateway) storeInCache(request *http.Request, response *http.Response, entry httpcache.Entry, cached bool) *http.Response {
+	if gateway.cache == nil || !httpcache.Cacheable(request) || !gateway.cacheMatches(request) {
+		return response
+	}
+
+	switch response.StatusCode {
+	case http.StatusNotModified:
+		if cached {
+			response.Body.Close()
+			entry.StoredAt = time.Now()
+			gateway.cache.Put(entry)
+			return entry.Response()
+		}
+	case http.StatusOK:
+		body, err := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		response.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err == nil {
+			gateway.cache.Put(httpcache.NewEntry(request.URL.String(), response, body, time.Now()))
+		}
+	}
+
+	return response
+}
+
+// cacheMatches returns true if request is made with a token for the identity
+// the cache belongs to. Logging in can change the user partway through a
+// command, and responses must never be shared between users.
+func (gateway Gateway) cacheMatches(request *http.Request) bool {
+	return httpcache.Identity(request.Header.Get("Authorization")) == gateway.cache.Identity()
+}
+
+func (gateway Gateway) recordHAR(request *http.Request, requestBody []byte, response *http.Response, started time.Time) {
+	if len(gateway.harRecorders) == 0 {
+		return
+	}
+
+	var responseBody []byte
+	if isTextual(response.Header.Get("Content-Type")) {
+		responseBody, _ = ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		response.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+	}
+
+	for _, recorder := range gateway.harRecorders {
+		err := recorder.Record(request, requestBody, response, responseBody, started)
+		if err != nil && gateway.ui != nil {
+			gateway.ui.Warn(T("Error recording HTTP Archive: {{.Err}}", map[string]interface{}{"Err": err}))
+		}
+	}
+}
+
+// isTextual returns true for content types whose bodies are small enough to
+// be kept in memory and are redacted in HTTP Archives.
+func isTextual(contentType string) bool {
+	return strings.Contains(contentType, "json") ||
+		strings.Contains(contentType, "x-www-form-urlencoded") ||
+		strings.HasPrefix(contentType, "text/")
+}
+
+// Certificates returns the CA bundle and client certificate set with
+// SetCertificates.
+func (gateway Gateway) Certificates() tlsconfig.Certificates {
+	return gateway.certificates
+}
+
+// TLSConfig returns the TLS configuration used by the gateway's transport.
+func (gateway Gateway) TLSConfig() *tls.Config {
+	return NewTLSConfig(gateway.trustedCerts, gateway.certificates, gateway.config.IsSSLDisabled())
+}
@@ -20,6 +20,7 @@ import (
 	"code.cloudfoundry.org/cli/cf/net/netfakes"
 	"code.cloudfoundry.org/cli/cf/terminal/terminalfakes"
 	"code.cloudfoundry.org/cli/cf/trace/tracefakes"
+	"code.cloudfoundry.org/cli/util/httpcache"
 	testconfig "code.cloudfoundry.org/cli/util/testhelpers/configuration"
 	testnet "code.cloudfoundry.org/cli/util/testhelpers/net"
 	"code.cloudfoundry.org/cli/version"
@@ -194,6 +195,153 @@ var _ = Describe("Gateway", func() {
 
 	})
 
+	Describe("recording HTTP Archives", func() {
+		var (
+			apiServer    *httptest.Server
+			fakeRecorder *netfakes.FakeHARRecorder
+		)
+
+		BeforeEach(func() {
+			apiServer = httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				writer.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(writer, `{"name":"some-name"}`)
+			}))
+			ccGateway.SetTrustedCerts(apiServer.TLS.Certificates)
+
+			fakeRecorder = new(netfakes.FakeHARRecorder)
+			ccGateway.SetHARRecorders([]HARRecorder{fakeRecorder})
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+		})
+
+		It("records the request and response bodies", func() {
+			var resource struct {
+				Name string `json:"name"`
+			}
+			err := ccGateway.UpdateResourceFromStruct(apiServer.URL, "/v2/foobars/SOME_GUID", map[string]string{"name": "new-name"})
+			Expect(err).ToNot(HaveOccurred())
+			_, err = ccGateway.PerformRequestForJSONResponse(mustNewRequest(ccGateway, apiServer.URL+"/v2/foobars/SOME_GUID"), &resource)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resource.Name).To(Equal("some-name"))
+
+			Expect(fakeRecorder.RecordCallCount()).To(Equal(2))
+			request, requestBody, response, responseBody, _ := fakeRecorder.RecordArgsForCall(0)
+			Expect(request.Method).To(Equal("PUT"))
+			Expect(requestBody).To(MatchJSON(`{"name":"new-name"}`))
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(responseBody).To(MatchJSON(`{"name":"some-name"}`))
+		})
+	})
+
+	Describe("caching responses", func() {
+		var (
+			apiServer    *httptest.Server
+			fakeCache    *netfakes.FakeResponseCache
+			requestCount int
+			ifNoneMatch  string
+		)
+
+		BeforeEach(func() {
+			requestCount = 0
+			apiServer = httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				requestCount++
+				ifNoneMatch = request.Header.Get("If-None-Match")
+				if ifNoneMatch == `"v1"` {
+					writer.WriteHeader(http.StatusNotModified)
+					return
+				}
+				writer.Header().Set("Etag", `"v1"`)
+				fmt.Fprint(writer, `{"name":"some-name"}`)
+			}))
+			ccGateway.SetTrustedCerts(apiServer.TLS.Certificates)
+
+			fakeCache = new(netfakes.FakeResponseCache)
+			fakeCache.TTLReturns(time.Minute)
+			ccGateway.SetResponseCache(fakeCache)
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+		})
+
+		getResource := func() string {
+			var resource struct {
+				Name string `json:"name"`
+			}
+			_, err := ccGateway.PerformRequestForJSONResponse(mustNewRequest(ccGateway, apiServer.URL+"/v2/stacks"), &resource)
+			Expect(err).ToNot(HaveOccurred())
+			return resource.Name
+		}
+
+		It("stores successful responses", func() {
+			Expect(getResource()).To(Equal("some-name"))
+			Expect(fakeCache.PutCallCount()).To(Equal(1))
+			entry := fakeCache.PutArgsForCall(0)
+			Expect(entry.URL).To(Equal(apiServer.URL + "/v2/stacks"))
+			Expect(entry.Body).To(MatchJSON(`{"name":"some-name"}`))
+		})
+
+		It("returns fresh responses without making a request", func() {
+			fakeCache.GetReturns(httpcache.Entry{
+				StoredAt:   time.Now(),
+				StatusCode: http.StatusOK,
+				Body:       []byte(`{"name":"cached-name"}`),
+			}, true)
+
+			Expect(getResource()).To(Equal("cached-name"))
+			Expect(requestCount).To(BeZero())
+		})
+
+		It("revalidates stale responses", func() {
+			fakeCache.GetReturns(httpcache.Entry{
+				StoredAt:   time.Now().Add(-time.Hour),
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": {`"v1"`}},
+				Body:       []byte(`{"name":"cached-name"}`),
+			}, true)
+
+			Expect(getResource()).To(Equal("cached-name"))
+			Expect(requestCount).To(Equal(1))
+			Expect(ifNoneMatch).To(Equal(`"v1"`))
+			Expect(fakeCache.PutArgsForCall(0).StoredAt).To(BeTemporally("~", time.Now(), time.Second))
+		})
+
+		It("bypasses the cache when the request is made for someone else", func() {
+			accessToken, err := testconfig.EncodeAccessToken(coreconfig.TokenInfo{UserGUID: "other-user-guid"})
+			Expect(err).ToNot(HaveOccurred())
+			fakeCache.IdentityReturns("user:some-user-guid")
+
+			request, err := ccGateway.NewRequest("GET", apiServer.URL+"/v2/stacks", accessToken, nil)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = ccGateway.PerformRequestForJSONResponse(request, new(struct{}))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(requestCount).To(Equal(1))
+			Expect(fakeCache.GetCallCount()).To(BeZero())
+			Expect(fakeCache.PutCallCount()).To(BeZero())
+		})
+
+		It("does not cache resources that are polled or hold credentials", func() {
+			request := mustNewRequest(ccGateway, apiServer.URL+"/v2/jobs/SOME_GUID")
+			_, err := ccGateway.PerformRequestForJSONResponse(request, new(struct{}))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(requestCount).To(Equal(1))
+			Expect(fakeCache.GetCallCount()).To(BeZero())
+			Expect(fakeCache.PutCallCount()).To(BeZero())
+			Expect(fakeCache.ClearCallCount()).To(BeZero())
+		})
+
+		It("clears the cache on other requests", func() {
+			err := ccGateway.UpdateResourceFromStruct(apiServer.URL, "/v2/stacks", map[string]string{"name": "new-name"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fakeCache.ClearCallCount()).To(Equal(1))
+			Expect(fakeCache.GetCallCount()).To(BeZero())
+		})
+	})
+
 	Describe("CRUD methods", func() {
 		Describe("Delete", func() {
 			var apiServer *httptest.Server
@@ -686,3 +834,9 @@ func createAuthenticationRepository(apiServer *httptest.Server, authServer *http
 
 	return config, authenticator
 }
+
+func mustNewRequest(gateway Gateway, url string) *Request {
+	request, err := gateway.NewRequest("GET", url, "BEARER my_access_token", nil)
+	Expect(err).ToNot(HaveOccurred())
+	return request
+}
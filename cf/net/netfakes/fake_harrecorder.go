@@ -0,0 +1,96 @@
+// This file was generated by counterfeiter
+package netfakes
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/net"
+)
+
+type FakeHARRecorder struct {
+	RecordStub        func(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte, started time.Time) error
+	recordMutex       sync.RWMutex
+	recordArgsForCall []struct {
+		request      *http.Request
+		requestBody  []byte
+		response     *http.Response
+		responseBody []byte
+		started      time.Time
+	}
+	recordReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeHARRecorder) Record(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte, started time.Time) error {
+	var requestBodyCopy []byte
+	if requestBody != nil {
+		requestBodyCopy = make([]byte, len(requestBody))
+		copy(requestBodyCopy, requestBody)
+	}
+	var responseBodyCopy []byte
+	if responseBody != nil {
+		responseBodyCopy = make([]byte, len(responseBody))
+		copy(responseBodyCopy, responseBody)
+	}
+	fake.recordMutex.Lock()
+	fake.recordArgsForCall = append(fake.recordArgsForCall, struct {
+		request      *http.Request
+		requestBody  []byte
+		response     *http.Response
+		responseBody []byte
+		started      time.Time
+	}{request, requestBodyCopy, response, responseBodyCopy, started})
+	fake.recordInvocation("Record", []interface{}{request, requestBodyCopy, response, responseBodyCopy, started})
+	fake.recordMutex.Unlock()
+	if fake.RecordStub != nil {
+		return fake.RecordStub(request, requestBody, response, responseBody, started)
+	} else {
+		return fake.recordReturns.result1
+	}
+}
+
+func (fake *FakeHARRecorder) RecordCallCount() int {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return len(fake.recordArgsForCall)
+}
+
+func (fake *FakeHARRecorder) RecordArgsForCall(i int) (*http.Request, []byte, *http.Response, []byte, time.Time) {
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return fake.recordArgsForCall[i].request, fake.recordArgsForCall[i].requestBody, fake.recordArgsForCall[i].response, fake.recordArgsForCall[i].responseBody, fake.recordArgsForCall[i].started
+}
+
+func (fake *FakeHARRecorder) RecordReturns(result1 error) {
+	fake.RecordStub = nil
+	fake.recordReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeHARRecorder) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.recordMutex.RLock()
+	defer fake.recordMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeHARRecorder) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ net.HARRecorder = new(FakeHARRecorder)
@@ -0,0 +1,222 @@
+// This file was generated by counterfeiter
+package netfakes
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/net"
+	"code.cloudfoundry.org/cli/util/httpcache"
+)
+
+type FakeResponseCache struct {
+	GetStub        func(url string) (httpcache.Entry, bool)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		url string
+	}
+	getReturns struct {
+		result1 httpcache.Entry
+		result2 bool
+	}
+	PutStub        func(entry httpcache.Entry) error
+	putMutex       sync.RWMutex
+	putArgsForCall []struct {
+		entry httpcache.Entry
+	}
+	putReturns struct {
+		result1 error
+	}
+	ClearStub        func() error
+	clearMutex       sync.RWMutex
+	clearArgsForCall []struct{}
+	clearReturns     struct {
+		result1 error
+	}
+	IdentityStub        func() string
+	identityMutex       sync.RWMutex
+	identityArgsForCall []struct{}
+	identityReturns     struct {
+		result1 string
+	}
+	TTLStub        func() time.Duration
+	tTLMutex       sync.RWMutex
+	tTLArgsForCall []struct{}
+	tTLReturns     struct {
+		result1 time.Duration
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeResponseCache) Get(url string) (httpcache.Entry, bool) {
+	fake.getMutex.Lock()
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		url string
+	}{url})
+	fake.recordInvocation("Get", []interface{}{url})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(url)
+	} else {
+		return fake.getReturns.result1, fake.getReturns.result2
+	}
+}
+
+func (fake *FakeResponseCache) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeResponseCache) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].url
+}
+
+func (fake *FakeResponseCache) GetReturns(result1 httpcache.Entry, result2 bool) {
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 httpcache.Entry
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeResponseCache) Put(entry httpcache.Entry) error {
+	fake.putMutex.Lock()
+	fake.putArgsForCall = append(fake.putArgsForCall, struct {
+		entry httpcache.Entry
+	}{entry})
+	fake.recordInvocation("Put", []interface{}{entry})
+	fake.putMutex.Unlock()
+	if fake.PutStub != nil {
+		return fake.PutStub(entry)
+	} else {
+		return fake.putReturns.result1
+	}
+}
+
+func (fake *FakeResponseCache) PutCallCount() int {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	return len(fake.putArgsForCall)
+}
+
+func (fake *FakeResponseCache) PutArgsForCall(i int) httpcache.Entry {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	return fake.putArgsForCall[i].entry
+}
+
+func (fake *FakeResponseCache) PutReturns(result1 error) {
+	fake.PutStub = nil
+	fake.putReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResponseCache) Clear() error {
+	fake.clearMutex.Lock()
+	fake.clearArgsForCall = append(fake.clearArgsForCall, struct{}{})
+	fake.recordInvocation("Clear", []interface{}{})
+	fake.clearMutex.Unlock()
+	if fake.ClearStub != nil {
+		return fake.ClearStub()
+	} else {
+		return fake.clearReturns.result1
+	}
+}
+
+func (fake *FakeResponseCache) ClearCallCount() int {
+	fake.clearMutex.RLock()
+	defer fake.clearMutex.RUnlock()
+	return len(fake.clearArgsForCall)
+}
+
+func (fake *FakeResponseCache) ClearReturns(result1 error) {
+	fake.ClearStub = nil
+	fake.clearReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResponseCache) Identity() string {
+	fake.identityMutex.Lock()
+	fake.identityArgsForCall = append(fake.identityArgsForCall, struct{}{})
+	fake.recordInvocation("Identity", []interface{}{})
+	fake.identityMutex.Unlock()
+	if fake.IdentityStub != nil {
+		return fake.IdentityStub()
+	} else {
+		return fake.identityReturns.result1
+	}
+}
+
+func (fake *FakeResponseCache) IdentityCallCount() int {
+	fake.identityMutex.RLock()
+	defer fake.identityMutex.RUnlock()
+	return len(fake.identityArgsForCall)
+}
+
+func (fake *FakeResponseCache) IdentityReturns(result1 string) {
+	fake.IdentityStub = nil
+	fake.identityReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeResponseCache) TTL() time.Duration {
+	fake.tTLMutex.Lock()
+	fake.tTLArgsForCall = append(fake.tTLArgsForCall, struct{}{})
+	fake.recordInvocation("TTL", []interface{}{})
+	fake.tTLMutex.Unlock()
+	if fake.TTLStub != nil {
+		return fake.TTLStub()
+	} else {
+		return fake.tTLReturns.result1
+	}
+}
+
+func (fake *FakeResponseCache) TTLCallCount() int {
+	fake.tTLMutex.RLock()
+	defer fake.tTLMutex.RUnlock()
+	return len(fake.tTLArgsForCall)
+}
+
+func (fake *FakeResponseCache) TTLReturns(result1 time.Duration) {
+	fake.TTLStub = nil
+	fake.tTLReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
+func (fake *FakeResponseCache) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	fake.clearMutex.RLock()
+	defer fake.clearMutex.RUnlock()
+	fake.identityMutex.RLock()
+	defer fake.identityMutex.RUnlock()
+	fake.tTLMutex.RLock()
+	defer fake.tTLMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeResponseCache) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ net.ResponseCache = new(FakeResponseCache)
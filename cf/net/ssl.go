@@ -3,11 +3,15 @@ package net
 import (
 	"crypto/tls"
 	"crypto/x509"
+
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 )
 
-func NewTLSConfig(trustedCerts []tls.Certificate, disableSSL bool) (TLSConfig *tls.Config) {
+func NewTLSConfig(trustedCerts []tls.Certificate, certificates tlsconfig.Certificates, disableSSL bool) (TLSConfig *tls.Config) {
 	TLSConfig = &tls.Config{
-		MinVersion: tls.VersionTLS10,
+		MinVersion:   tls.VersionTLS10,
+		RootCAs:      certificates.RootCAs,
+		Certificates: certificates.ClientCertificates,
 	}
 
 	if len(trustedCerts) > 0 {
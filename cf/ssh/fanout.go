@@ -0,0 +1,176 @@
+package sshCmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+
+	"code.cloudfoundry.org/cli/cf/ssh/options"
+	"code.cloudfoundry.org/cli/cf/ssh/terminal"
+)
+
+const connectionFailedExitStatus = 255
+
+// InstanceResult is the outcome of running a command on a single application
+// instance. Err is set when the command could not be run or was terminated by
+// a signal.
+type InstanceResult struct {
+	Index      uint
+	ExitStatus int
+	Err        error
+}
+
+// SecureShellFactory returns a new, unconnected SecureShell that reads and
+// writes through the given terminal helper.
+type SecureShellFactory func(terminalHelper terminal.TerminalHelper) (SecureShell, error)
+
+// RunOnAllInstances runs opts.Command on every given instance index, with at
+// most maxInFlight sessions open at a time (0 means no limit). Every line of
+// output is prefixed with the index of the instance that produced it. Results
+// are returned in the same order as indexes.
+func RunOnAllInstances(opts *options.SSHOptions, indexes []uint, maxInFlight uint, stdout io.Writer, stderr io.Writer, newSecureShell SecureShellFactory) []InstanceResult {
+	results := make([]InstanceResult, len(indexes))
+
+	if maxInFlight == 0 || int(maxInFlight) > len(indexes) {
+		maxInFlight = uint(len(indexes))
+	}
+
+	outputMutex := &sync.Mutex{}
+	semaphore := make(chan struct{}, maxInFlight)
+	wg := &sync.WaitGroup{}
+
+	for i, index := range indexes {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(i int, index uint) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			prefix := fmt.Sprintf("[%d] ", index)
+			instanceStdout := newPrefixWriter(outputMutex, stdout, prefix)
+			instanceStderr := newPrefixWriter(outputMutex, stderr, prefix)
+
+			results[i] = runOnInstance(opts, index, instanceStdout, instanceStderr, newSecureShell)
+
+			instanceStdout.Flush()
+			instanceStderr.Flush()
+		}(i, index)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func runOnInstance(opts *options.SSHOptions, index uint, stdout io.Writer, stderr io.Writer, newSecureShell SecureShellFactory) InstanceResult {
+	result := InstanceResult{Index: index}
+
+	secureShell, err := newSecureShell(&streamsHelper{
+		TerminalHelper: terminal.DefaultHelper(),
+		stdout:         stdout,
+		stderr:         stderr,
+	})
+	if err != nil {
+		result.ExitStatus = connectionFailedExitStatus
+		result.Err = err
+		return result
+	}
+
+	instanceOpts := *opts
+	instanceOpts.Index = index
+	instanceOpts.TerminalRequest = options.RequestTTYNo
+
+	err = secureShell.Connect(&instanceOpts)
+	if err != nil {
+		result.ExitStatus = connectionFailedExitStatus
+		result.Err = err
+		return result
+	}
+	defer secureShell.Close()
+
+	err = secureShell.InteractiveSession()
+	if err != nil {
+		if exitError, ok := err.(*ssh.ExitError); ok {
+			result.ExitStatus = exitError.ExitStatus()
+			if sig := exitError.Signal(); sig != "" {
+				result.Err = fmt.Errorf("Process terminated by signal: %s", sig)
+			}
+			return result
+		}
+
+		result.ExitStatus = connectionFailedExitStatus
+		result.Err = err
+	}
+
+	return result
+}
+
+// streamsHelper is a TerminalHelper whose standard streams are replaced.
+// Stdin is always empty so remote commands never wait on local input.
+type streamsHelper struct {
+	terminal.TerminalHelper
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (h *streamsHelper) StdStreams() (io.ReadCloser, io.Writer, io.Writer) {
+	return ioutil.NopCloser(&bytes.Buffer{}), h.stdout, h.stderr
+}
+
+// prefixWriter writes complete lines to the underlying writer, each one
+// preceded by a prefix. Writers sharing a mutex never interleave lines.
+type prefixWriter struct {
+	mutex  *sync.Mutex
+	writer io.Writer
+	prefix string
+	buffer bytes.Buffer
+}
+
+func newPrefixWriter(mutex *sync.Mutex, writer io.Writer, prefix string) *prefixWriter {
+	return &prefixWriter{
+		mutex:  mutex,
+		writer: writer,
+		prefix: prefix,
+	}
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buffer.Write(p)
+
+	for {
+		line, err := w.buffer.ReadBytes('\n')
+		if err != nil {
+			// incomplete line, keep it until the rest arrives
+			w.buffer.Reset()
+			w.buffer.Write(line)
+			return len(p), nil
+		}
+
+		if err := w.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Flush writes any remaining partial line followed by a newline.
+func (w *prefixWriter) Flush() {
+	if w.buffer.Len() == 0 {
+		return
+	}
+
+	line := append(w.buffer.Bytes(), '\n')
+	w.buffer.Reset()
+	_ = w.writeLine(line)
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	_, err := w.writer.Write(append([]byte(w.prefix), line...))
+	return err
+}
@@ -0,0 +1,182 @@
+package sshCmd_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/ssh"
+	"code.cloudfoundry.org/cli/cf/ssh/options"
+	"code.cloudfoundry.org/cli/cf/ssh/sshfakes"
+	"code.cloudfoundry.org/cli/cf/ssh/terminal"
+	"github.com/onsi/gomega/gbytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunOnAllInstances", func() {
+	var (
+		opts        *options.SSHOptions
+		indexes     []uint
+		maxInFlight uint
+		stdout      *gbytes.Buffer
+		stderr      *gbytes.Buffer
+
+		shellsMutex sync.Mutex
+		shells      []*sshfakes.FakeSecureShell
+		factoryErr  error
+
+		sessionStub func(opts *options.SSHOptions, terminalHelper terminal.TerminalHelper) error
+
+		results []sshCmd.InstanceResult
+	)
+
+	BeforeEach(func() {
+		opts = &options.SSHOptions{
+			AppName:      "app-1",
+			Command:      []string{"hostname"},
+			AllInstances: true,
+		}
+		indexes = []uint{0, 1, 2}
+		maxInFlight = 0
+		stdout = gbytes.NewBuffer()
+		stderr = gbytes.NewBuffer()
+		shells = nil
+		factoryErr = nil
+
+		sessionStub = func(opts *options.SSHOptions, terminalHelper terminal.TerminalHelper) error {
+			_, out, errOut := terminalHelper.StdStreams()
+			fmt.Fprintf(out, "hello from %d\n", opts.Index)
+			fmt.Fprintf(errOut, "partial line from %d", opts.Index)
+			return nil
+		}
+	})
+
+	JustBeforeEach(func() {
+		factory := func(terminalHelper terminal.TerminalHelper) (sshCmd.SecureShell, error) {
+			if factoryErr != nil {
+				return nil, factoryErr
+			}
+
+			shell := new(sshfakes.FakeSecureShell)
+			shell.InteractiveSessionStub = func() error {
+				return sessionStub(shell.ConnectArgsForCall(0), terminalHelper)
+			}
+
+			shellsMutex.Lock()
+			shells = append(shells, shell)
+			shellsMutex.Unlock()
+			return shell, nil
+		}
+
+		results = sshCmd.RunOnAllInstances(opts, indexes, maxInFlight, stdout, stderr, factory)
+	})
+
+	It("connects to every instance without a tty", func() {
+		Expect(shells).To(HaveLen(3))
+
+		var connected []uint
+		for _, shell := range shells {
+			Expect(shell.ConnectCallCount()).To(Equal(1))
+			Expect(shell.InteractiveSessionCallCount()).To(Equal(1))
+			Expect(shell.CloseCallCount()).To(Equal(1))
+
+			connectOpts := shell.ConnectArgsForCall(0)
+			Expect(connectOpts.TerminalRequest).To(Equal(options.RequestTTYNo))
+			connected = append(connected, connectOpts.Index)
+		}
+		Expect(connected).To(ConsistOf(uint(0), uint(1), uint(2)))
+		Expect(opts.Index).To(BeZero())
+	})
+
+	It("prefixes each line of output with the instance index", func() {
+		Expect(stdout).To(gbytes.Say(`\[\d\] hello from \d\n`))
+		output := string(stdout.Contents())
+		Expect(output).To(ContainSubstring("[0] hello from 0\n"))
+		Expect(output).To(ContainSubstring("[1] hello from 1\n"))
+		Expect(output).To(ContainSubstring("[2] hello from 2\n"))
+
+		errOutput := string(stderr.Contents())
+		Expect(errOutput).To(ContainSubstring("[0] partial line from 0\n"))
+		Expect(errOutput).To(ContainSubstring("[2] partial line from 2\n"))
+		Expect(strings.Count(errOutput, "\n")).To(Equal(3))
+	})
+
+	It("returns a result for each instance in order", func() {
+		Expect(results).To(Equal([]sshCmd.InstanceResult{
+			{Index: 0, ExitStatus: 0},
+			{Index: 1, ExitStatus: 0},
+			{Index: 2, ExitStatus: 0},
+		}))
+	})
+
+	Context("when the session fails on an instance", func() {
+		BeforeEach(func() {
+			sessionStub = func(opts *options.SSHOptions, _ terminal.TerminalHelper) error {
+				if opts.Index == 1 {
+					return errors.New("session broke")
+				}
+				return nil
+			}
+		})
+
+		It("reports the failure for that instance only", func() {
+			Expect(results[0]).To(Equal(sshCmd.InstanceResult{Index: 0}))
+			Expect(results[1].ExitStatus).To(Equal(255))
+			Expect(results[1].Err).To(MatchError("session broke"))
+			Expect(results[2]).To(Equal(sshCmd.InstanceResult{Index: 2}))
+		})
+	})
+
+	Context("when a secure shell cannot be created", func() {
+		BeforeEach(func() {
+			factoryErr = errors.New("no auth code")
+		})
+
+		It("reports the failure for every instance", func() {
+			for _, result := range results {
+				Expect(result.ExitStatus).To(Equal(255))
+				Expect(result.Err).To(MatchError("no auth code"))
+			}
+		})
+	})
+
+	Context("when a concurrency limit is set", func() {
+		var (
+			inFlight    int
+			maxObserved int
+			countMutex  sync.Mutex
+		)
+
+		BeforeEach(func() {
+			indexes = []uint{0, 1, 2, 3, 4, 5}
+			maxInFlight = 2
+			inFlight = 0
+			maxObserved = 0
+
+			sessionStub = func(_ *options.SSHOptions, _ terminal.TerminalHelper) error {
+				countMutex.Lock()
+				inFlight++
+				if inFlight > maxObserved {
+					maxObserved = inFlight
+				}
+				countMutex.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				countMutex.Lock()
+				inFlight--
+				countMutex.Unlock()
+				return nil
+			}
+		})
+
+		It("never runs more sessions at once than the limit", func() {
+			Expect(results).To(HaveLen(6))
+			Expect(maxObserved).To(BeNumerically("<=", 2))
+		})
+	})
+})
@@ -0,0 +1,171 @@
+package sshCmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+
+	"code.cloudfoundry.org/cli/cf/ssh/options"
+)
+
+const defaultSSHPort = "22"
+
+// HostKeyChangedError is returned when the key presented by an SSH endpoint
+// does not match the key previously pinned for it in the known hosts file.
+type HostKeyChangedError struct {
+	Host                string
+	KnownHostsFile      string
+	Line                int
+	ReceivedFingerprint string
+}
+
+func (e HostKeyChangedError) Error() string {
+	return fmt.Sprintf("Host key verification failed.\n\nThe host key for %s has changed since it was last seen. The fingerprint of the received key was %q.\nIf this change is expected, remove line %d of %s.", e.Host, e.ReceivedFingerprint, e.Line, e.KnownHostsFile)
+}
+
+// KnownHosts is a trust on first use store of SSH host keys, kept in an
+// OpenSSH known_hosts formatted file.
+type KnownHosts struct {
+	path  string
+	mutex *sync.Mutex
+}
+
+// knownHostsMutexes holds one mutex per known hosts file. The sessions of an
+// --all-instances run each have their own KnownHosts, and must not all pin
+// the endpoint's key when they connect for the first time at once.
+var (
+	knownHostsMutexesLock sync.Mutex
+	knownHostsMutexes     = map[string]*sync.Mutex{}
+)
+
+func NewKnownHosts(path string) *KnownHosts {
+	knownHostsMutexesLock.Lock()
+	defer knownHostsMutexesLock.Unlock()
+
+	mutex, ok := knownHostsMutexes[path]
+	if !ok {
+		mutex = &sync.Mutex{}
+		knownHostsMutexes[path] = mutex
+	}
+
+	return &KnownHosts{path: path, mutex: mutex}
+}
+
+// Lookup returns the key pinned for the given host and the line it is on. A
+// nil key is returned when the host has not been seen before.
+func (k *KnownHosts) Lookup(host string) (ssh.PublicKey, int, error) {
+	file, err := os.Open(k.path)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	normalizedHost := normalizeKnownHost(host)
+
+	lineNumber := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lineNumber++
+
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		marker, hosts, key, _, _, err := ssh.ParseKnownHosts(line)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Unable to parse line %d of %s: %s", lineNumber, k.path, err.Error())
+		}
+		if marker != "" {
+			continue
+		}
+
+		for _, h := range hosts {
+			if h == normalizedHost {
+				return key, lineNumber, nil
+			}
+		}
+	}
+
+	return nil, 0, scanner.Err()
+}
+
+// Add pins key for host by appending it to the known hosts file.
+func (k *KnownHosts) Add(host string, key ssh.PublicKey) error {
+	err := os.MkdirAll(filepath.Dir(k.path), 0700)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(k.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "%s %s", normalizeKnownHost(host), ssh.MarshalAuthorizedKey(key))
+	return err
+}
+
+// Path returns the location of the known hosts file.
+func (k *KnownHosts) Path() string {
+	return k.path
+}
+
+func normalizeKnownHost(host string) string {
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return host
+	}
+
+	if port == defaultSSHPort {
+		return hostname
+	}
+
+	return fmt.Sprintf("[%s]:%s", hostname, port)
+}
+
+func knownHostsCallback(knownHosts *KnownHosts, mode options.HostKeyChecking, host string, warnings io.Writer) hostKeyCallback {
+	return func(_ string, _ net.Addr, key ssh.PublicKey) error {
+		knownHosts.mutex.Lock()
+		defer knownHosts.mutex.Unlock()
+
+		knownKey, line, err := knownHosts.Lookup(host)
+		if err != nil {
+			return err
+		}
+
+		if knownKey == nil {
+			return knownHosts.Add(host, key)
+		}
+
+		if bytes.Equal(knownKey.Marshal(), key.Marshal()) {
+			return nil
+		}
+
+		changedErr := HostKeyChangedError{
+			Host:                normalizeKnownHost(host),
+			KnownHostsFile:      knownHosts.Path(),
+			Line:                line,
+			ReceivedFingerprint: base64Sha256Fingerprint(key),
+		}
+
+		if mode == options.HostKeyCheckingStrict {
+			return changedErr
+		}
+
+		fmt.Fprintf(warnings, "WARNING: %s\n", strings.TrimPrefix(changedErr.Error(), "Host key verification failed.\n\n"))
+		return nil
+	}
+}
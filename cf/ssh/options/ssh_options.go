@@ -1,6 +1,7 @@
 package options
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -16,11 +17,38 @@ const (
 	RequestTTYForce
 )
 
+type HostKeyChecking int
+
+const (
+	HostKeyCheckingOff HostKeyChecking = iota
+	HostKeyCheckingWarn
+	HostKeyCheckingStrict
+)
+
+// ParseHostKeyChecking converts the value of the CF_SSH_HOST_KEY_CHECKING
+// environment variable into a HostKeyChecking mode.
+func ParseHostKeyChecking(value string) (HostKeyChecking, error) {
+	switch strings.ToLower(value) {
+	case "", "off":
+		return HostKeyCheckingOff, nil
+	case "warn":
+		return HostKeyCheckingWarn, nil
+	case "strict":
+		return HostKeyCheckingStrict, nil
+	default:
+		return HostKeyCheckingOff, fmt.Errorf("Invalid host key checking mode %q, expected one of off, warn or strict", value)
+	}
+}
+
 type ForwardSpec struct {
 	ListenAddress  string
 	ConnectAddress string
 }
 
+type DynamicForwardSpec struct {
+	ListenAddress string
+}
+
 type SSHOptions struct {
 	AppName             string
 	Command             []string
@@ -29,6 +57,12 @@ type SSHOptions struct {
 	SkipRemoteExecution bool
 	TerminalRequest     TTYRequest
 	ForwardSpecs        []ForwardSpec
+	RemoteForwardSpecs  []ForwardSpec
+	DynamicForwardSpecs []DynamicForwardSpec
+	AllInstances        bool
+	MaxInFlight         uint
+	HostKeyChecking     HostKeyChecking
+	KnownHostsFile      string
 }
 
 func NewSSHOptions(fc flags.FlagContext) (*SSHOptions, error) {
@@ -39,6 +73,14 @@ func NewSSHOptions(fc flags.FlagContext) (*SSHOptions, error) {
 	sshOptions.SkipHostValidation = fc.Bool("k")
 	sshOptions.SkipRemoteExecution = fc.Bool("N")
 	sshOptions.Command = fc.StringSlice("c")
+	sshOptions.AllInstances = fc.Bool("all-instances")
+
+	if fc.Bool("strict-host-key-checking") {
+		if sshOptions.SkipHostValidation {
+			return sshOptions, errors.New("--strict-host-key-checking cannot be used with --skip-host-validation")
+		}
+		sshOptions.HostKeyChecking = HostKeyCheckingStrict
+	}
 
 	if fc.IsSet("L") {
 		for _, arg := range fc.StringSlice("L") {
@@ -50,6 +92,26 @@ func NewSSHOptions(fc flags.FlagContext) (*SSHOptions, error) {
 		}
 	}
 
+	if fc.IsSet("R") {
+		for _, arg := range fc.StringSlice("R") {
+			forwardSpec, err := sshOptions.parseRemoteForwardingSpec(arg)
+			if err != nil {
+				return sshOptions, err
+			}
+			sshOptions.RemoteForwardSpecs = append(sshOptions.RemoteForwardSpecs, *forwardSpec)
+		}
+	}
+
+	if fc.IsSet("D") {
+		for _, arg := range fc.StringSlice("D") {
+			forwardSpec, err := sshOptions.parseDynamicForwardingSpec(arg)
+			if err != nil {
+				return sshOptions, err
+			}
+			sshOptions.DynamicForwardSpecs = append(sshOptions.DynamicForwardSpecs, *forwardSpec)
+		}
+	}
+
 	if fc.IsSet("t") && fc.Bool("t") {
 		sshOptions.TerminalRequest = RequestTTYYes
 	}
@@ -62,14 +124,100 @@ func NewSSHOptions(fc flags.FlagContext) (*SSHOptions, error) {
 		sshOptions.TerminalRequest = RequestTTYNo
 	}
 
+	if fc.IsSet("max-in-flight") {
+		if !sshOptions.AllInstances {
+			return sshOptions, errors.New("--max-in-flight can only be used with --all-instances")
+		}
+		if fc.Int("max-in-flight") < 1 {
+			return sshOptions, errors.New("Value for flag 'max-in-flight' must be greater than zero")
+		}
+		sshOptions.MaxInFlight = uint(fc.Int("max-in-flight"))
+	}
+
+	if sshOptions.AllInstances {
+		err := sshOptions.validateAllInstances(fc)
+		if err != nil {
+			return sshOptions, err
+		}
+		sshOptions.TerminalRequest = RequestTTYNo
+	}
+
 	return sshOptions, nil
 }
 
+func (o *SSHOptions) validateAllInstances(fc flags.FlagContext) error {
+	if len(o.Command) == 0 {
+		return errors.New("--all-instances requires a command to be specified with -c")
+	}
+
+	if fc.IsSet("i") {
+		return errors.New("--all-instances cannot be used with --app-instance-index")
+	}
+
+	if o.SkipRemoteExecution || len(o.ForwardSpecs) > 0 || len(o.RemoteForwardSpecs) > 0 || len(o.DynamicForwardSpecs) > 0 {
+		return errors.New("--all-instances cannot be used with port forwarding or --skip-remote-execution")
+	}
+
+	if o.TerminalRequest == RequestTTYYes || o.TerminalRequest == RequestTTYForce {
+		return errors.New("--all-instances cannot be used with pseudo-tty allocation")
+	}
+
+	return nil
+}
+
 func (o *SSHOptions) parseLocalForwardingSpec(arg string) (*ForwardSpec, error) {
-	arg = strings.TrimSpace(arg)
+	parts, err := tokenizeForwardingSpec(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	forwardSpec, ok := forwardSpecFromParts(parts)
+	if !ok {
+		return nil, fmt.Errorf("Unable to parse local forwarding argument: %q", strings.TrimSpace(arg))
+	}
 
+	return forwardSpec, nil
+}
+
+func (o *SSHOptions) parseRemoteForwardingSpec(arg string) (*ForwardSpec, error) {
+	parts, err := tokenizeForwardingSpec(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	forwardSpec, ok := forwardSpecFromParts(parts)
+	if !ok {
+		return nil, fmt.Errorf("Unable to parse remote forwarding argument: %q", strings.TrimSpace(arg))
+	}
+
+	return forwardSpec, nil
+}
+
+func (o *SSHOptions) parseDynamicForwardingSpec(arg string) (*DynamicForwardSpec, error) {
+	parts, err := tokenizeForwardingSpec(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	forwardSpec := &DynamicForwardSpec{}
+	switch len(parts) {
+	case 2:
+		if parts[0] == "*" {
+			parts[0] = ""
+		}
+		forwardSpec.ListenAddress = fmt.Sprintf("%s:%s", parts[0], parts[1])
+	case 1:
+		forwardSpec.ListenAddress = fmt.Sprintf("localhost:%s", parts[0])
+	default:
+		return nil, fmt.Errorf("Unable to parse dynamic forwarding argument: %q", strings.TrimSpace(arg))
+	}
+
+	return forwardSpec, nil
+}
+
+func tokenizeForwardingSpec(arg string) ([]string, error) {
 	parts := []string{}
-	for remainder := arg; remainder != ""; {
+	for remainder := strings.TrimSpace(arg); remainder != ""; {
 		part, r, err := tokenizeForward(remainder)
 		if err != nil {
 			return nil, err
@@ -79,6 +227,10 @@ func (o *SSHOptions) parseLocalForwardingSpec(arg string) (*ForwardSpec, error)
 		remainder = r
 	}
 
+	return parts, nil
+}
+
+func forwardSpecFromParts(parts []string) (*ForwardSpec, bool) {
 	forwardSpec := &ForwardSpec{}
 	switch len(parts) {
 	case 4:
@@ -91,10 +243,10 @@ func (o *SSHOptions) parseLocalForwardingSpec(arg string) (*ForwardSpec, error)
 		forwardSpec.ListenAddress = fmt.Sprintf("localhost:%s", parts[0])
 		forwardSpec.ConnectAddress = fmt.Sprintf("%s:%s", parts[1], parts[2])
 	default:
-		return nil, fmt.Errorf("Unable to parse local forwarding argument: %q", arg)
+		return nil, false
 	}
 
-	return forwardSpec, nil
+	return forwardSpec, true
 }
 
 func tokenizeForward(arg string) (string, string, error) {
@@ -20,6 +20,8 @@ var _ = Describe("SSHOptions", func() {
 		BeforeEach(func() {
 			fc = flags.New()
 			fc.NewStringSliceFlag("L", "", "")
+			fc.NewStringSliceFlag("R", "", "")
+			fc.NewStringSliceFlag("D", "", "")
 			fc.NewStringSliceFlag("command", "c", "")
 			fc.NewIntFlag("app-instance-index", "i", "")
 			fc.NewBoolFlag("skip-host-validation", "k", "")
@@ -27,6 +29,9 @@ var _ = Describe("SSHOptions", func() {
 			fc.NewBoolFlag("request-pseudo-tty", "t", "")
 			fc.NewBoolFlag("force-pseudo-tty", "tt", "")
 			fc.NewBoolFlag("disable-pseudo-tty", "T", "")
+			fc.NewBoolFlag("all-instances", "", "")
+			fc.NewIntFlag("max-in-flight", "", "")
+			fc.NewBoolFlag("strict-host-key-checking", "", "")
 
 			args = []string{}
 			parseError = nil
@@ -87,6 +92,27 @@ var _ = Describe("SSHOptions", func() {
 			})
 		})
 
+		Context("when --strict-host-key-checking is set", func() {
+			BeforeEach(func() {
+				args = append(args, "app-name", "--strict-host-key-checking")
+			})
+
+			It("enables strict host key checking", func() {
+				Expect(parseError).ToNot(HaveOccurred())
+				Expect(opts.HostKeyChecking).To(Equal(options.HostKeyCheckingStrict))
+			})
+
+			Context("when -k is also set", func() {
+				BeforeEach(func() {
+					args = append(args, "-k")
+				})
+
+				It("returns an error", func() {
+					Expect(parseError).To(MatchError("--strict-host-key-checking cannot be used with --skip-host-validation"))
+				})
+			})
+		})
+
 		Context("when the -t and -T flags are not used", func() {
 			BeforeEach(func() {
 				args = append(args, "app-name")
@@ -281,6 +307,168 @@ var _ = Describe("SSHOptions", func() {
 			})
 		})
 
+		Context("when remote port forwarding is requested", func() {
+			BeforeEach(func() {
+				args = append(args, "app-name")
+			})
+
+			Context("without an explicit bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-R", "9999:localhost:8888")
+				})
+
+				It("sets the remote forward spec", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.RemoteForwardSpecs).To(ConsistOf(options.ForwardSpec{ListenAddress: "localhost:9999", ConnectAddress: "localhost:8888"}))
+				})
+			})
+
+			Context("with * as the bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-R", "*:9999:localhost:8888")
+				})
+
+				It("sets the remote forward spec", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.RemoteForwardSpecs).To(ConsistOf(options.ForwardSpec{ListenAddress: ":9999", ConnectAddress: "localhost:8888"}))
+				})
+			})
+
+			Context("when the spec cannot be parsed", func() {
+				BeforeEach(func() {
+					args = append(args, "-R", "9999:8888")
+				})
+
+				It("returns an error", func() {
+					Expect(parseError).To(MatchError(`Unable to parse remote forwarding argument: "9999:8888"`))
+				})
+			})
+		})
+
+		Context("when dynamic port forwarding is requested", func() {
+			BeforeEach(func() {
+				args = append(args, "app-name")
+			})
+
+			Context("without an explicit bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "1080")
+				})
+
+				It("sets the dynamic forward spec", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.DynamicForwardSpecs).To(ConsistOf(options.DynamicForwardSpec{ListenAddress: "localhost:1080"}))
+				})
+			})
+
+			Context("with an explicit ipv6 bind address", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "[::1]:1080")
+				})
+
+				It("sets the dynamic forward spec", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.DynamicForwardSpecs).To(ConsistOf(options.DynamicForwardSpec{ListenAddress: "[::1]:1080"}))
+				})
+			})
+
+			Context("when the spec cannot be parsed", func() {
+				BeforeEach(func() {
+					args = append(args, "-D", "1080:remote:80")
+				})
+
+				It("returns an error", func() {
+					Expect(parseError).To(MatchError(`Unable to parse dynamic forwarding argument: "1080:remote:80"`))
+				})
+			})
+		})
+
+		Context("when --all-instances is specified", func() {
+			BeforeEach(func() {
+				args = append(args, "app-name", "--all-instances")
+			})
+
+			Context("with a command", func() {
+				BeforeEach(func() {
+					args = append(args, "-c", "cat /etc/hosts")
+				})
+
+				It("runs the command on all instances without a tty", func() {
+					Expect(parseError).NotTo(HaveOccurred())
+					Expect(opts.AllInstances).To(BeTrue())
+					Expect(opts.MaxInFlight).To(BeZero())
+					Expect(opts.TerminalRequest).To(Equal(options.RequestTTYNo))
+				})
+
+				Context("when --max-in-flight is specified", func() {
+					BeforeEach(func() {
+						args = append(args, "--max-in-flight", "5")
+					})
+
+					It("sets the concurrency limit", func() {
+						Expect(parseError).NotTo(HaveOccurred())
+						Expect(opts.MaxInFlight).To(BeEquivalentTo(5))
+					})
+				})
+
+				Context("when --max-in-flight is not positive", func() {
+					BeforeEach(func() {
+						args = append(args, "--max-in-flight", "0")
+					})
+
+					It("returns an error", func() {
+						Expect(parseError).To(MatchError("Value for flag 'max-in-flight' must be greater than zero"))
+					})
+				})
+
+				Context("when an instance index is also provided", func() {
+					BeforeEach(func() {
+						args = append(args, "-i", "2")
+					})
+
+					It("returns an error", func() {
+						Expect(parseError).To(MatchError("--all-instances cannot be used with --app-instance-index"))
+					})
+				})
+
+				Context("when port forwarding is also requested", func() {
+					BeforeEach(func() {
+						args = append(args, "-L", "9999:remote:8888")
+					})
+
+					It("returns an error", func() {
+						Expect(parseError).To(MatchError("--all-instances cannot be used with port forwarding or --skip-remote-execution"))
+					})
+				})
+
+				Context("when a tty is requested", func() {
+					BeforeEach(func() {
+						args = append(args, "-t")
+					})
+
+					It("returns an error", func() {
+						Expect(parseError).To(MatchError("--all-instances cannot be used with pseudo-tty allocation"))
+					})
+				})
+			})
+
+			Context("without a command", func() {
+				It("returns an error", func() {
+					Expect(parseError).To(MatchError("--all-instances requires a command to be specified with -c"))
+				})
+			})
+		})
+
+		Context("when --max-in-flight is specified without --all-instances", func() {
+			BeforeEach(func() {
+				args = append(args, "app-name", "--max-in-flight", "2")
+			})
+
+			It("returns an error", func() {
+				Expect(parseError).To(MatchError("--max-in-flight can only be used with --all-instances"))
+			})
+		})
+
 		Context("when -N is specified", func() {
 			BeforeEach(func() {
 				args = append(args, "app-name", "-N")
@@ -294,4 +482,23 @@ var _ = Describe("SSHOptions", func() {
 		})
 	})
 
+	Describe("ParseHostKeyChecking", func() {
+		It("parses the supported modes", func() {
+			for value, expected := range map[string]options.HostKeyChecking{
+				"":       options.HostKeyCheckingOff,
+				"off":    options.HostKeyCheckingOff,
+				"warn":   options.HostKeyCheckingWarn,
+				"strict": options.HostKeyCheckingStrict,
+			} {
+				mode, err := options.ParseHostKeyChecking(value)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mode).To(Equal(expected))
+			}
+		})
+
+		It("returns an error for an unknown mode", func() {
+			_, err := options.ParseHostKeyChecking("sometimes")
+			Expect(err).To(MatchError(`Invalid host key checking mode "sometimes", expected one of off, warn or strict`))
+		})
+	})
 })
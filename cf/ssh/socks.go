@@ -0,0 +1,116 @@
+package sshCmd
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+)
+
+const (
+	socksVersion5 = 0x05
+
+	socksMethodNoAuth       = 0x00
+	socksMethodNoAcceptable = 0xff
+
+	socksCommandConnect = 0x01
+
+	socksAddressIPv4   = 0x01
+	socksAddressDomain = 0x03
+	socksAddressIPv6   = 0x04
+
+	socksReplySucceeded           = 0x00
+	socksReplyHostUnreachable     = 0x04
+	socksReplyCommandNotSupported = 0x07
+	socksReplyAddressNotSupported = 0x08
+)
+
+// socksHandshake performs the server side of a SOCKS5 (RFC 1928) negotiation
+// without authentication and returns the address requested by a CONNECT
+// command. Any other command is rejected.
+func socksHandshake(conn io.ReadWriter) (string, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", err
+	}
+	if header[0] != socksVersion5 {
+		return "", fmt.Errorf("unsupported SOCKS version: %d", header[0])
+	}
+
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return "", err
+	}
+
+	method := byte(socksMethodNoAcceptable)
+	for _, m := range methods {
+		if m == socksMethodNoAuth {
+			method = socksMethodNoAuth
+			break
+		}
+	}
+	if _, err := conn.Write([]byte{socksVersion5, method}); err != nil {
+		return "", err
+	}
+	if method == socksMethodNoAcceptable {
+		return "", errors.New("no acceptable SOCKS authentication methods")
+	}
+
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(conn, request); err != nil {
+		return "", err
+	}
+	if request[0] != socksVersion5 {
+		return "", fmt.Errorf("unsupported SOCKS version: %d", request[0])
+	}
+
+	var host string
+	switch request[3] {
+	case socksAddressIPv4:
+		addr := make([]byte, net.IPv4len)
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			return "", err
+		}
+		host = net.IP(addr).String()
+	case socksAddressIPv6:
+		addr := make([]byte, net.IPv6len)
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			return "", err
+		}
+		host = net.IP(addr).String()
+	case socksAddressDomain:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return "", err
+		}
+		addr := make([]byte, length[0])
+		if _, err := io.ReadFull(conn, addr); err != nil {
+			return "", err
+		}
+		host = string(addr)
+	default:
+		_ = socksReply(conn, socksReplyAddressNotSupported)
+		return "", fmt.Errorf("unsupported SOCKS address type: %d", request[3])
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return "", err
+	}
+
+	if request[1] != socksCommandConnect {
+		_ = socksReply(conn, socksReplyCommandNotSupported)
+		return "", fmt.Errorf("unsupported SOCKS command: %d", request[1])
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port)))), nil
+}
+
+// socksReply writes a SOCKS5 reply with the given status. The bound address
+// is always reported as 0.0.0.0:0 since the connection is tunneled over SSH.
+func socksReply(conn io.Writer, status byte) error {
+	_, err := conn.Write([]byte{socksVersion5, status, 0x00, socksAddressIPv4, 0, 0, 0, 0, 0, 0})
+	return err
+}
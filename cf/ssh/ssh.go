@@ -38,6 +38,8 @@ type SecureShell interface {
 	Connect(opts *options.SSHOptions) error
 	InteractiveSession() error
 	LocalPortForward() error
+	RemotePortForward() error
+	DynamicPortForward() error
 	Wait() error
 	Close() error
 }
@@ -54,6 +56,7 @@ type SecureClient interface {
 	NewSession() (SecureSession, error)
 	Conn() ssh.Conn
 	Dial(network, address string) (net.Conn, error)
+	Listen(network, address string) (net.Listener, error)
 	Wait() error
 	Close() error
 }
@@ -90,7 +93,8 @@ type secureShell struct {
 	secureClient           SecureClient
 	opts                   *options.SSHOptions
 
-	localListeners []net.Listener
+	localListeners  []net.Listener
+	remoteListeners []net.Listener
 }
 
 func NewSecureShell(
@@ -113,6 +117,7 @@ func NewSecureShell(
 		sshEndpoint:            sshEndpoint,
 		token:                  token,
 		localListeners:         []net.Listener{},
+		remoteListeners:        []net.Listener{},
 	}
 }
 
@@ -127,7 +132,7 @@ func (c *secureShell) Connect(opts *options.SSHOptions) error {
 		Auth: []ssh.AuthMethod{
 			ssh.Password(c.token),
 		},
-		HostKeyCallback: fingerprintCallback(opts, c.sshEndpointFingerprint),
+		HostKeyCallback: c.hostKeyCallback(opts),
 	}
 
 	secureClient, err := c.secureDialer.Dial("tcp", c.sshEndpoint, clientConfig)
@@ -144,6 +149,9 @@ func (c *secureShell) Close() error {
 	for _, listener := range c.localListeners {
 		_ = listener.Close()
 	}
+	for _, listener := range c.remoteListeners {
+		_ = listener.Close()
+	}
 	return c.secureClient.Close()
 }
 
@@ -155,13 +163,41 @@ func (c *secureShell) LocalPortForward() error {
 		}
 		c.localListeners = append(c.localListeners, listener)
 
-		go c.localForwardAcceptLoop(listener, forwardSpec.ConnectAddress)
+		go c.forwardAcceptLoop(listener, c.localForwardHandler(forwardSpec.ConnectAddress))
+	}
+
+	return nil
+}
+
+func (c *secureShell) RemotePortForward() error {
+	for _, forwardSpec := range c.opts.RemoteForwardSpecs {
+		listener, err := c.secureClient.Listen("tcp", forwardSpec.ListenAddress)
+		if err != nil {
+			return err
+		}
+		c.remoteListeners = append(c.remoteListeners, listener)
+
+		go c.forwardAcceptLoop(listener, c.remoteForwardHandler(forwardSpec.ConnectAddress))
+	}
+
+	return nil
+}
+
+func (c *secureShell) DynamicPortForward() error {
+	for _, forwardSpec := range c.opts.DynamicForwardSpecs {
+		listener, err := c.listenerFactory.Listen("tcp", forwardSpec.ListenAddress)
+		if err != nil {
+			return err
+		}
+		c.localListeners = append(c.localListeners, listener)
+
+		go c.forwardAcceptLoop(listener, c.handleSOCKSConnection)
 	}
 
 	return nil
 }
 
-func (c *secureShell) localForwardAcceptLoop(listener net.Listener, addr string) {
+func (c *secureShell) forwardAcceptLoop(listener net.Listener, handler func(net.Conn)) {
 	defer listener.Close()
 
 	for {
@@ -174,20 +210,59 @@ func (c *secureShell) localForwardAcceptLoop(listener net.Listener, addr string)
 			return
 		}
 
-		go c.handleForwardConnection(conn, addr)
+		go handler(conn)
+	}
+}
+
+func (c *secureShell) localForwardHandler(targetAddr string) func(net.Conn) {
+	return func(conn net.Conn) {
+		c.handleForwardConnection(conn, targetAddr, c.secureClient.Dial)
+	}
+}
+
+func (c *secureShell) remoteForwardHandler(targetAddr string) func(net.Conn) {
+	return func(conn net.Conn) {
+		c.handleForwardConnection(conn, targetAddr, net.Dial)
+	}
+}
+
+func (c *secureShell) handleForwardConnection(conn net.Conn, targetAddr string, dial func(network, address string) (net.Conn, error)) {
+	defer conn.Close()
+
+	target, err := dial("tcp", targetAddr)
+	if err != nil {
+		fmt.Printf("connect to %s failed: %s\n", targetAddr, err.Error())
+		return
 	}
+	defer target.Close()
+
+	proxyConnections(conn, target)
 }
 
-func (c *secureShell) handleForwardConnection(conn net.Conn, targetAddr string) {
+func (c *secureShell) handleSOCKSConnection(conn net.Conn) {
 	defer conn.Close()
 
+	targetAddr, err := socksHandshake(conn)
+	if err != nil {
+		return
+	}
+
 	target, err := c.secureClient.Dial("tcp", targetAddr)
 	if err != nil {
+		_ = socksReply(conn, socksReplyHostUnreachable)
 		fmt.Printf("connect to %s failed: %s\n", targetAddr, err.Error())
 		return
 	}
 	defer target.Close()
 
+	if err := socksReply(conn, socksReplySucceeded); err != nil {
+		return
+	}
+
+	proxyConnections(conn, target)
+}
+
+func proxyConnections(conn net.Conn, target net.Conn) {
 	wg := &sync.WaitGroup{}
 	wg.Add(2)
 
@@ -351,6 +426,25 @@ func base64Sha256Fingerprint(key ssh.PublicKey) string {
 
 type hostKeyCallback func(hostname string, remote net.Addr, key ssh.PublicKey) error
 
+func (c *secureShell) hostKeyCallback(opts *options.SSHOptions) hostKeyCallback {
+	callback := fingerprintCallback(opts, c.sshEndpointFingerprint)
+	if callback == nil || opts.HostKeyChecking == options.HostKeyCheckingOff || opts.KnownHostsFile == "" {
+		return callback
+	}
+
+	_, _, stderr := c.terminalHelper.StdStreams()
+	pinnedKeyCallback := knownHostsCallback(NewKnownHosts(opts.KnownHostsFile), opts.HostKeyChecking, c.sshEndpoint, stderr)
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := callback(hostname, remote, key)
+		if err != nil {
+			return err
+		}
+
+		return pinnedKeyCallback(hostname, remote, key)
+	}
+}
+
 func fingerprintCallback(opts *options.SSHOptions, expectedFingerprint string) hostKeyCallback {
 	if opts.SkipHostValidation {
 		return nil
@@ -479,6 +573,9 @@ func (sc *secureClient) Wait() error    { return sc.client.Wait() }
 func (sc *secureClient) Dial(n, addr string) (net.Conn, error) {
 	return sc.client.Dial(n, addr)
 }
+func (sc *secureClient) Listen(n, addr string) (net.Listener, error) {
+	return sc.client.Listen(n, addr)
+}
 func (sc *secureClient) NewSession() (SecureSession, error) {
 	return sc.client.NewSession()
 }
@@ -10,8 +10,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -317,6 +321,146 @@ var _ = Describe("SSH", func() {
 					Eventually(err).Should(MatchError(MatchRegexp("Unsupported host key fingerprint format")))
 				})
 			})
+
+			Context("when a known hosts file is configured", func() {
+				var (
+					knownHostsDir  string
+					knownHostsFile string
+					stderr         *fake_io.FakeWriteCloser
+					warnings       []byte
+				)
+
+				BeforeEach(func() {
+					sshEndpointFingerprint = "sp/jrLuj66r+yrLDUKZdJU5tdzt4mq/UaSiNBjpgr+8"
+
+					var err error
+					knownHostsDir, err = ioutil.TempDir("", "known-hosts")
+					Expect(err).NotTo(HaveOccurred())
+					knownHostsFile = filepath.Join(knownHostsDir, "known_hosts")
+
+					opts.KnownHostsFile = knownHostsFile
+					opts.HostKeyChecking = options.HostKeyCheckingWarn
+
+					warnings = nil
+					stderr = &fake_io.FakeWriteCloser{}
+					stderr.WriteStub = func(p []byte) (int, error) {
+						warnings = append(warnings, p...)
+						return len(p), nil
+					}
+					fakeTerminalHelper.StdStreamsReturns(os.Stdin, os.Stdout, stderr)
+					terminalHelper = fakeTerminalHelper
+				})
+
+				AfterEach(func() {
+					os.RemoveAll(knownHostsDir)
+				})
+
+				Context("when the host has not been seen before", func() {
+					It("pins the host key in the known hosts file", func() {
+						Expect(callback("", addr, TestHostKey.PublicKey())).To(Succeed())
+
+						contents, err := ioutil.ReadFile(knownHostsFile)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(string(contents)).To(Equal("ssh.example.com " + string(ssh.MarshalAuthorizedKey(TestHostKey.PublicKey()))))
+					})
+
+					Context("when the endpoint does not use the default port", func() {
+						BeforeEach(func() {
+							sshEndpoint = "ssh.example.com:2222"
+						})
+
+						It("pins the key for the host and port", func() {
+							Expect(callback("", addr, TestHostKey.PublicKey())).To(Succeed())
+
+							contents, err := ioutil.ReadFile(knownHostsFile)
+							Expect(err).NotTo(HaveOccurred())
+							Expect(string(contents)).To(HavePrefix("[ssh.example.com]:2222 ssh-rsa "))
+						})
+					})
+				})
+
+				Context("when several sessions see the host for the first time at once", func() {
+					It("pins the host key once", func() {
+						start := make(chan struct{})
+						wg := sync.WaitGroup{}
+						for i := 0; i < 20; i++ {
+							wg.Add(1)
+							go func() {
+								defer GinkgoRecover()
+								defer wg.Done()
+								<-start
+								Expect(callback("", addr, TestHostKey.PublicKey())).To(Succeed())
+							}()
+						}
+						close(start)
+						wg.Wait()
+
+						contents, err := ioutil.ReadFile(knownHostsFile)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(strings.Count(string(contents), "\n")).To(Equal(1))
+					})
+				})
+
+				Context("when the pinned key matches", func() {
+					BeforeEach(func() {
+						err := sshCmd.NewKnownHosts(knownHostsFile).Add("ssh.example.com:22", TestHostKey.PublicKey())
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("does not return an error or pin the key again", func() {
+						Expect(callback("", addr, TestHostKey.PublicKey())).To(Succeed())
+
+						contents, err := ioutil.ReadFile(knownHostsFile)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(strings.Count(string(contents), "\n")).To(Equal(1))
+						Expect(warnings).To(BeEmpty())
+					})
+				})
+
+				Context("when the pinned key has changed", func() {
+					BeforeEach(func() {
+						err := ioutil.WriteFile(knownHostsFile, []byte("# comment\nother.example.com "+string(ssh.MarshalAuthorizedKey(TestHostKey.PublicKey()))), 0600)
+						Expect(err).NotTo(HaveOccurred())
+						err = sshCmd.NewKnownHosts(knownHostsFile).Add("ssh.example.com:22", TestPrivateKey.PublicKey())
+						Expect(err).NotTo(HaveOccurred())
+					})
+
+					It("writes a warning and continues", func() {
+						Expect(callback("", addr, TestHostKey.PublicKey())).To(Succeed())
+						Expect(string(warnings)).To(ContainSubstring("WARNING: The host key for ssh.example.com has changed"))
+						Expect(string(warnings)).To(ContainSubstring("remove line 3 of " + knownHostsFile))
+					})
+
+					Context("when strict host key checking is enabled", func() {
+						BeforeEach(func() {
+							opts.HostKeyChecking = options.HostKeyCheckingStrict
+						})
+
+						It("returns a HostKeyChangedError", func() {
+							err := callback("", addr, TestHostKey.PublicKey())
+							Expect(err).To(Equal(sshCmd.HostKeyChangedError{
+								Host:                "ssh.example.com",
+								KnownHostsFile:      knownHostsFile,
+								Line:                3,
+								ReceivedFingerprint: "sp/jrLuj66r+yrLDUKZdJU5tdzt4mq/UaSiNBjpgr+8",
+							}))
+							Expect(err).To(MatchError(MatchRegexp("Host key verification failed\\.")))
+						})
+					})
+				})
+
+				Context("when host key checking is off", func() {
+					BeforeEach(func() {
+						opts.HostKeyChecking = options.HostKeyCheckingOff
+					})
+
+					It("does not create the known hosts file", func() {
+						Expect(callback("", addr, TestHostKey.PublicKey())).To(Succeed())
+						_, err := os.Stat(knownHostsFile)
+						Expect(os.IsNotExist(err)).To(BeTrue())
+					})
+				})
+			})
 		})
 
 		Context("when the skip host validation flag is set", func() {
@@ -1165,6 +1309,252 @@ var _ = Describe("SSH", func() {
 		})
 	})
 
+	Describe("RemotePortForward", func() {
+		var (
+			opts               *options.SSHOptions
+			remoteForwardError error
+
+			echoAddress  string
+			echoListener net.Listener
+
+			remoteListener net.Listener
+		)
+
+		BeforeEach(func() {
+			var err error
+			echoListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			echoAddress = echoListener.Addr().String()
+
+			go func() {
+				for {
+					conn, err := echoListener.Accept()
+					if err != nil {
+						return
+					}
+					go func() {
+						io.Copy(conn, conn)
+						conn.Close()
+					}()
+				}
+			}()
+
+			remoteListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			fakeSecureClient.ListenReturns(remoteListener, nil)
+
+			opts = &options.SSHOptions{
+				AppName: "app-1",
+				RemoteForwardSpecs: []options.ForwardSpec{{
+					ListenAddress:  "localhost:9999",
+					ConnectAddress: echoAddress,
+				}},
+			}
+
+			currentApp.State = "STARTED"
+			currentApp.Diego = true
+		})
+
+		JustBeforeEach(func() {
+			connectErr := secureShell.Connect(opts)
+			Expect(connectErr).NotTo(HaveOccurred())
+
+			remoteForwardError = secureShell.RemotePortForward()
+		})
+
+		AfterEach(func() {
+			err := secureShell.Close()
+			Expect(err).NotTo(HaveOccurred())
+			echoListener.Close()
+		})
+
+		It("requests a listener on the remote side with the listen address", func() {
+			Expect(remoteForwardError).NotTo(HaveOccurred())
+			Expect(fakeSecureClient.ListenCallCount()).To(Equal(1))
+
+			network, addr := fakeSecureClient.ListenArgsForCall(0)
+			Expect(network).To(Equal("tcp"))
+			Expect(addr).To(Equal("localhost:9999"))
+		})
+
+		It("copies data between remote connections and the local connect address", func() {
+			conn, err := net.Dial("tcp", remoteListener.Addr().String())
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			msg := "Hello from the app container\n"
+			_, err = conn.Write([]byte(msg))
+			Expect(err).NotTo(HaveOccurred())
+
+			response := make([]byte, len(msg))
+			_, err = io.ReadFull(conn, response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(response)).To(Equal(msg))
+
+			Expect(fakeSecureClient.DialCallCount()).To(Equal(0))
+		})
+
+		It("closes the remote listeners when the shell is closed", func() {
+			Expect(secureShell.Close()).To(Succeed())
+
+			_, err := remoteListener.Accept()
+			Expect(err).To(HaveOccurred())
+		})
+
+		Context("when the remote listen request fails", func() {
+			BeforeEach(func() {
+				fakeSecureClient.ListenReturns(nil, errors.New("tcpip-forward request denied by peer"))
+			})
+
+			It("returns the error", func() {
+				Expect(remoteForwardError).To(MatchError("tcpip-forward request denied by peer"))
+			})
+		})
+	})
+
+	Describe("DynamicPortForward", func() {
+		var (
+			opts                *options.SSHOptions
+			dynamicForwardError error
+
+			echoAddress  string
+			echoListener net.Listener
+
+			localAddress string
+		)
+
+		BeforeEach(func() {
+			var err error
+			echoListener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			echoAddress = echoListener.Addr().String()
+
+			go func() {
+				for {
+					conn, err := echoListener.Accept()
+					if err != nil {
+						return
+					}
+					go func() {
+						io.Copy(conn, conn)
+						conn.Close()
+					}()
+				}
+			}()
+
+			localListener, err := net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			localAddress = localListener.Addr().String()
+			fakeListenerFactory.ListenReturns(localListener, nil)
+
+			opts = &options.SSHOptions{
+				AppName: "app-1",
+				DynamicForwardSpecs: []options.DynamicForwardSpec{{
+					ListenAddress: "localhost:1080",
+				}},
+			}
+
+			currentApp.State = "STARTED"
+			currentApp.Diego = true
+
+			fakeSecureClient.DialStub = net.Dial
+		})
+
+		JustBeforeEach(func() {
+			connectErr := secureShell.Connect(opts)
+			Expect(connectErr).NotTo(HaveOccurred())
+
+			dynamicForwardError = secureShell.DynamicPortForward()
+		})
+
+		AfterEach(func() {
+			err := secureShell.Close()
+			Expect(err).NotTo(HaveOccurred())
+			echoListener.Close()
+		})
+
+		socksConnect := func(host string, port int) (net.Conn, []byte) {
+			conn, err := net.Dial("tcp", localAddress)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = conn.Write([]byte{0x05, 0x01, 0x00})
+			Expect(err).NotTo(HaveOccurred())
+
+			methodReply := make([]byte, 2)
+			_, err = io.ReadFull(conn, methodReply)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(methodReply).To(Equal([]byte{0x05, 0x00}))
+
+			request := []byte{0x05, 0x01, 0x00, 0x03, byte(len(host))}
+			request = append(request, []byte(host)...)
+			request = append(request, byte(port>>8), byte(port))
+			_, err = conn.Write(request)
+			Expect(err).NotTo(HaveOccurred())
+
+			reply := make([]byte, 10)
+			_, err = io.ReadFull(conn, reply)
+			Expect(err).NotTo(HaveOccurred())
+
+			return conn, reply
+		}
+
+		It("listens on the dynamic forward address", func() {
+			Expect(dynamicForwardError).NotTo(HaveOccurred())
+
+			network, addr := fakeListenerFactory.ListenArgsForCall(0)
+			Expect(network).To(Equal("tcp"))
+			Expect(addr).To(Equal("localhost:1080"))
+		})
+
+		It("dials the address requested by the SOCKS client through the secure client", func() {
+			host, port, err := net.SplitHostPort(echoAddress)
+			Expect(err).NotTo(HaveOccurred())
+			var portNum int
+			_, err = fmt.Sscanf(port, "%d", &portNum)
+			Expect(err).NotTo(HaveOccurred())
+
+			conn, reply := socksConnect(host, portNum)
+			defer conn.Close()
+			Expect(reply[1]).To(Equal(byte(0x00)))
+
+			network, addr := fakeSecureClient.DialArgsForCall(0)
+			Expect(network).To(Equal("tcp"))
+			Expect(addr).To(Equal(echoAddress))
+
+			msg := "Hello over SOCKS\n"
+			_, err = conn.Write([]byte(msg))
+			Expect(err).NotTo(HaveOccurred())
+
+			response := make([]byte, len(msg))
+			_, err = io.ReadFull(conn, response)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(response)).To(Equal(msg))
+		})
+
+		Context("when dialing the requested address fails", func() {
+			BeforeEach(func() {
+				fakeSecureClient.DialStub = nil
+				fakeSecureClient.DialReturns(nil, errors.New("connection refused"))
+			})
+
+			It("replies with a host unreachable status", func() {
+				conn, reply := socksConnect("internal.example.com", 8080)
+				defer conn.Close()
+				Expect(reply[1]).To(Equal(byte(0x04)))
+			})
+		})
+
+		Context("when listen fails", func() {
+			BeforeEach(func() {
+				fakeListenerFactory.ListenReturns(nil, errors.New("address in use"))
+			})
+
+			It("returns the error", func() {
+				Expect(dynamicForwardError).To(MatchError("address in use"))
+			})
+		})
+	})
+
 	Describe("Wait", func() {
 		var opts *options.SSHOptions
 		var waitErr error
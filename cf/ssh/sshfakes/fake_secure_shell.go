@@ -29,6 +29,18 @@ type FakeSecureShell struct {
 	localPortForwardReturns     struct {
 		result1 error
 	}
+	RemotePortForwardStub        func() error
+	remotePortForwardMutex       sync.RWMutex
+	remotePortForwardArgsForCall []struct{}
+	remotePortForwardReturns     struct {
+		result1 error
+	}
+	DynamicPortForwardStub        func() error
+	dynamicPortForwardMutex       sync.RWMutex
+	dynamicPortForwardArgsForCall []struct{}
+	dynamicPortForwardReturns     struct {
+		result1 error
+	}
 	WaitStub        func() error
 	waitMutex       sync.RWMutex
 	waitArgsForCall []struct{}
@@ -128,6 +140,56 @@ func (fake *FakeSecureShell) LocalPortForwardReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeSecureShell) RemotePortForward() error {
+	fake.remotePortForwardMutex.Lock()
+	fake.remotePortForwardArgsForCall = append(fake.remotePortForwardArgsForCall, struct{}{})
+	fake.recordInvocation("RemotePortForward", []interface{}{})
+	fake.remotePortForwardMutex.Unlock()
+	if fake.RemotePortForwardStub != nil {
+		return fake.RemotePortForwardStub()
+	} else {
+		return fake.remotePortForwardReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) RemotePortForwardCallCount() int {
+	fake.remotePortForwardMutex.RLock()
+	defer fake.remotePortForwardMutex.RUnlock()
+	return len(fake.remotePortForwardArgsForCall)
+}
+
+func (fake *FakeSecureShell) RemotePortForwardReturns(result1 error) {
+	fake.RemotePortForwardStub = nil
+	fake.remotePortForwardReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeSecureShell) DynamicPortForward() error {
+	fake.dynamicPortForwardMutex.Lock()
+	fake.dynamicPortForwardArgsForCall = append(fake.dynamicPortForwardArgsForCall, struct{}{})
+	fake.recordInvocation("DynamicPortForward", []interface{}{})
+	fake.dynamicPortForwardMutex.Unlock()
+	if fake.DynamicPortForwardStub != nil {
+		return fake.DynamicPortForwardStub()
+	} else {
+		return fake.dynamicPortForwardReturns.result1
+	}
+}
+
+func (fake *FakeSecureShell) DynamicPortForwardCallCount() int {
+	fake.dynamicPortForwardMutex.RLock()
+	defer fake.dynamicPortForwardMutex.RUnlock()
+	return len(fake.dynamicPortForwardArgsForCall)
+}
+
+func (fake *FakeSecureShell) DynamicPortForwardReturns(result1 error) {
+	fake.DynamicPortForwardStub = nil
+	fake.dynamicPortForwardReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeSecureShell) Wait() error {
 	fake.waitMutex.Lock()
 	fake.waitArgsForCall = append(fake.waitArgsForCall, struct{}{})
@@ -187,6 +249,10 @@ func (fake *FakeSecureShell) Invocations() map[string][][]interface{} {
 	defer fake.interactiveSessionMutex.RUnlock()
 	fake.localPortForwardMutex.RLock()
 	defer fake.localPortForwardMutex.RUnlock()
+	fake.remotePortForwardMutex.RLock()
+	defer fake.remotePortForwardMutex.RUnlock()
+	fake.dynamicPortForwardMutex.RLock()
+	defer fake.dynamicPortForwardMutex.RUnlock()
 	fake.waitMutex.RLock()
 	defer fake.waitMutex.RUnlock()
 	fake.closeMutex.RLock()
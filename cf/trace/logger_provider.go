@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	. "code.cloudfoundry.org/cli/cf/i18n"
 )
@@ -16,11 +17,19 @@ func NewLogger(writer io.Writer, verbose bool, cfTrace, configTrace string) Prin
 
 	stdoutLogger := NewWriterPrinter(writer, true)
 
+	// CF_TRACE=true or false overrides a true or false trace setting in the
+	// config, the same as for the commands in command/.
+	if _, err := strconv.ParseBool(cfTrace); err == nil {
+		if _, err := strconv.ParseBool(configTrace); err == nil {
+			configTrace = ""
+		}
+	}
+
 	for _, path := range []string{cfTrace, configTrace} {
 		b, err := strconv.ParseBool(path)
 		LoggingToStdout = LoggingToStdout || b
 
-		if path != "" && err != nil {
+		if path != "" && err != nil && !harFormat() {
 			var file *os.File
 			err = os.MkdirAll(filepath.Dir(path), os.ModeDir|os.ModePerm)
 			if err == nil {
@@ -44,3 +53,24 @@ func NewLogger(writer io.Writer, verbose bool, cfTrace, configTrace string) Prin
 
 	return CombinePrinters(printers)
 }
+
+// HARPaths returns the trace file paths that should be written as HTTP
+// Archives instead of by the logger returned by NewLogger. It is empty unless
+// CF_TRACE_FORMAT is har.
+func HARPaths(cfTrace, configTrace string) []string {
+	if !harFormat() {
+		return nil
+	}
+
+	var paths []string
+	for _, path := range []string{cfTrace, configTrace} {
+		if _, err := strconv.ParseBool(path); path != "" && err != nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+func harFormat() bool {
+	return strings.EqualFold(os.Getenv("CF_TRACE_FORMAT"), "har")
+}
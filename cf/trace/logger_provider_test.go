@@ -70,6 +70,14 @@ var _ = Describe("NewLogger", func() {
 		Expect(err).To(HaveOccurred())
 	})
 
+	It("returns a logger that doesn't write anywhere when CF_TRACE=false and config.trace=true", func() {
+		logger := NewLogger(buffer, false, "false", "true")
+
+		logger.Print("Hello World")
+
+		Expect(buffer).NotTo(gbytes.Say("Hello World"))
+	})
+
 	It("returns a logger that only writes to STDOUT when verbose is set and config.trace=false", func() {
 		logger := NewLogger(buffer, true, "", "false")
 
@@ -202,4 +210,37 @@ var _ = Describe("NewLogger", func() {
 			Expect(buffer).To(gbytes.Say("Hello World"))
 		}
 	})
+
+	Context("when CF_TRACE_FORMAT is har", func() {
+		var originalFormat string
+
+		BeforeEach(func() {
+			originalFormat = os.Getenv("CF_TRACE_FORMAT")
+			os.Setenv("CF_TRACE_FORMAT", "har")
+		})
+
+		AfterEach(func() {
+			os.Setenv("CF_TRACE_FORMAT", originalFormat)
+		})
+
+		It("returns a logger that does not write text to the trace files", func() {
+			fileutils.TempFile("trace_test", func(file *os.File, err error) {
+				logger := NewLogger(buffer, false, file.Name(), "")
+
+				logger.Print("Hello World")
+
+				fileContents, _ := ioutil.ReadAll(file)
+				Expect(fileContents).To(BeEmpty())
+			})
+		})
+
+		It("returns the trace file paths from HARPaths", func() {
+			Expect(HARPaths("/some/trace.har", "true")).To(Equal([]string{"/some/trace.har"}))
+			Expect(HARPaths("true", "/config/trace.har")).To(Equal([]string{"/config/trace.har"}))
+		})
+	})
+
+	It("returns no HARPaths when CF_TRACE_FORMAT is not set", func() {
+		Expect(HARPaths("/some/trace.log", "")).To(BeEmpty())
+	})
 })
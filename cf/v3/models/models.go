@@ -27,3 +27,14 @@ type V3Route struct {
 	Host string `json:"host"`
 	Path string `json:"path"`
 }
+
+type V3Task struct {
+	GUID       string `json:"guid"`
+	SequenceID int    `json:"sequence_id"`
+	Name       string `json:"name"`
+	Command    string `json:"command"`
+	State      string `json:"state"`
+	MemoryInMB int64  `json:"memory_in_mb"`
+	DiskInMB   int64  `json:"disk_in_mb"`
+	CreatedAt  string `json:"created_at"`
+}
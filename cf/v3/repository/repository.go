@@ -15,6 +15,7 @@ type Repository interface {
 	GetApplications() ([]models.V3Application, error)
 	GetProcesses(path string) ([]models.V3Process, error)
 	GetRoutes(path string) ([]models.V3Route, error)
+	GetTasks(path string) ([]models.V3Task, error)
 }
 
 type repository struct {
@@ -87,3 +88,20 @@ func (r *repository) GetRoutes(path string) ([]models.V3Route, error) {
 
 	return routes, nil
 }
+
+func (r *repository) GetTasks(path string) ([]models.V3Task, error) {
+	jsonResponse, err := r.client.GetResources(path, 0)
+	if err != nil {
+		return []models.V3Task{}, err
+	}
+
+	r.handleUpdatedTokens()
+
+	tasks := []models.V3Task{}
+	err = json.Unmarshal(jsonResponse, &tasks)
+	if err != nil {
+		return []models.V3Task{}, err
+	}
+
+	return tasks, nil
+}
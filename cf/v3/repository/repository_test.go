@@ -208,6 +208,59 @@ var _ = Describe("Repository", func() {
 			})
 		})
 	})
+
+	Describe("GetTasks", func() {
+		It("tries to get tasks from CC", func() {
+			r.GetTasks("/the-path")
+			Expect(ccClient.GetResourcesCallCount()).To(Equal(1))
+			Expect(ccClient.GetResourcesArgsForCall(0)).To(Equal("/the-path"))
+		})
+
+		Context("when getting the tasks fails", func() {
+			BeforeEach(func() {
+				ccClient.GetResourcesReturns([]byte{}, errors.New("get-tasks-err"))
+			})
+
+			It("returns an error", func() {
+				_, err := r.GetTasks("/the-path")
+				Expect(err).To(MatchError("get-tasks-err"))
+			})
+		})
+
+		Context("when getting the tasks succeeds", func() {
+			BeforeEach(func() {
+				ccClient.GetResourcesReturns([]byte(`[
+					{
+						"guid": "task-1-guid",
+						"sequence_id": 1,
+						"name": "task-1-name",
+						"command": "rake db:migrate",
+						"state": "SUCCEEDED",
+						"memory_in_mb": 256,
+						"disk_in_mb": 1024,
+						"created_at": "2016-11-08T22:26:02Z"
+					}
+				]`), nil)
+			})
+
+			It("returns a slice of task model objects", func() {
+				tasks, err := r.GetTasks("/the-path")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(tasks).To(Equal([]models.V3Task{
+					{
+						GUID:       "task-1-guid",
+						SequenceID: 1,
+						Name:       "task-1-name",
+						Command:    "rake db:migrate",
+						State:      "SUCCEEDED",
+						MemoryInMB: 256,
+						DiskInMB:   1024,
+						CreatedAt:  "2016-11-08T22:26:02Z",
+					},
+				}))
+			})
+		})
+	})
 })
 
 var getApplicationsJSON = []byte(`[
@@ -34,6 +34,15 @@ type FakeRepository struct {
 		result1 []models.V3Route
 		result2 error
 	}
+	GetTasksStub        func(path string) ([]models.V3Task, error)
+	getTasksMutex       sync.RWMutex
+	getTasksArgsForCall []struct {
+		path string
+	}
+	getTasksReturns struct {
+		result1 []models.V3Task
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -132,6 +141,40 @@ func (fake *FakeRepository) GetRoutesReturns(result1 []models.V3Route, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeRepository) GetTasks(path string) ([]models.V3Task, error) {
+	fake.getTasksMutex.Lock()
+	fake.getTasksArgsForCall = append(fake.getTasksArgsForCall, struct {
+		path string
+	}{path})
+	fake.recordInvocation("GetTasks", []interface{}{path})
+	fake.getTasksMutex.Unlock()
+	if fake.GetTasksStub != nil {
+		return fake.GetTasksStub(path)
+	} else {
+		return fake.getTasksReturns.result1, fake.getTasksReturns.result2
+	}
+}
+
+func (fake *FakeRepository) GetTasksCallCount() int {
+	fake.getTasksMutex.RLock()
+	defer fake.getTasksMutex.RUnlock()
+	return len(fake.getTasksArgsForCall)
+}
+
+func (fake *FakeRepository) GetTasksArgsForCall(i int) string {
+	fake.getTasksMutex.RLock()
+	defer fake.getTasksMutex.RUnlock()
+	return fake.getTasksArgsForCall[i].path
+}
+
+func (fake *FakeRepository) GetTasksReturns(result1 []models.V3Task, result2 error) {
+	fake.GetTasksStub = nil
+	fake.getTasksReturns = struct {
+		result1 []models.V3Task
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -141,6 +184,8 @@ func (fake *FakeRepository) Invocations() map[string][][]interface{} {
 	defer fake.getProcessesMutex.RUnlock()
 	fake.getRoutesMutex.RLock()
 	defer fake.getRoutesMutex.RUnlock()
+	fake.getTasksMutex.RLock()
+	defer fake.getTasksMutex.RUnlock()
 	return fake.invocations
 }
 
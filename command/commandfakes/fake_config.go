@@ -5,8 +5,11 @@ import (
 	"sync"
 	"time"
 
+	"code.cloudfoundry.org/cli/api/retry"
 	"code.cloudfoundry.org/cli/command"
 	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/contexts"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 )
 
 type FakeConfig struct {
@@ -22,6 +25,14 @@ type FakeConfig struct {
 	accessTokenReturns     struct {
 		result1 string
 	}
+	AddContextStub        func(name string) error
+	addContextMutex       sync.RWMutex
+	addContextArgsForCall []struct {
+		name string
+	}
+	addContextReturns struct {
+		result1 error
+	}
 	BinaryNameStub        func() string
 	binaryNameMutex       sync.RWMutex
 	binaryNameArgsForCall []struct{}
@@ -34,12 +45,38 @@ type FakeConfig struct {
 	binaryVersionReturns     struct {
 		result1 string
 	}
+	CacheDirectoryStub        func() string
+	cacheDirectoryMutex       sync.RWMutex
+	cacheDirectoryArgsForCall []struct{}
+	cacheDirectoryReturns     struct {
+		result1 string
+	}
+	CacheTTLStub        func() time.Duration
+	cacheTTLMutex       sync.RWMutex
+	cacheTTLArgsForCall []struct{}
+	cacheTTLReturns     struct {
+		result1 time.Duration
+	}
 	ColorEnabledStub        func() configv3.ColorSetting
 	colorEnabledMutex       sync.RWMutex
 	colorEnabledArgsForCall []struct{}
 	colorEnabledReturns     struct {
 		result1 configv3.ColorSetting
 	}
+	ConcurrencyStub        func() int
+	concurrencyMutex       sync.RWMutex
+	concurrencyArgsForCall []struct{}
+	concurrencyReturns     struct {
+		result1 int
+	}
+	ContextsStub        func() ([]contexts.Context, string, error)
+	contextsMutex       sync.RWMutex
+	contextsArgsForCall []struct{}
+	contextsReturns     struct {
+		result1 []contexts.Context
+		result2 string
+		result3 error
+	}
 	CurrentUserStub        func() (configv3.User, error)
 	currentUserMutex       sync.RWMutex
 	currentUserArgsForCall []struct{}
@@ -47,12 +84,26 @@ type FakeConfig struct {
 		result1 configv3.User
 		result2 error
 	}
+	DeleteContextStub        func(name string) error
+	deleteContextMutex       sync.RWMutex
+	deleteContextArgsForCall []struct {
+		name string
+	}
+	deleteContextReturns struct {
+		result1 error
+	}
 	DialTimeoutStub        func() time.Duration
 	dialTimeoutMutex       sync.RWMutex
 	dialTimeoutArgsForCall []struct{}
 	dialTimeoutReturns     struct {
 		result1 time.Duration
 	}
+	RetryPolicyStub        func() retry.Policy
+	retryPolicyMutex       sync.RWMutex
+	retryPolicyArgsForCall []struct{}
+	retryPolicyReturns     struct {
+		result1 retry.Policy
+	}
 	ExperimentalStub        func() bool
 	experimentalMutex       sync.RWMutex
 	experimentalArgsForCall []struct{}
@@ -149,6 +200,17 @@ type FakeConfig struct {
 		refreshToken   string
 		sshOAuthClient string
 	}
+	SetUAAClientCredentialsStub        func(client string, clientSecret string)
+	setUAAClientCredentialsMutex       sync.RWMutex
+	setUAAClientCredentialsArgsForCall []struct {
+		client       string
+		clientSecret string
+	}
+	SetUAAGrantTypeStub        func(uaaGrantType string)
+	setUAAGrantTypeMutex       sync.RWMutex
+	setUAAGrantTypeArgsForCall []struct {
+		uaaGrantType string
+	}
 	StagingTimeoutStub        func() time.Duration
 	stagingTimeoutMutex       sync.RWMutex
 	stagingTimeoutArgsForCall []struct{}
@@ -185,12 +247,38 @@ type FakeConfig struct {
 	targetedSpaceReturns     struct {
 		result1 configv3.Space
 	}
+	TLSFilesStub        func() tlsconfig.Files
+	tLSFilesMutex       sync.RWMutex
+	tLSFilesArgsForCall []struct{}
+	tLSFilesReturns     struct {
+		result1 tlsconfig.Files
+	}
+	TraceFormatStub        func() string
+	traceFormatMutex       sync.RWMutex
+	traceFormatArgsForCall []struct{}
+	traceFormatReturns     struct {
+		result1 string
+	}
+	UseContextStub        func(name string) error
+	useContextMutex       sync.RWMutex
+	useContextArgsForCall []struct {
+		name string
+	}
+	useContextReturns struct {
+		result1 error
+	}
 	UAAOAuthClientStub        func() string
 	uAAOAuthClientMutex       sync.RWMutex
 	uAAOAuthClientArgsForCall []struct{}
 	uAAOAuthClientReturns     struct {
 		result1 string
 	}
+	UAAGrantTypeStub        func() string
+	uAAGrantTypeMutex       sync.RWMutex
+	uAAGrantTypeArgsForCall []struct{}
+	uAAGrantTypeReturns     struct {
+		result1 string
+	}
 	UAAOAuthClientSecretStub        func() string
 	uAAOAuthClientSecretMutex       sync.RWMutex
 	uAAOAuthClientSecretArgsForCall []struct{}
@@ -264,6 +352,39 @@ func (fake *FakeConfig) AccessTokenReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeConfig) AddContext(name string) error {
+	fake.addContextMutex.Lock()
+	fake.addContextArgsForCall = append(fake.addContextArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("AddContext", []interface{}{name})
+	fake.addContextMutex.Unlock()
+	if fake.AddContextStub != nil {
+		return fake.AddContextStub(name)
+	} else {
+		return fake.addContextReturns.result1
+	}
+}
+
+func (fake *FakeConfig) AddContextCallCount() int {
+	fake.addContextMutex.RLock()
+	defer fake.addContextMutex.RUnlock()
+	return len(fake.addContextArgsForCall)
+}
+
+func (fake *FakeConfig) AddContextArgsForCall(i int) string {
+	fake.addContextMutex.RLock()
+	defer fake.addContextMutex.RUnlock()
+	return fake.addContextArgsForCall[i].name
+}
+
+func (fake *FakeConfig) AddContextReturns(result1 error) {
+	fake.AddContextStub = nil
+	fake.addContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeConfig) BinaryName() string {
 	fake.binaryNameMutex.Lock()
 	fake.binaryNameArgsForCall = append(fake.binaryNameArgsForCall, struct{}{})
@@ -314,6 +435,56 @@ func (fake *FakeConfig) BinaryVersionReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeConfig) CacheDirectory() string {
+	fake.cacheDirectoryMutex.Lock()
+	fake.cacheDirectoryArgsForCall = append(fake.cacheDirectoryArgsForCall, struct{}{})
+	fake.recordInvocation("CacheDirectory", []interface{}{})
+	fake.cacheDirectoryMutex.Unlock()
+	if fake.CacheDirectoryStub != nil {
+		return fake.CacheDirectoryStub()
+	} else {
+		return fake.cacheDirectoryReturns.result1
+	}
+}
+
+func (fake *FakeConfig) CacheDirectoryCallCount() int {
+	fake.cacheDirectoryMutex.RLock()
+	defer fake.cacheDirectoryMutex.RUnlock()
+	return len(fake.cacheDirectoryArgsForCall)
+}
+
+func (fake *FakeConfig) CacheDirectoryReturns(result1 string) {
+	fake.CacheDirectoryStub = nil
+	fake.cacheDirectoryReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeConfig) CacheTTL() time.Duration {
+	fake.cacheTTLMutex.Lock()
+	fake.cacheTTLArgsForCall = append(fake.cacheTTLArgsForCall, struct{}{})
+	fake.recordInvocation("CacheTTL", []interface{}{})
+	fake.cacheTTLMutex.Unlock()
+	if fake.CacheTTLStub != nil {
+		return fake.CacheTTLStub()
+	} else {
+		return fake.cacheTTLReturns.result1
+	}
+}
+
+func (fake *FakeConfig) CacheTTLCallCount() int {
+	fake.cacheTTLMutex.RLock()
+	defer fake.cacheTTLMutex.RUnlock()
+	return len(fake.cacheTTLArgsForCall)
+}
+
+func (fake *FakeConfig) CacheTTLReturns(result1 time.Duration) {
+	fake.CacheTTLStub = nil
+	fake.cacheTTLReturns = struct {
+		result1 time.Duration
+	}{result1}
+}
+
 func (fake *FakeConfig) ColorEnabled() configv3.ColorSetting {
 	fake.colorEnabledMutex.Lock()
 	fake.colorEnabledArgsForCall = append(fake.colorEnabledArgsForCall, struct{}{})
@@ -339,6 +510,58 @@ func (fake *FakeConfig) ColorEnabledReturns(result1 configv3.ColorSetting) {
 	}{result1}
 }
 
+func (fake *FakeConfig) Concurrency() int {
+	fake.concurrencyMutex.Lock()
+	fake.concurrencyArgsForCall = append(fake.concurrencyArgsForCall, struct{}{})
+	fake.recordInvocation("Concurrency", []interface{}{})
+	fake.concurrencyMutex.Unlock()
+	if fake.ConcurrencyStub != nil {
+		return fake.ConcurrencyStub()
+	} else {
+		return fake.concurrencyReturns.result1
+	}
+}
+
+func (fake *FakeConfig) ConcurrencyCallCount() int {
+	fake.concurrencyMutex.RLock()
+	defer fake.concurrencyMutex.RUnlock()
+	return len(fake.concurrencyArgsForCall)
+}
+
+func (fake *FakeConfig) ConcurrencyReturns(result1 int) {
+	fake.ConcurrencyStub = nil
+	fake.concurrencyReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeConfig) Contexts() ([]contexts.Context, string, error) {
+	fake.contextsMutex.Lock()
+	fake.contextsArgsForCall = append(fake.contextsArgsForCall, struct{}{})
+	fake.recordInvocation("Contexts", []interface{}{})
+	fake.contextsMutex.Unlock()
+	if fake.ContextsStub != nil {
+		return fake.ContextsStub()
+	} else {
+		return fake.contextsReturns.result1, fake.contextsReturns.result2, fake.contextsReturns.result3
+	}
+}
+
+func (fake *FakeConfig) ContextsCallCount() int {
+	fake.contextsMutex.RLock()
+	defer fake.contextsMutex.RUnlock()
+	return len(fake.contextsArgsForCall)
+}
+
+func (fake *FakeConfig) ContextsReturns(result1 []contexts.Context, result2 string, result3 error) {
+	fake.ContextsStub = nil
+	fake.contextsReturns = struct {
+		result1 []contexts.Context
+		result2 string
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeConfig) CurrentUser() (configv3.User, error) {
 	fake.currentUserMutex.Lock()
 	fake.currentUserArgsForCall = append(fake.currentUserArgsForCall, struct{}{})
@@ -365,6 +588,39 @@ func (fake *FakeConfig) CurrentUserReturns(result1 configv3.User, result2 error)
 	}{result1, result2}
 }
 
+func (fake *FakeConfig) DeleteContext(name string) error {
+	fake.deleteContextMutex.Lock()
+	fake.deleteContextArgsForCall = append(fake.deleteContextArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("DeleteContext", []interface{}{name})
+	fake.deleteContextMutex.Unlock()
+	if fake.DeleteContextStub != nil {
+		return fake.DeleteContextStub(name)
+	} else {
+		return fake.deleteContextReturns.result1
+	}
+}
+
+func (fake *FakeConfig) DeleteContextCallCount() int {
+	fake.deleteContextMutex.RLock()
+	defer fake.deleteContextMutex.RUnlock()
+	return len(fake.deleteContextArgsForCall)
+}
+
+func (fake *FakeConfig) DeleteContextArgsForCall(i int) string {
+	fake.deleteContextMutex.RLock()
+	defer fake.deleteContextMutex.RUnlock()
+	return fake.deleteContextArgsForCall[i].name
+}
+
+func (fake *FakeConfig) DeleteContextReturns(result1 error) {
+	fake.DeleteContextStub = nil
+	fake.deleteContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeConfig) DialTimeout() time.Duration {
 	fake.dialTimeoutMutex.Lock()
 	fake.dialTimeoutArgsForCall = append(fake.dialTimeoutArgsForCall, struct{}{})
@@ -390,6 +646,31 @@ func (fake *FakeConfig) DialTimeoutReturns(result1 time.Duration) {
 	}{result1}
 }
 
+func (fake *FakeConfig) RetryPolicy() retry.Policy {
+	fake.retryPolicyMutex.Lock()
+	fake.retryPolicyArgsForCall = append(fake.retryPolicyArgsForCall, struct{}{})
+	fake.recordInvocation("RetryPolicy", []interface{}{})
+	fake.retryPolicyMutex.Unlock()
+	if fake.RetryPolicyStub != nil {
+		return fake.RetryPolicyStub()
+	} else {
+		return fake.retryPolicyReturns.result1
+	}
+}
+
+func (fake *FakeConfig) RetryPolicyCallCount() int {
+	fake.retryPolicyMutex.RLock()
+	defer fake.retryPolicyMutex.RUnlock()
+	return len(fake.retryPolicyArgsForCall)
+}
+
+func (fake *FakeConfig) RetryPolicyReturns(result1 retry.Policy) {
+	fake.RetryPolicyStub = nil
+	fake.retryPolicyReturns = struct {
+		result1 retry.Policy
+	}{result1}
+}
+
 func (fake *FakeConfig) Experimental() bool {
 	fake.experimentalMutex.Lock()
 	fake.experimentalArgsForCall = append(fake.experimentalArgsForCall, struct{}{})
@@ -771,6 +1052,55 @@ func (fake *FakeConfig) SetTokenInformationArgsForCall(i int) (string, string, s
 	return fake.setTokenInformationArgsForCall[i].accessToken, fake.setTokenInformationArgsForCall[i].refreshToken, fake.setTokenInformationArgsForCall[i].sshOAuthClient
 }
 
+func (fake *FakeConfig) SetUAAClientCredentials(client string, clientSecret string) {
+	fake.setUAAClientCredentialsMutex.Lock()
+	fake.setUAAClientCredentialsArgsForCall = append(fake.setUAAClientCredentialsArgsForCall, struct {
+		client       string
+		clientSecret string
+	}{client, clientSecret})
+	fake.recordInvocation("SetUAAClientCredentials", []interface{}{client, clientSecret})
+	fake.setUAAClientCredentialsMutex.Unlock()
+	if fake.SetUAAClientCredentialsStub != nil {
+		fake.SetUAAClientCredentialsStub(client, clientSecret)
+	}
+}
+
+func (fake *FakeConfig) SetUAAClientCredentialsCallCount() int {
+	fake.setUAAClientCredentialsMutex.RLock()
+	defer fake.setUAAClientCredentialsMutex.RUnlock()
+	return len(fake.setUAAClientCredentialsArgsForCall)
+}
+
+func (fake *FakeConfig) SetUAAClientCredentialsArgsForCall(i int) (string, string) {
+	fake.setUAAClientCredentialsMutex.RLock()
+	defer fake.setUAAClientCredentialsMutex.RUnlock()
+	return fake.setUAAClientCredentialsArgsForCall[i].client, fake.setUAAClientCredentialsArgsForCall[i].clientSecret
+}
+
+func (fake *FakeConfig) SetUAAGrantType(uaaGrantType string) {
+	fake.setUAAGrantTypeMutex.Lock()
+	fake.setUAAGrantTypeArgsForCall = append(fake.setUAAGrantTypeArgsForCall, struct {
+		uaaGrantType string
+	}{uaaGrantType})
+	fake.recordInvocation("SetUAAGrantType", []interface{}{uaaGrantType})
+	fake.setUAAGrantTypeMutex.Unlock()
+	if fake.SetUAAGrantTypeStub != nil {
+		fake.SetUAAGrantTypeStub(uaaGrantType)
+	}
+}
+
+func (fake *FakeConfig) SetUAAGrantTypeCallCount() int {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return len(fake.setUAAGrantTypeArgsForCall)
+}
+
+func (fake *FakeConfig) SetUAAGrantTypeArgsForCall(i int) string {
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
+	return fake.setUAAGrantTypeArgsForCall[i].uaaGrantType
+}
+
 func (fake *FakeConfig) StagingTimeout() time.Duration {
 	fake.stagingTimeoutMutex.Lock()
 	fake.stagingTimeoutArgsForCall = append(fake.stagingTimeoutArgsForCall, struct{}{})
@@ -921,6 +1251,89 @@ func (fake *FakeConfig) TargetedSpaceReturns(result1 configv3.Space) {
 	}{result1}
 }
 
+func (fake *FakeConfig) TLSFiles() tlsconfig.Files {
+	fake.tLSFilesMutex.Lock()
+	fake.tLSFilesArgsForCall = append(fake.tLSFilesArgsForCall, struct{}{})
+	fake.recordInvocation("TLSFiles", []interface{}{})
+	fake.tLSFilesMutex.Unlock()
+	if fake.TLSFilesStub != nil {
+		return fake.TLSFilesStub()
+	} else {
+		return fake.tLSFilesReturns.result1
+	}
+}
+
+func (fake *FakeConfig) TLSFilesCallCount() int {
+	fake.tLSFilesMutex.RLock()
+	defer fake.tLSFilesMutex.RUnlock()
+	return len(fake.tLSFilesArgsForCall)
+}
+
+func (fake *FakeConfig) TLSFilesReturns(result1 tlsconfig.Files) {
+	fake.TLSFilesStub = nil
+	fake.tLSFilesReturns = struct {
+		result1 tlsconfig.Files
+	}{result1}
+}
+
+func (fake *FakeConfig) TraceFormat() string {
+	fake.traceFormatMutex.Lock()
+	fake.traceFormatArgsForCall = append(fake.traceFormatArgsForCall, struct{}{})
+	fake.recordInvocation("TraceFormat", []interface{}{})
+	fake.traceFormatMutex.Unlock()
+	if fake.TraceFormatStub != nil {
+		return fake.TraceFormatStub()
+	} else {
+		return fake.traceFormatReturns.result1
+	}
+}
+
+func (fake *FakeConfig) TraceFormatCallCount() int {
+	fake.traceFormatMutex.RLock()
+	defer fake.traceFormatMutex.RUnlock()
+	return len(fake.traceFormatArgsForCall)
+}
+
+func (fake *FakeConfig) TraceFormatReturns(result1 string) {
+	fake.TraceFormatStub = nil
+	fake.traceFormatReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeConfig) UseContext(name string) error {
+	fake.useContextMutex.Lock()
+	fake.useContextArgsForCall = append(fake.useContextArgsForCall, struct {
+		name string
+	}{name})
+	fake.recordInvocation("UseContext", []interface{}{name})
+	fake.useContextMutex.Unlock()
+	if fake.UseContextStub != nil {
+		return fake.UseContextStub(name)
+	} else {
+		return fake.useContextReturns.result1
+	}
+}
+
+func (fake *FakeConfig) UseContextCallCount() int {
+	fake.useContextMutex.RLock()
+	defer fake.useContextMutex.RUnlock()
+	return len(fake.useContextArgsForCall)
+}
+
+func (fake *FakeConfig) UseContextArgsForCall(i int) string {
+	fake.useContextMutex.RLock()
+	defer fake.useContextMutex.RUnlock()
+	return fake.useContextArgsForCall[i].name
+}
+
+func (fake *FakeConfig) UseContextReturns(result1 error) {
+	fake.UseContextStub = nil
+	fake.useContextReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeConfig) UAAOAuthClient() string {
 	fake.uAAOAuthClientMutex.Lock()
 	fake.uAAOAuthClientArgsForCall = append(fake.uAAOAuthClientArgsForCall, struct{}{})
@@ -946,6 +1359,31 @@ func (fake *FakeConfig) UAAOAuthClientReturns(result1 string) {
 	}{result1}
 }
 
+func (fake *FakeConfig) UAAGrantType() string {
+	fake.uAAGrantTypeMutex.Lock()
+	fake.uAAGrantTypeArgsForCall = append(fake.uAAGrantTypeArgsForCall, struct{}{})
+	fake.recordInvocation("UAAGrantType", []interface{}{})
+	fake.uAAGrantTypeMutex.Unlock()
+	if fake.UAAGrantTypeStub != nil {
+		return fake.UAAGrantTypeStub()
+	} else {
+		return fake.uAAGrantTypeReturns.result1
+	}
+}
+
+func (fake *FakeConfig) UAAGrantTypeCallCount() int {
+	fake.uAAGrantTypeMutex.RLock()
+	defer fake.uAAGrantTypeMutex.RUnlock()
+	return len(fake.uAAGrantTypeArgsForCall)
+}
+
+func (fake *FakeConfig) UAAGrantTypeReturns(result1 string) {
+	fake.UAAGrantTypeStub = nil
+	fake.uAAGrantTypeReturns = struct {
+		result1 string
+	}{result1}
+}
+
 func (fake *FakeConfig) UAAOAuthClientSecret() string {
 	fake.uAAOAuthClientSecretMutex.Lock()
 	fake.uAAOAuthClientSecretArgsForCall = append(fake.uAAOAuthClientSecretArgsForCall, struct{}{})
@@ -1036,16 +1474,30 @@ func (fake *FakeConfig) Invocations() map[string][][]interface{} {
 	defer fake.aPIVersionMutex.RUnlock()
 	fake.accessTokenMutex.RLock()
 	defer fake.accessTokenMutex.RUnlock()
+	fake.addContextMutex.RLock()
+	defer fake.addContextMutex.RUnlock()
 	fake.binaryNameMutex.RLock()
 	defer fake.binaryNameMutex.RUnlock()
 	fake.binaryVersionMutex.RLock()
 	defer fake.binaryVersionMutex.RUnlock()
+	fake.cacheDirectoryMutex.RLock()
+	defer fake.cacheDirectoryMutex.RUnlock()
+	fake.cacheTTLMutex.RLock()
+	defer fake.cacheTTLMutex.RUnlock()
 	fake.colorEnabledMutex.RLock()
 	defer fake.colorEnabledMutex.RUnlock()
+	fake.concurrencyMutex.RLock()
+	defer fake.concurrencyMutex.RUnlock()
+	fake.contextsMutex.RLock()
+	defer fake.contextsMutex.RUnlock()
 	fake.currentUserMutex.RLock()
 	defer fake.currentUserMutex.RUnlock()
+	fake.deleteContextMutex.RLock()
+	defer fake.deleteContextMutex.RUnlock()
 	fake.dialTimeoutMutex.RLock()
 	defer fake.dialTimeoutMutex.RUnlock()
+	fake.retryPolicyMutex.RLock()
+	defer fake.retryPolicyMutex.RUnlock()
 	fake.experimentalMutex.RLock()
 	defer fake.experimentalMutex.RUnlock()
 	fake.hasTargetedOrganizationMutex.RLock()
@@ -1076,6 +1528,10 @@ func (fake *FakeConfig) Invocations() map[string][][]interface{} {
 	defer fake.setTargetInformationMutex.RUnlock()
 	fake.setTokenInformationMutex.RLock()
 	defer fake.setTokenInformationMutex.RUnlock()
+	fake.setUAAClientCredentialsMutex.RLock()
+	defer fake.setUAAClientCredentialsMutex.RUnlock()
+	fake.setUAAGrantTypeMutex.RLock()
+	defer fake.setUAAGrantTypeMutex.RUnlock()
 	fake.stagingTimeoutMutex.RLock()
 	defer fake.stagingTimeoutMutex.RUnlock()
 	fake.startupTimeoutMutex.RLock()
@@ -1088,8 +1544,16 @@ func (fake *FakeConfig) Invocations() map[string][][]interface{} {
 	defer fake.targetedOrganizationMutex.RUnlock()
 	fake.targetedSpaceMutex.RLock()
 	defer fake.targetedSpaceMutex.RUnlock()
+	fake.tLSFilesMutex.RLock()
+	defer fake.tLSFilesMutex.RUnlock()
+	fake.traceFormatMutex.RLock()
+	defer fake.traceFormatMutex.RUnlock()
+	fake.useContextMutex.RLock()
+	defer fake.useContextMutex.RUnlock()
 	fake.uAAOAuthClientMutex.RLock()
 	defer fake.uAAOAuthClientMutex.RUnlock()
+	fake.uAAGrantTypeMutex.RLock()
+	defer fake.uAAGrantTypeMutex.RUnlock()
 	fake.uAAOAuthClientSecretMutex.RLock()
 	defer fake.uAAOAuthClientSecretMutex.RUnlock()
 	fake.unsetSpaceInformationMutex.RLock()
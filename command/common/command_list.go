@@ -12,11 +12,13 @@ type commandList struct {
 	App                                v2.AppCommand                                `command:"app" description:"Display health and status for app"`
 	Help                               HelpCommand                                  `command:"help" alias:"h" description:"Show help"`
 	Version                            VersionCommand                               `command:"version" description:"Print the version"`
+	Completion                         CompletionCommand                            `command:"completion" description:"Print a shell completion script for bash, zsh or fish"`
 	Login                              v2.LoginCommand                              `command:"login" alias:"l" description:"Log user in"`
 	Logout                             v2.LogoutCommand                             `command:"logout" alias:"lo" description:"Log user out"`
 	Passwd                             v2.PasswdCommand                             `command:"passwd" alias:"pw" description:"Change user password"`
 	Target                             v2.TargetCommand                             `command:"target" alias:"t" description:"Set or view the targeted org or space"`
 	Api                                v2.ApiCommand                                `command:"api" description:"Set or view target api url"`
+	Context                            v2.ContextCommand                            `command:"context" description:"Add, switch between, list or delete saved targets"`
 	Auth                               v2.AuthCommand                               `command:"auth" description:"Authenticate user non-interactively"`
 	Apps                               v2.AppsCommand                               `command:"apps" alias:"a" description:"List all apps in the target space"`
 	Push                               v2.PushCommand                               `command:"push" alias:"p" description:"Push a new app or sync changes to an existing app"`
@@ -154,14 +156,17 @@ type commandList struct {
 	Curl                               v2.CurlCommand                               `command:"curl" description:"Executes a request to the targeted API endpoint"`
 	Config                             v2.ConfigCommand                             `command:"config" description:"Write default values to the config"`
 	OauthToken                         v2.OauthTokenCommand                         `command:"oauth-token" description:"Retrieve and display the OAuth token for the current session"`
+	TokenInfo                          v2.TokenInfoCommand                          `command:"token-info" description:"Decode and display the OAuth token for the current session"`
 	SSHCode                            v2.SSHCodeCommand                            `command:"ssh-code" description:"Get a one time password for ssh clients"`
 	AddPluginRepo                      v2.AddPluginRepoCommand                      `command:"add-plugin-repo" description:"Add a new plugin repository"`
 	RemovePluginRepo                   v2.RemovePluginRepoCommand                   `command:"remove-plugin-repo" description:"Remove a plugin repository"`
 	ListPluginRepos                    v2.ListPluginReposCommand                    `command:"list-plugin-repos" description:"List all the added plugin repositories"`
+	PluginRepo                         v2.PluginRepoCommand                         `command:"plugin-repo" description:"Serve a directory of plugin binaries as a plugin repository"`
 	RepoPlugins                        v2.RepoPluginsCommand                        `command:"repo-plugins" description:"List all available plugins in specified repository or in all added repositories"`
 	Plugins                            v2.PluginsCommand                            `command:"plugins" description:"List all available plugin commands"`
 	InstallPlugin                      v2.InstallPluginCommand                      `command:"install-plugin" description:"Install CLI plugin"`
 	UninstallPlugin                    v2.UninstallPluginCommand                    `command:"uninstall-plugin" description:"Uninstall the plugin defined in command argument"`
+	UpdatePlugin                       v2.UpdatePluginCommand                       `command:"update-plugin" description:"Update installed plugins to the latest version in the registered repositories"`
 	RunTask                            v3.RunTaskCommand                            `command:"run-task" alias:"rt" description:"Run a one-off task on an app"`
 	Tasks                              v3.TasksCommand                              `command:"tasks" description:"List tasks of an app"`
 	TerminateTask                      v3.TerminateTaskCommand                      `command:"terminate-task" description:"Terminate a running task of an app"`
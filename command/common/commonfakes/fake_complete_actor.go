@@ -0,0 +1,314 @@
+// This file was generated by counterfeiter
+package commonfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/command/common"
+)
+
+type FakeCompleteActor struct {
+	GetApplicationsBySpaceStub        func(spaceGUID string) ([]v2action.Application, v2action.Warnings, error)
+	getApplicationsBySpaceMutex       sync.RWMutex
+	getApplicationsBySpaceArgsForCall []struct {
+		spaceGUID string
+	}
+	getApplicationsBySpaceReturns struct {
+		result1 []v2action.Application
+		result2 v2action.Warnings
+		result3 error
+	}
+	GetOrganizationByNameStub        func(orgName string) (v2action.Organization, v2action.Warnings, error)
+	getOrganizationByNameMutex       sync.RWMutex
+	getOrganizationByNameArgsForCall []struct {
+		orgName string
+	}
+	getOrganizationByNameReturns struct {
+		result1 v2action.Organization
+		result2 v2action.Warnings
+		result3 error
+	}
+	GetOrganizationsStub        func() ([]v2action.Organization, v2action.Warnings, error)
+	getOrganizationsMutex       sync.RWMutex
+	getOrganizationsArgsForCall []struct{}
+	getOrganizationsReturns     struct {
+		result1 []v2action.Organization
+		result2 v2action.Warnings
+		result3 error
+	}
+	GetOrganizationSpacesStub        func(orgGUID string) ([]v2action.Space, v2action.Warnings, error)
+	getOrganizationSpacesMutex       sync.RWMutex
+	getOrganizationSpacesArgsForCall []struct {
+		orgGUID string
+	}
+	getOrganizationSpacesReturns struct {
+		result1 []v2action.Space
+		result2 v2action.Warnings
+		result3 error
+	}
+	GetServiceInstancesBySpaceStub        func(spaceGUID string) ([]v2action.ServiceInstance, v2action.Warnings, error)
+	getServiceInstancesBySpaceMutex       sync.RWMutex
+	getServiceInstancesBySpaceArgsForCall []struct {
+		spaceGUID string
+	}
+	getServiceInstancesBySpaceReturns struct {
+		result1 []v2action.ServiceInstance
+		result2 v2action.Warnings
+		result3 error
+	}
+	GetSpaceRoutesStub        func(spaceGUID string, query []ccv2.Query) ([]v2action.Route, v2action.Warnings, error)
+	getSpaceRoutesMutex       sync.RWMutex
+	getSpaceRoutesArgsForCall []struct {
+		spaceGUID string
+		query     []ccv2.Query
+	}
+	getSpaceRoutesReturns struct {
+		result1 []v2action.Route
+		result2 v2action.Warnings
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCompleteActor) GetApplicationsBySpace(spaceGUID string) ([]v2action.Application, v2action.Warnings, error) {
+	fake.getApplicationsBySpaceMutex.Lock()
+	fake.getApplicationsBySpaceArgsForCall = append(fake.getApplicationsBySpaceArgsForCall, struct {
+		spaceGUID string
+	}{spaceGUID})
+	fake.recordInvocation("GetApplicationsBySpace", []interface{}{spaceGUID})
+	fake.getApplicationsBySpaceMutex.Unlock()
+	if fake.GetApplicationsBySpaceStub != nil {
+		return fake.GetApplicationsBySpaceStub(spaceGUID)
+	} else {
+		return fake.getApplicationsBySpaceReturns.result1, fake.getApplicationsBySpaceReturns.result2, fake.getApplicationsBySpaceReturns.result3
+	}
+}
+
+func (fake *FakeCompleteActor) GetApplicationsBySpaceCallCount() int {
+	fake.getApplicationsBySpaceMutex.RLock()
+	defer fake.getApplicationsBySpaceMutex.RUnlock()
+	return len(fake.getApplicationsBySpaceArgsForCall)
+}
+
+func (fake *FakeCompleteActor) GetApplicationsBySpaceArgsForCall(i int) string {
+	fake.getApplicationsBySpaceMutex.RLock()
+	defer fake.getApplicationsBySpaceMutex.RUnlock()
+	return fake.getApplicationsBySpaceArgsForCall[i].spaceGUID
+}
+
+func (fake *FakeCompleteActor) GetApplicationsBySpaceReturns(result1 []v2action.Application, result2 v2action.Warnings, result3 error) {
+	fake.GetApplicationsBySpaceStub = nil
+	fake.getApplicationsBySpaceReturns = struct {
+		result1 []v2action.Application
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCompleteActor) GetOrganizationByName(orgName string) (v2action.Organization, v2action.Warnings, error) {
+	fake.getOrganizationByNameMutex.Lock()
+	fake.getOrganizationByNameArgsForCall = append(fake.getOrganizationByNameArgsForCall, struct {
+		orgName string
+	}{orgName})
+	fake.recordInvocation("GetOrganizationByName", []interface{}{orgName})
+	fake.getOrganizationByNameMutex.Unlock()
+	if fake.GetOrganizationByNameStub != nil {
+		return fake.GetOrganizationByNameStub(orgName)
+	} else {
+		return fake.getOrganizationByNameReturns.result1, fake.getOrganizationByNameReturns.result2, fake.getOrganizationByNameReturns.result3
+	}
+}
+
+func (fake *FakeCompleteActor) GetOrganizationByNameCallCount() int {
+	fake.getOrganizationByNameMutex.RLock()
+	defer fake.getOrganizationByNameMutex.RUnlock()
+	return len(fake.getOrganizationByNameArgsForCall)
+}
+
+func (fake *FakeCompleteActor) GetOrganizationByNameArgsForCall(i int) string {
+	fake.getOrganizationByNameMutex.RLock()
+	defer fake.getOrganizationByNameMutex.RUnlock()
+	return fake.getOrganizationByNameArgsForCall[i].orgName
+}
+
+func (fake *FakeCompleteActor) GetOrganizationByNameReturns(result1 v2action.Organization, result2 v2action.Warnings, result3 error) {
+	fake.GetOrganizationByNameStub = nil
+	fake.getOrganizationByNameReturns = struct {
+		result1 v2action.Organization
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCompleteActor) GetOrganizations() ([]v2action.Organization, v2action.Warnings, error) {
+	fake.getOrganizationsMutex.Lock()
+	fake.getOrganizationsArgsForCall = append(fake.getOrganizationsArgsForCall, struct{}{})
+	fake.recordInvocation("GetOrganizations", []interface{}{})
+	fake.getOrganizationsMutex.Unlock()
+	if fake.GetOrganizationsStub != nil {
+		return fake.GetOrganizationsStub()
+	} else {
+		return fake.getOrganizationsReturns.result1, fake.getOrganizationsReturns.result2, fake.getOrganizationsReturns.result3
+	}
+}
+
+func (fake *FakeCompleteActor) GetOrganizationsCallCount() int {
+	fake.getOrganizationsMutex.RLock()
+	defer fake.getOrganizationsMutex.RUnlock()
+	return len(fake.getOrganizationsArgsForCall)
+}
+
+func (fake *FakeCompleteActor) GetOrganizationsReturns(result1 []v2action.Organization, result2 v2action.Warnings, result3 error) {
+	fake.GetOrganizationsStub = nil
+	fake.getOrganizationsReturns = struct {
+		result1 []v2action.Organization
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCompleteActor) GetOrganizationSpaces(orgGUID string) ([]v2action.Space, v2action.Warnings, error) {
+	fake.getOrganizationSpacesMutex.Lock()
+	fake.getOrganizationSpacesArgsForCall = append(fake.getOrganizationSpacesArgsForCall, struct {
+		orgGUID string
+	}{orgGUID})
+	fake.recordInvocation("GetOrganizationSpaces", []interface{}{orgGUID})
+	fake.getOrganizationSpacesMutex.Unlock()
+	if fake.GetOrganizationSpacesStub != nil {
+		return fake.GetOrganizationSpacesStub(orgGUID)
+	} else {
+		return fake.getOrganizationSpacesReturns.result1, fake.getOrganizationSpacesReturns.result2, fake.getOrganizationSpacesReturns.result3
+	}
+}
+
+func (fake *FakeCompleteActor) GetOrganizationSpacesCallCount() int {
+	fake.getOrganizationSpacesMutex.RLock()
+	defer fake.getOrganizationSpacesMutex.RUnlock()
+	return len(fake.getOrganizationSpacesArgsForCall)
+}
+
+func (fake *FakeCompleteActor) GetOrganizationSpacesArgsForCall(i int) string {
+	fake.getOrganizationSpacesMutex.RLock()
+	defer fake.getOrganizationSpacesMutex.RUnlock()
+	return fake.getOrganizationSpacesArgsForCall[i].orgGUID
+}
+
+func (fake *FakeCompleteActor) GetOrganizationSpacesReturns(result1 []v2action.Space, result2 v2action.Warnings, result3 error) {
+	fake.GetOrganizationSpacesStub = nil
+	fake.getOrganizationSpacesReturns = struct {
+		result1 []v2action.Space
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCompleteActor) GetServiceInstancesBySpace(spaceGUID string) ([]v2action.ServiceInstance, v2action.Warnings, error) {
+	fake.getServiceInstancesBySpaceMutex.Lock()
+	fake.getServiceInstancesBySpaceArgsForCall = append(fake.getServiceInstancesBySpaceArgsForCall, struct {
+		spaceGUID string
+	}{spaceGUID})
+	fake.recordInvocation("GetServiceInstancesBySpace", []interface{}{spaceGUID})
+	fake.getServiceInstancesBySpaceMutex.Unlock()
+	if fake.GetServiceInstancesBySpaceStub != nil {
+		return fake.GetServiceInstancesBySpaceStub(spaceGUID)
+	} else {
+		return fake.getServiceInstancesBySpaceReturns.result1, fake.getServiceInstancesBySpaceReturns.result2, fake.getServiceInstancesBySpaceReturns.result3
+	}
+}
+
+func (fake *FakeCompleteActor) GetServiceInstancesBySpaceCallCount() int {
+	fake.getServiceInstancesBySpaceMutex.RLock()
+	defer fake.getServiceInstancesBySpaceMutex.RUnlock()
+	return len(fake.getServiceInstancesBySpaceArgsForCall)
+}
+
+func (fake *FakeCompleteActor) GetServiceInstancesBySpaceArgsForCall(i int) string {
+	fake.getServiceInstancesBySpaceMutex.RLock()
+	defer fake.getServiceInstancesBySpaceMutex.RUnlock()
+	return fake.getServiceInstancesBySpaceArgsForCall[i].spaceGUID
+}
+
+func (fake *FakeCompleteActor) GetServiceInstancesBySpaceReturns(result1 []v2action.ServiceInstance, result2 v2action.Warnings, result3 error) {
+	fake.GetServiceInstancesBySpaceStub = nil
+	fake.getServiceInstancesBySpaceReturns = struct {
+		result1 []v2action.ServiceInstance
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCompleteActor) GetSpaceRoutes(spaceGUID string, query []ccv2.Query) ([]v2action.Route, v2action.Warnings, error) {
+	var queryCopy []ccv2.Query
+	if query != nil {
+		queryCopy = make([]ccv2.Query, len(query))
+		copy(queryCopy, query)
+	}
+	fake.getSpaceRoutesMutex.Lock()
+	fake.getSpaceRoutesArgsForCall = append(fake.getSpaceRoutesArgsForCall, struct {
+		spaceGUID string
+		query     []ccv2.Query
+	}{spaceGUID, queryCopy})
+	fake.recordInvocation("GetSpaceRoutes", []interface{}{spaceGUID, queryCopy})
+	fake.getSpaceRoutesMutex.Unlock()
+	if fake.GetSpaceRoutesStub != nil {
+		return fake.GetSpaceRoutesStub(spaceGUID, query)
+	} else {
+		return fake.getSpaceRoutesReturns.result1, fake.getSpaceRoutesReturns.result2, fake.getSpaceRoutesReturns.result3
+	}
+}
+
+func (fake *FakeCompleteActor) GetSpaceRoutesCallCount() int {
+	fake.getSpaceRoutesMutex.RLock()
+	defer fake.getSpaceRoutesMutex.RUnlock()
+	return len(fake.getSpaceRoutesArgsForCall)
+}
+
+func (fake *FakeCompleteActor) GetSpaceRoutesArgsForCall(i int) (string, []ccv2.Query) {
+	fake.getSpaceRoutesMutex.RLock()
+	defer fake.getSpaceRoutesMutex.RUnlock()
+	return fake.getSpaceRoutesArgsForCall[i].spaceGUID, fake.getSpaceRoutesArgsForCall[i].query
+}
+
+func (fake *FakeCompleteActor) GetSpaceRoutesReturns(result1 []v2action.Route, result2 v2action.Warnings, result3 error) {
+	fake.GetSpaceRoutesStub = nil
+	fake.getSpaceRoutesReturns = struct {
+		result1 []v2action.Route
+		result2 v2action.Warnings
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeCompleteActor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getApplicationsBySpaceMutex.RLock()
+	defer fake.getApplicationsBySpaceMutex.RUnlock()
+	fake.getOrganizationByNameMutex.RLock()
+	defer fake.getOrganizationByNameMutex.RUnlock()
+	fake.getOrganizationsMutex.RLock()
+	defer fake.getOrganizationsMutex.RUnlock()
+	fake.getOrganizationSpacesMutex.RLock()
+	defer fake.getOrganizationSpacesMutex.RUnlock()
+	fake.getServiceInstancesBySpaceMutex.RLock()
+	defer fake.getServiceInstancesBySpaceMutex.RUnlock()
+	fake.getSpaceRoutesMutex.RLock()
+	defer fake.getSpaceRoutesMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeCompleteActor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ common.CompleteActor = new(FakeCompleteActor)
@@ -0,0 +1,84 @@
+// This file was generated by counterfeiter
+package commonfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/command/common"
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/util/configv3"
+)
+
+type FakePluginFlagCompleter struct {
+	CompleteFlagStub        func(location string, flag configv3.PluginFlag, request plugin.CompletionRequest) ([]string, error)
+	completeFlagMutex       sync.RWMutex
+	completeFlagArgsForCall []struct {
+		location string
+		flag     configv3.PluginFlag
+		request  plugin.CompletionRequest
+	}
+	completeFlagReturns struct {
+		result1 []string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePluginFlagCompleter) CompleteFlag(location string, flag configv3.PluginFlag, request plugin.CompletionRequest) ([]string, error) {
+	fake.completeFlagMutex.Lock()
+	fake.completeFlagArgsForCall = append(fake.completeFlagArgsForCall, struct {
+		location string
+		flag     configv3.PluginFlag
+		request  plugin.CompletionRequest
+	}{location, flag, request})
+	fake.recordInvocation("CompleteFlag", []interface{}{location, flag, request})
+	fake.completeFlagMutex.Unlock()
+	if fake.CompleteFlagStub != nil {
+		return fake.CompleteFlagStub(location, flag, request)
+	} else {
+		return fake.completeFlagReturns.result1, fake.completeFlagReturns.result2
+	}
+}
+
+func (fake *FakePluginFlagCompleter) CompleteFlagCallCount() int {
+	fake.completeFlagMutex.RLock()
+	defer fake.completeFlagMutex.RUnlock()
+	return len(fake.completeFlagArgsForCall)
+}
+
+func (fake *FakePluginFlagCompleter) CompleteFlagArgsForCall(i int) (string, configv3.PluginFlag, plugin.CompletionRequest) {
+	fake.completeFlagMutex.RLock()
+	defer fake.completeFlagMutex.RUnlock()
+	return fake.completeFlagArgsForCall[i].location, fake.completeFlagArgsForCall[i].flag, fake.completeFlagArgsForCall[i].request
+}
+
+func (fake *FakePluginFlagCompleter) CompleteFlagReturns(result1 []string, result2 error) {
+	fake.CompleteFlagStub = nil
+	fake.completeFlagReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePluginFlagCompleter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.completeFlagMutex.RLock()
+	defer fake.completeFlagMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakePluginFlagCompleter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ common.PluginFlagCompleter = new(FakePluginFlagCompleter)
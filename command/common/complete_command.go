@@ -0,0 +1,511 @@
+package common
+
+import (
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/v2/shared"
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/util/configv3"
+	flags "github.com/jessevdk/go-flags"
+)
+
+// CompleteCommandName is the name of the hidden command that the completion
+// scripts run to complete a command line.
+const CompleteCommandName = "__complete"
+
+// Names of the values completed for arguments and flags. Flags select them
+// with a `complete` tag, arguments by their positional-arg-name.
+const (
+	completeApp     = "app"
+	completeCommand = "command"
+	completeOrg     = "org"
+	completeRoute   = "route"
+	completeService = "service"
+	completeSpace   = "space"
+)
+
+// globalFlagsWithValue are the global flags, given before the command name,
+// that take a value.
+var globalFlagsWithValue = map[string]bool{
+	"--context":   true,
+	"--trace-har": true,
+}
+
+var positionalCompletions = map[string]string{
+	"APP_NAME":         completeApp,
+	"COMMAND_NAME":     completeCommand,
+	"HOST":             completeRoute,
+	"ORG":              completeOrg,
+	"SERVICE_INSTANCE": completeService,
+	"SPACE":            completeSpace,
+	"SPACE_NAME":       completeSpace,
+}
+
+//go:generate counterfeiter . CompleteActor
+
+// CompleteActor lists the names of the resources completed on the command
+// line.
+type CompleteActor interface {
+	GetApplicationsBySpace(spaceGUID string) ([]v2action.Application, v2action.Warnings, error)
+	GetOrganizationByName(orgName string) (v2action.Organization, v2action.Warnings, error)
+	GetOrganizations() ([]v2action.Organization, v2action.Warnings, error)
+	GetOrganizationSpaces(orgGUID string) ([]v2action.Space, v2action.Warnings, error)
+	GetServiceInstancesBySpace(spaceGUID string) ([]v2action.ServiceInstance, v2action.Warnings, error)
+	GetSpaceRoutes(spaceGUID string, query []ccv2.Query) ([]v2action.Route, v2action.Warnings, error)
+}
+
+//go:generate counterfeiter . PluginFlagCompleter
+
+// PluginFlagCompleter completes the values of plugin command flags.
+type PluginFlagCompleter interface {
+	CompleteFlag(location string, flag configv3.PluginFlag, request plugin.CompletionRequest) ([]string, error)
+}
+
+// CompleteCommand displays the candidates for the last of its arguments,
+// which are the words of a command line after the binary name. Every
+// candidate is displayed on its own line, followed by a tab and its
+// description when it has one.
+type CompleteCommand struct {
+	UI              command.UI
+	Config          command.Config
+	Actor           CompleteActor
+	PluginCompleter PluginFlagCompleter
+}
+
+func (cmd *CompleteCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.Config = config
+	cmd.UI = ui
+	cmd.PluginCompleter = pluginFlagCompleter{}
+
+	return nil
+}
+
+func (cmd *CompleteCommand) Execute(args []string) error {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	words, current := args[:len(args)-1], args[len(args)-1]
+
+	for len(words) > 0 && strings.HasPrefix(words[0], "-") {
+		if globalFlagsWithValue[words[0]] {
+			if len(words) == 1 {
+				return nil
+			}
+			words = words[1:]
+		}
+		words = words[1:]
+	}
+
+	if len(words) == 0 {
+		if !strings.HasPrefix(current, "-") {
+			cmd.displayCandidates(cmd.commandCandidates(current))
+		}
+		return nil
+	}
+
+	if field, found := findCommandField(words[0]); found {
+		return cmd.completeCoreCommand(field.Type, words[1:], current)
+	}
+
+	for _, pluginConfig := range cmd.Config.Plugins() {
+		for _, pluginCommand := range pluginConfig.Commands {
+			if pluginCommand.Name == words[0] || pluginCommand.Alias == words[0] {
+				return cmd.completePluginCommand(pluginConfig, pluginCommand, words[1:], current)
+			}
+		}
+	}
+
+	return nil
+}
+
+// completeCoreCommand completes the flags, flag values and arguments of a
+// command in the command list.
+func (cmd *CompleteCommand) completeCoreCommand(commandType reflect.Type, words []string, current string) error {
+	var (
+		options    []reflect.StructField
+		positional []reflect.StructField
+	)
+
+	for i := 0; i < commandType.NumField(); i++ {
+		field := commandType.Field(i)
+		switch {
+		case field.Tag.Get("hidden") != "":
+		case field.Tag.Get("positional-args") != "":
+			for j := 0; j < field.Type.NumField(); j++ {
+				positional = append(positional, field.Type.Field(j))
+			}
+		case field.Tag.Get("short") != "" || field.Tag.Get("long") != "":
+			options = append(options, field)
+		}
+	}
+
+	findOption := func(name string) (reflect.StructField, bool) {
+		for _, option := range options {
+			if name != "" && (option.Tag.Get("short") == name || option.Tag.Get("long") == name) {
+				return option, true
+			}
+		}
+		return reflect.StructField{}, false
+	}
+
+	var (
+		pending     *reflect.StructField
+		positionals int
+		given       = map[string]string{}
+	)
+
+	for _, word := range words {
+		if pending != nil {
+			given[pending.Tag.Get("complete")] = word
+			pending = nil
+			continue
+		}
+
+		if strings.HasPrefix(word, "-") {
+			name := strings.TrimLeft(word, "-")
+			if i := strings.Index(name, "="); i >= 0 {
+				if option, found := findOption(name[:i]); found {
+					given[option.Tag.Get("complete")] = name[i+1:]
+				}
+				continue
+			}
+			if option, found := findOption(name); found && option.Type.Kind() != reflect.Bool {
+				pending = &option
+			}
+			continue
+		}
+
+		if positionals < len(positional) {
+			given[positionalCompletions[positional[positionals].Tag.Get("positional-arg-name")]] = word
+		}
+		positionals++
+	}
+
+	if pending != nil {
+		return cmd.completeValue(*pending, pending.Tag.Get("complete"), current, given)
+	}
+
+	if strings.HasPrefix(current, "-") {
+		candidates := []completionCandidate{}
+		for _, option := range options {
+			description := cmd.UI.TranslateText(option.Tag.Get("description"))
+			if long := option.Tag.Get("long"); long != "" {
+				candidates = append(candidates, completionCandidate{Name: "--" + long, Description: description})
+			}
+			if short := option.Tag.Get("short"); short != "" {
+				candidates = append(candidates, completionCandidate{Name: "-" + short, Description: description})
+			}
+		}
+		cmd.displayCandidates(filterCandidates(candidates, current))
+		return nil
+	}
+
+	if positionals < len(positional) {
+		field := positional[positionals]
+		return cmd.completeValue(field, positionalCompletions[field.Tag.Get("positional-arg-name")], current, given)
+	}
+
+	return nil
+}
+
+// completeValue completes the value of a flag or argument, either with the
+// names of a kind of resource or with the completions of the value's type.
+func (cmd *CompleteCommand) completeValue(field reflect.StructField, kind string, current string, given map[string]string) error {
+	if kind == "" {
+		completer, ok := reflect.New(field.Type).Interface().(flags.Completer)
+		if !ok {
+			return nil
+		}
+
+		candidates := []completionCandidate{}
+		for _, completion := range completer.Complete(current) {
+			candidates = append(candidates, completionCandidate{Name: completion.Item})
+		}
+		cmd.displayCandidates(candidates)
+		return nil
+	}
+
+	if kind == completeCommand {
+		cmd.displayCandidates(cmd.commandCandidates(current))
+		return nil
+	}
+
+	names, err := cmd.resourceNames(kind, given)
+	if err != nil {
+		return err
+	}
+
+	candidates := []completionCandidate{}
+	for _, name := range names {
+		candidates = append(candidates, completionCandidate{Name: name})
+	}
+	cmd.displayCandidates(filterCandidates(candidates, current))
+	return nil
+}
+
+// resourceNames lists the names of a kind of resource in the targeted org or
+// space. Spaces are listed in the org given on the command line, if any.
+func (cmd *CompleteCommand) resourceNames(kind string, given map[string]string) ([]string, error) {
+	actor, err := cmd.actor()
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	spaceGUID := cmd.Config.TargetedSpace().GUID
+
+	switch kind {
+	case completeApp:
+		if spaceGUID == "" {
+			return nil, nil
+		}
+		apps, _, err := actor.GetApplicationsBySpace(spaceGUID)
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range apps {
+			names = append(names, app.Name)
+		}
+	case completeOrg:
+		orgs, _, err := actor.GetOrganizations()
+		if err != nil {
+			return nil, err
+		}
+		for _, org := range orgs {
+			names = append(names, org.Name)
+		}
+	case completeRoute:
+		if spaceGUID == "" {
+			return nil, nil
+		}
+		routes, _, err := actor.GetSpaceRoutes(spaceGUID, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, route := range routes {
+			if route.Host != "" {
+				names = append(names, route.Host)
+			}
+		}
+	case completeService:
+		if spaceGUID == "" {
+			return nil, nil
+		}
+		serviceInstances, _, err := actor.GetServiceInstancesBySpace(spaceGUID)
+		if err != nil {
+			return nil, err
+		}
+		for _, serviceInstance := range serviceInstances {
+			names = append(names, serviceInstance.Name)
+		}
+	case completeSpace:
+		orgGUID := cmd.Config.TargetedOrganization().GUID
+		if orgName := given[completeOrg]; orgName != "" {
+			org, _, err := actor.GetOrganizationByName(orgName)
+			if err != nil {
+				return nil, err
+			}
+			orgGUID = org.GUID
+		}
+		if orgGUID == "" {
+			return nil, nil
+		}
+		spaces, _, err := actor.GetOrganizationSpaces(orgGUID)
+		if err != nil {
+			return nil, err
+		}
+		for _, space := range spaces {
+			names = append(names, space.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// actor returns the command's actor, creating it on first use. The Cloud
+// Controller is only contacted when resource names are completed, so that
+// commands and flags complete quickly and without a target.
+func (cmd *CompleteCommand) actor() (CompleteActor, error) {
+	if cmd.Actor == nil {
+		ccClient, uaaClient, err := shared.NewClients(cmd.Config, cmd.UI)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Actor = v2action.NewActor(ccClient, uaaClient)
+	}
+
+	return cmd.Actor, nil
+}
+
+// completePluginCommand completes the flags and flag values of a plugin
+// command. Flag values are completed by the plugin.
+func (cmd *CompleteCommand) completePluginCommand(pluginConfig configv3.Plugin, pluginCommand configv3.PluginCommand, words []string, current string) error {
+	usage := pluginCommand.UsageDetails
+
+	if len(usage.Flags) == 0 {
+		if !strings.HasPrefix(current, "-") {
+			return nil
+		}
+
+		candidates := []completionCandidate{}
+		for option, description := range usage.Options {
+			name := strings.Trim(option, "-")
+			if len(name) == 1 {
+				name = "-" + name
+			} else {
+				name = "--" + name
+			}
+			candidates = append(candidates, completionCandidate{Name: name, Description: description})
+		}
+		cmd.displayCandidates(filterCandidates(candidates, current))
+		return nil
+	}
+
+	findFlag := func(name string) (configv3.PluginFlag, bool) {
+		for _, pluginFlag := range usage.Flags {
+			if name != "" && (pluginFlag.Name == name || pluginFlag.ShortName == name) {
+				return pluginFlag, true
+			}
+		}
+		return configv3.PluginFlag{}, false
+	}
+
+	if len(words) > 0 {
+		previous := words[len(words)-1]
+		if strings.HasPrefix(previous, "-") {
+			pluginFlag, found := findFlag(strings.TrimLeft(previous, "-"))
+			if found && pluginFlag.Type != "" && pluginFlag.Type != string(plugin.BoolFlag) {
+				values, err := cmd.PluginCompleter.CompleteFlag(pluginConfig.Location, pluginFlag, plugin.CompletionRequest{
+					Command: pluginCommand.Name,
+					Flag:    pluginFlag.Name,
+					Args:    words,
+					Prefix:  current,
+				})
+				if err != nil {
+					return err
+				}
+
+				candidates := []completionCandidate{}
+				for _, value := range values {
+					candidates = append(candidates, completionCandidate{Name: value})
+				}
+				cmd.displayCandidates(candidates)
+				return nil
+			}
+		}
+	}
+
+	if !strings.HasPrefix(current, "-") {
+		return nil
+	}
+
+	candidates := []completionCandidate{}
+	for _, pluginFlag := range usage.Flags {
+		candidates = append(candidates, completionCandidate{Name: "--" + pluginFlag.Name, Description: pluginFlag.Usage})
+		if pluginFlag.ShortName != "" {
+			candidates = append(candidates, completionCandidate{Name: "-" + pluginFlag.ShortName, Description: pluginFlag.Usage})
+		}
+	}
+	cmd.displayCandidates(filterCandidates(candidates, current))
+	return nil
+}
+
+// commandCandidates returns the commands, plugin commands and their aliases
+// that start with prefix.
+func (cmd *CompleteCommand) commandCandidates(prefix string) []completionCandidate {
+	candidates := []completionCandidate{}
+
+	handler := reflect.TypeOf(Commands)
+	for i := 0; i < handler.NumField(); i++ {
+		tag := handler.Field(i).Tag
+		if tag.Get("command") == "" || tag.Get("hidden") != "" {
+			continue
+		}
+
+		description := cmd.UI.TranslateText(tag.Get("description"))
+		candidates = append(candidates, completionCandidate{Name: tag.Get("command"), Description: description})
+		if alias := tag.Get("alias"); alias != "" {
+			candidates = append(candidates, completionCandidate{Name: alias, Description: description})
+		}
+	}
+
+	for _, pluginConfig := range cmd.Config.Plugins() {
+		for _, pluginCommand := range pluginConfig.Commands {
+			candidates = append(candidates, completionCandidate{Name: pluginCommand.Name, Description: pluginCommand.HelpText})
+			if pluginCommand.Alias != "" {
+				candidates = append(candidates, completionCandidate{Name: pluginCommand.Alias, Description: pluginCommand.HelpText})
+			}
+		}
+	}
+
+	return filterCandidates(candidates, prefix)
+}
+
+func (cmd *CompleteCommand) displayCandidates(candidates []completionCandidate) {
+	sort.Sort(completionCandidates(candidates))
+
+	for _, candidate := range candidates {
+		if candidate.Description == "" {
+			cmd.UI.DisplayText("{{.Candidate}}", map[string]interface{}{
+				"Candidate": candidate.Name,
+			})
+		} else {
+			cmd.UI.DisplayText("{{.Candidate}}\t{{.Description}}", map[string]interface{}{
+				"Candidate":   candidate.Name,
+				"Description": candidate.Description,
+			})
+		}
+	}
+}
+
+func findCommandField(name string) (reflect.StructField, bool) {
+	handler := reflect.TypeOf(Commands)
+	return handler.FieldByNameFunc(func(fieldName string) bool {
+		field, _ := handler.FieldByName(fieldName)
+		return field.Tag.Get("command") != "" && (field.Tag.Get("command") == name || field.Tag.Get("alias") == name)
+	})
+}
+
+type completionCandidate struct {
+	Name        string
+	Description string
+}
+
+type completionCandidates []completionCandidate
+
+func (c completionCandidates) Len() int           { return len(c) }
+func (c completionCandidates) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+func (c completionCandidates) Less(i, j int) bool { return c[i].Name < c[j].Name }
+
+func filterCandidates(candidates []completionCandidate, prefix string) []completionCandidate {
+	matches := []completionCandidate{}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate.Name, prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// pluginFlagCompleter completes plugin flag values through the plugin RPC
+// service of the legacy code base.
+type pluginFlagCompleter struct{}
+
+func (pluginFlagCompleter) CompleteFlag(location string, pluginFlag configv3.PluginFlag, request plugin.CompletionRequest) ([]string, error) {
+	return cmd.CompletePluginFlag(os.Getenv("CF_TRACE"), location, plugin.Flag{
+		Name:      pluginFlag.Name,
+		ShortName: pluginFlag.ShortName,
+		Type:      plugin.FlagType(pluginFlag.Type),
+		Default:   pluginFlag.Default,
+		Usage:     pluginFlag.Usage,
+		Values:    pluginFlag.Values,
+		Complete:  pluginFlag.Complete,
+	}, request)
+}
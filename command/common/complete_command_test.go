@@ -0,0 +1,355 @@
+package common_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	. "code.cloudfoundry.org/cli/command/common"
+	"code.cloudfoundry.org/cli/command/common/commonfakes"
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("__complete Command", func() {
+	var (
+		testUI              *ui.UI
+		fakeConfig          *commandfakes.FakeConfig
+		fakeActor           *commonfakes.FakeCompleteActor
+		fakePluginCompleter *commonfakes.FakePluginFlagCompleter
+		cmd                 CompleteCommand
+		executeErr          error
+		args                []string
+	)
+
+	BeforeEach(func() {
+		testUI = ui.NewTestUI(nil, NewBuffer(), NewBuffer())
+		fakeConfig = new(commandfakes.FakeConfig)
+		fakeActor = new(commonfakes.FakeCompleteActor)
+		fakePluginCompleter = new(commonfakes.FakePluginFlagCompleter)
+
+		fakeConfig.TargetedOrganizationReturns(configv3.Organization{GUID: "some-org-guid", Name: "some-org"})
+		fakeConfig.TargetedSpaceReturns(configv3.Space{GUID: "some-space-guid", Name: "some-space"})
+		fakeConfig.PluginsReturns(map[string]configv3.Plugin{
+			"Deployer": {
+				Location: "/plugins/deployer",
+				Commands: []configv3.PluginCommand{
+					{
+						Name:     "deploy",
+						Alias:    "dep",
+						HelpText: "Deploys an app",
+						UsageDetails: configv3.PluginUsageDetails{
+							Flags: []configv3.PluginFlag{
+								{Name: "app", ShortName: "a", Type: "string", Usage: "App to deploy", Complete: true},
+								{Name: "force", ShortName: "f", Usage: "Skip confirmation"},
+							},
+						},
+					},
+					{
+						Name:     "diego",
+						HelpText: "Enable Diego",
+						UsageDetails: configv3.PluginUsageDetails{
+							Options: map[string]string{
+								"--first": "foobar",
+								"s":       "baz",
+							},
+						},
+					},
+				},
+			},
+		})
+
+		cmd = CompleteCommand{
+			UI:              testUI,
+			Config:          fakeConfig,
+			Actor:           fakeActor,
+			PluginCompleter: fakePluginCompleter,
+		}
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(args)
+	})
+
+	Context("when completing the command name", func() {
+		BeforeEach(func() {
+			args = []string{"de"}
+		})
+
+		It("displays the matching commands, plugin commands and aliases with their descriptions", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(testUI.Out).To(Say("delete\tDelete an app\n"))
+			Expect(testUI.Out).To(Say("delete-buildpack\t"))
+			Expect(testUI.Out).To(Say("dep\tDeploys an app\n"))
+			Expect(testUI.Out).To(Say("deploy\tDeploys an app\n"))
+			Expect(testUI.Out).ToNot(Say("diego"))
+		})
+
+		Context("when the word is empty", func() {
+			BeforeEach(func() {
+				args = nil
+			})
+
+			It("displays all commands", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(testUI.Out).To(Say("a\tList all apps in the target space\n"))
+				Expect(testUI.Out).To(Say("completion\tPrint a shell completion script for bash, zsh or fish\n"))
+				Expect(testUI.Out).To(Say("target\t"))
+			})
+		})
+
+		Context("when global flags come first", func() {
+			BeforeEach(func() {
+				args = []string{"-v", "ver"}
+			})
+
+			It("skips them", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(testUI.Out).To(Say("version\tPrint the version\n"))
+			})
+		})
+
+		Context("when a global flag with a value comes first", func() {
+			BeforeEach(func() {
+				args = []string{"--context", "prod", "ver"}
+			})
+
+			It("skips the flag and its value", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(testUI.Out).To(Say("version\tPrint the version\n"))
+			})
+		})
+
+		Context("when completing the value of a global flag", func() {
+			BeforeEach(func() {
+				args = []string{"--trace-har", ""}
+			})
+
+			It("displays nothing", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(testUI.Out).ToNot(Say("."))
+			})
+		})
+	})
+
+	Context("when completing the flags of a command", func() {
+		BeforeEach(func() {
+			args = []string{"t", "-"}
+		})
+
+		It("displays the long and short flags with their descriptions", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(testUI.Out).To(Say("-o\tOrganization\n"))
+			Expect(testUI.Out).To(Say("-s\tSpace\n"))
+			Expect(fakeActor.GetOrganizationsCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when completing a flag with a fixed set of values", func() {
+		BeforeEach(func() {
+			args = []string{"set-health-check", "some-app", "p"}
+		})
+
+		It("displays the values of the flag's type", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(testUI.Out).To(Say("port\n"))
+			Expect(testUI.Out).To(Say("process\n"))
+		})
+	})
+
+	Context("when completing an app name", func() {
+		BeforeEach(func() {
+			args = []string{"restart", "some-"}
+			fakeActor.GetApplicationsBySpaceReturns([]v2action.Application{
+				{Name: "some-app-2"},
+				{Name: "some-app-1"},
+				{Name: "other-app"},
+			}, nil, nil)
+		})
+
+		It("displays the matching apps in the targeted space", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeActor.GetApplicationsBySpaceCallCount()).To(Equal(1))
+			Expect(fakeActor.GetApplicationsBySpaceArgsForCall(0)).To(Equal("some-space-guid"))
+			Expect(testUI.Out).To(Say("some-app-1\nsome-app-2\n"))
+			Expect(testUI.Out).ToNot(Say("other-app"))
+		})
+
+		Context("when no space is targeted", func() {
+			BeforeEach(func() {
+				fakeConfig.TargetedSpaceReturns(configv3.Space{})
+			})
+
+			It("displays nothing", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(fakeActor.GetApplicationsBySpaceCallCount()).To(Equal(0))
+				Expect(testUI.Out).ToNot(Say("some-app"))
+			})
+		})
+
+		Context("when listing the apps fails", func() {
+			var expectedErr error
+
+			BeforeEach(func() {
+				expectedErr = errors.New("some-error")
+				fakeActor.GetApplicationsBySpaceReturns(nil, nil, expectedErr)
+			})
+
+			It("returns the error", func() {
+				Expect(executeErr).To(MatchError(expectedErr))
+			})
+		})
+	})
+
+	Context("when completing a service instance name", func() {
+		BeforeEach(func() {
+			args = []string{"bind-service", "some-app", ""}
+			fakeActor.GetServiceInstancesBySpaceReturns([]v2action.ServiceInstance{
+				{Name: "some-db"},
+			}, nil, nil)
+		})
+
+		It("displays the service instances in the targeted space", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeActor.GetServiceInstancesBySpaceArgsForCall(0)).To(Equal("some-space-guid"))
+			Expect(testUI.Out).To(Say("some-db\n"))
+			Expect(fakeActor.GetApplicationsBySpaceCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when completing a route host name", func() {
+		BeforeEach(func() {
+			args = []string{"delete-route", "example.com", "--hostname", ""}
+			fakeActor.GetSpaceRoutesReturns([]v2action.Route{
+				{Host: "some-host", Domain: "example.com"},
+				{Domain: "example.com"},
+			}, nil, nil)
+		})
+
+		It("displays the host names of the routes in the targeted space", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			spaceGUID, _ := fakeActor.GetSpaceRoutesArgsForCall(0)
+			Expect(spaceGUID).To(Equal("some-space-guid"))
+			Expect(testUI.Out).To(Say("some-host\n"))
+		})
+	})
+
+	Context("when completing an org name", func() {
+		BeforeEach(func() {
+			args = []string{"target", "-o", "o"}
+			fakeActor.GetOrganizationsReturns([]v2action.Organization{
+				{Name: "org-1"},
+				{Name: "some-org"},
+			}, nil, nil)
+		})
+
+		It("displays the matching orgs", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(testUI.Out).To(Say("org-1\n"))
+			Expect(testUI.Out).ToNot(Say("some-org"))
+		})
+	})
+
+	Context("when completing a space name", func() {
+		BeforeEach(func() {
+			args = []string{"target", "-s", ""}
+			fakeActor.GetOrganizationSpacesReturns([]v2action.Space{
+				{Name: "some-space"},
+			}, nil, nil)
+		})
+
+		It("displays the spaces in the targeted org", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(fakeActor.GetOrganizationSpacesArgsForCall(0)).To(Equal("some-org-guid"))
+			Expect(testUI.Out).To(Say("some-space\n"))
+		})
+
+		Context("when an org is given on the command line", func() {
+			BeforeEach(func() {
+				args = []string{"target", "-o", "other-org", "-s", ""}
+				fakeActor.GetOrganizationByNameReturns(v2action.Organization{GUID: "other-org-guid"}, nil, nil)
+			})
+
+			It("displays the spaces in that org", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(fakeActor.GetOrganizationByNameArgsForCall(0)).To(Equal("other-org"))
+				Expect(fakeActor.GetOrganizationSpacesArgsForCall(0)).To(Equal("other-org-guid"))
+			})
+		})
+	})
+
+	Context("when completing a plugin command", func() {
+		Context("when completing its flags", func() {
+			BeforeEach(func() {
+				args = []string{"dep", "--"}
+			})
+
+			It("displays the long flags the plugin declared", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(testUI.Out).To(Say("--app\tApp to deploy\n"))
+				Expect(testUI.Out).To(Say("--force\tSkip confirmation\n"))
+			})
+		})
+
+		Context("when the plugin only declared options", func() {
+			BeforeEach(func() {
+				args = []string{"diego", "-"}
+			})
+
+			It("displays the options as flags", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(testUI.Out).To(Say("--first\tfoobar\n"))
+				Expect(testUI.Out).To(Say("-s\tbaz\n"))
+			})
+		})
+
+		Context("when completing a flag value", func() {
+			BeforeEach(func() {
+				args = []string{"deploy", "-f", "-a", "my"}
+				fakePluginCompleter.CompleteFlagReturns([]string{"my-app"}, nil)
+			})
+
+			It("displays the values the plugin offers", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(fakePluginCompleter.CompleteFlagCallCount()).To(Equal(1))
+				location, pluginFlag, request := fakePluginCompleter.CompleteFlagArgsForCall(0)
+				Expect(location).To(Equal("/plugins/deployer"))
+				Expect(pluginFlag.Name).To(Equal("app"))
+				Expect(request).To(Equal(plugin.CompletionRequest{
+					Command: "deploy",
+					Flag:    "app",
+					Args:    []string{"-f", "-a"},
+					Prefix:  "my",
+				}))
+				Expect(testUI.Out).To(Say("my-app\n"))
+			})
+		})
+
+		Context("when the previous flag is a bool flag", func() {
+			BeforeEach(func() {
+				args = []string{"deploy", "-f", ""}
+			})
+
+			It("does not complete a value", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(fakePluginCompleter.CompleteFlagCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when the command is unknown", func() {
+		BeforeEach(func() {
+			args = []string{"not-a-command", ""}
+		})
+
+		It("displays nothing", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(testUI.Out).ToNot(Say("."))
+		})
+	})
+})
@@ -0,0 +1,96 @@
+package common
+
+import (
+	"bytes"
+	"text/template"
+
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/flag"
+)
+
+// The completion scripts ask the hidden complete command for candidates, and
+// fall back to file names when it has none.
+var completionScripts = map[string]string{
+	"bash": `# bash completion for {{.BinaryName}}
+_{{.BinaryName}}_completions() {
+    local IFS=$'\n'
+    local candidates candidate
+    candidates=($({{.BinaryName}} {{.CompleteCommand}} "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+    COMPREPLY=()
+    for candidate in "${candidates[@]}"; do
+        COMPREPLY+=("${candidate%%$'\t'*}")
+    done
+}
+complete -o default -F _{{.BinaryName}}_completions {{.BinaryName}}`,
+
+	"zsh": `#compdef {{.BinaryName}}
+# zsh completion for {{.BinaryName}}
+_{{.BinaryName}}() {
+    local -a candidates described
+    local line name
+    candidates=("${(@f)$({{.BinaryName}} {{.CompleteCommand}} "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+    for line in $candidates; do
+        [[ -z $line ]] && continue
+        name=${line%%$'\t'*}
+        if [[ $line == *$'\t'* ]]; then
+            described+=("${name//:/\\:}:${line#*$'\t'}")
+        else
+            described+=("${name//:/\\:}")
+        fi
+    done
+    if (( ${#described} )); then
+        _describe -t candidates '{{.BinaryName}}' described
+    else
+        _files
+    fi
+}
+if [[ $funcstack[1] == _{{.BinaryName}} ]]; then
+    _{{.BinaryName}} "$@"
+else
+    compdef _{{.BinaryName}} {{.BinaryName}}
+fi`,
+
+	"fish": `# fish completion for {{.BinaryName}}
+function __{{.BinaryName}}_complete
+    set -l args (commandline -opc)
+    set -e args[1]
+    set -l candidates ({{.BinaryName}} {{.CompleteCommand}} $args (commandline -ct) 2>/dev/null)
+    if test (count $candidates) -eq 0
+        __fish_complete_path (commandline -ct)
+        return
+    end
+    printf '%s\n' $candidates
+end
+complete -c {{.BinaryName}} -f -a '(__{{.BinaryName}}_complete)'`,
+}
+
+type CompletionCommand struct {
+	RequiredArgs    flag.CompletionArgs `positional-args:"yes"`
+	usage           interface{}         `usage:"CF_NAME completion SHELL\n\n   Prints a script that completes commands, aliases, flags, plugin commands and the\n   names of apps, services, spaces, orgs and routes. SHELL is bash, zsh or fish.\n\nEXAMPLES:\n   source <(CF_NAME completion bash)\n   CF_NAME completion zsh > \"${fpath[1]}/_CF_NAME\"\n   CF_NAME completion fish > ~/.config/fish/completions/CF_NAME.fish"`
+	relatedCommands interface{}         `related_commands:"help"`
+
+	UI     command.UI
+	Config command.Config
+}
+
+func (cmd *CompletionCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.Config = config
+	cmd.UI = ui
+	return nil
+}
+
+func (cmd CompletionCommand) Execute(args []string) error {
+	var script bytes.Buffer
+	err := template.Must(template.New("completion").Parse(completionScripts[cmd.RequiredArgs.Shell.Shell])).Execute(&script, map[string]string{
+		"BinaryName":      cmd.Config.BinaryName(),
+		"CompleteCommand": CompleteCommandName,
+	})
+	if err != nil {
+		return err
+	}
+
+	cmd.UI.DisplayText("{{.Script}}", map[string]interface{}{
+		"Script": script.String(),
+	})
+	return nil
+}
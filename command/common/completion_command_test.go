@@ -0,0 +1,75 @@
+package common_test
+
+import (
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	. "code.cloudfoundry.org/cli/command/common"
+	"code.cloudfoundry.org/cli/command/flag"
+	"code.cloudfoundry.org/cli/util/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("completion Command", func() {
+	var (
+		cmd        CompletionCommand
+		testUI     *ui.UI
+		fakeConfig *commandfakes.FakeConfig
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		testUI = ui.NewTestUI(nil, NewBuffer(), NewBuffer())
+		fakeConfig = new(commandfakes.FakeConfig)
+		fakeConfig.BinaryNameReturns("faceman")
+
+		cmd = CompletionCommand{
+			UI:     testUI,
+			Config: fakeConfig,
+		}
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	Context("when the shell is bash", func() {
+		BeforeEach(func() {
+			cmd.RequiredArgs = flag.CompletionArgs{Shell: flag.Shell{Shell: "bash"}}
+		})
+
+		It("displays a bash script that completes through the __complete command", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(testUI.Out).To(Say(`_faceman_completions\(\) {`))
+			Expect(testUI.Out).To(Say(`faceman __complete "\$\{COMP_WORDS\[@\]:1:COMP_CWORD\}"`))
+			Expect(testUI.Out).To(Say(`complete -o default -F _faceman_completions faceman`))
+		})
+	})
+
+	Context("when the shell is zsh", func() {
+		BeforeEach(func() {
+			cmd.RequiredArgs = flag.CompletionArgs{Shell: flag.Shell{Shell: "zsh"}}
+		})
+
+		It("displays a zsh script that completes through the __complete command", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(testUI.Out).To(Say("#compdef faceman"))
+			Expect(testUI.Out).To(Say(`faceman __complete "\$\{\(@\)words\[2,CURRENT\]\}"`))
+			Expect(testUI.Out).To(Say("compdef _faceman faceman"))
+		})
+	})
+
+	Context("when the shell is fish", func() {
+		BeforeEach(func() {
+			cmd.RequiredArgs = flag.CompletionArgs{Shell: flag.Shell{Shell: "fish"}}
+		})
+
+		It("displays a fish script that completes through the __complete command", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(testUI.Out).To(Say("function __faceman_complete"))
+			Expect(testUI.Out).To(Say(`faceman __complete \$args \(commandline -ct\)`))
+			Expect(testUI.Out).To(Say(`complete -c faceman -f -a '\(__faceman_complete\)'`))
+		})
+	})
+})
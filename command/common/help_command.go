@@ -299,19 +299,34 @@ func (cmd HelpCommand) displayCommand() error {
 
 func (cmd HelpCommand) environmentalVariablesTableData() [][]string {
 	return [][]string{
+		{"CF_CA_CERT=path/to/ca.pem", cmd.UI.TranslateText("Trust the certificate authorities in this PEM bundle in addition to the system ones")},
+		{"CF_CACHE_TTL=60", cmd.UI.TranslateText("Reuse API responses for this many seconds, then revalidate them with the server")},
+		{"CF_CLIENT_CERT=path/to/cert.pem", cmd.UI.TranslateText("Present this PEM client certificate to servers that request one")},
+		{"CF_CLIENT_KEY=path/to/key.pem", cmd.UI.TranslateText("Private key for CF_CLIENT_CERT")},
 		{"CF_COLOR=false", cmd.UI.TranslateText("Do not colorize output")},
+		{"CF_CONCURRENCY=4", cmd.UI.TranslateText("Max number of API requests made at the same time by bulk operations")},
+		{"CF_CONTEXT=prod", cmd.UI.TranslateText("Run commands against a saved context (see 'cf context')")},
+		{"CF_CREDENTIAL_STORE=secret-service", cmd.UI.TranslateText("Keep tokens in secret-service (with the secret-tool command), encrypted-file (with CF_CREDENTIAL_PASSPHRASE) or a credential helper instead of the config file")},
 		{"CF_DIAL_TIMEOUT=5", cmd.UI.TranslateText("Max wait time to establish a connection, including name resolution, in seconds")},
 		{"CF_HOME=path/to/dir/", cmd.UI.TranslateText("Override path to default config directory")},
 		{"CF_PLUGIN_HOME=path/to/dir/", cmd.UI.TranslateText("Override path to default plugin config directory")},
+		{"CF_RETRY_BASE_DELAY=500ms", cmd.UI.TranslateText("Initial wait before retrying a failed API request; doubles on each retry")},
+		{"CF_RETRY_MAX=2", cmd.UI.TranslateText("Max number of times to retry a failed or rate limited API request")},
+		{"CF_RETRY_MAX_DELAY=30s", cmd.UI.TranslateText("Max wait between retries, including waits requested by the server")},
+		{"CF_SSH_HOST_KEY_CHECKING=strict", cmd.UI.TranslateText("Check 'cf ssh' host keys against the known hosts file: off, warn or strict")},
 		{"CF_TRACE=true", cmd.UI.TranslateText("Print API request diagnostics to stdout")},
 		{"CF_TRACE=path/to/trace.log", cmd.UI.TranslateText("Append API request diagnostics to a log file")},
+		{"CF_TRACE_FORMAT=har", cmd.UI.TranslateText("Write CF_TRACE files as HTTP Archives (HAR) instead of text")},
 		{"https_proxy=proxy.example.com:8080", cmd.UI.TranslateText("Enable HTTP proxying for API requests")},
 	}
 }
 
 func (cmd HelpCommand) globalOptionsTableData() [][]string {
 	return [][]string{
+		{"--context NAME", cmd.UI.TranslateText("Run the command against a saved context")},
 		{"--help, -h", cmd.UI.TranslateText("Show help")},
+		{"--no-cache", cmd.UI.TranslateText("Do not reuse cached API responses (see CF_CACHE_TTL)")},
+		{"--trace-har FILE", cmd.UI.TranslateText("Record API requests and responses in an HTTP Archive (HAR) file")},
 		{"-v", cmd.UI.TranslateText("Print API request diagnostics to stdout")},
 	}
 }
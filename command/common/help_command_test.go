@@ -326,6 +326,50 @@ var _ = Describe("help Command", func() {
 			})
 		})
 
+		Describe("plug-in command with structured flags", func() {
+			BeforeEach(func() {
+				cmd.OptionalArgs = flag.CommandName{
+					CommandName: "deploy",
+				}
+
+				fakeConfig.PluginsReturns(map[string]configv3.Plugin{
+					"Deployer": configv3.Plugin{
+						Commands: []configv3.PluginCommand{
+							{
+								Name:     "deploy",
+								HelpText: "Deploys an app",
+								UsageDetails: configv3.PluginUsageDetails{
+									Usage: "cf deploy [--app APP] [--env ENV]",
+									Options: map[string]string{
+										"--ignored": "only shown when there are no flags",
+									},
+									Flags: []configv3.PluginFlag{
+										{Name: "app", ShortName: "a", Type: "string", Usage: "App to deploy"},
+										{Name: "env", Type: "string", Default: "dev", Usage: "Environment to deploy to"},
+										{ShortName: "f", Usage: "Deploy without confirmation"},
+									},
+								},
+							},
+						},
+					},
+				})
+
+				fakeActor.CommandInfoByNameReturns(sharedaction.CommandInfo{},
+					sharedaction.ErrorInvalidCommand{CommandName: "deploy"})
+			})
+
+			It("displays the flags like those of core commands", func() {
+				err := cmd.Execute(nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(testUI.Out).To(Say("OPTIONS:"))
+				Expect(testUI.Out).To(Say("--app, -a\\s+App to deploy\n"))
+				Expect(testUI.Out).To(Say("--env\\s+Environment to deploy to \\(Default: dev\\)"))
+				Expect(testUI.Out).To(Say("-f\\s+Deploy without confirmation"))
+				Expect(testUI.Out).ToNot(Say("--ignored"))
+			})
+		})
+
 		Describe("plug-in alias", func() {
 			BeforeEach(func() {
 				cmd.OptionalArgs = flag.CommandName{
@@ -410,8 +454,11 @@ var _ = Describe("help Command", func() {
 			Expect(testUI.Out).To(Say("  install-plugin    list-plugin-repos"))
 
 			Expect(testUI.Out).To(Say("Global options:"))
-			Expect(testUI.Out).To(Say("  --help, -h                         Show help"))
-			Expect(testUI.Out).To(Say("  -v                                 Print API request diagnostics to stdout"))
+			Expect(testUI.Out).To(Say("  --context NAME                           Run the command against a saved context"))
+			Expect(testUI.Out).To(Say("  --help, -h                               Show help"))
+			Expect(testUI.Out).To(Say("  --no-cache                               Do not reuse cached API responses \\(see CF_CACHE_TTL\\)"))
+			Expect(testUI.Out).To(Say("  --trace-har FILE                         Record API requests and responses in an HTTP Archive \\(HAR\\) file"))
+			Expect(testUI.Out).To(Say("  -v                                       Print API request diagnostics to stdout"))
 
 			Expect(testUI.Out).To(Say("These are commonly used commands. Use 'cf help -a' to see all, with descriptions."))
 			Expect(testUI.Out).To(Say("See 'cf help <command>' to read about a specific command."))
@@ -575,17 +622,32 @@ var _ = Describe("help Command", func() {
 				Expect(testUI.Out).To(Say("   enable-diego\\s+enable Diego support for an app"))
 
 				Expect(testUI.Out).To(Say("ENVIRONMENT VARIABLES:"))
+				Expect(testUI.Out).To(Say("   CF_CA_CERT=path/to/ca.pem          Trust the certificate authorities in this PEM bundle in addition to the system ones"))
+				Expect(testUI.Out).To(Say("   CF_CACHE_TTL=60                    Reuse API responses for this many seconds, then revalidate them with the server"))
+				Expect(testUI.Out).To(Say("   CF_CLIENT_CERT=path/to/cert.pem    Present this PEM client certificate to servers that request one"))
+				Expect(testUI.Out).To(Say("   CF_CLIENT_KEY=path/to/key.pem      Private key for CF_CLIENT_CERT"))
 				Expect(testUI.Out).To(Say("   CF_COLOR=false                     Do not colorize output"))
+				Expect(testUI.Out).To(Say("   CF_CONCURRENCY=4                   Max number of API requests made at the same time by bulk operations"))
+				Expect(testUI.Out).To(Say("   CF_CONTEXT=prod                    Run commands against a saved context \\(see 'cf context'\\)"))
+				Expect(testUI.Out).To(Say("   CF_CREDENTIAL_STORE=secret-service Keep tokens in secret-service \\(with the secret-tool command\\), encrypted-file \\(with CF_CREDENTIAL_PASSPHRASE\\) or a credential helper instead of the config file"))
 				Expect(testUI.Out).To(Say("   CF_DIAL_TIMEOUT=5                  Max wait time to establish a connection, including name resolution, in seconds"))
 				Expect(testUI.Out).To(Say("   CF_HOME=path/to/dir/               Override path to default config directory"))
 				Expect(testUI.Out).To(Say("   CF_PLUGIN_HOME=path/to/dir/        Override path to default plugin config directory"))
+				Expect(testUI.Out).To(Say("   CF_RETRY_BASE_DELAY=500ms          Initial wait before retrying a failed API request; doubles on each retry"))
+				Expect(testUI.Out).To(Say("   CF_RETRY_MAX=2                     Max number of times to retry a failed or rate limited API request"))
+				Expect(testUI.Out).To(Say("   CF_RETRY_MAX_DELAY=30s             Max wait between retries, including waits requested by the server"))
+				Expect(testUI.Out).To(Say("   CF_SSH_HOST_KEY_CHECKING=strict    Check 'cf ssh' host keys against the known hosts file: off, warn or strict"))
 				Expect(testUI.Out).To(Say("   CF_TRACE=true                      Print API request diagnostics to stdout"))
 				Expect(testUI.Out).To(Say("   CF_TRACE=path/to/trace.log         Append API request diagnostics to a log file"))
+				Expect(testUI.Out).To(Say("   CF_TRACE_FORMAT=har                Write CF_TRACE files as HTTP Archives \\(HAR\\) instead of text"))
 				Expect(testUI.Out).To(Say("   https_proxy=proxy.example.com:8080 Enable HTTP proxying for API requests"))
 
 				Expect(testUI.Out).To(Say("GLOBAL OPTIONS:"))
-				Expect(testUI.Out).To(Say("   --help, -h                         Show help"))
-				Expect(testUI.Out).To(Say("   -v                                 Print API request diagnostics to stdout"))
+				Expect(testUI.Out).To(Say("   --context NAME                           Run the command against a saved context"))
+				Expect(testUI.Out).To(Say("   --help, -h                               Show help"))
+				Expect(testUI.Out).To(Say("   --no-cache                               Do not reuse cached API responses \\(see CF_CACHE_TTL\\)"))
+				Expect(testUI.Out).To(Say("   --trace-har FILE                         Record API requests and responses in an HTTP Archive \\(HAR\\) file"))
+				Expect(testUI.Out).To(Say("   -v                                       Print API request diagnostics to stdout"))
 			})
 
 			Context("when there are multiple installed plugins", func() {
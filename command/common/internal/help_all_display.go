@@ -5,7 +5,7 @@ var HelpCategoryList = []HelpCategory{
 		CategoryName: "GETTING STARTED:",
 		CommandList: [][]string{
 			{"help", "version", "login", "logout", "passwd", "target"},
-			{"api", "auth"},
+			{"api", "auth", "context"},
 		},
 	},
 	{
@@ -123,19 +123,19 @@ var HelpCategoryList = []HelpCategory{
 	{
 		CategoryName: "ADVANCED:",
 		CommandList: [][]string{
-			{"curl", "config", "oauth-token", "ssh-code"},
+			{"curl", "config", "oauth-token", "token-info", "ssh-code", "completion"},
 		},
 	},
 	{
 		CategoryName: "ADD/REMOVE PLUGIN REPOSITORY:",
 		CommandList: [][]string{
-			{"add-plugin-repo", "remove-plugin-repo", "list-plugin-repos", "repo-plugins"},
+			{"add-plugin-repo", "remove-plugin-repo", "list-plugin-repos", "repo-plugins", "plugin-repo"},
 		},
 	},
 	{
 		CategoryName: "ADD/REMOVE PLUGIN:",
 		CommandList: [][]string{
-			{"plugins", "install-plugin", "uninstall-plugin"},
+			{"plugins", "install-plugin", "uninstall-plugin", "update-plugin"},
 		},
 	},
 }
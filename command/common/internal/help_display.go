@@ -24,6 +24,19 @@ func ConvertPluginToCommandInfo(plugin configv3.PluginCommand) sharedaction.Comm
 		Flags:       []sharedaction.CommandFlag{},
 	}
 
+	if len(plugin.UsageDetails.Flags) > 0 {
+		for _, flag := range plugin.UsageDetails.Flags {
+			commandInfo.Flags = append(commandInfo.Flags,
+				sharedaction.CommandFlag{
+					Short:       flag.ShortName,
+					Long:        flag.Name,
+					Description: flag.Usage,
+					Default:     flag.Default,
+				})
+		}
+		return commandInfo
+	}
+
 	flagNames := sorting.Alphabetic{}
 	for flag := range plugin.UsageDetails.Options {
 		flagNames = append(flagNames, flag)
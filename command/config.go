@@ -3,7 +3,10 @@ package command
 import (
 	"time"
 
+	"code.cloudfoundry.org/cli/api/retry"
 	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/contexts"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 )
 
 //go:generate counterfeiter . Config
@@ -12,11 +15,18 @@ import (
 type Config interface {
 	APIVersion() string
 	AccessToken() string
+	AddContext(name string) error
 	BinaryName() string
 	BinaryVersion() string
+	CacheDirectory() string
+	CacheTTL() time.Duration
 	ColorEnabled() configv3.ColorSetting
+	Concurrency() int
+	Contexts() ([]contexts.Context, string, error)
 	CurrentUser() (configv3.User, error)
+	DeleteContext(name string) error
 	DialTimeout() time.Duration
+	RetryPolicy() retry.Policy
 	Experimental() bool
 	HasTargetedOrganization() bool
 	HasTargetedSpace() bool
@@ -32,13 +42,19 @@ type Config interface {
 	SetSpaceInformation(guid string, name string, allowSSH bool)
 	SetTargetInformation(api string, apiVersion string, auth string, minCLIVersion string, doppler string, uaa string, routing string, skipSSLValidation bool)
 	SetTokenInformation(accessToken string, refreshToken string, sshOAuthClient string)
+	SetUAAClientCredentials(client string, clientSecret string)
+	SetUAAGrantType(uaaGrantType string)
 	StagingTimeout() time.Duration
 	StartupTimeout() time.Duration
 	SkipSSLValidation() bool
 	Target() string
 	TargetedOrganization() configv3.Organization
 	TargetedSpace() configv3.Space
+	TLSFiles() tlsconfig.Files
+	TraceFormat() string
+	UseContext(name string) error
 	UAAOAuthClient() string
+	UAAGrantType() string
 	UAAOAuthClientSecret() string
 	UnsetSpaceInformation()
 	UnsetOrganizationInformation()
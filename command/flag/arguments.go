@@ -40,6 +40,11 @@ type APIPath struct {
 	Path string `positional-arg-name:"PATH" required:"true" description:"The API endpoint"`
 }
 
+type ContextArgs struct {
+	Action string `positional-arg-name:"ACTION" required:"true" description:"The action to perform: add, use, list or delete"`
+	Name   string `positional-arg-name:"NAME" description:"The context name"`
+}
+
 type PluginRepoName struct {
 	PluginRepoName string `positional-arg-name:"REPO_NAME" required:"true" description:"The plugin repo name"`
 }
@@ -48,6 +53,10 @@ type PluginName struct {
 	PluginName string `positional-arg-name:"PLUGIN_NAME" required:"true" description:"The plugin name"`
 }
 
+type OptionalPluginName struct {
+	PluginName string `positional-arg-name:"PLUGIN_NAME" description:"The plugin name"`
+}
+
 type Quota struct {
 	Quota string `positional-arg-name:"QUOTA" required:"true" description:"The organization quota"`
 }
@@ -229,7 +238,7 @@ type SetOrgRoleArgs struct {
 type SetSpaceRoleArgs struct {
 	Username     string    `positional-arg-name:"USERNAME" required:"true" description:"The user"`
 	Organization string    `positional-arg-name:"ORG" required:"true" description:"The organization"`
-	Space        string    `positional-arg-name:"ORG" required:"true" description:"The space"`
+	Space        string    `positional-arg-name:"SPACE" required:"true" description:"The space"`
 	Role         SpaceRole `positional-arg-name:"ROLE" required:"true" description:"The space role"`
 }
 
@@ -274,6 +283,11 @@ type AddPluginRepoArgs struct {
 	PluginRepoURL  string `positional-arg-name:"URL" required:"true" description:"The URL to the plugin repo"`
 }
 
+type PluginRepoServeArgs struct {
+	Subcommand string `positional-arg-name:"serve" required:"true" description:"The plugin-repo subcommand"`
+	Dir        string `positional-arg-name:"DIR" required:"true" description:"The directory of plugin binaries to serve"`
+}
+
 type InstallPluginArgs struct {
 	LocalPath string `positional-arg-name:"LOCAL_PATH/TO/PLUGIN" description:"The local path to the plugin, if the plugin exists locally"`
 	URL       string `positional-arg-name:"URL" description:"The URL to the plugin, if the plugin exists online"`
@@ -288,3 +302,7 @@ type TerminateTaskArgs struct {
 	AppName    string `positional-arg-name:"APP_NAME" required:"true" description:"The application name"`
 	SequenceID string `positional-arg-name:"TASK_ID" required:"true" description:"The task's unique sequence ID"`
 }
+
+type CompletionArgs struct {
+	Shell Shell `positional-arg-name:"SHELL" required:"true" description:"The shell to generate a completion script for"`
+}
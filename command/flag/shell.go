@@ -0,0 +1,29 @@
+package flag
+
+import (
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+)
+
+type Shell struct {
+	Shell string
+}
+
+func (_ Shell) Complete(prefix string) []flags.Completion {
+	return completions([]string{"bash", "fish", "zsh"}, prefix, false)
+}
+
+func (s *Shell) UnmarshalFlag(val string) error {
+	valLower := strings.ToLower(val)
+	switch valLower {
+	case "bash", "zsh", "fish":
+		s.Shell = valLower
+	default:
+		return &flags.Error{
+			Type:    flags.ErrRequired,
+			Message: `SHELL must be "bash", "zsh", or "fish"`,
+		}
+	}
+	return nil
+}
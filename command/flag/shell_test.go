@@ -0,0 +1,58 @@
+package flag_test
+
+import (
+	. "code.cloudfoundry.org/cli/command/flag"
+	flags "github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Shell", func() {
+	var shell Shell
+
+	Describe("Complete", func() {
+		DescribeTable("returns list of completions",
+			func(prefix string, matches []flags.Completion) {
+				completions := shell.Complete(prefix)
+				Expect(completions).To(Equal(matches))
+			},
+			Entry("returns 'bash' when passed 'b'", "b",
+				[]flags.Completion{{Item: "bash"}}),
+			Entry("returns 'zsh' when passed 'Z'", "Z",
+				[]flags.Completion{{Item: "zsh"}}),
+			Entry("completes to 'bash', 'fish', and 'zsh' when passed nothing", "",
+				[]flags.Completion{{Item: "bash"}, {Item: "fish"}, {Item: "zsh"}}),
+			Entry("completes to nothing when passed 'wut'", "wut",
+				[]flags.Completion{}),
+		)
+	})
+
+	Describe("UnmarshalFlag", func() {
+		BeforeEach(func() {
+			shell = Shell{}
+		})
+
+		DescribeTable("downcases and sets shell",
+			func(settingShell string, expectedShell string) {
+				err := shell.UnmarshalFlag(settingShell)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(shell.Shell).To(Equal(expectedShell))
+			},
+			Entry("sets 'bash' when passed 'bash'", "bash", "bash"),
+			Entry("sets 'zsh' when passed 'ZSH'", "ZSH", "zsh"),
+			Entry("sets 'fish' when passed 'fish'", "fish", "fish"),
+		)
+
+		Context("when passed anything else", func() {
+			It("returns an error", func() {
+				err := shell.UnmarshalFlag("tcsh")
+				Expect(err).To(MatchError(&flags.Error{
+					Type:    flags.ErrRequired,
+					Message: `SHELL must be "bash", "zsh", or "fish"`,
+				}))
+				Expect(shell.Shell).To(BeEmpty())
+			})
+		})
+	})
+})
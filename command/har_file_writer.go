@@ -0,0 +1,47 @@
+package command
+
+import (
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/cli/util/har"
+	"code.cloudfoundry.org/cli/version"
+)
+
+// HARFileWriter records requests and responses in HTTP Archive files. Bodies
+// are redacted with SanitizeJSON.
+type HARFileWriter struct {
+	ui        UI
+	recorders []*har.Recorder
+}
+
+func NewHARFileWriter(ui UI, filePaths []string) *HARFileWriter {
+	writer := &HARFileWriter{ui: ui}
+	for _, filePath := range filePaths {
+		writer.recorders = append(writer.recorders, NewHARRecorder(filePath))
+	}
+	return writer
+}
+
+// NewHARRecorder returns a recorder for a single HAR file that redacts bodies
+// with SanitizeJSON.
+func NewHARRecorder(filePath string) *har.Recorder {
+	return har.NewRecorder(filePath, har.Creator{
+		Name:    "cf",
+		Version: version.VersionString(),
+	}, SanitizeJSON)
+}
+
+func (writer *HARFileWriter) Record(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte, started time.Time) error {
+	for _, recorder := range writer.recorders {
+		err := recorder.Record(request, requestBody, response, responseBody, started)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (writer *HARFileWriter) HandleInternalError(err error) {
+	writer.ui.DisplayWarning(err.Error())
+}
@@ -0,0 +1,81 @@
+package command_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/util/har"
+	"code.cloudfoundry.org/cli/util/ui"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("HAR File Writer", func() {
+	var (
+		testUI  *ui.UI
+		writer  *HARFileWriter
+		tmpdir  string
+		harFile string
+	)
+
+	BeforeEach(func() {
+		testUI = ui.NewTestUI(NewBuffer(), NewBuffer(), NewBuffer())
+		var err error
+		tmpdir, err = ioutil.TempDir("", "har_file_writer")
+		Expect(err).ToNot(HaveOccurred())
+
+		harFile = filepath.Join(tmpdir, "trace.har")
+		writer = NewHARFileWriter(testUI, []string{harFile})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpdir)
+	})
+
+	Describe("Record", func() {
+		It("writes the entry with secrets redacted by SanitizeJSON", func() {
+			request, err := http.NewRequest(http.MethodPut, "https://api.example.com/v2/users/guid", bytes.NewBufferString(""))
+			Expect(err).ToNot(HaveOccurred())
+			request.Header.Set("Authorization", "bearer some-token")
+			request.Header.Set("Content-Type", "application/json")
+
+			response := &http.Response{
+				StatusCode: http.StatusOK,
+				Proto:      "HTTP/1.1",
+				Header:     http.Header{"Content-Type": {"application/json"}},
+			}
+
+			err = writer.Record(request, []byte(`{"password":"hunter2","name":"admin"}`), response, []byte(`{"metadata":{"guid":"guid"}}`), time.Now())
+			Expect(err).ToNot(HaveOccurred())
+
+			raw, err := ioutil.ReadFile(harFile)
+			Expect(err).ToNot(HaveOccurred())
+
+			var archive har.Archive
+			Expect(json.Unmarshal(raw, &archive)).To(Succeed())
+			Expect(archive.Log.Creator.Name).To(Equal("cf"))
+			Expect(archive.Log.Entries).To(HaveLen(1))
+
+			entry := archive.Log.Entries[0]
+			Expect(entry.Request.Headers).To(ContainElement(har.NameValue{Name: "Authorization", Value: RedactedValue}))
+			Expect(entry.Request.PostData.Text).To(MatchJSON(`{"password":"[PRIVATE DATA HIDDEN]","name":"admin"}`))
+			Expect(entry.Response.Content.Text).To(MatchJSON(`{"metadata":{"guid":"guid"}}`))
+		})
+	})
+
+	Describe("HandleInternalError", func() {
+		It("displays the error as a warning", func() {
+			writer.HandleInternalError(errors.New("some-error"))
+			Expect(testUI.Err).To(Say("some-error"))
+		})
+	})
+})
@@ -114,10 +114,16 @@ func (display *RequestLoggerFileWriter) HandleInternalError(err error) {
 	display.ui.DisplayWarning(err.Error())
 }
 
+// Start opens the log files. It waits for any other request being logged to
+// be stopped first, and a successful Start must be followed by Stop.
 func (display *RequestLoggerFileWriter) Start() error {
+	requestLoggerLock.Lock()
+
 	for _, filePath := range display.filePaths {
 		logFile, err := os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
 		if err != nil {
+			display.closeLogFiles()
+			requestLoggerLock.Unlock()
 			return err
 		}
 
@@ -127,13 +133,22 @@ func (display *RequestLoggerFileWriter) Start() error {
 }
 
 func (display *RequestLoggerFileWriter) Stop() error {
+	defer requestLoggerLock.Unlock()
+
 	for _, logFile := range display.logFiles {
 		logFile.WriteString("\n")
+	}
+	return display.closeLogFiles()
+}
+
+func (display *RequestLoggerFileWriter) closeLogFiles() error {
+	var closeErr error
+	for _, logFile := range display.logFiles {
 		err := logFile.Close()
-		if err != nil {
-			return err
+		if err != nil && closeErr == nil {
+			closeErr = err
 		}
 	}
 	display.logFiles = []*os.File{}
-	return nil
+	return closeErr
 }
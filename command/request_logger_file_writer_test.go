@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	. "code.cloudfoundry.org/cli/command"
@@ -203,6 +204,45 @@ var _ = Describe("Request Logger File Writer", func() {
 			err := errors.New("foobar")
 			display.HandleInternalError(err)
 			Expect(testUI.Err).To(Say("foobar"))
+
+			err = display.Stop()
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("when requests are logged at the same time", func() {
+		It("writes each request without interleaving it with the others", func() {
+			err := display.Stop()
+			Expect(err).ToNot(HaveOccurred())
+
+			display = NewRequestLoggerFileWriter(testUI, []string{logFile1})
+			passedTime := time.Now()
+			errs := make(chan error, 10)
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					if err := display.Start(); err != nil {
+						errs <- err
+						return
+					}
+					display.DisplayType(fmt.Sprintf("request-%d", i), passedTime)
+					display.DisplayHeader("key", fmt.Sprintf("value-%d", i))
+					errs <- display.Stop()
+				}(i)
+			}
+			wg.Wait()
+			close(errs)
+			for err := range errs {
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			contents, err := ioutil.ReadFile(logFile1)
+			Expect(err).ToNot(HaveOccurred())
+			for i := 0; i < 10; i++ {
+				Expect(string(contents)).To(ContainSubstring(fmt.Sprintf("request-%d: [%s]\nkey: value-%d\n\n", i, passedTime.Format(time.RFC3339), i)))
+			}
 		})
 	})
 })
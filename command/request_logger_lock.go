@@ -0,0 +1,9 @@
+package command
+
+import "sync"
+
+// requestLoggerLock is held by the request logger outputs from Start to Stop,
+// so that requests made at the same time, by several goroutines or by the
+// Cloud Controller and UAA clients, are logged one after the other instead of
+// interleaved.
+var requestLoggerLock sync.Mutex
@@ -81,9 +81,16 @@ func (display *RequestLoggerTerminalDisplay) HandleInternalError(err error) {
 	display.ui.DisplayWarning(err.Error())
 }
 
-func (display *RequestLoggerTerminalDisplay) Start() error { return nil }
+// Start waits for any other request being logged to be stopped. It must be
+// followed by Stop.
+func (display *RequestLoggerTerminalDisplay) Start() error {
+	requestLoggerLock.Lock()
+	return nil
+}
 
 func (display *RequestLoggerTerminalDisplay) Stop() error {
+	defer requestLoggerLock.Unlock()
+
 	display.ui.DisplayNewline()
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/cli/command"
 	"code.cloudfoundry.org/cli/command/flag"
 	"code.cloudfoundry.org/cli/command/v2/shared"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 )
 
 //go:generate counterfeiter . APIActor
@@ -90,10 +91,17 @@ func (cmd *ApiCommand) setAPI() error {
 
 	apiURL := processURL(cmd.OptionalArgs.URL)
 
-	_, err := cmd.Actor.SetTarget(cmd.Config, v2action.TargetSettings{
-		URL:               apiURL,
-		SkipSSLValidation: cmd.SkipSSLValidation,
-		DialTimeout:       cmd.Config.DialTimeout(),
+	certificates, err := tlsconfig.Load(cmd.Config.TLSFiles())
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.Actor.SetTarget(cmd.Config, v2action.TargetSettings{
+		URL:                apiURL,
+		SkipSSLValidation:  cmd.SkipSSLValidation,
+		DialTimeout:        cmd.Config.DialTimeout(),
+		RootCAs:            certificates.RootCAs,
+		ClientCertificates: certificates.ClientCertificates,
 	})
 	if err != nil {
 		return shared.HandleError(err)
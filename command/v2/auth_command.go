@@ -1,24 +1,67 @@
 package v2
 
 import (
-	"os"
-
-	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/uaa"
 	"code.cloudfoundry.org/cli/command"
 	"code.cloudfoundry.org/cli/command/flag"
+	"code.cloudfoundry.org/cli/command/v2/shared"
 )
 
+//go:generate counterfeiter . AuthActor
+
+type AuthActor interface {
+	Authenticate(config v2action.Config, ID string, secret string, grantType uaa.GrantType) error
+}
+
 type AuthCommand struct {
-	RequiredArgs    flag.Authentication `positional-args:"yes"`
-	usage           interface{}         `usage:"CF_NAME auth USERNAME PASSWORD\n\nWARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\nEXAMPLES:\n   CF_NAME auth name@example.com \"my password\" (use quotes for passwords with a space)\n   CF_NAME auth name@example.com \"\\\"password\\\"\" (escape quotes if used in password)"`
-	relatedCommands interface{}         `related_commands:"api, login, target"`
+	RequiredArgs      flag.Authentication `positional-args:"yes"`
+	ClientCredentials bool                `long:"client-credentials" description:"Use (non-user) service account (also called client credentials)"`
+	usage             interface{}         `usage:"CF_NAME auth USERNAME PASSWORD\n   CF_NAME auth CLIENT_ID CLIENT_SECRET --client-credentials\n\nWARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\nEXAMPLES:\n   CF_NAME auth name@example.com \"my password\" (use quotes for passwords with a space)\n   CF_NAME auth name@example.com \"\\\"password\\\"\" (escape quotes if used in password)\n   CF_NAME auth my-ci-client \"my secret\" --client-credentials"`
+	relatedCommands   interface{}         `related_commands:"api, login, target"`
+
+	UI     command.UI
+	Config command.Config
+	Actor  AuthActor
 }
 
-func (_ AuthCommand) Setup(config command.Config, ui command.UI) error {
+func (cmd *AuthCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.UI = ui
+	cmd.Config = config
+
+	ccClient, uaaClient, err := shared.NewClients(config, ui)
+	if err != nil {
+		return err
+	}
+	cmd.Actor = v2action.NewActor(ccClient, uaaClient)
+
 	return nil
 }
 
-func (_ AuthCommand) Execute(args []string) error {
-	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+func (cmd *AuthCommand) Execute(args []string) error {
+	grantType := uaa.GrantTypePassword
+	if cmd.ClientCredentials {
+		grantType = uaa.GrantTypeClientCredentials
+	} else if cmd.Config.UAAGrantType() == string(uaa.GrantTypeClientCredentials) {
+		return shared.PasswordGrantTypeLogoutRequiredError{
+			BinaryName: cmd.Config.BinaryName(),
+		}
+	}
+
+	cmd.UI.DisplayTextWithFlavor("API endpoint: {{.Endpoint}}", map[string]interface{}{
+		"Endpoint": cmd.Config.Target(),
+	})
+	cmd.UI.DisplayText("Authenticating...")
+
+	err := cmd.Actor.Authenticate(cmd.Config, cmd.RequiredArgs.Username, cmd.RequiredArgs.Password, grantType)
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	cmd.UI.DisplayOK()
+	cmd.UI.DisplayTextWithFlavor("Use '{{.Command}}' to view or set your target org and space.", map[string]interface{}{
+		"Command": cmd.Config.BinaryName() + " target",
+	})
+
 	return nil
 }
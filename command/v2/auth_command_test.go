@@ -0,0 +1,118 @@
+package v2_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/api/uaa"
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	"code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/command/v2/shared"
+	"code.cloudfoundry.org/cli/command/v2/v2fakes"
+	"code.cloudfoundry.org/cli/util/ui"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("auth Command", func() {
+	var (
+		cmd        v2.AuthCommand
+		testUI     *ui.UI
+		fakeActor  *v2fakes.FakeAuthActor
+		fakeConfig *commandfakes.FakeConfig
+		binaryName string
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		testUI = ui.NewTestUI(nil, NewBuffer(), NewBuffer())
+		fakeActor = new(v2fakes.FakeAuthActor)
+		fakeConfig = new(commandfakes.FakeConfig)
+
+		cmd = v2.AuthCommand{
+			UI:     testUI,
+			Config: fakeConfig,
+			Actor:  fakeActor,
+		}
+		cmd.RequiredArgs.Username = "some-id"
+		cmd.RequiredArgs.Password = "some-secret"
+
+		binaryName = "faceman"
+		fakeConfig.BinaryNameReturns(binaryName)
+		fakeConfig.TargetReturns("some-api-target")
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	Context("when authenticating as a user", func() {
+		It("authenticates with the password grant", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+
+			Expect(testUI.Out).To(Say("API endpoint: some-api-target"))
+			Expect(testUI.Out).To(Say("Authenticating..."))
+			Expect(testUI.Out).To(Say("OK"))
+			Expect(testUI.Out).To(Say("Use 'faceman target' to view or set your target org and space."))
+
+			Expect(fakeActor.AuthenticateCallCount()).To(Equal(1))
+			config, ID, secret, grantType := fakeActor.AuthenticateArgsForCall(0)
+			Expect(config).To(Equal(fakeConfig))
+			Expect(ID).To(Equal("some-id"))
+			Expect(secret).To(Equal("some-secret"))
+			Expect(grantType).To(Equal(uaa.GrantTypePassword))
+		})
+
+		Context("when a service account is logged in", func() {
+			BeforeEach(func() {
+				fakeConfig.UAAGrantTypeReturns("client_credentials")
+			})
+
+			It("returns a PasswordGrantTypeLogoutRequiredError", func() {
+				Expect(executeErr).To(MatchError(shared.PasswordGrantTypeLogoutRequiredError{BinaryName: binaryName}))
+				Expect(fakeActor.AuthenticateCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when --client-credentials is passed", func() {
+		BeforeEach(func() {
+			cmd.ClientCredentials = true
+			fakeConfig.UAAGrantTypeReturns("client_credentials")
+		})
+
+		It("authenticates with the client credentials grant", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+
+			Expect(fakeActor.AuthenticateCallCount()).To(Equal(1))
+			_, ID, secret, grantType := fakeActor.AuthenticateArgsForCall(0)
+			Expect(ID).To(Equal("some-id"))
+			Expect(secret).To(Equal("some-secret"))
+			Expect(grantType).To(Equal(uaa.GrantTypeClientCredentials))
+		})
+	})
+
+	Context("when the credentials are rejected", func() {
+		BeforeEach(func() {
+			fakeActor.AuthenticateReturns(uaa.BadCredentialsError{Message: "Bad credentials"})
+		})
+
+		It("returns a BadCredentialsError", func() {
+			Expect(executeErr).To(MatchError(shared.BadCredentialsError{}))
+			Expect(testUI.Out).ToNot(Say("OK"))
+		})
+	})
+
+	Context("when authentication fails", func() {
+		var expectedErr error
+
+		BeforeEach(func() {
+			expectedErr = errors.New("some error")
+			fakeActor.AuthenticateReturns(expectedErr)
+		})
+
+		It("returns the error", func() {
+			Expect(executeErr).To(MatchError(expectedErr))
+		})
+	})
+})
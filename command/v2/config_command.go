@@ -9,11 +9,16 @@ import (
 )
 
 type ConfigCommand struct {
-	AsyncTimeout int               `long:"async-timeout" description:"Timeout for async HTTP requests"`
-	Color        flag.Color        `long:"color" description:"Enable or disable color"`
-	Locale       flag.Locale       `long:"locale" description:"Set default locale. If LOCALE is 'CLEAR', previous locale is deleted."`
-	Trace        flag.PathWithBool `long:"trace" description:"Trace HTTP requests"`
-	usage        interface{}       `usage:"CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)]"`
+	AsyncTimeout  int               `long:"async-timeout" description:"Timeout for async HTTP requests"`
+	CACert        string            `long:"ca-cert" description:"PEM bundle of additional certificate authorities to trust. If PATH is 'CLEAR', the previous bundle is deleted."`
+	ClientCert    string            `long:"client-cert" description:"PEM client certificate presented to servers that request one. If PATH is 'CLEAR', the previous certificate is deleted."`
+	ClientKey     string            `long:"client-key" description:"PEM private key for the client certificate. If PATH is 'CLEAR', the previous key is deleted."`
+	Color         flag.Color        `long:"color" description:"Enable or disable color"`
+	Locale        flag.Locale       `long:"locale" description:"Set default locale. If LOCALE is 'CLEAR', previous locale is deleted."`
+	PluginKeys    string            `long:"plugin-trusted-keys" description:"File of base64 encoded ed25519 public keys, one per line, that plugin signatures are verified with. If PATH is 'CLEAR', the previous file is deleted."`
+	RequireSigned string            `long:"require-signed-plugins" description:"Only install plugins signed by a trusted key"`
+	Trace         flag.PathWithBool `long:"trace" description:"Trace HTTP requests"`
+	usage         interface{}       `usage:"CF_NAME config [--async-timeout TIMEOUT_IN_MINUTES] [--trace (true | false | path/to/file)] [--color (true | false)] [--locale (LOCALE | CLEAR)] [--ca-cert (PATH | CLEAR)] [--client-cert (PATH | CLEAR)] [--client-key (PATH | CLEAR)] [--plugin-trusted-keys (PATH | CLEAR)] [--require-signed-plugins (true | false)]"`
 }
 
 func (_ ConfigCommand) Setup(config command.Config, ui command.UI) error {
@@ -0,0 +1,106 @@
+package v2
+
+import (
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/flag"
+	"code.cloudfoundry.org/cli/command/v2/shared"
+)
+
+type ContextCommand struct {
+	RequiredArgs    flag.ContextArgs `positional-args:"yes"`
+	usage           interface{}      `usage:"CF_NAME context add NAME\n   CF_NAME context use NAME\n   CF_NAME context list\n   CF_NAME context delete NAME\n\nEXAMPLES:\n   CF_NAME context add prod\n   CF_NAME context use staging\n   CF_NAME --context prod apps"`
+	relatedCommands interface{}      `related_commands:"api, login, target"`
+
+	UI     command.UI
+	Config command.Config
+}
+
+func (cmd *ContextCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.UI = ui
+	cmd.Config = config
+	return nil
+}
+
+func (cmd *ContextCommand) Execute(args []string) error {
+	switch cmd.RequiredArgs.Action {
+	case "list":
+		return cmd.listContexts()
+	case "add", "use", "delete":
+	default:
+		return command.ParseArgumentError{
+			ArgumentName: "ACTION",
+			ExpectedType: "add, use, list or delete",
+		}
+	}
+
+	if cmd.RequiredArgs.Name == "" {
+		return command.RequiredArgumentError{ArgumentName: "NAME"}
+	}
+
+	var err error
+	switch cmd.RequiredArgs.Action {
+	case "add":
+		cmd.UI.DisplayTextWithFlavor("Saving the current target as context {{.Name}}...", map[string]interface{}{
+			"Name": cmd.RequiredArgs.Name,
+		})
+		err = cmd.Config.AddContext(cmd.RequiredArgs.Name)
+	case "use":
+		cmd.UI.DisplayTextWithFlavor("Switching to context {{.Name}}...", map[string]interface{}{
+			"Name": cmd.RequiredArgs.Name,
+		})
+		err = cmd.Config.UseContext(cmd.RequiredArgs.Name)
+	case "delete":
+		cmd.UI.DisplayTextWithFlavor("Deleting context {{.Name}}...", map[string]interface{}{
+			"Name": cmd.RequiredArgs.Name,
+		})
+		err = cmd.Config.DeleteContext(cmd.RequiredArgs.Name)
+	}
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	cmd.UI.DisplayOK()
+	return nil
+}
+
+func (cmd *ContextCommand) listContexts() error {
+	cmd.UI.DisplayText("Getting contexts...")
+
+	savedContexts, current, err := cmd.Config.Contexts()
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	cmd.UI.DisplayNewline()
+
+	if len(savedContexts) == 0 {
+		cmd.UI.DisplayText("No contexts found.")
+		return nil
+	}
+
+	table := [][]string{
+		{
+			"",
+			cmd.UI.TranslateText("name"),
+			cmd.UI.TranslateText("api endpoint"),
+			cmd.UI.TranslateText("org"),
+			cmd.UI.TranslateText("space"),
+		},
+	}
+	for _, context := range savedContexts {
+		marker := ""
+		if context.Name == current {
+			marker = "*"
+		}
+		table = append(table, []string{
+			marker,
+			context.Name,
+			context.Target,
+			context.OrganizationName,
+			context.SpaceName,
+		})
+	}
+	cmd.UI.DisplayTable("", table, 3)
+
+	return nil
+}
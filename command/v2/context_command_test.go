@@ -0,0 +1,171 @@
+package v2_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	"code.cloudfoundry.org/cli/command/flag"
+	. "code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/command/v2/shared"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/contexts"
+	"code.cloudfoundry.org/cli/util/ui"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("context Command", func() {
+	var (
+		cmd        ContextCommand
+		testUI     *ui.UI
+		fakeConfig *commandfakes.FakeConfig
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		testUI = ui.NewTestUI(nil, NewBuffer(), NewBuffer())
+		fakeConfig = new(commandfakes.FakeConfig)
+		fakeConfig.BinaryNameReturns("faceman")
+
+		cmd = ContextCommand{
+			UI:     testUI,
+			Config: fakeConfig,
+		}
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	Context("when the action is unknown", func() {
+		BeforeEach(func() {
+			cmd.RequiredArgs = flag.ContextArgs{Action: "rename", Name: "prod"}
+		})
+
+		It("returns a ParseArgumentError", func() {
+			Expect(executeErr).To(MatchError(command.ParseArgumentError{
+				ArgumentName: "ACTION",
+				ExpectedType: "add, use, list or delete",
+			}))
+		})
+	})
+
+	Context("when the name is not provided", func() {
+		BeforeEach(func() {
+			cmd.RequiredArgs = flag.ContextArgs{Action: "use"}
+		})
+
+		It("returns a RequiredArgumentError", func() {
+			Expect(executeErr).To(MatchError(command.RequiredArgumentError{ArgumentName: "NAME"}))
+			Expect(fakeConfig.UseContextCallCount()).To(Equal(0))
+		})
+	})
+
+	Describe("add", func() {
+		BeforeEach(func() {
+			cmd.RequiredArgs = flag.ContextArgs{Action: "add", Name: "prod"}
+		})
+
+		It("saves the current target as the context", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(testUI.Out).To(Say("Saving the current target as context prod..."))
+			Expect(testUI.Out).To(Say("OK"))
+
+			Expect(fakeConfig.AddContextCallCount()).To(Equal(1))
+			Expect(fakeConfig.AddContextArgsForCall(0)).To(Equal("prod"))
+		})
+
+		Context("when the context already exists", func() {
+			BeforeEach(func() {
+				fakeConfig.AddContextReturns(configv3.ContextAlreadyExistsError{Name: "prod"})
+			})
+
+			It("returns a ContextAlreadyExistsError", func() {
+				Expect(executeErr).To(MatchError(shared.ContextAlreadyExistsError{Name: "prod"}))
+			})
+		})
+	})
+
+	Describe("use", func() {
+		BeforeEach(func() {
+			cmd.RequiredArgs = flag.ContextArgs{Action: "use", Name: "prod"}
+		})
+
+		It("switches to the context", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(testUI.Out).To(Say("Switching to context prod..."))
+			Expect(testUI.Out).To(Say("OK"))
+
+			Expect(fakeConfig.UseContextCallCount()).To(Equal(1))
+			Expect(fakeConfig.UseContextArgsForCall(0)).To(Equal("prod"))
+		})
+
+		Context("when the context does not exist", func() {
+			BeforeEach(func() {
+				fakeConfig.UseContextReturns(contexts.NotFoundError{Name: "prod"})
+			})
+
+			It("returns a ContextNotFoundError", func() {
+				Expect(executeErr).To(MatchError(shared.ContextNotFoundError{Name: "prod"}))
+				Expect(testUI.Out).ToNot(Say("OK"))
+			})
+		})
+	})
+
+	Describe("delete", func() {
+		BeforeEach(func() {
+			cmd.RequiredArgs = flag.ContextArgs{Action: "delete", Name: "prod"}
+		})
+
+		It("deletes the context", func() {
+			Expect(executeErr).ToNot(HaveOccurred())
+			Expect(testUI.Out).To(Say("Deleting context prod..."))
+			Expect(testUI.Out).To(Say("OK"))
+
+			Expect(fakeConfig.DeleteContextCallCount()).To(Equal(1))
+			Expect(fakeConfig.DeleteContextArgsForCall(0)).To(Equal("prod"))
+		})
+	})
+
+	Describe("list", func() {
+		BeforeEach(func() {
+			cmd.RequiredArgs = flag.ContextArgs{Action: "list"}
+		})
+
+		Context("when there are no contexts", func() {
+			It("says so", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(testUI.Out).To(Say("Getting contexts..."))
+				Expect(testUI.Out).To(Say("No contexts found."))
+			})
+		})
+
+		Context("when there are contexts", func() {
+			BeforeEach(func() {
+				fakeConfig.ContextsReturns([]contexts.Context{
+					{Name: "dev", Target: "https://api.dev.example.com", OrganizationName: "dev-org", SpaceName: "dev-space"},
+					{Name: "prod", Target: "https://api.prod.example.com", OrganizationName: "prod-org"},
+				}, "prod", nil)
+			})
+
+			It("displays them and marks the one in use", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(testUI.Out).To(Say(`\s+name\s+api endpoint\s+org\s+space`))
+				Expect(testUI.Out).To(Say(`\s+dev\s+https://api.dev.example.com\s+dev-org\s+dev-space`))
+				Expect(testUI.Out).To(Say(`\*\s+prod\s+https://api.prod.example.com\s+prod-org`))
+			})
+		})
+
+		Context("when the contexts cannot be read", func() {
+			BeforeEach(func() {
+				fakeConfig.ContextsReturns(nil, "", errors.New("some-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(executeErr).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -11,8 +11,8 @@ import (
 type CopySourceCommand struct {
 	RequiredArgs        flag.CopySourceArgs `positional-args:"yes"`
 	NoRestart           bool                `long:"no-restart" description:"Override restart of the application in target environment after copy-source completes"`
-	Organization        string              `short:"o" description:"Org that contains the target application"`
-	Space               string              `short:"s" description:"Space that contains the target application"`
+	Organization        string              `short:"o" description:"Org that contains the target application" complete:"org"`
+	Space               string              `short:"s" description:"Space that contains the target application" complete:"space"`
 	usage               interface{}         `usage:"CF_NAME copy-source SOURCE_APP TARGET_APP [-s TARGET_SPACE [-o TARGET_ORG]] [--no-restart]"`
 	relatedCommands     interface{}         `related_commands:"apps, push, restart, target"`
 	envCFStagingTimeout interface{}         `environmentName:"CF_STAGING_TIMEOUT" environmentDescription:"Max wait time for buildpack staging, in minutes" environmentDefault:"15"`
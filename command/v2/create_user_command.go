@@ -74,11 +74,20 @@ func (cmd *CreateUserCommand) Execute(args []string) error {
 	cmd.UI.DisplayWarnings(warnings)
 
 	if err != nil {
-		if _, ok := err.(uaa.ConflictError); ok {
+		switch e := err.(type) {
+		case uaa.ConflictError:
 			cmd.UI.DisplayWarning("user {{.User}} already exists", map[string]interface{}{
 				"User": cmd.Args.Username,
 			})
-		} else {
+		case uaa.InsufficientScopeError:
+			cmd.UI.DisplayTextWithFlavor("Error creating user {{.User}}.", map[string]interface{}{
+				"User": cmd.Args.Username,
+			})
+			return shared.InsufficientScopeError{
+				RequiredScopes: e.RequiredScopes,
+				BinaryName:     cmd.Config.BinaryName(),
+			}
+		default:
 			cmd.UI.DisplayTextWithFlavor("Error creating user {{.User}}.", map[string]interface{}{
 				"User": cmd.Args.Username,
 			})
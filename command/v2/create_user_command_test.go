@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/cli/command"
 	"code.cloudfoundry.org/cli/command/commandfakes"
 	"code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/command/v2/shared"
 	"code.cloudfoundry.org/cli/command/v2/v2fakes"
 	"code.cloudfoundry.org/cli/util/ui"
 	. "github.com/onsi/ginkgo"
@@ -163,6 +164,24 @@ var _ = Describe("create-user Command", func() {
 				})
 			})
 
+			Context("when the error is a uaa.InsufficientScopeError", func() {
+				BeforeEach(func() {
+					fakeConfig.BinaryNameReturns("faceman")
+					fakeActor.NewUserReturns(
+						v2action.User{},
+						nil,
+						uaa.InsufficientScopeError{RequiredScopes: []string{"scim.write"}})
+				})
+
+				It("returns an InsufficientScopeError with the binary name", func() {
+					Expect(executeErr).To(MatchError(shared.InsufficientScopeError{
+						RequiredScopes: []string{"scim.write"},
+						BinaryName:     "faceman",
+					}))
+					Expect(testUI.Out).To(Say("Error creating user some-user."))
+				})
+			})
+
 			Context("when the error is a uaa.ConflictError", func() {
 				var returnedErr error
 
@@ -5,13 +5,14 @@ import (
 	"code.cloudfoundry.org/cli/actor/v2action"
 	"code.cloudfoundry.org/cli/command"
 	"code.cloudfoundry.org/cli/command/v2/shared"
+	"code.cloudfoundry.org/cli/util/workpool"
 )
 
 //go:generate counterfeiter . DeleteOrphanedRoutesActor
 
 type DeleteOrphanedRoutesActor interface {
 	GetOrphanedRoutesBySpace(spaceGUID string) ([]v2action.Route, v2action.Warnings, error)
-	DeleteRoute(routeGUID string) (v2action.Warnings, error)
+	DeleteRoutes(routes []v2action.Route) (v2action.Warnings, error)
 }
 
 type DeleteOrphanedRoutesCommand struct {
@@ -34,7 +35,9 @@ func (cmd *DeleteOrphanedRoutesCommand) Setup(config command.Config, ui command.
 	if err != nil {
 		return err
 	}
-	cmd.Actor = v2action.NewActor(ccClient, uaaClient)
+	actor := v2action.NewActor(ccClient, uaaClient)
+	actor.Workers = config.Concurrency()
+	cmd.Actor = actor
 
 	return nil
 }
@@ -77,14 +80,24 @@ func (cmd *DeleteOrphanedRoutesCommand) Execute(args []string) error {
 		}
 	}
 
-	for _, route := range routes {
-		cmd.UI.DisplayText("Deleting route {{.Route}} ...", map[string]interface{}{
-			"Route": route.String(),
-		})
+	if len(routes) > 0 {
+		for _, route := range routes {
+			cmd.UI.DisplayText("Deleting route {{.Route}} ...", map[string]interface{}{
+				"Route": route.String(),
+			})
+		}
 
-		warnings, err = cmd.Actor.DeleteRoute(route.GUID)
+		warnings, err = cmd.Actor.DeleteRoutes(routes)
 		cmd.UI.DisplayWarnings(warnings)
 		if err != nil {
+			if e, ok := err.(workpool.PartialFailureError); ok {
+				for _, failure := range e.Failures {
+					cmd.UI.DisplayWarning("Failed to delete route {{.Route}}: {{.Error}}", map[string]interface{}{
+						"Route": routes[failure.Index].String(),
+						"Error": failure.Err.Error(),
+					})
+				}
+			}
 			return shared.HandleError(err)
 		}
 	}
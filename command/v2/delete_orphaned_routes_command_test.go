@@ -8,9 +8,11 @@ import (
 	"code.cloudfoundry.org/cli/command"
 	"code.cloudfoundry.org/cli/command/commandfakes"
 	"code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/command/v2/shared"
 	"code.cloudfoundry.org/cli/command/v2/v2fakes"
 	"code.cloudfoundry.org/cli/util/configv3"
 	"code.cloudfoundry.org/cli/util/ui"
+	"code.cloudfoundry.org/cli/util/workpool"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
@@ -136,7 +138,7 @@ var _ = Describe("deleted-orphaned-routes Command", func() {
 							Expect(executeErr).ToNot(HaveOccurred())
 
 							Expect(fakeActor.GetOrphanedRoutesBySpaceCallCount()).To(Equal(0))
-							Expect(fakeActor.DeleteRouteCallCount()).To(Equal(0))
+							Expect(fakeActor.DeleteRoutesCallCount()).To(Equal(0))
 						})
 					})
 
@@ -149,7 +151,7 @@ var _ = Describe("deleted-orphaned-routes Command", func() {
 							Expect(executeErr).To(HaveOccurred())
 
 							Expect(fakeActor.GetOrphanedRoutesBySpaceCallCount()).To(Equal(0))
-							Expect(fakeActor.DeleteRouteCallCount()).To(Equal(0))
+							Expect(fakeActor.DeleteRoutesCallCount()).To(Equal(0))
 						})
 					})
 
@@ -187,9 +189,8 @@ var _ = Describe("deleted-orphaned-routes Command", func() {
 
 							Expect(fakeActor.GetOrphanedRoutesBySpaceCallCount()).To(Equal(1))
 							Expect(fakeActor.GetOrphanedRoutesBySpaceArgsForCall(0)).To(Equal("some-space-guid"))
-							Expect(fakeActor.DeleteRouteCallCount()).To(Equal(2))
-							Expect(fakeActor.DeleteRouteArgsForCall(0)).To(Equal(routes[0].GUID))
-							Expect(fakeActor.DeleteRouteArgsForCall(1)).To(Equal(routes[1].GUID))
+							Expect(fakeActor.DeleteRoutesCallCount()).To(Equal(1))
+							Expect(fakeActor.DeleteRoutesArgsForCall(0)).To(Equal(routes))
 
 							Expect(testUI.Out).To(Say("Deleting route route-1.bosh-lite.com/path..."))
 							Expect(testUI.Out).To(Say("Deleting route route-2.bosh-lite.com..."))
@@ -202,7 +203,7 @@ var _ = Describe("deleted-orphaned-routes Command", func() {
 									[]v2action.Route{{GUID: "some-route-guid"}},
 									[]string{"foo", "bar"},
 									nil)
-								fakeActor.DeleteRouteReturns([]string{"baz"}, nil)
+								fakeActor.DeleteRoutesReturns([]string{"baz"}, nil)
 							})
 
 							It("displays the warnings", func() {
@@ -237,7 +238,7 @@ var _ = Describe("deleted-orphaned-routes Command", func() {
 								It("should not return an error and only display 'OK'", func() {
 									Expect(executeErr).ToNot(HaveOccurred())
 
-									Expect(fakeActor.DeleteRouteCallCount()).To(Equal(0))
+									Expect(fakeActor.DeleteRoutesCallCount()).To(Equal(0))
 								})
 							})
 
@@ -253,22 +254,36 @@ var _ = Describe("deleted-orphaned-routes Command", func() {
 							})
 						})
 
-						Context("when deleting a route returns an error", func() {
+						Context("when deleting routes returns an error", func() {
 							var expectedErr error
 
 							BeforeEach(func() {
 								expectedErr = errors.New("deleting route error")
-								fakeActor.GetOrphanedRoutesBySpaceReturns(
-									[]v2action.Route{{GUID: "some-route-guid"}},
-									nil,
-									nil)
-								fakeActor.DeleteRouteReturns(nil, expectedErr)
+								fakeActor.DeleteRoutesReturns(nil, expectedErr)
 							})
 
 							It("returns the error", func() {
 								Expect(executeErr).To(MatchError(expectedErr))
 							})
 						})
+
+						Context("when some of the routes fail to delete", func() {
+							BeforeEach(func() {
+								fakeActor.DeleteRoutesReturns(nil, workpool.PartialFailureError{
+									Total: 2,
+									Failures: []workpool.Failure{
+										{Index: 1, Err: errors.New("route in use")},
+									},
+								})
+							})
+
+							It("displays the routes that failed and returns a PartialFailureError", func() {
+								Expect(executeErr).To(MatchError(shared.PartialFailureError{Failed: 1, Total: 2}))
+
+								Expect(testUI.Err).To(Say("Failed to delete route route-2.bosh-lite.com: route in use"))
+								Expect(testUI.Out).ToNot(Say("OK"))
+							})
+						})
 					})
 				})
 			})
@@ -11,7 +11,7 @@ import (
 type DeleteRouteCommand struct {
 	RequiredArgs    flag.Domain `positional-args:"yes"`
 	Force           bool        `short:"f" description:"Force deletion without confirmation"`
-	Hostname        string      `long:"hostname" short:"n" description:"Hostname used to identify the HTTP route"`
+	Hostname        string      `long:"hostname" short:"n" description:"Hostname used to identify the HTTP route" complete:"route"`
 	Path            string      `long:"path" description:"Path used to identify the HTTP route"`
 	Port            int         `long:"port" description:"Port used to identify the TCP route"`
 	usage           interface{} `usage:"Delete an HTTP route:\n      CF_NAME delete-route DOMAIN [--hostname HOSTNAME] [--path PATH] [-f]\n\n   Delete a TCP route:\n      CF_NAME delete-route DOMAIN --port PORT [-f]\n\nEXAMPLES:\n   CF_NAME delete-route example.com                              # example.com\n   CF_NAME delete-route example.com --hostname myhost            # myhost.example.com\n   CF_NAME delete-route example.com --hostname myhost --path foo # myhost.example.com/foo\n   CF_NAME delete-route example.com --port 5000                  # example.com:5000"`
@@ -11,7 +11,7 @@ import (
 type DeleteSpaceCommand struct {
 	RequiredArgs flag.Space  `positional-args:"yes"`
 	Force        bool        `short:"f" description:"Force deletion without confirmation"`
-	Org          string      `short:"o" description:"Delete space within specified org"`
+	Org          string      `short:"o" description:"Delete space within specified org" complete:"org"`
 	usage        interface{} `usage:"CF_NAME delete-space SPACE [-o ORG] [-f]"`
 }
 
@@ -10,7 +10,7 @@ import (
 
 type EnableServiceAccessCommand struct {
 	RequiredArgs    flag.Service `positional-args:"yes"`
-	Organization    string       `short:"o" description:"Enable access for a specified organization"`
+	Organization    string       `short:"o" description:"Enable access for a specified organization" complete:"org"`
 	ServicePlan     string       `short:"p" description:"Enable access to a specified service plan"`
 	usage           interface{}  `usage:"CF_NAME enable-service-access SERVICE [-p PLAN] [-o ORG]"`
 	relatedCommands interface{}  `related_commands:"marketplace, service-access, service-brokers"`
@@ -10,8 +10,11 @@ import (
 
 type FilesCommand struct {
 	RequiredArgs    flag.FilesArgs `positional-args:"yes"`
+	Download        string         `long:"download" description:"Download the directory and all of its subdirectories into the given local directory"`
 	Instance        int            `short:"i" description:"Instance"`
-	usage           interface{}    `usage:"CF_NAME files APP_NAME [PATH] [-i INSTANCE]\n\nTIP:\n   To list and inspect files of an app running on the Diego backend, use 'CF_NAME ssh'"`
+	Recursive       bool           `long:"recursive" short:"r" description:"List the contents of the directory and all of its subdirectories"`
+	Tail            string         `long:"tail" description:"Print the contents of the given file and keep printing new contents as the file grows"`
+	usage           interface{}    `usage:"CF_NAME files APP_NAME [PATH] [-i INSTANCE] [-r | --download DIR]\n   CF_NAME files APP_NAME --tail PATH [-i INSTANCE]\n\nTIP:\n   To list and inspect files of an app running on the Diego backend, use 'CF_NAME ssh'"`
 	relatedCommands interface{}    `related_commands:"ssh"`
 }
 
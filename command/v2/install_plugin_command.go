@@ -12,7 +12,8 @@ type InstallPluginCommand struct {
 	OptionalArgs         flag.InstallPluginArgs `positional-args:"yes"`
 	Force                bool                   `short:"f" description:"Force install of plugin without confirmation"`
 	RegisteredRepository string                 `short:"r" description:"Name of a registered repository where the specified plugin is located"`
-	usage                interface{}            `usage:"CF_NAME install-plugin (LOCAL-PATH/TO/PLUGIN | URL | -r REPO_NAME PLUGIN_NAME) [-f]\n\n   Prompts for confirmation unless '-f' is provided.\n\nEXAMPLES:\n   CF_NAME install-plugin ~/Downloads/plugin-foobar\n   CF_NAME install-plugin https://example.com/plugin-foobar_linux_amd64\n   CF_NAME install-plugin -r My-Repo plugin-echo"`
+	RequireSigned        bool                   `long:"require-signed" description:"Only install the plugin if it is signed by a trusted key"`
+	usage                interface{}            `usage:"CF_NAME install-plugin (LOCAL-PATH/TO/PLUGIN | URL | -r REPO_NAME PLUGIN_NAME) [-f] [--require-signed]\n\n   Prompts for confirmation unless '-f' is provided.\n\n   Plugin signatures are read from the binary's location with '.sig' appended and verified\n   with the keys set by 'CF_NAME config --plugin-trusted-keys'.\n\nEXAMPLES:\n   CF_NAME install-plugin ~/Downloads/plugin-foobar\n   CF_NAME install-plugin https://example.com/plugin-foobar_linux_amd64\n   CF_NAME install-plugin -r My-Repo plugin-echo"`
 	relatedCommands      interface{}            `related_commands:"add-plugin-repo, list-plugin-repos, plugins"`
 }
 
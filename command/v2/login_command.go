@@ -9,14 +9,14 @@ import (
 
 type LoginCommand struct {
 	APIEndpoint       string      `short:"a" description:"API endpoint (e.g. https://api.example.com)"`
-	Organization      string      `short:"o" description:"Org"`
+	Organization      string      `short:"o" description:"Org" complete:"org"`
 	Password          string      `short:"p" description:"Password"`
-	Space             string      `short:"s" description:"Space"`
+	Space             string      `short:"s" description:"Space" complete:"space"`
 	SkipSSLValidation bool        `long:"skip-ssl-validation" description:"Skip verification of the API endpoint. Not recommended!"`
-	SSO               bool        `long:"sso" description:"Prompt for a one-time passcode to login"`
+	SSO               bool        `long:"sso" description:"Log in through a browser, or with a one-time passcode if no browser is available"`
 	SSOPasscode       string      `long:"sso-passcode" description:"One-time passcode"`
 	Username          string      `short:"u" description:"Username"`
-	usage             interface{} `usage:"CF_NAME login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE] [--sso | --sso-passcode PASSCODE]\n\nWARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\nEXAMPLES:\n   CF_NAME login (omit username and password to login interactively -- CF_NAME will prompt for both)\n   CF_NAME login -u name@example.com -p pa55woRD (specify username and password as arguments)\n   CF_NAME login -u name@example.com -p \"my password\" (use quotes for passwords with a space)\n   CF_NAME login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)\n   CF_NAME login --sso (CF_NAME will provide a url to obtain a one-time passcode to login)"`
+	usage             interface{} `usage:"CF_NAME login [-a API_URL] [-u USERNAME] [-p PASSWORD] [-o ORG] [-s SPACE] [--sso | --sso-passcode PASSCODE]\n\nWARNING:\n   Providing your password as a command line option is highly discouraged\n   Your password may be visible to others and may be recorded in your shell history\n\nEXAMPLES:\n   CF_NAME login (omit username and password to login interactively -- CF_NAME will prompt for both)\n   CF_NAME login -u name@example.com -p pa55woRD (specify username and password as arguments)\n   CF_NAME login -u name@example.com -p \"my password\" (use quotes for passwords with a space)\n   CF_NAME login -u name@example.com -p \"\\\"password\\\"\" (escape quotes if used in password)\n   CF_NAME login --sso (CF_NAME will open a browser to log in, or provide a url to obtain a one-time passcode)"`
 	relatedCommands   interface{} `related_commands:"api, auth, target"`
 }
 
@@ -10,7 +10,7 @@ import (
 
 type MapRouteCommand struct {
 	RequiredArgs    flag.AppDomain `positional-args:"yes"`
-	Hostname        string         `long:"hostname" short:"n" description:"Hostname for the HTTP route (required for shared domains)"`
+	Hostname        string         `long:"hostname" short:"n" description:"Hostname for the HTTP route (required for shared domains)" complete:"route"`
 	Path            string         `long:"path" description:"Path for the HTTP route"`
 	Port            int            `long:"port" description:"Port for the TCP route"`
 	RandomPort      bool           `long:"random-port" description:"Create a random port for the TCP route"`
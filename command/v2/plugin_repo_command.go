@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/flag"
+)
+
+type PluginRepoCommand struct {
+	RequiredArgs    flag.PluginRepoServeArgs `positional-args:"yes"`
+	Port            int                      `long:"port" description:"Port to listen on (Default: 8080)"`
+	Address         string                   `long:"address" description:"Address to listen on, such as 0.0.0.0 for every interface (Default: 127.0.0.1)"`
+	usage           interface{}              `usage:"CF_NAME plugin-repo serve DIR [--port PORT] [--address ADDRESS]\n\n   Binaries in DIR are named NAME_VERSION_PLATFORM, where PLATFORM is osx, linux32, linux64,\n   win32, win64 or a GOOS_GOARCH pair such as linux_amd64. Windows binaries may end in '.exe'.\n   Signatures named after the binary with '.sig' appended are served alongside it.\n\n   The repository is only reachable from this machine unless another ADDRESS is given.\n\nEXAMPLES:\n   CF_NAME plugin-repo serve /srv/cf-plugins --address 0.0.0.0 --port 8080\n   CF_NAME add-plugin-repo internal http://plugins.example.com:8080"`
+	relatedCommands interface{}              `related_commands:"add-plugin-repo, install-plugin, repo-plugins"`
+}
+
+func (_ PluginRepoCommand) Setup(config command.Config, ui command.UI) error {
+	return nil
+}
+
+func (_ PluginRepoCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -10,7 +10,7 @@ import (
 type ServiceAccessCommand struct {
 	Broker          string      `short:"b" description:"Access for plans of a particular broker"`
 	Service         string      `short:"e" description:"Access for service name of a particular service offering"`
-	Organization    string      `short:"o" description:"Plans accessible by a particular organization"`
+	Organization    string      `short:"o" description:"Plans accessible by a particular organization" complete:"org"`
 	usage           interface{} `usage:"CF_NAME service-access [-b BROKER] [-e SERVICE] [-o ORG]"`
 	relatedCommands interface{} `related_commands:"marketplace, disable-service-access, enable-service-access, service-brokers"`
 }
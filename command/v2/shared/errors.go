@@ -2,6 +2,7 @@ package shared
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -96,6 +97,109 @@ func (e InvalidRefreshTokenError) Translate(translate func(string, ...interface{
 	return translate(e.Error())
 }
 
+type BadCredentialsError struct{}
+
+func (e BadCredentialsError) Error() string {
+	return "Credentials were rejected, please try again."
+}
+
+func (e BadCredentialsError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error())
+}
+
+type PasswordGrantTypeLogoutRequiredError struct {
+	BinaryName string
+}
+
+func (e PasswordGrantTypeLogoutRequiredError) Error() string {
+	return "Service account currently logged in. Use '{{.BinaryName}} logout' to log out service account and try again."
+}
+
+func (e PasswordGrantTypeLogoutRequiredError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"BinaryName": e.BinaryName,
+	})
+}
+
+// InsufficientScopeError explains a UAA insufficient_scope error. The tip to
+// inspect the token is only given when BinaryName is known.
+type InsufficientScopeError struct {
+	RequiredScopes []string
+	BinaryName     string
+}
+
+func (e InsufficientScopeError) Error() string {
+	if e.BinaryName == "" {
+		return e.message()
+	}
+	return e.message() + "\n" + e.tip()
+}
+
+func (e InsufficientScopeError) Translate(translate func(string, ...interface{}) string) string {
+	message := translate(e.message(), map[string]interface{}{
+		"Scopes": strings.Join(e.RequiredScopes, ", "),
+	})
+	if e.BinaryName == "" {
+		return message
+	}
+	return message + "\n" + translate(e.tip(), map[string]interface{}{
+		"BinaryName": e.BinaryName,
+	})
+}
+
+func (e InsufficientScopeError) message() string {
+	if len(e.RequiredScopes) == 0 {
+		return "Your token does not have the scope required for this request."
+	}
+	return "Your token does not have the scope required for this request. It needs one of: {{.Scopes}}"
+}
+
+func (e InsufficientScopeError) tip() string {
+	return "TIP: Use '{{.BinaryName}} token-info' to see the scopes in your token."
+}
+
+type MissingScopeError struct {
+	Scope string
+}
+
+func (e MissingScopeError) Error() string {
+	return "Token does not have scope {{.Scope}}."
+}
+
+func (e MissingScopeError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Scope": e.Scope,
+	})
+}
+
+type ContextNotFoundError struct {
+	Name string
+}
+
+func (e ContextNotFoundError) Error() string {
+	return "Context '{{.Name}}' not found."
+}
+
+func (e ContextNotFoundError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Name": e.Name,
+	})
+}
+
+type ContextAlreadyExistsError struct {
+	Name string
+}
+
+func (e ContextAlreadyExistsError) Error() string {
+	return "Context '{{.Name}}' already exists."
+}
+
+func (e ContextAlreadyExistsError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Name": e.Name,
+	})
+}
+
 type StagingFailedError struct {
 	Message    string
 	BinaryName string
@@ -111,3 +215,19 @@ func (e StagingFailedError) Translate(translate func(string, ...interface{}) str
 		"BuildpackCommand": fmt.Sprintf("%s buildpacks", e.BinaryName),
 	})
 }
+
+type PartialFailureError struct {
+	Failed int
+	Total  int
+}
+
+func (e PartialFailureError) Error() string {
+	return "{{.Failed}} of {{.Total}} operations failed."
+}
+
+func (e PartialFailureError) Translate(translate func(string, ...interface{}) string) string {
+	return translate(e.Error(), map[string]interface{}{
+		"Failed": e.Failed,
+		"Total":  e.Total,
+	})
+}
@@ -41,10 +41,13 @@ var _ = Describe("Translatable Errors", func() {
 		Entry("JobTimeoutError", JobTimeoutError{}),
 		Entry("HTTPHealthCheckInvalidError", HTTPHealthCheckInvalidError{}),
 		Entry("StagingFailedError", StagingFailedError{}),
+		Entry("PartialFailureError", PartialFailureError{}),
 
 		// Command errors.
 		Entry("NoOrgTargetedError", NoOrganizationTargetedError{}),
 		Entry("OrgNotFoundError", OrganizationNotFoundError{}),
 		Entry("SpaceNotFoundError", SpaceNotFoundError{}),
+		Entry("InsufficientScopeError", InsufficientScopeError{RequiredScopes: []string{"scim.write"}}),
+		Entry("InsufficientScopeError with a tip", InsufficientScopeError{BinaryName: "faceman"}),
 	)
 })
@@ -7,6 +7,9 @@ import (
 	"code.cloudfoundry.org/cli/api/cloudcontroller/ccv2"
 	"code.cloudfoundry.org/cli/api/uaa"
 	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/contexts"
+	"code.cloudfoundry.org/cli/util/workpool"
 )
 
 func HandleError(err error) error {
@@ -25,8 +28,12 @@ func HandleError(err error) error {
 	case ccv2.JobTimeoutError:
 		return JobTimeoutError{JobGUID: e.JobGUID}
 
+	case uaa.BadCredentialsError:
+		return BadCredentialsError{}
 	case uaa.InvalidAuthTokenError:
 		return InvalidRefreshTokenError{}
+	case uaa.InsufficientScopeError:
+		return InsufficientScopeError{RequiredScopes: e.RequiredScopes}
 
 	case sharedaction.NotLoggedInError:
 		return command.NotLoggedInError{BinaryName: e.BinaryName}
@@ -45,6 +52,14 @@ func HandleError(err error) error {
 		return SpaceNotFoundError{Name: e.Name}
 	case v2action.HTTPHealthCheckInvalidError:
 		return HTTPHealthCheckInvalidError{}
+
+	case configv3.ContextAlreadyExistsError:
+		return ContextAlreadyExistsError{Name: e.Name}
+	case contexts.NotFoundError:
+		return ContextNotFoundError{Name: e.Name}
+
+	case workpool.PartialFailureError:
+		return PartialFailureError{Failed: len(e.Failures), Total: e.Total}
 	}
 
 	return err
@@ -10,6 +10,9 @@ import (
 	"code.cloudfoundry.org/cli/api/uaa"
 	"code.cloudfoundry.org/cli/command"
 	. "code.cloudfoundry.org/cli/command/v2/shared"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/contexts"
+	"code.cloudfoundry.org/cli/util/workpool"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -81,11 +84,36 @@ var _ = Describe("HandleError", func() {
 			HTTPHealthCheckInvalidError{},
 		),
 
+		Entry("uaa.BadCredentialsError -> BadCredentialsError",
+			uaa.BadCredentialsError{Message: "some-message"},
+			BadCredentialsError{},
+		),
+
 		Entry("uaa.InvalidAuthTokenError -> InvalidRefreshTokenError",
 			uaa.InvalidAuthTokenError{},
 			InvalidRefreshTokenError{},
 		),
 
+		Entry("uaa.InsufficientScopeError -> InsufficientScopeError",
+			uaa.InsufficientScopeError{Message: "some-message", RequiredScopes: []string{"scim.write"}},
+			InsufficientScopeError{RequiredScopes: []string{"scim.write"}},
+		),
+
+		Entry("configv3.ContextAlreadyExistsError -> ContextAlreadyExistsError",
+			configv3.ContextAlreadyExistsError{Name: "some-context"},
+			ContextAlreadyExistsError{Name: "some-context"},
+		),
+
+		Entry("contexts.NotFoundError -> ContextNotFoundError",
+			contexts.NotFoundError{Name: "some-context"},
+			ContextNotFoundError{Name: "some-context"},
+		),
+
+		Entry("workpool.PartialFailureError -> PartialFailureError",
+			workpool.PartialFailureError{Total: 3, Failures: []workpool.Failure{{Index: 1, Err: err}}},
+			PartialFailureError{Failed: 1, Total: 3},
+		),
+
 		Entry("default case -> original error",
 			err,
 			err),
@@ -6,6 +6,9 @@ import (
 	"code.cloudfoundry.org/cli/api/uaa"
 	uaaWrapper "code.cloudfoundry.org/cli/api/uaa/wrapper"
 	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/httpcache"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 )
 
 // NewClients creates a new V2 Cloud Controller client and UAA client using the
@@ -17,29 +20,39 @@ func NewClients(config command.Config, ui command.UI) (*ccv2.Client, *uaa.Client
 		}
 	}
 
+	certificates, err := tlsconfig.Load(config.TLSFiles())
+	if err != nil {
+		return nil, nil, err
+	}
+
 	ccClient := ccv2.NewClient(ccv2.Config{
 		AppName:            config.BinaryName(),
 		AppVersion:         config.BinaryVersion(),
 		JobPollingTimeout:  config.OverallPollingTimeout(),
 		JobPollingInterval: config.PollingInterval(),
 	})
-	_, err := ccClient.TargetCF(ccv2.TargetSettings{
-		URL:               config.Target(),
-		SkipSSLValidation: config.SkipSSLValidation(),
-		DialTimeout:       config.DialTimeout(),
+	_, err = ccClient.TargetCF(ccv2.TargetSettings{
+		URL:                config.Target(),
+		SkipSSLValidation:  config.SkipSSLValidation(),
+		DialTimeout:        config.DialTimeout(),
+		RootCAs:            certificates.RootCAs,
+		ClientCertificates: certificates.ClientCertificates,
 	})
 	if err != nil {
 		return nil, nil, err
 	}
 
 	uaaClient := uaa.NewClient(uaa.Config{
-		AppName:           config.BinaryName(),
-		AppVersion:        config.BinaryVersion(),
-		ClientID:          config.UAAOAuthClient(),
-		ClientSecret:      config.UAAOAuthClientSecret(),
-		DialTimeout:       config.DialTimeout(),
-		SkipSSLValidation: config.SkipSSLValidation(),
-		URL:               ccClient.TokenEndpoint(),
+		AppName:            config.BinaryName(),
+		AppVersion:         config.BinaryVersion(),
+		ClientID:           config.UAAOAuthClient(),
+		ClientSecret:       config.UAAOAuthClientSecret(),
+		DialTimeout:        config.DialTimeout(),
+		GrantType:          uaa.GrantType(config.UAAGrantType()),
+		SkipSSLValidation:  config.SkipSSLValidation(),
+		RootCAs:            certificates.RootCAs,
+		ClientCertificates: certificates.ClientCertificates,
+		URL:                ccClient.TokenEndpoint(),
 	})
 
 	verbose, location := config.Verbose()
@@ -48,15 +61,25 @@ func NewClients(config command.Config, ui command.UI) (*ccv2.Client, *uaa.Client
 		uaaClient.WrapConnection(uaaWrapper.NewRequestLogger(command.NewRequestLoggerTerminalDisplay(ui)))
 	}
 	if location != nil {
-		ccClient.WrapConnection(ccWrapper.NewRequestLogger(command.NewRequestLoggerFileWriter(ui, location)))
-		uaaClient.WrapConnection(uaaWrapper.NewRequestLogger(command.NewRequestLoggerFileWriter(ui, location)))
+		if config.TraceFormat() == configv3.TraceFormatHAR {
+			harWriter := command.NewHARFileWriter(ui, location)
+			ccClient.WrapConnection(ccWrapper.NewHARRecorder(harWriter))
+			uaaClient.WrapConnection(uaaWrapper.NewHARRecorder(harWriter))
+		} else {
+			ccClient.WrapConnection(ccWrapper.NewRequestLogger(command.NewRequestLoggerFileWriter(ui, location)))
+			uaaClient.WrapConnection(uaaWrapper.NewRequestLogger(command.NewRequestLoggerFileWriter(ui, location)))
+		}
 	}
 
 	ccClient.WrapConnection(ccWrapper.NewUAAAuthentication(uaaClient, config))
-	ccClient.WrapConnection(ccWrapper.NewRetryRequest(2))
+	ccClient.WrapConnection(ccWrapper.NewRetryRequest(config.RetryPolicy()))
+	if identity := httpcache.Identity(config.AccessToken()); identity != "" && config.CacheTTL() > 0 {
+		cache := httpcache.New(config.CacheDirectory(), config.Target(), identity, config.CacheTTL())
+		ccClient.WrapConnection(ccWrapper.NewHTTPCache(cache))
+	}
 
 	uaaClient.WrapConnection(uaaWrapper.NewUAAAuthentication(uaaClient, config))
-	uaaClient.WrapConnection(uaaWrapper.NewRetryRequest(2))
+	uaaClient.WrapConnection(uaaWrapper.NewRetryRequest(config.RetryPolicy()))
 
 	return ccClient, uaaClient, err
 }
@@ -1,25 +1,28 @@
 package shared
 
 import (
-	"crypto/tls"
 	"net/http"
 
 	"code.cloudfoundry.org/cli/api/uaa"
 	"code.cloudfoundry.org/cli/api/uaa/noaabridge"
 	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 	"github.com/cloudfoundry/noaa/consumer"
 )
 
 // NewNOAAClient returns back a configured NOAA Client.
-func NewNOAAClient(apiURL string, config command.Config, uaaClient *uaa.Client, ui command.UI) *consumer.Consumer {
+func NewNOAAClient(apiURL string, config command.Config, uaaClient *uaa.Client, ui command.UI) (*consumer.Consumer, error) {
+	certificates, err := tlsconfig.Load(config.TLSFiles())
+	if err != nil {
+		return nil, err
+	}
+
 	client := consumer.New(
 		apiURL,
-		&tls.Config{
-			InsecureSkipVerify: config.SkipSSLValidation(),
-		},
+		certificates.TLSConfig(config.SkipSSLValidation()),
 		http.ProxyFromEnvironment,
 	)
 	client.RefreshTokenFrom(noaabridge.NewTokenRefresher(uaaClient, config))
 
-	return client
+	return client, nil
 }
@@ -9,17 +9,22 @@ import (
 )
 
 type SSHCommand struct {
-	RequiredArgs        flag.AppName `positional-args:"yes"`
-	AppInstanceIndex    int          `long:"app-instance-index" short:"i" description:"Application instance index"`
-	Command             string       `long:"command" short:"c" description:"Command to run. This flag can be defined more than once."`
-	DisablePseudoTTY    bool         `long:"disable-pseudo-tty" short:"T" description:"Disable pseudo-tty allocation"`
-	ForcePseudoTTY      bool         `long:"force-pseudo-tty" description:"Force pseudo-tty allocation"`
-	LocalPort           string       `short:"L" description:"Local port forward specification. This flag can be defined more than once."`
-	RemotePseudoTTY     bool         `long:"request-pseudo-tty" short:"t" description:"Request pseudo-tty allocation"`
-	SkipHostValidation  bool         `long:"skip-host-validation" short:"k" description:"Skip host key validation"`
-	SkipRemoteExecution bool         `long:"skip-remote-execution" short:"N" description:"Do not execute a remote command"`
-	usage               interface{}  `usage:"CF_NAME ssh APP_NAME [-i app-instance-index] [-c command] [-L [bind_address:]port:host:hostport] [--skip-host-validation] [--skip-remote-execution] [--request-pseudo-tty] [--force-pseudo-tty] [--disable-pseudo-tty]"`
-	relatedCommands     interface{}  `related_commands:"allow-space-ssh, enable-ssh, space-ssh-allowed, ssh-code, ssh-enabled"`
+	RequiredArgs          flag.AppName `positional-args:"yes"`
+	AllInstances          bool         `long:"all-instances" description:"Run the command on every running instance concurrently"`
+	AppInstanceIndex      int          `long:"app-instance-index" short:"i" description:"Application instance index"`
+	Command               string       `long:"command" short:"c" description:"Command to run. This flag can be defined more than once."`
+	DisablePseudoTTY      bool         `long:"disable-pseudo-tty" short:"T" description:"Disable pseudo-tty allocation"`
+	DynamicPort           string       `short:"D" description:"Dynamic SOCKS5 port forward specification. This flag can be defined more than once."`
+	ForcePseudoTTY        bool         `long:"force-pseudo-tty" description:"Force pseudo-tty allocation"`
+	LocalPort             string       `short:"L" description:"Local port forward specification. This flag can be defined more than once."`
+	MaxInFlight           int          `long:"max-in-flight" description:"Maximum number of instances to run the command on at the same time when using --all-instances"`
+	RemotePort            string       `short:"R" description:"Remote port forward specification. This flag can be defined more than once."`
+	RemotePseudoTTY       bool         `long:"request-pseudo-tty" short:"t" description:"Request pseudo-tty allocation"`
+	SkipHostValidation    bool         `long:"skip-host-validation" short:"k" description:"Skip host key validation"`
+	SkipRemoteExecution   bool         `long:"skip-remote-execution" short:"N" description:"Do not execute a remote command"`
+	StrictHostKeyChecking bool         `long:"strict-host-key-checking" description:"Refuse to connect if the host key differs from the one pinned in the known hosts file"`
+	usage                 interface{}  `usage:"CF_NAME ssh APP_NAME [-i app-instance-index] [-c command] [-L [bind_address:]port:host:hostport] [-R [bind_address:]port:host:hostport] [-D [bind_address:]port] [--skip-host-validation] [--skip-remote-execution] [--request-pseudo-tty] [--force-pseudo-tty] [--disable-pseudo-tty] [--strict-host-key-checking]\n   CF_NAME ssh APP_NAME --all-instances -c command [--max-in-flight number]"`
+	relatedCommands       interface{}  `related_commands:"allow-space-ssh, enable-ssh, space-ssh-allowed, ssh-code, ssh-enabled"`
 }
 
 func (_ SSHCommand) Setup(config command.Config, ui command.UI) error {
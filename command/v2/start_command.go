@@ -45,9 +45,8 @@ func (cmd *StartCommand) Setup(config command.Config, ui command.UI) error {
 	}
 	cmd.Actor = v2action.NewActor(ccClient, uaaClient)
 
-	cmd.NOAAClient = shared.NewNOAAClient(ccClient.DopplerEndpoint(), config, uaaClient, ui)
-
-	return nil
+	cmd.NOAAClient, err = shared.NewNOAAClient(ccClient.DopplerEndpoint(), config, uaaClient, ui)
+	return err
 }
 
 func (cmd StartCommand) Execute(args []string) error {
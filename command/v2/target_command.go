@@ -18,8 +18,8 @@ type TargetActor interface {
 }
 
 type TargetCommand struct {
-	Organization    string      `short:"o" description:"Organization"`
-	Space           string      `short:"s" description:"Space"`
+	Organization    string      `short:"o" description:"Organization" complete:"org"`
+	Space           string      `short:"s" description:"Space" complete:"space"`
 	usage           interface{} `usage:"CF_NAME target [-o ORG] [-s SPACE]"`
 	relatedCommands interface{} `related_commands:"create-org, create-space, login, orgs, spaces"`
 
@@ -0,0 +1,76 @@
+package v2
+
+import (
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cli/actor/sharedaction"
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/v2/shared"
+)
+
+//go:generate counterfeiter . TokenInfoActor
+
+type TokenInfoActor interface {
+	TokenInformation(config sharedaction.Config) (sharedaction.TokenInfo, error)
+}
+
+type TokenInfoCommand struct {
+	CheckScope      string      `long:"check-scope" description:"Fail unless the token has this scope"`
+	usage           interface{} `usage:"CF_NAME token-info [--check-scope SCOPE]\n\nEXAMPLES:\n   CF_NAME token-info\n   CF_NAME token-info --check-scope cloud_controller.admin"`
+	relatedCommands interface{} `related_commands:"auth, login, oauth-token"`
+
+	UI     command.UI
+	Config command.Config
+	Actor  TokenInfoActor
+}
+
+func (cmd *TokenInfoCommand) Setup(config command.Config, ui command.UI) error {
+	cmd.UI = ui
+	cmd.Config = config
+	cmd.Actor = sharedaction.NewActor()
+	return nil
+}
+
+func (cmd *TokenInfoCommand) Execute(args []string) error {
+	info, err := cmd.Actor.TokenInformation(cmd.Config)
+	if err != nil {
+		return shared.HandleError(err)
+	}
+
+	table := [][]string{
+		{cmd.UI.TranslateText("issuer:"), info.Issuer},
+		{cmd.UI.TranslateText("client:"), info.ClientID},
+	}
+	if info.UserName != "" {
+		table = append(table, []string{cmd.UI.TranslateText("user:"), info.UserName})
+	}
+	table = append(table,
+		[]string{cmd.UI.TranslateText("grant type:"), info.GrantType},
+		[]string{cmd.UI.TranslateText("scopes:"), strings.Join(info.Scopes, ", ")},
+		[]string{cmd.UI.TranslateText("issued at:"), cmd.UI.UserFriendlyDate(info.IssuedAt)},
+		[]string{cmd.UI.TranslateText("expires at:"), cmd.UI.UserFriendlyDate(info.ExpiresAt)},
+		[]string{cmd.UI.TranslateText("remaining lifetime:"), cmd.remainingLifetime(info)},
+	)
+	cmd.UI.DisplayTable("", table, 3)
+
+	if cmd.CheckScope != "" {
+		cmd.UI.DisplayNewline()
+		if !info.HasScope(cmd.CheckScope) {
+			return shared.MissingScopeError{Scope: cmd.CheckScope}
+		}
+		cmd.UI.DisplayText("Token has scope {{.Scope}}.", map[string]interface{}{
+			"Scope": cmd.CheckScope,
+		})
+	}
+
+	return nil
+}
+
+func (cmd *TokenInfoCommand) remainingLifetime(info sharedaction.TokenInfo) string {
+	remaining := info.RemainingLifetime(time.Now())
+	if remaining == 0 {
+		return cmd.UI.TranslateText("expired (the token is refreshed by the next command that needs it)")
+	}
+	return (remaining - remaining%time.Second).String()
+}
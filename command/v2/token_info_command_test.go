@@ -0,0 +1,155 @@
+package v2_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/cli/actor/sharedaction"
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/commandfakes"
+	. "code.cloudfoundry.org/cli/command/v2"
+	"code.cloudfoundry.org/cli/command/v2/shared"
+	"code.cloudfoundry.org/cli/command/v2/v2fakes"
+	"code.cloudfoundry.org/cli/util/ui"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("token-info Command", func() {
+	var (
+		cmd        TokenInfoCommand
+		testUI     *ui.UI
+		fakeConfig *commandfakes.FakeConfig
+		fakeActor  *v2fakes.FakeTokenInfoActor
+		executeErr error
+	)
+
+	BeforeEach(func() {
+		testUI = ui.NewTestUI(nil, NewBuffer(), NewBuffer())
+		fakeConfig = new(commandfakes.FakeConfig)
+		fakeConfig.BinaryNameReturns("faceman")
+		fakeActor = new(v2fakes.FakeTokenInfoActor)
+
+		cmd = TokenInfoCommand{
+			UI:     testUI,
+			Config: fakeConfig,
+			Actor:  fakeActor,
+		}
+	})
+
+	JustBeforeEach(func() {
+		executeErr = cmd.Execute(nil)
+	})
+
+	Context("when the user is not logged in", func() {
+		BeforeEach(func() {
+			fakeActor.TokenInformationReturns(sharedaction.TokenInfo{}, sharedaction.NotLoggedInError{BinaryName: "faceman"})
+		})
+
+		It("returns a NotLoggedInError", func() {
+			Expect(executeErr).To(MatchError(command.NotLoggedInError{BinaryName: "faceman"}))
+		})
+	})
+
+	Context("when the token cannot be decoded", func() {
+		BeforeEach(func() {
+			fakeActor.TokenInformationReturns(sharedaction.TokenInfo{}, errors.New("not a jwt"))
+		})
+
+		It("returns the error", func() {
+			Expect(executeErr).To(MatchError("not a jwt"))
+		})
+	})
+
+	Context("when the token is decoded", func() {
+		var info sharedaction.TokenInfo
+
+		BeforeEach(func() {
+			info = sharedaction.TokenInfo{
+				Issuer:    "https://uaa.example.com/oauth/token",
+				ClientID:  "cf",
+				UserName:  "admin",
+				GrantType: "password",
+				Scopes:    []string{"openid", "cloud_controller.read"},
+				IssuedAt:  time.Unix(1473284577, 0),
+				ExpiresAt: time.Unix(1473285177, 0),
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(fakeActor.TokenInformationCallCount()).To(Equal(1))
+			Expect(fakeActor.TokenInformationArgsForCall(0)).To(Equal(fakeConfig))
+		})
+
+		Context("when the token has expired", func() {
+			BeforeEach(func() {
+				fakeActor.TokenInformationReturns(info, nil)
+			})
+
+			It("displays the token information", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(testUI.Out).To(Say(`issuer:\s+https://uaa.example.com/oauth/token`))
+				Expect(testUI.Out).To(Say(`client:\s+cf`))
+				Expect(testUI.Out).To(Say(`user:\s+admin`))
+				Expect(testUI.Out).To(Say(`grant type:\s+password`))
+				Expect(testUI.Out).To(Say(`scopes:\s+openid, cloud_controller.read`))
+				Expect(testUI.Out).To(Say(`issued at:\s+2016-09-07T21:42:57Z`))
+				Expect(testUI.Out).To(Say(`expires at:\s+2016-09-07T21:52:57Z`))
+				Expect(testUI.Out).To(Say(`remaining lifetime:\s+expired \(the token is refreshed by the next command that needs it\)`))
+			})
+		})
+
+		Context("when the token has not expired", func() {
+			BeforeEach(func() {
+				info.ExpiresAt = time.Now().Add(time.Hour)
+				fakeActor.TokenInformationReturns(info, nil)
+			})
+
+			It("displays the remaining lifetime", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(testUI.Out).To(Say(`remaining lifetime:\s+(59m59s|1h0m0s)`))
+			})
+		})
+
+		Context("when the token is for a client", func() {
+			BeforeEach(func() {
+				info.UserName = ""
+				info.GrantType = "client_credentials"
+				fakeActor.TokenInformationReturns(info, nil)
+			})
+
+			It("does not display a user", func() {
+				Expect(executeErr).ToNot(HaveOccurred())
+				Expect(testUI.Out).ToNot(Say("user:"))
+			})
+		})
+
+		Context("when --check-scope is provided", func() {
+			BeforeEach(func() {
+				fakeActor.TokenInformationReturns(info, nil)
+			})
+
+			Context("when the token has the scope", func() {
+				BeforeEach(func() {
+					cmd.CheckScope = "cloud_controller.read"
+				})
+
+				It("says so", func() {
+					Expect(executeErr).ToNot(HaveOccurred())
+					Expect(testUI.Out).To(Say("Token has scope cloud_controller.read."))
+				})
+			})
+
+			Context("when the token does not have the scope", func() {
+				BeforeEach(func() {
+					cmd.CheckScope = "cloud_controller.admin"
+				})
+
+				It("returns a MissingScopeError", func() {
+					Expect(executeErr).To(MatchError(shared.MissingScopeError{Scope: "cloud_controller.admin"}))
+				})
+			})
+		})
+	})
+})
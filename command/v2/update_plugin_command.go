@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"os"
+
+	"code.cloudfoundry.org/cli/cf/cmd"
+	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/command/flag"
+)
+
+type UpdatePluginCommand struct {
+	OptionalArgs    flag.OptionalPluginName `positional-args:"yes"`
+	All             bool                    `long:"all" description:"Update all installed plugins that have a newer version in a registered repository"`
+	Force           bool                    `short:"f" description:"Force update of plugins without confirmation"`
+	usage           interface{}             `usage:"CF_NAME update-plugin (PLUGIN_NAME | --all) [-f]\n\n   Prompts for confirmation unless '-f' is provided.\n\n   The replaced binary of each plugin is kept in the plugin directory with a '.rollback'\n   suffix. To go back to it, move that file out of the plugin directory, run\n   'CF_NAME uninstall-plugin PLUGIN_NAME' and install the moved file with 'CF_NAME install-plugin'.\n\nEXAMPLES:\n   CF_NAME update-plugin plugin-echo\n   CF_NAME update-plugin --all -f"`
+	relatedCommands interface{}             `related_commands:"install-plugin, plugins, repo-plugins"`
+}
+
+func (_ UpdatePluginCommand) Setup(config command.Config, ui command.UI) error {
+	return nil
+}
+
+func (_ UpdatePluginCommand) Execute(args []string) error {
+	cmd.Main(os.Getenv("CF_TRACE"), os.Args)
+	return nil
+}
@@ -0,0 +1,84 @@
+// This file was generated by counterfeiter
+package v2fakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/actor/v2action"
+	"code.cloudfoundry.org/cli/api/uaa"
+	"code.cloudfoundry.org/cli/command/v2"
+)
+
+type FakeAuthActor struct {
+	AuthenticateStub        func(config v2action.Config, ID string, secret string, grantType uaa.GrantType) error
+	authenticateMutex       sync.RWMutex
+	authenticateArgsForCall []struct {
+		config    v2action.Config
+		ID        string
+		secret    string
+		grantType uaa.GrantType
+	}
+	authenticateReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuthActor) Authenticate(config v2action.Config, ID string, secret string, grantType uaa.GrantType) error {
+	fake.authenticateMutex.Lock()
+	fake.authenticateArgsForCall = append(fake.authenticateArgsForCall, struct {
+		config    v2action.Config
+		ID        string
+		secret    string
+		grantType uaa.GrantType
+	}{config, ID, secret, grantType})
+	fake.recordInvocation("Authenticate", []interface{}{config, ID, secret, grantType})
+	fake.authenticateMutex.Unlock()
+	if fake.AuthenticateStub != nil {
+		return fake.AuthenticateStub(config, ID, secret, grantType)
+	} else {
+		return fake.authenticateReturns.result1
+	}
+}
+
+func (fake *FakeAuthActor) AuthenticateCallCount() int {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return len(fake.authenticateArgsForCall)
+}
+
+func (fake *FakeAuthActor) AuthenticateArgsForCall(i int) (v2action.Config, string, string, uaa.GrantType) {
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.authenticateArgsForCall[i].config, fake.authenticateArgsForCall[i].ID, fake.authenticateArgsForCall[i].secret, fake.authenticateArgsForCall[i].grantType
+}
+
+func (fake *FakeAuthActor) AuthenticateReturns(result1 error) {
+	fake.AuthenticateStub = nil
+	fake.authenticateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuthActor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.authenticateMutex.RLock()
+	defer fake.authenticateMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeAuthActor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ v2.AuthActor = new(FakeAuthActor)
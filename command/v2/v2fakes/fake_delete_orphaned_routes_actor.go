@@ -19,12 +19,12 @@ type FakeDeleteOrphanedRoutesActor struct {
 		result2 v2action.Warnings
 		result3 error
 	}
-	DeleteRouteStub        func(routeGUID string) (v2action.Warnings, error)
-	deleteRouteMutex       sync.RWMutex
-	deleteRouteArgsForCall []struct {
-		routeGUID string
+	DeleteRoutesStub        func(routes []v2action.Route) (v2action.Warnings, error)
+	deleteRoutesMutex       sync.RWMutex
+	deleteRoutesArgsForCall []struct {
+		routes []v2action.Route
 	}
-	deleteRouteReturns struct {
+	deleteRoutesReturns struct {
 		result1 v2action.Warnings
 		result2 error
 	}
@@ -67,35 +67,40 @@ func (fake *FakeDeleteOrphanedRoutesActor) GetOrphanedRoutesBySpaceReturns(resul
 	}{result1, result2, result3}
 }
 
-func (fake *FakeDeleteOrphanedRoutesActor) DeleteRoute(routeGUID string) (v2action.Warnings, error) {
-	fake.deleteRouteMutex.Lock()
-	fake.deleteRouteArgsForCall = append(fake.deleteRouteArgsForCall, struct {
-		routeGUID string
-	}{routeGUID})
-	fake.recordInvocation("DeleteRoute", []interface{}{routeGUID})
-	fake.deleteRouteMutex.Unlock()
-	if fake.DeleteRouteStub != nil {
-		return fake.DeleteRouteStub(routeGUID)
+func (fake *FakeDeleteOrphanedRoutesActor) DeleteRoutes(routes []v2action.Route) (v2action.Warnings, error) {
+	var routesCopy []v2action.Route
+	if routes != nil {
+		routesCopy = make([]v2action.Route, len(routes))
+		copy(routesCopy, routes)
+	}
+	fake.deleteRoutesMutex.Lock()
+	fake.deleteRoutesArgsForCall = append(fake.deleteRoutesArgsForCall, struct {
+		routes []v2action.Route
+	}{routesCopy})
+	fake.recordInvocation("DeleteRoutes", []interface{}{routesCopy})
+	fake.deleteRoutesMutex.Unlock()
+	if fake.DeleteRoutesStub != nil {
+		return fake.DeleteRoutesStub(routes)
 	} else {
-		return fake.deleteRouteReturns.result1, fake.deleteRouteReturns.result2
+		return fake.deleteRoutesReturns.result1, fake.deleteRoutesReturns.result2
 	}
 }
 
-func (fake *FakeDeleteOrphanedRoutesActor) DeleteRouteCallCount() int {
-	fake.deleteRouteMutex.RLock()
-	defer fake.deleteRouteMutex.RUnlock()
-	return len(fake.deleteRouteArgsForCall)
+func (fake *FakeDeleteOrphanedRoutesActor) DeleteRoutesCallCount() int {
+	fake.deleteRoutesMutex.RLock()
+	defer fake.deleteRoutesMutex.RUnlock()
+	return len(fake.deleteRoutesArgsForCall)
 }
 
-func (fake *FakeDeleteOrphanedRoutesActor) DeleteRouteArgsForCall(i int) string {
-	fake.deleteRouteMutex.RLock()
-	defer fake.deleteRouteMutex.RUnlock()
-	return fake.deleteRouteArgsForCall[i].routeGUID
+func (fake *FakeDeleteOrphanedRoutesActor) DeleteRoutesArgsForCall(i int) []v2action.Route {
+	fake.deleteRoutesMutex.RLock()
+	defer fake.deleteRoutesMutex.RUnlock()
+	return fake.deleteRoutesArgsForCall[i].routes
 }
 
-func (fake *FakeDeleteOrphanedRoutesActor) DeleteRouteReturns(result1 v2action.Warnings, result2 error) {
-	fake.DeleteRouteStub = nil
-	fake.deleteRouteReturns = struct {
+func (fake *FakeDeleteOrphanedRoutesActor) DeleteRoutesReturns(result1 v2action.Warnings, result2 error) {
+	fake.DeleteRoutesStub = nil
+	fake.deleteRoutesReturns = struct {
 		result1 v2action.Warnings
 		result2 error
 	}{result1, result2}
@@ -106,8 +111,8 @@ func (fake *FakeDeleteOrphanedRoutesActor) Invocations() map[string][][]interfac
 	defer fake.invocationsMutex.RUnlock()
 	fake.getOrphanedRoutesBySpaceMutex.RLock()
 	defer fake.getOrphanedRoutesBySpaceMutex.RUnlock()
-	fake.deleteRouteMutex.RLock()
-	defer fake.deleteRouteMutex.RUnlock()
+	fake.deleteRoutesMutex.RLock()
+	defer fake.deleteRoutesMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,79 @@
+// This file was generated by counterfeiter
+package v2fakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/actor/sharedaction"
+	"code.cloudfoundry.org/cli/command/v2"
+)
+
+type FakeTokenInfoActor struct {
+	TokenInformationStub        func(config sharedaction.Config) (sharedaction.TokenInfo, error)
+	tokenInformationMutex       sync.RWMutex
+	tokenInformationArgsForCall []struct {
+		config sharedaction.Config
+	}
+	tokenInformationReturns struct {
+		result1 sharedaction.TokenInfo
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeTokenInfoActor) TokenInformation(config sharedaction.Config) (sharedaction.TokenInfo, error) {
+	fake.tokenInformationMutex.Lock()
+	fake.tokenInformationArgsForCall = append(fake.tokenInformationArgsForCall, struct {
+		config sharedaction.Config
+	}{config})
+	fake.recordInvocation("TokenInformation", []interface{}{config})
+	fake.tokenInformationMutex.Unlock()
+	if fake.TokenInformationStub != nil {
+		return fake.TokenInformationStub(config)
+	} else {
+		return fake.tokenInformationReturns.result1, fake.tokenInformationReturns.result2
+	}
+}
+
+func (fake *FakeTokenInfoActor) TokenInformationCallCount() int {
+	fake.tokenInformationMutex.RLock()
+	defer fake.tokenInformationMutex.RUnlock()
+	return len(fake.tokenInformationArgsForCall)
+}
+
+func (fake *FakeTokenInfoActor) TokenInformationArgsForCall(i int) sharedaction.Config {
+	fake.tokenInformationMutex.RLock()
+	defer fake.tokenInformationMutex.RUnlock()
+	return fake.tokenInformationArgsForCall[i].config
+}
+
+func (fake *FakeTokenInfoActor) TokenInformationReturns(result1 sharedaction.TokenInfo, result2 error) {
+	fake.TokenInformationStub = nil
+	fake.tokenInformationReturns = struct {
+		result1 sharedaction.TokenInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTokenInfoActor) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.tokenInformationMutex.RLock()
+	defer fake.tokenInformationMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeTokenInfoActor) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ v2.TokenInfoActor = new(FakeTokenInfoActor)
@@ -6,6 +6,9 @@ import (
 	"code.cloudfoundry.org/cli/api/uaa"
 	uaaWrapper "code.cloudfoundry.org/cli/api/uaa/wrapper"
 	"code.cloudfoundry.org/cli/command"
+	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/httpcache"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
 )
 
 // NewClients creates a new V3 Cloud Controller client and UAA client using the
@@ -17,24 +20,33 @@ func NewClients(config command.Config, ui command.UI) (*ccv3.Client, error) {
 		}
 	}
 
+	certificates, err := tlsconfig.Load(config.TLSFiles())
+	if err != nil {
+		return nil, err
+	}
+
 	ccClient := ccv3.NewClient(config.BinaryName(), config.BinaryVersion())
-	_, err := ccClient.TargetCF(ccv3.TargetSettings{
-		URL:               config.Target(),
-		SkipSSLValidation: config.SkipSSLValidation(),
-		DialTimeout:       config.DialTimeout(),
+	_, err = ccClient.TargetCF(ccv3.TargetSettings{
+		URL:                config.Target(),
+		SkipSSLValidation:  config.SkipSSLValidation(),
+		DialTimeout:        config.DialTimeout(),
+		RootCAs:            certificates.RootCAs,
+		ClientCertificates: certificates.ClientCertificates,
 	})
 	if err != nil {
 		return nil, ClientTargetError{Message: err.Error()}
 	}
 
 	uaaClient := uaa.NewClient(uaa.Config{
-		AppName:           config.BinaryName(),
-		AppVersion:        config.BinaryVersion(),
-		ClientID:          config.UAAOAuthClient(),
-		ClientSecret:      config.UAAOAuthClientSecret(),
-		DialTimeout:       config.DialTimeout(),
-		SkipSSLValidation: config.SkipSSLValidation(),
-		URL:               ccClient.UAA(),
+		AppName:            config.BinaryName(),
+		AppVersion:         config.BinaryVersion(),
+		ClientID:           config.UAAOAuthClient(),
+		ClientSecret:       config.UAAOAuthClientSecret(),
+		DialTimeout:        config.DialTimeout(),
+		SkipSSLValidation:  config.SkipSSLValidation(),
+		RootCAs:            certificates.RootCAs,
+		ClientCertificates: certificates.ClientCertificates,
+		URL:                ccClient.UAA(),
 	})
 
 	verbose, location := config.Verbose()
@@ -43,12 +55,24 @@ func NewClients(config command.Config, ui command.UI) (*ccv3.Client, error) {
 		uaaClient.WrapConnection(uaaWrapper.NewRequestLogger(command.NewRequestLoggerTerminalDisplay(ui)))
 	}
 	if location != nil {
-		ccClient.WrapConnection(ccWrapper.NewRequestLogger(command.NewRequestLoggerFileWriter(ui, location)))
-		uaaClient.WrapConnection(uaaWrapper.NewRequestLogger(command.NewRequestLoggerFileWriter(ui, location)))
+		if config.TraceFormat() == configv3.TraceFormatHAR {
+			harWriter := command.NewHARFileWriter(ui, location)
+			ccClient.WrapConnection(ccWrapper.NewHARRecorder(harWriter))
+			uaaClient.WrapConnection(uaaWrapper.NewHARRecorder(harWriter))
+		} else {
+			ccClient.WrapConnection(ccWrapper.NewRequestLogger(command.NewRequestLoggerFileWriter(ui, location)))
+			uaaClient.WrapConnection(uaaWrapper.NewRequestLogger(command.NewRequestLoggerFileWriter(ui, location)))
+		}
 	}
 
 	ccClient.WrapConnection(ccWrapper.NewUAAAuthentication(uaaClient, config))
-	ccClient.WrapConnection(ccWrapper.NewRetryRequest(2))
+	ccClient.WrapConnection(ccWrapper.NewRetryRequest(config.RetryPolicy()))
+	if identity := httpcache.Identity(config.AccessToken()); identity != "" && config.CacheTTL() > 0 {
+		cache := httpcache.New(config.CacheDirectory(), config.Target(), identity, config.CacheTTL())
+		ccClient.WrapConnection(ccWrapper.NewHTTPCache(cache))
+	}
+
+	uaaClient.WrapConnection(uaaWrapper.NewRetryRequest(config.RetryPolicy()))
 
 	return ccClient, nil
 }
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"code.cloudfoundry.org/cli/plugin"
+)
+
+type Completions struct{}
+
+func (c *Completions) Run(cliConnection plugin.CliConnection, args []string) {}
+
+// CompleteFlag offers app names for --app, and a production app once
+// --env prod has been given.
+func (c *Completions) CompleteFlag(cliConnection plugin.CliConnection, request plugin.CompletionRequest) []string {
+	values := []string{"app-one", "app-two", "backend"}
+	for i, arg := range request.Args {
+		if arg == "--env" && i+1 < len(request.Args) && request.Args[i+1] == "prod" {
+			values = append(values, "app-prod")
+		}
+	}
+	return values
+}
+
+func (c *Completions) GetMetadata() plugin.PluginMetadata {
+	return plugin.PluginMetadata{
+		Name: "Completions",
+		Commands: []plugin.Command{
+			{
+				Name:     "deploy",
+				HelpText: "Deploys an app",
+				UsageDetails: plugin.Usage{
+					Usage: "cf deploy [--app APP] [--env ENV] [--force]",
+					Flags: []plugin.Flag{
+						{Name: "app", ShortName: "a", Type: plugin.StringFlag, Usage: "App to deploy", Complete: true},
+						{Name: "env", Type: plugin.StringFlag, Default: "dev", Usage: "Environment to deploy to", Values: []string{"dev", "staging", "prod"}},
+						{Name: "force", ShortName: "f", Usage: "Deploy without confirmation"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func main() {
+	plugin.Start(new(Completions))
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"code.cloudfoundry.org/cli/plugin"
+)
+
+type Hooks struct{}
+
+func (c *Hooks) Run(cliConnection plugin.CliConnection, args []string) {}
+
+// RunHook vetoes pushing an app named "forbidden-app" and writes every event
+// it receives to $HOOKS_EVENT_FILE.
+func (c *Hooks) RunHook(cliConnection plugin.CliConnection, event plugin.HookEvent) plugin.HookResult {
+	if path := os.Getenv("HOOKS_EVENT_FILE"); path != "" {
+		contents, _ := json.Marshal(event)
+		_ = ioutil.WriteFile(path, contents, 0600)
+	}
+
+	for _, arg := range event.Args {
+		if event.Stage == plugin.PreHook && arg == "forbidden-app" {
+			return plugin.HookResult{Veto: true, Message: "app names must not be forbidden"}
+		}
+	}
+	return plugin.HookResult{}
+}
+
+func (c *Hooks) GetMetadata() plugin.PluginMetadata {
+	return plugin.PluginMetadata{
+		Name:     "Hooks",
+		Commands: []plugin.Command{},
+		Hooks: []plugin.Hook{
+			{Command: "push", Stage: plugin.PreHook},
+			{Command: "push", Stage: plugin.PostHook},
+		},
+	}
+}
+
+func main() {
+	plugin.Start(new(Hooks))
+}
@@ -12,6 +12,8 @@ import (
 	"code.cloudfoundry.org/cli/command/common"
 	"code.cloudfoundry.org/cli/command/v2"
 	"code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/contexts"
+	"code.cloudfoundry.org/cli/util/httpcache"
 	"code.cloudfoundry.org/cli/util/panichandler"
 	"code.cloudfoundry.org/cli/util/ui"
 	"github.com/jessevdk/go-flags"
@@ -26,12 +28,122 @@ var ParseErr = errors.New("incorrect type for arg")
 
 func main() {
 	defer panichandler.HandlePanic()
+	if len(os.Args) > 1 && os.Args[1] == common.CompleteCommandName {
+		complete(os.Args[2:])
+		return
+	}
+	os.Args = selectGlobalFlags(os.Args)
 	parse(os.Args[1:])
 }
 
+// complete runs the hidden command that the completion scripts call. It is
+// dispatched before parsing, since the words it completes are commands and
+// flags themselves, and it displays nothing but candidates: tracing is
+// disabled and errors are dropped. The global flags among the words already
+// typed select the context the candidates are looked up in, but stay in the
+// words being completed.
+func complete(args []string) {
+	if len(args) > 0 {
+		selectGlobalFlags(append([]string{os.Args[0]}, args[:len(args)-1]...))
+	}
+	// Unlike an unset CF_TRACE, false also overrides trace in the config.
+	os.Setenv("CF_TRACE", "false")
+
+	cfConfig, err := configv3.LoadConfig()
+	if err != nil {
+		return
+	}
+
+	commandUI, err := ui.NewUI(cfConfig)
+	if err != nil {
+		return
+	}
+
+	accessToken, refreshToken := cfConfig.AccessToken(), cfConfig.RefreshToken()
+
+	completeCmd := common.CompleteCommand{}
+	err = completeCmd.Setup(cfConfig, commandUI)
+	if err != nil {
+		return
+	}
+
+	err = completeCmd.Execute(args)
+	if err != nil {
+		return
+	}
+
+	// Looking up candidates only changes the config when it refreshes the
+	// tokens, and completion runs on every tab press.
+	if cfConfig.AccessToken() != accessToken || cfConfig.RefreshToken() != refreshToken {
+		configv3.WriteConfig(cfConfig)
+	}
+}
+
+// selectGlobalFlags removes the global flags that are passed on through
+// environment variables from args, so that the legacy commands and plugins
+// see them too:
+//
+//   --context NAME    selects the context through CF_CONTEXT
+//   --trace-har FILE  traces to FILE in the HTTP Archive format through
+//                     CF_TRACE and CF_TRACE_FORMAT
+//   --no-cache        disables the response cache through CF_CACHE_TTL
+//
+// Only the flags before the command name are global. The ones after it
+// belong to the command, or to a plugin, and are left alone.
+func selectGlobalFlags(args []string) []string {
+	if len(args) == 0 {
+		return args
+	}
+
+	remaining := []string{args[0]}
+	i := 1
+	for ; i < len(args) && strings.HasPrefix(args[i], "-"); i++ {
+		switch arg := args[i]; {
+		case arg == "--context" && i+1 < len(args):
+			os.Setenv(contexts.EnvVar, args[i+1])
+			i++
+		case strings.HasPrefix(arg, "--context="):
+			os.Setenv(contexts.EnvVar, strings.TrimPrefix(arg, "--context="))
+		case arg == "--trace-har" && i+1 < len(args):
+			setHARTrace(args[i+1])
+			i++
+		case strings.HasPrefix(arg, "--trace-har="):
+			setHARTrace(strings.TrimPrefix(arg, "--trace-har="))
+		case arg == "--no-cache":
+			os.Setenv(httpcache.TTLEnvVar, "0")
+		default:
+			remaining = append(remaining, arg)
+		}
+	}
+	return append(remaining, args[i:]...)
+}
+
+// argsAfterCommand returns the arguments given after the name, or an alias,
+// of command. Flags such as -v can come before the name.
+func argsAfterCommand(args []string, command *flags.Command) []string {
+	for i, arg := range args {
+		if arg == command.Name {
+			return args[i+1:]
+		}
+		for _, alias := range command.Aliases {
+			if arg == alias {
+				return args[i+1:]
+			}
+		}
+	}
+	return []string{}
+}
+
+func setHARTrace(path string) {
+	os.Setenv("CF_TRACE", path)
+	os.Setenv("CF_TRACE_FORMAT", configv3.TraceFormatHAR)
+}
+
 func parse(args []string) {
 	parser := flags.NewParser(&common.Commands, flags.HelpFlag)
-	parser.CommandHandler = executionWrapper
+	parser.CommandHandler = func(commander flags.Commander, commandArgs []string) error {
+		return executionWrapper(parser.Active.Name, argsAfterCommand(args, parser.Active), commander, commandArgs)
+	}
 	extraArgs, err := parser.ParseArgs(args)
 	if err == nil {
 		return
@@ -133,7 +245,9 @@ func isOption(s string) bool {
 	return strings.HasPrefix(s, "-")
 }
 
-func executionWrapper(cmd flags.Commander, args []string) error {
+// executionWrapper runs a command, surrounded by the hooks plugins registered
+// for it. hookArgs are the command line arguments given after the command.
+func executionWrapper(commandName string, hookArgs []string, commander flags.Commander, args []string) error {
 	cfConfig, err := configv3.LoadConfig(configv3.FlagOverride{
 		Verbose: common.Commands.VerboseOrVersion,
 	})
@@ -148,7 +262,7 @@ func executionWrapper(cmd flags.Commander, args []string) error {
 		}
 	}()
 
-	if extendedCmd, ok := cmd.(command.ExtendedCommander); ok {
+	if extendedCmd, ok := commander.(command.ExtendedCommander); ok {
 		commandUI, err := ui.NewUI(cfConfig)
 		if err != nil {
 			return err
@@ -158,7 +272,16 @@ func executionWrapper(cmd flags.Commander, args []string) error {
 		if err != nil {
 			return handleError(err, commandUI)
 		}
-		return handleError(extendedCmd.Execute(args), commandUI)
+
+		err = cmd.RunPreCommandHooks(os.Getenv("CF_TRACE"), commandName, hookArgs)
+		if err != nil {
+			return handleError(err, commandUI)
+		}
+
+		err = extendedCmd.Execute(args)
+		executeErr := handleError(err, commandUI)
+		cmd.RunPostCommandHooks(os.Getenv("CF_TRACE"), commandName, hookArgs, err)
+		return executeErr
 	}
 
 	return fmt.Errorf("command does not conform to ExtendedCommander")
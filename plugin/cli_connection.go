@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/rpc"
 	"os"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/cli/plugin/models"
@@ -50,6 +51,66 @@ func (c *cliConnection) sendPluginMetadataToCliServer(metadata PluginMetadata) {
 	os.Exit(0)
 }
 
+func (c *cliConnection) getHookEvent() HookEvent {
+	var event HookEvent
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetHookEvent", "", &event)
+	})
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	return event
+}
+
+func (c *cliConnection) sendHookResultToCliServer(result HookResult) {
+	var success bool
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.SetHookResult", result, &success)
+	})
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+func (c *cliConnection) getCompletionRequest() CompletionRequest {
+	var request CompletionRequest
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetCompletionRequest", "", &request)
+	})
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	return request
+}
+
+func (c *cliConnection) sendCompletionToCliServer(values []string) {
+	var success bool
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.SetCompletion", values, &success)
+	})
+
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
 func (c *cliConnection) isMinCliVersion(version string) bool {
 	var result bool
 
@@ -109,6 +170,39 @@ func (c *cliConnection) callCliCommand(silently bool, args ...string) ([]string,
 	return cmdOutput, nil
 }
 
+func (c *cliConnection) CliCommandStream(onOutput func(line string), args ...string) (plugin_models.CommandResult, error) {
+	var result plugin_models.CommandResult
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		var started bool
+		err := client.Call("CliRpcCmd.StartCoreCommand", args, &started)
+		if err != nil {
+			return err
+		}
+
+		for {
+			var output plugin_models.CommandOutput
+			err = client.Call("CliRpcCmd.ReadCoreCommandOutput", "", &output)
+			if err != nil {
+				return err
+			}
+
+			if onOutput != nil {
+				for _, line := range output.Lines {
+					onOutput(line)
+				}
+			}
+
+			if output.Done {
+				result = output.Result
+				return nil
+			}
+		}
+	})
+
+	return result, err
+}
+
 func (c *cliConnection) pingCLI() {
 	//call back to cf saying we have been setup
 	var connErr error
@@ -381,3 +475,118 @@ func (c *cliConnection) GetService(serviceInstance string) (plugin_models.GetSer
 
 	return result, err
 }
+
+// PluginAPIVersion returns the plugin API version served by the CLI. CLIs that
+// predate versioning do not know the method and are reported as version 1.
+func (c *cliConnection) PluginAPIVersion() (int, error) {
+	var result int
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetPluginAPIVersion", "", &result)
+	})
+
+	if err != nil && strings.Contains(err.Error(), "can't find method") {
+		return 1, nil
+	}
+
+	return result, err
+}
+
+func (c *cliConnection) GetRoutes() ([]plugin_models.GetRoutes_Model, error) {
+	var result []plugin_models.GetRoutes_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetRoutes", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetDomains() ([]plugin_models.GetDomains_Model, error) {
+	var result []plugin_models.GetDomains_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetDomains", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetServiceKeys(serviceInstance string) ([]plugin_models.GetServiceKeys_Model, error) {
+	var result []plugin_models.GetServiceKeys_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetServiceKeys", serviceInstance, &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetSecurityGroups() ([]plugin_models.GetSecurityGroups_Model, error) {
+	var result []plugin_models.GetSecurityGroups_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetSecurityGroups", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetBuildpacks() ([]plugin_models.GetBuildpacks_Model, error) {
+	var result []plugin_models.GetBuildpacks_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetBuildpacks", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetStacks() ([]plugin_models.GetStacks_Model, error) {
+	var result []plugin_models.GetStacks_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetStacks", "", &result)
+	})
+
+	return result, err
+}
+
+func (c *cliConnection) GetTasks(appName string) ([]plugin_models.GetTasks_Model, error) {
+	var result []plugin_models.GetTasks_Model
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call("CliRpcCmd.GetTasks", appName, &result)
+	})
+
+	return result, err
+}
+
+// CloudControllerRequest sends a request to the targeted Cloud Controller
+// through the CLI, which authenticates, retries and traces it like its own
+// requests. path is relative to the API endpoint. Error statuses are returned
+// in the response rather than as an error.
+func (c *cliConnection) CloudControllerRequest(method string, path string, body []byte) (plugin_models.APIResponse, error) {
+	return c.apiRequest("CliRpcCmd.CloudControllerRequest", method, path, body)
+}
+
+// UAARequest is like CloudControllerRequest, but for the targeted UAA.
+func (c *cliConnection) UAARequest(method string, path string, body []byte) (plugin_models.APIResponse, error) {
+	return c.apiRequest("CliRpcCmd.UAARequest", method, path, body)
+}
+
+func (c *cliConnection) apiRequest(serviceMethod string, method string, path string, body []byte) (plugin_models.APIResponse, error) {
+	var result plugin_models.APIResponse
+
+	request := plugin_models.APIRequest{
+		Method: method,
+		Path:   path,
+		Body:   body,
+	}
+
+	err := c.withClientDo(func(client *rpc.Client) error {
+		return client.Call(serviceMethod, request, &result)
+	})
+
+	return result, err
+}
@@ -0,0 +1,13 @@
+package plugin_models
+
+type APIRequest struct {
+	Method string
+	Path   string
+	Body   []byte
+}
+
+type APIResponse struct {
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+}
@@ -0,0 +1,13 @@
+package plugin_models
+
+type CommandResult struct {
+	ExitCode int
+	Warnings []string
+	Error    string
+}
+
+type CommandOutput struct {
+	Lines  []string
+	Done   bool
+	Result CommandResult
+}
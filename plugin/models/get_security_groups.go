@@ -0,0 +1,13 @@
+package plugin_models
+
+type GetSecurityGroups_Model struct {
+	Guid   string
+	Name   string
+	Rules  []map[string]interface{}
+	Spaces []GetSecurityGroups_Space
+}
+
+type GetSecurityGroups_Space struct {
+	Guid string
+	Name string
+}
@@ -0,0 +1,17 @@
+package plugin_models
+
+import "encoding/gob"
+
+type GetServiceKeys_Model struct {
+	Guid        string
+	Name        string
+	Credentials map[string]interface{}
+}
+
+func init() {
+	// Credentials and security group rules are decoded from JSON, so their
+	// values can be nested objects and arrays. gob has to know these types on
+	// both sides of the RPC connection.
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
@@ -0,0 +1,12 @@
+package plugin_models
+
+type GetTasks_Model struct {
+	Guid       string
+	SequenceId int
+	Name       string
+	Command    string
+	State      string
+	MemoryInMB int64
+	DiskInMB   int64
+	CreatedAt  string
+}
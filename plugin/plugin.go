@@ -44,6 +44,46 @@ type CliConnection interface {
 	GetSpace(string) (plugin_models.GetSpace_Model, error)
 }
 
+// APIVersion is the version of the RPC API that the CLI serves to plugins.
+// Version 1 is everything in CliConnection; versions 2 and 3 add the methods
+// in CliConnectionV2 and CliConnectionV3.
+const APIVersion = 3
+
+//go:generate counterfeiter . CliConnectionV2
+/**
+	CliConnectionV2 holds the methods added in version 2 of the plugin API.
+	The connection passed to Run implements it; plugins type assert to use it
+	and should check PluginAPIVersion before relying on it against older CLIs.
+**/
+type CliConnectionV2 interface {
+	CliConnection
+	PluginAPIVersion() (int, error)
+	GetRoutes() ([]plugin_models.GetRoutes_Model, error)
+	GetDomains() ([]plugin_models.GetDomains_Model, error)
+	GetServiceKeys(string) ([]plugin_models.GetServiceKeys_Model, error)
+	GetSecurityGroups() ([]plugin_models.GetSecurityGroups_Model, error)
+	GetBuildpacks() ([]plugin_models.GetBuildpacks_Model, error)
+	GetStacks() ([]plugin_models.GetStacks_Model, error)
+	GetTasks(string) ([]plugin_models.GetTasks_Model, error)
+	CloudControllerRequest(method string, path string, body []byte) (plugin_models.APIResponse, error)
+	UAARequest(method string, path string, body []byte) (plugin_models.APIResponse, error)
+}
+
+//go:generate counterfeiter . CliConnectionV3
+/**
+	CliConnectionV3 holds the methods added in version 3 of the plugin API.
+
+	CliCommandStream runs a core command without terminal output and calls
+	onOutput with each line the command prints, while it runs. The result holds
+	the command's exit code and the warnings it showed; a failed command is
+	reported in the result, and the error is only set when the command could
+	not be run.
+**/
+type CliConnectionV3 interface {
+	CliConnectionV2
+	CliCommandStream(onOutput func(line string), args ...string) (plugin_models.CommandResult, error)
+}
+
 type VersionType struct {
 	Major int
 	Minor int
@@ -55,11 +95,84 @@ type PluginMetadata struct {
 	Version       VersionType
 	MinCliVersion VersionType
 	Commands      []Command
+	Hooks         []Hook
+}
+
+/**
+	HookPlugin needs to be implemented by plugins that register Hooks in their
+	metadata. RunHook is called instead of Run whenever a hooked core command
+	runs; a pre hook can stop the command by returning a HookResult with Veto
+	set.
+**/
+type HookPlugin interface {
+	Plugin
+	RunHook(cliConnection CliConnection, event HookEvent) HookResult
+}
+
+type HookStage string
+
+const (
+	PreHook  HookStage = "pre"
+	PostHook HookStage = "post"
+)
+
+type Hook struct {
+	Command string // name of the core command, e.g. "push"; aliases are not matched
+	Stage   HookStage
+}
+
+type HookEvent struct {
+	Stage     HookStage
+	Command   string
+	Args      []string // arguments and flags given after the command name
+	Succeeded bool     // post hooks only
+	Error     string   // post hooks only, the error the command failed with
+}
+
+type HookResult struct {
+	Veto    bool   // pre hooks only, stops the command from running
+	Message string // shown to the user when the command is vetoed
 }
 
 type Usage struct {
 	Usage   string
 	Options map[string]string
+	Flags   []Flag // shown instead of Options when set, and used for shell completion
+}
+
+type FlagType string
+
+const (
+	BoolFlag   FlagType = "bool"
+	StringFlag FlagType = "string"
+	IntFlag    FlagType = "int"
+)
+
+type Flag struct {
+	Name      string   // long name, without the leading dashes
+	ShortName string   // optional single letter name
+	Type      FlagType // BoolFlag when empty
+	Default   string   // shown in help when set
+	Usage     string
+	Values    []string // fixed values offered when completing the flag's value
+	Complete  bool     // values are completed by the plugin's CompleteFlag method
+}
+
+/**
+	FlagCompleter needs to be implemented by plugins that set Complete on any
+	of their flags. CompleteFlag is called instead of Run when the shell
+	completes the value of such a flag, and returns the candidate values.
+**/
+type FlagCompleter interface {
+	Plugin
+	CompleteFlag(cliConnection CliConnection, request CompletionRequest) []string
+}
+
+type CompletionRequest struct {
+	Command string   // name of the plugin command being completed
+	Flag    string   // long name of the flag whose value is completed
+	Args    []string // arguments and flags already given after the command name
+	Prefix  string   // the partial value typed so far
 }
 
 type Command struct {
@@ -11,6 +11,8 @@ import (
 	* os.Args[1] port CF_CLI rpc server is running on
 	* os.Args[2] **OPTIONAL**
 		* SendMetadata - used to fetch the plugin metadata
+		* RunHook - used to run a hook registered in the plugin metadata
+		* CompleteFlag - used to complete the value of a flag registered in the plugin metadata
 **/
 func Start(cmd Plugin) {
 	if len(os.Args) < 2 {
@@ -22,6 +24,18 @@ func Start(cmd Plugin) {
 	cliConnection.pingCLI()
 	if isMetadataRequest(os.Args) {
 		cliConnection.sendPluginMetadataToCliServer(cmd.GetMetadata())
+	} else if isHookRequest(os.Args) {
+		var result HookResult
+		if hookPlugin, ok := cmd.(HookPlugin); ok {
+			result = hookPlugin.RunHook(cliConnection, cliConnection.getHookEvent())
+		}
+		cliConnection.sendHookResultToCliServer(result)
+	} else if isCompletionRequest(os.Args) {
+		var values []string
+		if completer, ok := cmd.(FlagCompleter); ok {
+			values = completer.CompleteFlag(cliConnection, cliConnection.getCompletionRequest())
+		}
+		cliConnection.sendCompletionToCliServer(values)
 	} else {
 		if version := MinCliVersionStr(cmd.GetMetadata().MinCliVersion); version != "" {
 			ok := cliConnection.isMinCliVersion(version)
@@ -39,6 +53,14 @@ func isMetadataRequest(args []string) bool {
 	return len(args) == 3 && args[2] == "SendMetadata"
 }
 
+func isHookRequest(args []string) bool {
+	return len(args) == 3 && args[2] == "RunHook"
+}
+
+func isCompletionRequest(args []string) bool {
+	return len(args) == 3 && args[2] == "CompleteFlag"
+}
+
 func MinCliVersionStr(version VersionType) string {
 	if version.Major == 0 && version.Minor == 0 && version.Build == 0 {
 		return ""
@@ -0,0 +1,1518 @@
+// This file was generated by counterfeiter
+package pluginfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/plugin/models"
+)
+
+type FakeCliConnectionV3 struct {
+	CliCommandWithoutTerminalOutputStub        func(args ...string) ([]string, error)
+	cliCommandWithoutTerminalOutputMutex       sync.RWMutex
+	cliCommandWithoutTerminalOutputArgsForCall []struct {
+		args []string
+	}
+	cliCommandWithoutTerminalOutputReturns struct {
+		result1 []string
+		result2 error
+	}
+	CliCommandStub        func(args ...string) ([]string, error)
+	cliCommandMutex       sync.RWMutex
+	cliCommandArgsForCall []struct {
+		args []string
+	}
+	cliCommandReturns struct {
+		result1 []string
+		result2 error
+	}
+	GetCurrentOrgStub        func() (plugin_models.Organization, error)
+	getCurrentOrgMutex       sync.RWMutex
+	getCurrentOrgArgsForCall []struct{}
+	getCurrentOrgReturns     struct {
+		result1 plugin_models.Organization
+		result2 error
+	}
+	GetCurrentSpaceStub        func() (plugin_models.Space, error)
+	getCurrentSpaceMutex       sync.RWMutex
+	getCurrentSpaceArgsForCall []struct{}
+	getCurrentSpaceReturns     struct {
+		result1 plugin_models.Space
+		result2 error
+	}
+	UsernameStub        func() (string, error)
+	usernameMutex       sync.RWMutex
+	usernameArgsForCall []struct{}
+	usernameReturns     struct {
+		result1 string
+		result2 error
+	}
+	UserGuidStub        func() (string, error)
+	userGuidMutex       sync.RWMutex
+	userGuidArgsForCall []struct{}
+	userGuidReturns     struct {
+		result1 string
+		result2 error
+	}
+	UserEmailStub        func() (string, error)
+	userEmailMutex       sync.RWMutex
+	userEmailArgsForCall []struct{}
+	userEmailReturns     struct {
+		result1 string
+		result2 error
+	}
+	IsLoggedInStub        func() (bool, error)
+	isLoggedInMutex       sync.RWMutex
+	isLoggedInArgsForCall []struct{}
+	isLoggedInReturns     struct {
+		result1 bool
+		result2 error
+	}
+	IsSSLDisabledStub        func() (bool, error)
+	isSSLDisabledMutex       sync.RWMutex
+	isSSLDisabledArgsForCall []struct{}
+	isSSLDisabledReturns     struct {
+		result1 bool
+		result2 error
+	}
+	HasOrganizationStub        func() (bool, error)
+	hasOrganizationMutex       sync.RWMutex
+	hasOrganizationArgsForCall []struct{}
+	hasOrganizationReturns     struct {
+		result1 bool
+		result2 error
+	}
+	HasSpaceStub        func() (bool, error)
+	hasSpaceMutex       sync.RWMutex
+	hasSpaceArgsForCall []struct{}
+	hasSpaceReturns     struct {
+		result1 bool
+		result2 error
+	}
+	ApiEndpointStub        func() (string, error)
+	apiEndpointMutex       sync.RWMutex
+	apiEndpointArgsForCall []struct{}
+	apiEndpointReturns     struct {
+		result1 string
+		result2 error
+	}
+	ApiVersionStub        func() (string, error)
+	apiVersionMutex       sync.RWMutex
+	apiVersionArgsForCall []struct{}
+	apiVersionReturns     struct {
+		result1 string
+		result2 error
+	}
+	HasAPIEndpointStub        func() (bool, error)
+	hasAPIEndpointMutex       sync.RWMutex
+	hasAPIEndpointArgsForCall []struct{}
+	hasAPIEndpointReturns     struct {
+		result1 bool
+		result2 error
+	}
+	LoggregatorEndpointStub        func() (string, error)
+	loggregatorEndpointMutex       sync.RWMutex
+	loggregatorEndpointArgsForCall []struct{}
+	loggregatorEndpointReturns     struct {
+		result1 string
+		result2 error
+	}
+	DopplerEndpointStub        func() (string, error)
+	dopplerEndpointMutex       sync.RWMutex
+	dopplerEndpointArgsForCall []struct{}
+	dopplerEndpointReturns     struct {
+		result1 string
+		result2 error
+	}
+	AccessTokenStub        func() (string, error)
+	accessTokenMutex       sync.RWMutex
+	accessTokenArgsForCall []struct{}
+	accessTokenReturns     struct {
+		result1 string
+		result2 error
+	}
+	GetAppStub        func(string) (plugin_models.GetAppModel, error)
+	getAppMutex       sync.RWMutex
+	getAppArgsForCall []struct {
+		arg1 string
+	}
+	getAppReturns struct {
+		result1 plugin_models.GetAppModel
+		result2 error
+	}
+	GetAppsStub        func() ([]plugin_models.GetAppsModel, error)
+	getAppsMutex       sync.RWMutex
+	getAppsArgsForCall []struct{}
+	getAppsReturns     struct {
+		result1 []plugin_models.GetAppsModel
+		result2 error
+	}
+	GetOrgsStub        func() ([]plugin_models.GetOrgs_Model, error)
+	getOrgsMutex       sync.RWMutex
+	getOrgsArgsForCall []struct{}
+	getOrgsReturns     struct {
+		result1 []plugin_models.GetOrgs_Model
+		result2 error
+	}
+	GetSpacesStub        func() ([]plugin_models.GetSpaces_Model, error)
+	getSpacesMutex       sync.RWMutex
+	getSpacesArgsForCall []struct{}
+	getSpacesReturns     struct {
+		result1 []plugin_models.GetSpaces_Model
+		result2 error
+	}
+	GetOrgUsersStub        func(string, ...string) ([]plugin_models.GetOrgUsers_Model, error)
+	getOrgUsersMutex       sync.RWMutex
+	getOrgUsersArgsForCall []struct {
+		arg1 string
+		arg2 []string
+	}
+	getOrgUsersReturns struct {
+		result1 []plugin_models.GetOrgUsers_Model
+		result2 error
+	}
+	GetSpaceUsersStub        func(string, string) ([]plugin_models.GetSpaceUsers_Model, error)
+	getSpaceUsersMutex       sync.RWMutex
+	getSpaceUsersArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	getSpaceUsersReturns struct {
+		result1 []plugin_models.GetSpaceUsers_Model
+		result2 error
+	}
+	GetServicesStub        func() ([]plugin_models.GetServices_Model, error)
+	getServicesMutex       sync.RWMutex
+	getServicesArgsForCall []struct{}
+	getServicesReturns     struct {
+		result1 []plugin_models.GetServices_Model
+		result2 error
+	}
+	GetServiceStub        func(string) (plugin_models.GetService_Model, error)
+	getServiceMutex       sync.RWMutex
+	getServiceArgsForCall []struct {
+		arg1 string
+	}
+	getServiceReturns struct {
+		result1 plugin_models.GetService_Model
+		result2 error
+	}
+	GetOrgStub        func(string) (plugin_models.GetOrg_Model, error)
+	getOrgMutex       sync.RWMutex
+	getOrgArgsForCall []struct {
+		arg1 string
+	}
+	getOrgReturns struct {
+		result1 plugin_models.GetOrg_Model
+		result2 error
+	}
+	GetSpaceStub        func(string) (plugin_models.GetSpace_Model, error)
+	getSpaceMutex       sync.RWMutex
+	getSpaceArgsForCall []struct {
+		arg1 string
+	}
+	getSpaceReturns struct {
+		result1 plugin_models.GetSpace_Model
+		result2 error
+	}
+	PluginAPIVersionStub        func() (int, error)
+	pluginAPIVersionMutex       sync.RWMutex
+	pluginAPIVersionArgsForCall []struct{}
+	pluginAPIVersionReturns     struct {
+		result1 int
+		result2 error
+	}
+	GetRoutesStub        func() ([]plugin_models.GetRoutes_Model, error)
+	getRoutesMutex       sync.RWMutex
+	getRoutesArgsForCall []struct{}
+	getRoutesReturns     struct {
+		result1 []plugin_models.GetRoutes_Model
+		result2 error
+	}
+	GetDomainsStub        func() ([]plugin_models.GetDomains_Model, error)
+	getDomainsMutex       sync.RWMutex
+	getDomainsArgsForCall []struct{}
+	getDomainsReturns     struct {
+		result1 []plugin_models.GetDomains_Model
+		result2 error
+	}
+	GetServiceKeysStub        func(string) ([]plugin_models.GetServiceKeys_Model, error)
+	getServiceKeysMutex       sync.RWMutex
+	getServiceKeysArgsForCall []struct {
+		arg1 string
+	}
+	getServiceKeysReturns struct {
+		result1 []plugin_models.GetServiceKeys_Model
+		result2 error
+	}
+	GetSecurityGroupsStub        func() ([]plugin_models.GetSecurityGroups_Model, error)
+	getSecurityGroupsMutex       sync.RWMutex
+	getSecurityGroupsArgsForCall []struct{}
+	getSecurityGroupsReturns     struct {
+		result1 []plugin_models.GetSecurityGroups_Model
+		result2 error
+	}
+	GetBuildpacksStub        func() ([]plugin_models.GetBuildpacks_Model, error)
+	getBuildpacksMutex       sync.RWMutex
+	getBuildpacksArgsForCall []struct{}
+	getBuildpacksReturns     struct {
+		result1 []plugin_models.GetBuildpacks_Model
+		result2 error
+	}
+	GetStacksStub        func() ([]plugin_models.GetStacks_Model, error)
+	getStacksMutex       sync.RWMutex
+	getStacksArgsForCall []struct{}
+	getStacksReturns     struct {
+		result1 []plugin_models.GetStacks_Model
+		result2 error
+	}
+	GetTasksStub        func(string) ([]plugin_models.GetTasks_Model, error)
+	getTasksMutex       sync.RWMutex
+	getTasksArgsForCall []struct {
+		arg1 string
+	}
+	getTasksReturns struct {
+		result1 []plugin_models.GetTasks_Model
+		result2 error
+	}
+	CloudControllerRequestStub        func(method string, path string, body []byte) (plugin_models.APIResponse, error)
+	cloudControllerRequestMutex       sync.RWMutex
+	cloudControllerRequestArgsForCall []struct {
+		method string
+		path   string
+		body   []byte
+	}
+	cloudControllerRequestReturns struct {
+		result1 plugin_models.APIResponse
+		result2 error
+	}
+	UAARequestStub        func(method string, path string, body []byte) (plugin_models.APIResponse, error)
+	uAARequestMutex       sync.RWMutex
+	uAARequestArgsForCall []struct {
+		method string
+		path   string
+		body   []byte
+	}
+	uAARequestReturns struct {
+		result1 plugin_models.APIResponse
+		result2 error
+	}
+	CliCommandStreamStub        func(onOutput func(line string), args ...string) (plugin_models.CommandResult, error)
+	cliCommandStreamMutex       sync.RWMutex
+	cliCommandStreamArgsForCall []struct {
+		onOutput func(line string)
+		args     []string
+	}
+	cliCommandStreamReturns struct {
+		result1 plugin_models.CommandResult
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCliConnectionV3) CliCommandWithoutTerminalOutput(args ...string) ([]string, error) {
+	fake.cliCommandWithoutTerminalOutputMutex.Lock()
+	fake.cliCommandWithoutTerminalOutputArgsForCall = append(fake.cliCommandWithoutTerminalOutputArgsForCall, struct {
+		args []string
+	}{args})
+	fake.recordInvocation("CliCommandWithoutTerminalOutput", []interface{}{args})
+	fake.cliCommandWithoutTerminalOutputMutex.Unlock()
+	if fake.CliCommandWithoutTerminalOutputStub != nil {
+		return fake.CliCommandWithoutTerminalOutputStub(args...)
+	} else {
+		return fake.cliCommandWithoutTerminalOutputReturns.result1, fake.cliCommandWithoutTerminalOutputReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) CliCommandWithoutTerminalOutputCallCount() int {
+	fake.cliCommandWithoutTerminalOutputMutex.RLock()
+	defer fake.cliCommandWithoutTerminalOutputMutex.RUnlock()
+	return len(fake.cliCommandWithoutTerminalOutputArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) CliCommandWithoutTerminalOutputArgsForCall(i int) []string {
+	fake.cliCommandWithoutTerminalOutputMutex.RLock()
+	defer fake.cliCommandWithoutTerminalOutputMutex.RUnlock()
+	return fake.cliCommandWithoutTerminalOutputArgsForCall[i].args
+}
+
+func (fake *FakeCliConnectionV3) CliCommandWithoutTerminalOutputReturns(result1 []string, result2 error) {
+	fake.CliCommandWithoutTerminalOutputStub = nil
+	fake.cliCommandWithoutTerminalOutputReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) CliCommand(args ...string) ([]string, error) {
+	fake.cliCommandMutex.Lock()
+	fake.cliCommandArgsForCall = append(fake.cliCommandArgsForCall, struct {
+		args []string
+	}{args})
+	fake.recordInvocation("CliCommand", []interface{}{args})
+	fake.cliCommandMutex.Unlock()
+	if fake.CliCommandStub != nil {
+		return fake.CliCommandStub(args...)
+	} else {
+		return fake.cliCommandReturns.result1, fake.cliCommandReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) CliCommandCallCount() int {
+	fake.cliCommandMutex.RLock()
+	defer fake.cliCommandMutex.RUnlock()
+	return len(fake.cliCommandArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) CliCommandArgsForCall(i int) []string {
+	fake.cliCommandMutex.RLock()
+	defer fake.cliCommandMutex.RUnlock()
+	return fake.cliCommandArgsForCall[i].args
+}
+
+func (fake *FakeCliConnectionV3) CliCommandReturns(result1 []string, result2 error) {
+	fake.CliCommandStub = nil
+	fake.cliCommandReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetCurrentOrg() (plugin_models.Organization, error) {
+	fake.getCurrentOrgMutex.Lock()
+	fake.getCurrentOrgArgsForCall = append(fake.getCurrentOrgArgsForCall, struct{}{})
+	fake.recordInvocation("GetCurrentOrg", []interface{}{})
+	fake.getCurrentOrgMutex.Unlock()
+	if fake.GetCurrentOrgStub != nil {
+		return fake.GetCurrentOrgStub()
+	} else {
+		return fake.getCurrentOrgReturns.result1, fake.getCurrentOrgReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetCurrentOrgCallCount() int {
+	fake.getCurrentOrgMutex.RLock()
+	defer fake.getCurrentOrgMutex.RUnlock()
+	return len(fake.getCurrentOrgArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetCurrentOrgReturns(result1 plugin_models.Organization, result2 error) {
+	fake.GetCurrentOrgStub = nil
+	fake.getCurrentOrgReturns = struct {
+		result1 plugin_models.Organization
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetCurrentSpace() (plugin_models.Space, error) {
+	fake.getCurrentSpaceMutex.Lock()
+	fake.getCurrentSpaceArgsForCall = append(fake.getCurrentSpaceArgsForCall, struct{}{})
+	fake.recordInvocation("GetCurrentSpace", []interface{}{})
+	fake.getCurrentSpaceMutex.Unlock()
+	if fake.GetCurrentSpaceStub != nil {
+		return fake.GetCurrentSpaceStub()
+	} else {
+		return fake.getCurrentSpaceReturns.result1, fake.getCurrentSpaceReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetCurrentSpaceCallCount() int {
+	fake.getCurrentSpaceMutex.RLock()
+	defer fake.getCurrentSpaceMutex.RUnlock()
+	return len(fake.getCurrentSpaceArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetCurrentSpaceReturns(result1 plugin_models.Space, result2 error) {
+	fake.GetCurrentSpaceStub = nil
+	fake.getCurrentSpaceReturns = struct {
+		result1 plugin_models.Space
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) Username() (string, error) {
+	fake.usernameMutex.Lock()
+	fake.usernameArgsForCall = append(fake.usernameArgsForCall, struct{}{})
+	fake.recordInvocation("Username", []interface{}{})
+	fake.usernameMutex.Unlock()
+	if fake.UsernameStub != nil {
+		return fake.UsernameStub()
+	} else {
+		return fake.usernameReturns.result1, fake.usernameReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) UsernameCallCount() int {
+	fake.usernameMutex.RLock()
+	defer fake.usernameMutex.RUnlock()
+	return len(fake.usernameArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) UsernameReturns(result1 string, result2 error) {
+	fake.UsernameStub = nil
+	fake.usernameReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) UserGuid() (string, error) {
+	fake.userGuidMutex.Lock()
+	fake.userGuidArgsForCall = append(fake.userGuidArgsForCall, struct{}{})
+	fake.recordInvocation("UserGuid", []interface{}{})
+	fake.userGuidMutex.Unlock()
+	if fake.UserGuidStub != nil {
+		return fake.UserGuidStub()
+	} else {
+		return fake.userGuidReturns.result1, fake.userGuidReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) UserGuidCallCount() int {
+	fake.userGuidMutex.RLock()
+	defer fake.userGuidMutex.RUnlock()
+	return len(fake.userGuidArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) UserGuidReturns(result1 string, result2 error) {
+	fake.UserGuidStub = nil
+	fake.userGuidReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) UserEmail() (string, error) {
+	fake.userEmailMutex.Lock()
+	fake.userEmailArgsForCall = append(fake.userEmailArgsForCall, struct{}{})
+	fake.recordInvocation("UserEmail", []interface{}{})
+	fake.userEmailMutex.Unlock()
+	if fake.UserEmailStub != nil {
+		return fake.UserEmailStub()
+	} else {
+		return fake.userEmailReturns.result1, fake.userEmailReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) UserEmailCallCount() int {
+	fake.userEmailMutex.RLock()
+	defer fake.userEmailMutex.RUnlock()
+	return len(fake.userEmailArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) UserEmailReturns(result1 string, result2 error) {
+	fake.UserEmailStub = nil
+	fake.userEmailReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) IsLoggedIn() (bool, error) {
+	fake.isLoggedInMutex.Lock()
+	fake.isLoggedInArgsForCall = append(fake.isLoggedInArgsForCall, struct{}{})
+	fake.recordInvocation("IsLoggedIn", []interface{}{})
+	fake.isLoggedInMutex.Unlock()
+	if fake.IsLoggedInStub != nil {
+		return fake.IsLoggedInStub()
+	} else {
+		return fake.isLoggedInReturns.result1, fake.isLoggedInReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) IsLoggedInCallCount() int {
+	fake.isLoggedInMutex.RLock()
+	defer fake.isLoggedInMutex.RUnlock()
+	return len(fake.isLoggedInArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) IsLoggedInReturns(result1 bool, result2 error) {
+	fake.IsLoggedInStub = nil
+	fake.isLoggedInReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) IsSSLDisabled() (bool, error) {
+	fake.isSSLDisabledMutex.Lock()
+	fake.isSSLDisabledArgsForCall = append(fake.isSSLDisabledArgsForCall, struct{}{})
+	fake.recordInvocation("IsSSLDisabled", []interface{}{})
+	fake.isSSLDisabledMutex.Unlock()
+	if fake.IsSSLDisabledStub != nil {
+		return fake.IsSSLDisabledStub()
+	} else {
+		return fake.isSSLDisabledReturns.result1, fake.isSSLDisabledReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) IsSSLDisabledCallCount() int {
+	fake.isSSLDisabledMutex.RLock()
+	defer fake.isSSLDisabledMutex.RUnlock()
+	return len(fake.isSSLDisabledArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) IsSSLDisabledReturns(result1 bool, result2 error) {
+	fake.IsSSLDisabledStub = nil
+	fake.isSSLDisabledReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) HasOrganization() (bool, error) {
+	fake.hasOrganizationMutex.Lock()
+	fake.hasOrganizationArgsForCall = append(fake.hasOrganizationArgsForCall, struct{}{})
+	fake.recordInvocation("HasOrganization", []interface{}{})
+	fake.hasOrganizationMutex.Unlock()
+	if fake.HasOrganizationStub != nil {
+		return fake.HasOrganizationStub()
+	} else {
+		return fake.hasOrganizationReturns.result1, fake.hasOrganizationReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) HasOrganizationCallCount() int {
+	fake.hasOrganizationMutex.RLock()
+	defer fake.hasOrganizationMutex.RUnlock()
+	return len(fake.hasOrganizationArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) HasOrganizationReturns(result1 bool, result2 error) {
+	fake.HasOrganizationStub = nil
+	fake.hasOrganizationReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) HasSpace() (bool, error) {
+	fake.hasSpaceMutex.Lock()
+	fake.hasSpaceArgsForCall = append(fake.hasSpaceArgsForCall, struct{}{})
+	fake.recordInvocation("HasSpace", []interface{}{})
+	fake.hasSpaceMutex.Unlock()
+	if fake.HasSpaceStub != nil {
+		return fake.HasSpaceStub()
+	} else {
+		return fake.hasSpaceReturns.result1, fake.hasSpaceReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) HasSpaceCallCount() int {
+	fake.hasSpaceMutex.RLock()
+	defer fake.hasSpaceMutex.RUnlock()
+	return len(fake.hasSpaceArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) HasSpaceReturns(result1 bool, result2 error) {
+	fake.HasSpaceStub = nil
+	fake.hasSpaceReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) ApiEndpoint() (string, error) {
+	fake.apiEndpointMutex.Lock()
+	fake.apiEndpointArgsForCall = append(fake.apiEndpointArgsForCall, struct{}{})
+	fake.recordInvocation("ApiEndpoint", []interface{}{})
+	fake.apiEndpointMutex.Unlock()
+	if fake.ApiEndpointStub != nil {
+		return fake.ApiEndpointStub()
+	} else {
+		return fake.apiEndpointReturns.result1, fake.apiEndpointReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) ApiEndpointCallCount() int {
+	fake.apiEndpointMutex.RLock()
+	defer fake.apiEndpointMutex.RUnlock()
+	return len(fake.apiEndpointArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) ApiEndpointReturns(result1 string, result2 error) {
+	fake.ApiEndpointStub = nil
+	fake.apiEndpointReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) ApiVersion() (string, error) {
+	fake.apiVersionMutex.Lock()
+	fake.apiVersionArgsForCall = append(fake.apiVersionArgsForCall, struct{}{})
+	fake.recordInvocation("ApiVersion", []interface{}{})
+	fake.apiVersionMutex.Unlock()
+	if fake.ApiVersionStub != nil {
+		return fake.ApiVersionStub()
+	} else {
+		return fake.apiVersionReturns.result1, fake.apiVersionReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) ApiVersionCallCount() int {
+	fake.apiVersionMutex.RLock()
+	defer fake.apiVersionMutex.RUnlock()
+	return len(fake.apiVersionArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) ApiVersionReturns(result1 string, result2 error) {
+	fake.ApiVersionStub = nil
+	fake.apiVersionReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) HasAPIEndpoint() (bool, error) {
+	fake.hasAPIEndpointMutex.Lock()
+	fake.hasAPIEndpointArgsForCall = append(fake.hasAPIEndpointArgsForCall, struct{}{})
+	fake.recordInvocation("HasAPIEndpoint", []interface{}{})
+	fake.hasAPIEndpointMutex.Unlock()
+	if fake.HasAPIEndpointStub != nil {
+		return fake.HasAPIEndpointStub()
+	} else {
+		return fake.hasAPIEndpointReturns.result1, fake.hasAPIEndpointReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) HasAPIEndpointCallCount() int {
+	fake.hasAPIEndpointMutex.RLock()
+	defer fake.hasAPIEndpointMutex.RUnlock()
+	return len(fake.hasAPIEndpointArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) HasAPIEndpointReturns(result1 bool, result2 error) {
+	fake.HasAPIEndpointStub = nil
+	fake.hasAPIEndpointReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) LoggregatorEndpoint() (string, error) {
+	fake.loggregatorEndpointMutex.Lock()
+	fake.loggregatorEndpointArgsForCall = append(fake.loggregatorEndpointArgsForCall, struct{}{})
+	fake.recordInvocation("LoggregatorEndpoint", []interface{}{})
+	fake.loggregatorEndpointMutex.Unlock()
+	if fake.LoggregatorEndpointStub != nil {
+		return fake.LoggregatorEndpointStub()
+	} else {
+		return fake.loggregatorEndpointReturns.result1, fake.loggregatorEndpointReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) LoggregatorEndpointCallCount() int {
+	fake.loggregatorEndpointMutex.RLock()
+	defer fake.loggregatorEndpointMutex.RUnlock()
+	return len(fake.loggregatorEndpointArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) LoggregatorEndpointReturns(result1 string, result2 error) {
+	fake.LoggregatorEndpointStub = nil
+	fake.loggregatorEndpointReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) DopplerEndpoint() (string, error) {
+	fake.dopplerEndpointMutex.Lock()
+	fake.dopplerEndpointArgsForCall = append(fake.dopplerEndpointArgsForCall, struct{}{})
+	fake.recordInvocation("DopplerEndpoint", []interface{}{})
+	fake.dopplerEndpointMutex.Unlock()
+	if fake.DopplerEndpointStub != nil {
+		return fake.DopplerEndpointStub()
+	} else {
+		return fake.dopplerEndpointReturns.result1, fake.dopplerEndpointReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) DopplerEndpointCallCount() int {
+	fake.dopplerEndpointMutex.RLock()
+	defer fake.dopplerEndpointMutex.RUnlock()
+	return len(fake.dopplerEndpointArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) DopplerEndpointReturns(result1 string, result2 error) {
+	fake.DopplerEndpointStub = nil
+	fake.dopplerEndpointReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) AccessToken() (string, error) {
+	fake.accessTokenMutex.Lock()
+	fake.accessTokenArgsForCall = append(fake.accessTokenArgsForCall, struct{}{})
+	fake.recordInvocation("AccessToken", []interface{}{})
+	fake.accessTokenMutex.Unlock()
+	if fake.AccessTokenStub != nil {
+		return fake.AccessTokenStub()
+	} else {
+		return fake.accessTokenReturns.result1, fake.accessTokenReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) AccessTokenCallCount() int {
+	fake.accessTokenMutex.RLock()
+	defer fake.accessTokenMutex.RUnlock()
+	return len(fake.accessTokenArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) AccessTokenReturns(result1 string, result2 error) {
+	fake.AccessTokenStub = nil
+	fake.accessTokenReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetApp(arg1 string) (plugin_models.GetAppModel, error) {
+	fake.getAppMutex.Lock()
+	fake.getAppArgsForCall = append(fake.getAppArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetApp", []interface{}{arg1})
+	fake.getAppMutex.Unlock()
+	if fake.GetAppStub != nil {
+		return fake.GetAppStub(arg1)
+	} else {
+		return fake.getAppReturns.result1, fake.getAppReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetAppCallCount() int {
+	fake.getAppMutex.RLock()
+	defer fake.getAppMutex.RUnlock()
+	return len(fake.getAppArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetAppArgsForCall(i int) string {
+	fake.getAppMutex.RLock()
+	defer fake.getAppMutex.RUnlock()
+	return fake.getAppArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnectionV3) GetAppReturns(result1 plugin_models.GetAppModel, result2 error) {
+	fake.GetAppStub = nil
+	fake.getAppReturns = struct {
+		result1 plugin_models.GetAppModel
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetApps() ([]plugin_models.GetAppsModel, error) {
+	fake.getAppsMutex.Lock()
+	fake.getAppsArgsForCall = append(fake.getAppsArgsForCall, struct{}{})
+	fake.recordInvocation("GetApps", []interface{}{})
+	fake.getAppsMutex.Unlock()
+	if fake.GetAppsStub != nil {
+		return fake.GetAppsStub()
+	} else {
+		return fake.getAppsReturns.result1, fake.getAppsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetAppsCallCount() int {
+	fake.getAppsMutex.RLock()
+	defer fake.getAppsMutex.RUnlock()
+	return len(fake.getAppsArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetAppsReturns(result1 []plugin_models.GetAppsModel, result2 error) {
+	fake.GetAppsStub = nil
+	fake.getAppsReturns = struct {
+		result1 []plugin_models.GetAppsModel
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetOrgs() ([]plugin_models.GetOrgs_Model, error) {
+	fake.getOrgsMutex.Lock()
+	fake.getOrgsArgsForCall = append(fake.getOrgsArgsForCall, struct{}{})
+	fake.recordInvocation("GetOrgs", []interface{}{})
+	fake.getOrgsMutex.Unlock()
+	if fake.GetOrgsStub != nil {
+		return fake.GetOrgsStub()
+	} else {
+		return fake.getOrgsReturns.result1, fake.getOrgsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetOrgsCallCount() int {
+	fake.getOrgsMutex.RLock()
+	defer fake.getOrgsMutex.RUnlock()
+	return len(fake.getOrgsArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetOrgsReturns(result1 []plugin_models.GetOrgs_Model, result2 error) {
+	fake.GetOrgsStub = nil
+	fake.getOrgsReturns = struct {
+		result1 []plugin_models.GetOrgs_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetSpaces() ([]plugin_models.GetSpaces_Model, error) {
+	fake.getSpacesMutex.Lock()
+	fake.getSpacesArgsForCall = append(fake.getSpacesArgsForCall, struct{}{})
+	fake.recordInvocation("GetSpaces", []interface{}{})
+	fake.getSpacesMutex.Unlock()
+	if fake.GetSpacesStub != nil {
+		return fake.GetSpacesStub()
+	} else {
+		return fake.getSpacesReturns.result1, fake.getSpacesReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetSpacesCallCount() int {
+	fake.getSpacesMutex.RLock()
+	defer fake.getSpacesMutex.RUnlock()
+	return len(fake.getSpacesArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetSpacesReturns(result1 []plugin_models.GetSpaces_Model, result2 error) {
+	fake.GetSpacesStub = nil
+	fake.getSpacesReturns = struct {
+		result1 []plugin_models.GetSpaces_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetOrgUsers(arg1 string, arg2 ...string) ([]plugin_models.GetOrgUsers_Model, error) {
+	fake.getOrgUsersMutex.Lock()
+	fake.getOrgUsersArgsForCall = append(fake.getOrgUsersArgsForCall, struct {
+		arg1 string
+		arg2 []string
+	}{arg1, arg2})
+	fake.recordInvocation("GetOrgUsers", []interface{}{arg1, arg2})
+	fake.getOrgUsersMutex.Unlock()
+	if fake.GetOrgUsersStub != nil {
+		return fake.GetOrgUsersStub(arg1, arg2...)
+	} else {
+		return fake.getOrgUsersReturns.result1, fake.getOrgUsersReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetOrgUsersCallCount() int {
+	fake.getOrgUsersMutex.RLock()
+	defer fake.getOrgUsersMutex.RUnlock()
+	return len(fake.getOrgUsersArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetOrgUsersArgsForCall(i int) (string, []string) {
+	fake.getOrgUsersMutex.RLock()
+	defer fake.getOrgUsersMutex.RUnlock()
+	return fake.getOrgUsersArgsForCall[i].arg1, fake.getOrgUsersArgsForCall[i].arg2
+}
+
+func (fake *FakeCliConnectionV3) GetOrgUsersReturns(result1 []plugin_models.GetOrgUsers_Model, result2 error) {
+	fake.GetOrgUsersStub = nil
+	fake.getOrgUsersReturns = struct {
+		result1 []plugin_models.GetOrgUsers_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetSpaceUsers(arg1 string, arg2 string) ([]plugin_models.GetSpaceUsers_Model, error) {
+	fake.getSpaceUsersMutex.Lock()
+	fake.getSpaceUsersArgsForCall = append(fake.getSpaceUsersArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("GetSpaceUsers", []interface{}{arg1, arg2})
+	fake.getSpaceUsersMutex.Unlock()
+	if fake.GetSpaceUsersStub != nil {
+		return fake.GetSpaceUsersStub(arg1, arg2)
+	} else {
+		return fake.getSpaceUsersReturns.result1, fake.getSpaceUsersReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetSpaceUsersCallCount() int {
+	fake.getSpaceUsersMutex.RLock()
+	defer fake.getSpaceUsersMutex.RUnlock()
+	return len(fake.getSpaceUsersArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetSpaceUsersArgsForCall(i int) (string, string) {
+	fake.getSpaceUsersMutex.RLock()
+	defer fake.getSpaceUsersMutex.RUnlock()
+	return fake.getSpaceUsersArgsForCall[i].arg1, fake.getSpaceUsersArgsForCall[i].arg2
+}
+
+func (fake *FakeCliConnectionV3) GetSpaceUsersReturns(result1 []plugin_models.GetSpaceUsers_Model, result2 error) {
+	fake.GetSpaceUsersStub = nil
+	fake.getSpaceUsersReturns = struct {
+		result1 []plugin_models.GetSpaceUsers_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetServices() ([]plugin_models.GetServices_Model, error) {
+	fake.getServicesMutex.Lock()
+	fake.getServicesArgsForCall = append(fake.getServicesArgsForCall, struct{}{})
+	fake.recordInvocation("GetServices", []interface{}{})
+	fake.getServicesMutex.Unlock()
+	if fake.GetServicesStub != nil {
+		return fake.GetServicesStub()
+	} else {
+		return fake.getServicesReturns.result1, fake.getServicesReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetServicesCallCount() int {
+	fake.getServicesMutex.RLock()
+	defer fake.getServicesMutex.RUnlock()
+	return len(fake.getServicesArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetServicesReturns(result1 []plugin_models.GetServices_Model, result2 error) {
+	fake.GetServicesStub = nil
+	fake.getServicesReturns = struct {
+		result1 []plugin_models.GetServices_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetService(arg1 string) (plugin_models.GetService_Model, error) {
+	fake.getServiceMutex.Lock()
+	fake.getServiceArgsForCall = append(fake.getServiceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetService", []interface{}{arg1})
+	fake.getServiceMutex.Unlock()
+	if fake.GetServiceStub != nil {
+		return fake.GetServiceStub(arg1)
+	} else {
+		return fake.getServiceReturns.result1, fake.getServiceReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetServiceCallCount() int {
+	fake.getServiceMutex.RLock()
+	defer fake.getServiceMutex.RUnlock()
+	return len(fake.getServiceArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetServiceArgsForCall(i int) string {
+	fake.getServiceMutex.RLock()
+	defer fake.getServiceMutex.RUnlock()
+	return fake.getServiceArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnectionV3) GetServiceReturns(result1 plugin_models.GetService_Model, result2 error) {
+	fake.GetServiceStub = nil
+	fake.getServiceReturns = struct {
+		result1 plugin_models.GetService_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetOrg(arg1 string) (plugin_models.GetOrg_Model, error) {
+	fake.getOrgMutex.Lock()
+	fake.getOrgArgsForCall = append(fake.getOrgArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetOrg", []interface{}{arg1})
+	fake.getOrgMutex.Unlock()
+	if fake.GetOrgStub != nil {
+		return fake.GetOrgStub(arg1)
+	} else {
+		return fake.getOrgReturns.result1, fake.getOrgReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetOrgCallCount() int {
+	fake.getOrgMutex.RLock()
+	defer fake.getOrgMutex.RUnlock()
+	return len(fake.getOrgArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetOrgArgsForCall(i int) string {
+	fake.getOrgMutex.RLock()
+	defer fake.getOrgMutex.RUnlock()
+	return fake.getOrgArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnectionV3) GetOrgReturns(result1 plugin_models.GetOrg_Model, result2 error) {
+	fake.GetOrgStub = nil
+	fake.getOrgReturns = struct {
+		result1 plugin_models.GetOrg_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetSpace(arg1 string) (plugin_models.GetSpace_Model, error) {
+	fake.getSpaceMutex.Lock()
+	fake.getSpaceArgsForCall = append(fake.getSpaceArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetSpace", []interface{}{arg1})
+	fake.getSpaceMutex.Unlock()
+	if fake.GetSpaceStub != nil {
+		return fake.GetSpaceStub(arg1)
+	} else {
+		return fake.getSpaceReturns.result1, fake.getSpaceReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetSpaceCallCount() int {
+	fake.getSpaceMutex.RLock()
+	defer fake.getSpaceMutex.RUnlock()
+	return len(fake.getSpaceArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetSpaceArgsForCall(i int) string {
+	fake.getSpaceMutex.RLock()
+	defer fake.getSpaceMutex.RUnlock()
+	return fake.getSpaceArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnectionV3) GetSpaceReturns(result1 plugin_models.GetSpace_Model, result2 error) {
+	fake.GetSpaceStub = nil
+	fake.getSpaceReturns = struct {
+		result1 plugin_models.GetSpace_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) PluginAPIVersion() (int, error) {
+	fake.pluginAPIVersionMutex.Lock()
+	fake.pluginAPIVersionArgsForCall = append(fake.pluginAPIVersionArgsForCall, struct{}{})
+	fake.recordInvocation("PluginAPIVersion", []interface{}{})
+	fake.pluginAPIVersionMutex.Unlock()
+	if fake.PluginAPIVersionStub != nil {
+		return fake.PluginAPIVersionStub()
+	} else {
+		return fake.pluginAPIVersionReturns.result1, fake.pluginAPIVersionReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) PluginAPIVersionCallCount() int {
+	fake.pluginAPIVersionMutex.RLock()
+	defer fake.pluginAPIVersionMutex.RUnlock()
+	return len(fake.pluginAPIVersionArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) PluginAPIVersionReturns(result1 int, result2 error) {
+	fake.PluginAPIVersionStub = nil
+	fake.pluginAPIVersionReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetRoutes() ([]plugin_models.GetRoutes_Model, error) {
+	fake.getRoutesMutex.Lock()
+	fake.getRoutesArgsForCall = append(fake.getRoutesArgsForCall, struct{}{})
+	fake.recordInvocation("GetRoutes", []interface{}{})
+	fake.getRoutesMutex.Unlock()
+	if fake.GetRoutesStub != nil {
+		return fake.GetRoutesStub()
+	} else {
+		return fake.getRoutesReturns.result1, fake.getRoutesReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetRoutesCallCount() int {
+	fake.getRoutesMutex.RLock()
+	defer fake.getRoutesMutex.RUnlock()
+	return len(fake.getRoutesArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetRoutesReturns(result1 []plugin_models.GetRoutes_Model, result2 error) {
+	fake.GetRoutesStub = nil
+	fake.getRoutesReturns = struct {
+		result1 []plugin_models.GetRoutes_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetDomains() ([]plugin_models.GetDomains_Model, error) {
+	fake.getDomainsMutex.Lock()
+	fake.getDomainsArgsForCall = append(fake.getDomainsArgsForCall, struct{}{})
+	fake.recordInvocation("GetDomains", []interface{}{})
+	fake.getDomainsMutex.Unlock()
+	if fake.GetDomainsStub != nil {
+		return fake.GetDomainsStub()
+	} else {
+		return fake.getDomainsReturns.result1, fake.getDomainsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetDomainsCallCount() int {
+	fake.getDomainsMutex.RLock()
+	defer fake.getDomainsMutex.RUnlock()
+	return len(fake.getDomainsArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetDomainsReturns(result1 []plugin_models.GetDomains_Model, result2 error) {
+	fake.GetDomainsStub = nil
+	fake.getDomainsReturns = struct {
+		result1 []plugin_models.GetDomains_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetServiceKeys(arg1 string) ([]plugin_models.GetServiceKeys_Model, error) {
+	fake.getServiceKeysMutex.Lock()
+	fake.getServiceKeysArgsForCall = append(fake.getServiceKeysArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetServiceKeys", []interface{}{arg1})
+	fake.getServiceKeysMutex.Unlock()
+	if fake.GetServiceKeysStub != nil {
+		return fake.GetServiceKeysStub(arg1)
+	} else {
+		return fake.getServiceKeysReturns.result1, fake.getServiceKeysReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetServiceKeysCallCount() int {
+	fake.getServiceKeysMutex.RLock()
+	defer fake.getServiceKeysMutex.RUnlock()
+	return len(fake.getServiceKeysArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetServiceKeysArgsForCall(i int) string {
+	fake.getServiceKeysMutex.RLock()
+	defer fake.getServiceKeysMutex.RUnlock()
+	return fake.getServiceKeysArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnectionV3) GetServiceKeysReturns(result1 []plugin_models.GetServiceKeys_Model, result2 error) {
+	fake.GetServiceKeysStub = nil
+	fake.getServiceKeysReturns = struct {
+		result1 []plugin_models.GetServiceKeys_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetSecurityGroups() ([]plugin_models.GetSecurityGroups_Model, error) {
+	fake.getSecurityGroupsMutex.Lock()
+	fake.getSecurityGroupsArgsForCall = append(fake.getSecurityGroupsArgsForCall, struct{}{})
+	fake.recordInvocation("GetSecurityGroups", []interface{}{})
+	fake.getSecurityGroupsMutex.Unlock()
+	if fake.GetSecurityGroupsStub != nil {
+		return fake.GetSecurityGroupsStub()
+	} else {
+		return fake.getSecurityGroupsReturns.result1, fake.getSecurityGroupsReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetSecurityGroupsCallCount() int {
+	fake.getSecurityGroupsMutex.RLock()
+	defer fake.getSecurityGroupsMutex.RUnlock()
+	return len(fake.getSecurityGroupsArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetSecurityGroupsReturns(result1 []plugin_models.GetSecurityGroups_Model, result2 error) {
+	fake.GetSecurityGroupsStub = nil
+	fake.getSecurityGroupsReturns = struct {
+		result1 []plugin_models.GetSecurityGroups_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetBuildpacks() ([]plugin_models.GetBuildpacks_Model, error) {
+	fake.getBuildpacksMutex.Lock()
+	fake.getBuildpacksArgsForCall = append(fake.getBuildpacksArgsForCall, struct{}{})
+	fake.recordInvocation("GetBuildpacks", []interface{}{})
+	fake.getBuildpacksMutex.Unlock()
+	if fake.GetBuildpacksStub != nil {
+		return fake.GetBuildpacksStub()
+	} else {
+		return fake.getBuildpacksReturns.result1, fake.getBuildpacksReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetBuildpacksCallCount() int {
+	fake.getBuildpacksMutex.RLock()
+	defer fake.getBuildpacksMutex.RUnlock()
+	return len(fake.getBuildpacksArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetBuildpacksReturns(result1 []plugin_models.GetBuildpacks_Model, result2 error) {
+	fake.GetBuildpacksStub = nil
+	fake.getBuildpacksReturns = struct {
+		result1 []plugin_models.GetBuildpacks_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetStacks() ([]plugin_models.GetStacks_Model, error) {
+	fake.getStacksMutex.Lock()
+	fake.getStacksArgsForCall = append(fake.getStacksArgsForCall, struct{}{})
+	fake.recordInvocation("GetStacks", []interface{}{})
+	fake.getStacksMutex.Unlock()
+	if fake.GetStacksStub != nil {
+		return fake.GetStacksStub()
+	} else {
+		return fake.getStacksReturns.result1, fake.getStacksReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetStacksCallCount() int {
+	fake.getStacksMutex.RLock()
+	defer fake.getStacksMutex.RUnlock()
+	return len(fake.getStacksArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetStacksReturns(result1 []plugin_models.GetStacks_Model, result2 error) {
+	fake.GetStacksStub = nil
+	fake.getStacksReturns = struct {
+		result1 []plugin_models.GetStacks_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) GetTasks(arg1 string) ([]plugin_models.GetTasks_Model, error) {
+	fake.getTasksMutex.Lock()
+	fake.getTasksArgsForCall = append(fake.getTasksArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("GetTasks", []interface{}{arg1})
+	fake.getTasksMutex.Unlock()
+	if fake.GetTasksStub != nil {
+		return fake.GetTasksStub(arg1)
+	} else {
+		return fake.getTasksReturns.result1, fake.getTasksReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) GetTasksCallCount() int {
+	fake.getTasksMutex.RLock()
+	defer fake.getTasksMutex.RUnlock()
+	return len(fake.getTasksArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) GetTasksArgsForCall(i int) string {
+	fake.getTasksMutex.RLock()
+	defer fake.getTasksMutex.RUnlock()
+	return fake.getTasksArgsForCall[i].arg1
+}
+
+func (fake *FakeCliConnectionV3) GetTasksReturns(result1 []plugin_models.GetTasks_Model, result2 error) {
+	fake.GetTasksStub = nil
+	fake.getTasksReturns = struct {
+		result1 []plugin_models.GetTasks_Model
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) CloudControllerRequest(method string, path string, body []byte) (plugin_models.APIResponse, error) {
+	var bodyCopy []byte
+	if body != nil {
+		bodyCopy = make([]byte, len(body))
+		copy(bodyCopy, body)
+	}
+	fake.cloudControllerRequestMutex.Lock()
+	fake.cloudControllerRequestArgsForCall = append(fake.cloudControllerRequestArgsForCall, struct {
+		method string
+		path   string
+		body   []byte
+	}{method, path, bodyCopy})
+	fake.recordInvocation("CloudControllerRequest", []interface{}{method, path, bodyCopy})
+	fake.cloudControllerRequestMutex.Unlock()
+	if fake.CloudControllerRequestStub != nil {
+		return fake.CloudControllerRequestStub(method, path, body)
+	} else {
+		return fake.cloudControllerRequestReturns.result1, fake.cloudControllerRequestReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) CloudControllerRequestCallCount() int {
+	fake.cloudControllerRequestMutex.RLock()
+	defer fake.cloudControllerRequestMutex.RUnlock()
+	return len(fake.cloudControllerRequestArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) CloudControllerRequestArgsForCall(i int) (string, string, []byte) {
+	fake.cloudControllerRequestMutex.RLock()
+	defer fake.cloudControllerRequestMutex.RUnlock()
+	return fake.cloudControllerRequestArgsForCall[i].method, fake.cloudControllerRequestArgsForCall[i].path, fake.cloudControllerRequestArgsForCall[i].body
+}
+
+func (fake *FakeCliConnectionV3) CloudControllerRequestReturns(result1 plugin_models.APIResponse, result2 error) {
+	fake.CloudControllerRequestStub = nil
+	fake.cloudControllerRequestReturns = struct {
+		result1 plugin_models.APIResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) UAARequest(method string, path string, body []byte) (plugin_models.APIResponse, error) {
+	var bodyCopy []byte
+	if body != nil {
+		bodyCopy = make([]byte, len(body))
+		copy(bodyCopy, body)
+	}
+	fake.uAARequestMutex.Lock()
+	fake.uAARequestArgsForCall = append(fake.uAARequestArgsForCall, struct {
+		method string
+		path   string
+		body   []byte
+	}{method, path, bodyCopy})
+	fake.recordInvocation("UAARequest", []interface{}{method, path, bodyCopy})
+	fake.uAARequestMutex.Unlock()
+	if fake.UAARequestStub != nil {
+		return fake.UAARequestStub(method, path, body)
+	} else {
+		return fake.uAARequestReturns.result1, fake.uAARequestReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) UAARequestCallCount() int {
+	fake.uAARequestMutex.RLock()
+	defer fake.uAARequestMutex.RUnlock()
+	return len(fake.uAARequestArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) UAARequestArgsForCall(i int) (string, string, []byte) {
+	fake.uAARequestMutex.RLock()
+	defer fake.uAARequestMutex.RUnlock()
+	return fake.uAARequestArgsForCall[i].method, fake.uAARequestArgsForCall[i].path, fake.uAARequestArgsForCall[i].body
+}
+
+func (fake *FakeCliConnectionV3) UAARequestReturns(result1 plugin_models.APIResponse, result2 error) {
+	fake.UAARequestStub = nil
+	fake.uAARequestReturns = struct {
+		result1 plugin_models.APIResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) CliCommandStream(onOutput func(line string), args ...string) (plugin_models.CommandResult, error) {
+	fake.cliCommandStreamMutex.Lock()
+	fake.cliCommandStreamArgsForCall = append(fake.cliCommandStreamArgsForCall, struct {
+		onOutput func(line string)
+		args     []string
+	}{onOutput, args})
+	fake.recordInvocation("CliCommandStream", []interface{}{onOutput, args})
+	fake.cliCommandStreamMutex.Unlock()
+	if fake.CliCommandStreamStub != nil {
+		return fake.CliCommandStreamStub(onOutput, args...)
+	} else {
+		return fake.cliCommandStreamReturns.result1, fake.cliCommandStreamReturns.result2
+	}
+}
+
+func (fake *FakeCliConnectionV3) CliCommandStreamCallCount() int {
+	fake.cliCommandStreamMutex.RLock()
+	defer fake.cliCommandStreamMutex.RUnlock()
+	return len(fake.cliCommandStreamArgsForCall)
+}
+
+func (fake *FakeCliConnectionV3) CliCommandStreamArgsForCall(i int) (func(line string), []string) {
+	fake.cliCommandStreamMutex.RLock()
+	defer fake.cliCommandStreamMutex.RUnlock()
+	return fake.cliCommandStreamArgsForCall[i].onOutput, fake.cliCommandStreamArgsForCall[i].args
+}
+
+func (fake *FakeCliConnectionV3) CliCommandStreamReturns(result1 plugin_models.CommandResult, result2 error) {
+	fake.CliCommandStreamStub = nil
+	fake.cliCommandStreamReturns = struct {
+		result1 plugin_models.CommandResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCliConnectionV3) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.cliCommandWithoutTerminalOutputMutex.RLock()
+	defer fake.cliCommandWithoutTerminalOutputMutex.RUnlock()
+	fake.cliCommandMutex.RLock()
+	defer fake.cliCommandMutex.RUnlock()
+	fake.getCurrentOrgMutex.RLock()
+	defer fake.getCurrentOrgMutex.RUnlock()
+	fake.getCurrentSpaceMutex.RLock()
+	defer fake.getCurrentSpaceMutex.RUnlock()
+	fake.usernameMutex.RLock()
+	defer fake.usernameMutex.RUnlock()
+	fake.userGuidMutex.RLock()
+	defer fake.userGuidMutex.RUnlock()
+	fake.userEmailMutex.RLock()
+	defer fake.userEmailMutex.RUnlock()
+	fake.isLoggedInMutex.RLock()
+	defer fake.isLoggedInMutex.RUnlock()
+	fake.isSSLDisabledMutex.RLock()
+	defer fake.isSSLDisabledMutex.RUnlock()
+	fake.hasOrganizationMutex.RLock()
+	defer fake.hasOrganizationMutex.RUnlock()
+	fake.hasSpaceMutex.RLock()
+	defer fake.hasSpaceMutex.RUnlock()
+	fake.apiEndpointMutex.RLock()
+	defer fake.apiEndpointMutex.RUnlock()
+	fake.apiVersionMutex.RLock()
+	defer fake.apiVersionMutex.RUnlock()
+	fake.hasAPIEndpointMutex.RLock()
+	defer fake.hasAPIEndpointMutex.RUnlock()
+	fake.loggregatorEndpointMutex.RLock()
+	defer fake.loggregatorEndpointMutex.RUnlock()
+	fake.dopplerEndpointMutex.RLock()
+	defer fake.dopplerEndpointMutex.RUnlock()
+	fake.accessTokenMutex.RLock()
+	defer fake.accessTokenMutex.RUnlock()
+	fake.getAppMutex.RLock()
+	defer fake.getAppMutex.RUnlock()
+	fake.getAppsMutex.RLock()
+	defer fake.getAppsMutex.RUnlock()
+	fake.getOrgsMutex.RLock()
+	defer fake.getOrgsMutex.RUnlock()
+	fake.getSpacesMutex.RLock()
+	defer fake.getSpacesMutex.RUnlock()
+	fake.getOrgUsersMutex.RLock()
+	defer fake.getOrgUsersMutex.RUnlock()
+	fake.getSpaceUsersMutex.RLock()
+	defer fake.getSpaceUsersMutex.RUnlock()
+	fake.getServicesMutex.RLock()
+	defer fake.getServicesMutex.RUnlock()
+	fake.getServiceMutex.RLock()
+	defer fake.getServiceMutex.RUnlock()
+	fake.getOrgMutex.RLock()
+	defer fake.getOrgMutex.RUnlock()
+	fake.getSpaceMutex.RLock()
+	defer fake.getSpaceMutex.RUnlock()
+	fake.pluginAPIVersionMutex.RLock()
+	defer fake.pluginAPIVersionMutex.RUnlock()
+	fake.getRoutesMutex.RLock()
+	defer fake.getRoutesMutex.RUnlock()
+	fake.getDomainsMutex.RLock()
+	defer fake.getDomainsMutex.RUnlock()
+	fake.getServiceKeysMutex.RLock()
+	defer fake.getServiceKeysMutex.RUnlock()
+	fake.getSecurityGroupsMutex.RLock()
+	defer fake.getSecurityGroupsMutex.RUnlock()
+	fake.getBuildpacksMutex.RLock()
+	defer fake.getBuildpacksMutex.RUnlock()
+	fake.getStacksMutex.RLock()
+	defer fake.getStacksMutex.RUnlock()
+	fake.getTasksMutex.RLock()
+	defer fake.getTasksMutex.RUnlock()
+	fake.cloudControllerRequestMutex.RLock()
+	defer fake.cloudControllerRequestMutex.RUnlock()
+	fake.uAARequestMutex.RLock()
+	defer fake.uAARequestMutex.RUnlock()
+	fake.cliCommandStreamMutex.RLock()
+	defer fake.cliCommandStreamMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeCliConnectionV3) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ plugin.CliConnectionV3 = new(FakeCliConnectionV3)
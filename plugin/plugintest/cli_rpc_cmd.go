@@ -0,0 +1,321 @@
+package plugintest
+
+import (
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/plugin/models"
+)
+
+func (cmd *cliRpcCmd) IsMinCliVersion(version string, retVal *bool) error {
+	*retVal = true
+	return nil
+}
+
+func (cmd *cliRpcCmd) SetPluginMetadata(pluginMetadata plugin.PluginMetadata, retVal *bool) error {
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+
+	cmd.metadata = pluginMetadata
+	cmd.metadataSet = true
+	*retVal = true
+	return nil
+}
+
+func (cmd *cliRpcCmd) GetHookEvent(_ string, retVal *plugin.HookEvent) error {
+	*retVal = cmd.server.HookEvent
+	return nil
+}
+
+func (cmd *cliRpcCmd) SetHookResult(result plugin.HookResult, retVal *bool) error {
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+
+	cmd.hookResult = result
+	cmd.hookResultSet = true
+	*retVal = true
+	return nil
+}
+
+func (cmd *cliRpcCmd) GetCompletionRequest(_ string, retVal *plugin.CompletionRequest) error {
+	*retVal = cmd.server.CompletionRequest
+	return nil
+}
+
+func (cmd *cliRpcCmd) SetCompletion(values []string, retVal *bool) error {
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+
+	cmd.completion = values
+	cmd.completionSet = true
+	*retVal = true
+	return nil
+}
+
+func (cmd *cliRpcCmd) DisableTerminalOutput(disable bool, retVal *bool) error {
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+
+	cmd.silently = disable
+	*retVal = true
+	return nil
+}
+
+func (cmd *cliRpcCmd) CallCoreCommand(args []string, retVal *bool) error {
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+
+	var err error
+	if cmd.silently {
+		cmd.output, err = cmd.server.CLI.CliCommandWithoutTerminalOutput(args...)
+	} else {
+		cmd.output, err = cmd.server.CLI.CliCommand(args...)
+	}
+
+	*retVal = err == nil
+	return err
+}
+
+func (cmd *cliRpcCmd) GetOutputAndReset(_ bool, retVal *[]string) error {
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+
+	*retVal = cmd.output
+	cmd.output = nil
+	return nil
+}
+
+// StartCoreCommand runs the CliCommandStream fake to completion, so that
+// ReadCoreCommandOutput returns all of its output at once.
+func (cmd *cliRpcCmd) StartCoreCommand(args []string, retVal *bool) error {
+	lines := []string{}
+	result, err := cmd.server.CLI.CliCommandStream(func(line string) {
+		lines = append(lines, line)
+	}, args...)
+	if err != nil {
+		return err
+	}
+
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+
+	cmd.stream = plugin_models.CommandOutput{Lines: lines, Done: true, Result: result}
+	*retVal = true
+	return nil
+}
+
+func (cmd *cliRpcCmd) ReadCoreCommandOutput(_ string, retVal *plugin_models.CommandOutput) error {
+	cmd.lock.Lock()
+	defer cmd.lock.Unlock()
+
+	*retVal = cmd.stream
+	return nil
+}
+
+func (cmd *cliRpcCmd) GetCurrentOrg(_ string, retVal *plugin_models.Organization) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetCurrentOrg()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetCurrentSpace(_ string, retVal *plugin_models.Space) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetCurrentSpace()
+	return err
+}
+
+func (cmd *cliRpcCmd) Username(_ string, retVal *string) error {
+	var err error
+	*retVal, err = cmd.server.CLI.Username()
+	return err
+}
+
+func (cmd *cliRpcCmd) UserGuid(_ string, retVal *string) error {
+	var err error
+	*retVal, err = cmd.server.CLI.UserGuid()
+	return err
+}
+
+func (cmd *cliRpcCmd) UserEmail(_ string, retVal *string) error {
+	var err error
+	*retVal, err = cmd.server.CLI.UserEmail()
+	return err
+}
+
+func (cmd *cliRpcCmd) IsLoggedIn(_ string, retVal *bool) error {
+	var err error
+	*retVal, err = cmd.server.CLI.IsLoggedIn()
+	return err
+}
+
+func (cmd *cliRpcCmd) IsSSLDisabled(_ string, retVal *bool) error {
+	var err error
+	*retVal, err = cmd.server.CLI.IsSSLDisabled()
+	return err
+}
+
+func (cmd *cliRpcCmd) HasOrganization(_ string, retVal *bool) error {
+	var err error
+	*retVal, err = cmd.server.CLI.HasOrganization()
+	return err
+}
+
+func (cmd *cliRpcCmd) HasSpace(_ string, retVal *bool) error {
+	var err error
+	*retVal, err = cmd.server.CLI.HasSpace()
+	return err
+}
+
+func (cmd *cliRpcCmd) ApiEndpoint(_ string, retVal *string) error {
+	var err error
+	*retVal, err = cmd.server.CLI.ApiEndpoint()
+	return err
+}
+
+func (cmd *cliRpcCmd) HasAPIEndpoint(_ string, retVal *bool) error {
+	var err error
+	*retVal, err = cmd.server.CLI.HasAPIEndpoint()
+	return err
+}
+
+func (cmd *cliRpcCmd) ApiVersion(_ string, retVal *string) error {
+	var err error
+	*retVal, err = cmd.server.CLI.ApiVersion()
+	return err
+}
+
+func (cmd *cliRpcCmd) LoggregatorEndpoint(_ string, retVal *string) error {
+	var err error
+	*retVal, err = cmd.server.CLI.LoggregatorEndpoint()
+	return err
+}
+
+func (cmd *cliRpcCmd) DopplerEndpoint(_ string, retVal *string) error {
+	var err error
+	*retVal, err = cmd.server.CLI.DopplerEndpoint()
+	return err
+}
+
+func (cmd *cliRpcCmd) AccessToken(_ string, retVal *string) error {
+	var err error
+	*retVal, err = cmd.server.CLI.AccessToken()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetApps(_ string, retVal *[]plugin_models.GetAppsModel) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetApps()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetOrgs(_ string, retVal *[]plugin_models.GetOrgs_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetOrgs()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetSpaces(_ string, retVal *[]plugin_models.GetSpaces_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetSpaces()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetServices(_ string, retVal *[]plugin_models.GetServices_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetServices()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetApp(appName string, retVal *plugin_models.GetAppModel) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetApp(appName)
+	return err
+}
+
+func (cmd *cliRpcCmd) GetOrg(orgName string, retVal *plugin_models.GetOrg_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetOrg(orgName)
+	return err
+}
+
+func (cmd *cliRpcCmd) GetSpace(spaceName string, retVal *plugin_models.GetSpace_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetSpace(spaceName)
+	return err
+}
+
+func (cmd *cliRpcCmd) GetService(serviceInstance string, retVal *plugin_models.GetService_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetService(serviceInstance)
+	return err
+}
+
+func (cmd *cliRpcCmd) GetOrgUsers(args []string, retVal *[]plugin_models.GetOrgUsers_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetOrgUsers(args[0], args[1:]...)
+	return err
+}
+
+func (cmd *cliRpcCmd) GetSpaceUsers(args []string, retVal *[]plugin_models.GetSpaceUsers_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetSpaceUsers(args[0], args[1])
+	return err
+}
+
+func (cmd *cliRpcCmd) GetPluginAPIVersion(_ string, retVal *int) error {
+	var err error
+	*retVal, err = cmd.server.CLI.PluginAPIVersion()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetRoutes(_ string, retVal *[]plugin_models.GetRoutes_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetRoutes()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetDomains(_ string, retVal *[]plugin_models.GetDomains_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetDomains()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetSecurityGroups(_ string, retVal *[]plugin_models.GetSecurityGroups_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetSecurityGroups()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetBuildpacks(_ string, retVal *[]plugin_models.GetBuildpacks_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetBuildpacks()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetStacks(_ string, retVal *[]plugin_models.GetStacks_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetStacks()
+	return err
+}
+
+func (cmd *cliRpcCmd) GetServiceKeys(serviceInstance string, retVal *[]plugin_models.GetServiceKeys_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetServiceKeys(serviceInstance)
+	return err
+}
+
+func (cmd *cliRpcCmd) GetTasks(appName string, retVal *[]plugin_models.GetTasks_Model) error {
+	var err error
+	*retVal, err = cmd.server.CLI.GetTasks(appName)
+	return err
+}
+
+func (cmd *cliRpcCmd) CloudControllerRequest(request plugin_models.APIRequest, retVal *plugin_models.APIResponse) error {
+	var err error
+	*retVal, err = cmd.server.CLI.CloudControllerRequest(request.Method, request.Path, request.Body)
+	return err
+}
+
+func (cmd *cliRpcCmd) UAARequest(request plugin_models.APIRequest, retVal *plugin_models.APIResponse) error {
+	var err error
+	*retVal, err = cmd.server.CLI.UAARequest(request.Method, request.Path, request.Body)
+	return err
+}
@@ -0,0 +1,18 @@
+package plugintest_test
+
+import (
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/util/testhelpers/pluginbuilder"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPlugintest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	pluginbuilder.BuildTestBinary(filepath.Join("..", "..", "fixtures", "plugins"), "test_1")
+	pluginbuilder.BuildTestBinary(filepath.Join("..", "..", "fixtures", "plugins"), "completions")
+	RunSpecs(t, "Plugintest Suite")
+}
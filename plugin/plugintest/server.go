@@ -0,0 +1,165 @@
+// Package plugintest runs an in-process fake of the RPC service that the CLI
+// serves to plugins, so that plugins can be unit tested without the CLI or a
+// Cloud Foundry.
+//
+// Responses are scripted, and calls recorded, on the Server's CLI fake. Run
+// hands the plugin a real connection to the fake service, so every call goes
+// through the same RPC client the plugin uses when the CLI runs it:
+//
+//	server := plugintest.NewServer()
+//	server.CLI.GetAppReturns(plugin_models.GetAppModel{Name: "my-app"}, nil)
+//	err := server.Start()
+//	defer server.Stop()
+//
+//	server.Run(&MyPlugin{}, "my-command", "my-app")
+//	Expect(server.CLI.GetAppArgsForCall(0)).To(Equal("my-app"))
+//
+// Plugin binaries built with plugin.Start can be pointed at the fake service
+// by passing Port as their first argument.
+package plugintest
+
+import (
+	"fmt"
+	"net"
+	"net/rpc"
+	"strconv"
+	"sync"
+
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/plugin/models"
+	"code.cloudfoundry.org/cli/plugin/pluginfakes"
+)
+
+// Server is a fake CLI plugin RPC service. CLI answers every CliConnection,
+// CliConnectionV2 and CliConnectionV3 method and records how it was called.
+// CliCommand, CliCommandWithoutTerminalOutput and CliCommandStream are
+// answered by the methods of the same name, with the arguments the plugin
+// passed; the lines a CliCommandStream stub passes to onOutput are sent to
+// the plugin.
+type Server struct {
+	CLI *pluginfakes.FakeCliConnectionV3
+
+	// HookEvent is sent to plugin binaries started to run a hook.
+	HookEvent plugin.HookEvent
+
+	// CompletionRequest is sent to plugin binaries started to complete a
+	// flag.
+	CompletionRequest plugin.CompletionRequest
+
+	listener net.Listener
+	stopCh   chan struct{}
+	server   *rpc.Server
+	cmd      *cliRpcCmd
+}
+
+// NewServer returns a Server that reports the current plugin API version and
+// answers every other call with zero values until it is scripted.
+func NewServer() *Server {
+	fake := new(pluginfakes.FakeCliConnectionV3)
+	fake.PluginAPIVersionReturns(plugin.APIVersion, nil)
+
+	s := &Server{CLI: fake}
+	s.cmd = &cliRpcCmd{server: s}
+
+	s.server = rpc.NewServer()
+	err := s.server.RegisterName("CliRpcCmd", s.cmd)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// Start listens on a random local port and serves plugin calls until Stop is
+// called.
+func (s *Server) Start() error {
+	var err error
+
+	s.stopCh = make(chan struct{})
+
+	s.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for {
+			conn, err := s.listener.Accept()
+			if err != nil {
+				select {
+				case <-s.stopCh:
+					return
+				default:
+					fmt.Println(err)
+				}
+			} else {
+				go s.server.ServeConn(conn)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop stops serving plugin calls.
+func (s *Server) Stop() {
+	close(s.stopCh)
+	s.listener.Close()
+}
+
+// Port returns the port the Server listens on, as passed to plugin binaries.
+func (s *Server) Port() string {
+	return strconv.Itoa(s.listener.Addr().(*net.TCPAddr).Port)
+}
+
+// Connection returns a connection to the Server, like the one the CLI passes
+// to a plugin's Run method.
+func (s *Server) Connection() plugin.CliConnectionV3 {
+	return plugin.NewCliConnection(s.Port())
+}
+
+// Run calls the plugin's Run method with a connection to the Server.
+func (s *Server) Run(p plugin.Plugin, args ...string) {
+	p.Run(s.Connection(), args)
+}
+
+// Metadata returns the metadata a plugin binary sent when it was started with
+// SendMetadata, and whether it sent any.
+func (s *Server) Metadata() (plugin.PluginMetadata, bool) {
+	s.cmd.lock.Lock()
+	defer s.cmd.lock.Unlock()
+	return s.cmd.metadata, s.cmd.metadataSet
+}
+
+// HookResult returns the result a plugin binary sent when it was started with
+// RunHook, and whether it sent any.
+func (s *Server) HookResult() (plugin.HookResult, bool) {
+	s.cmd.lock.Lock()
+	defer s.cmd.lock.Unlock()
+	return s.cmd.hookResult, s.cmd.hookResultSet
+}
+
+// Completion returns the values a plugin binary sent when it was started with
+// CompleteFlag, and whether it sent any.
+func (s *Server) Completion() ([]string, bool) {
+	s.cmd.lock.Lock()
+	defer s.cmd.lock.Unlock()
+	return s.cmd.completion, s.cmd.completionSet
+}
+
+// Calls to the RPC service are made on separate connections, so the state
+// shared between them is kept behind a lock.
+type cliRpcCmd struct {
+	server *Server
+
+	lock          sync.Mutex
+	silently      bool
+	output        []string
+	metadata      plugin.PluginMetadata
+	metadataSet   bool
+	hookResult    plugin.HookResult
+	hookResultSet bool
+	completion    []string
+	completionSet bool
+	stream        plugin_models.CommandOutput
+}
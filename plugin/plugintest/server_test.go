@@ -0,0 +1,180 @@
+package plugintest_test
+
+import (
+	"errors"
+	"os/exec"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/plugin"
+	"code.cloudfoundry.org/cli/plugin/models"
+	"code.cloudfoundry.org/cli/plugin/plugintest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+type recordingPlugin struct {
+	run func(plugin.CliConnection, []string)
+}
+
+func (p *recordingPlugin) Run(cliConnection plugin.CliConnection, args []string) {
+	p.run(cliConnection, args)
+}
+
+func (p *recordingPlugin) GetMetadata() plugin.PluginMetadata {
+	return plugin.PluginMetadata{Name: "recording"}
+}
+
+var _ = Describe("Server", func() {
+	var server *plugintest.Server
+
+	BeforeEach(func() {
+		server = plugintest.NewServer()
+		Expect(server.Start()).To(Succeed())
+	})
+
+	AfterEach(func() {
+		server.Stop()
+	})
+
+	It("passes the plugin its arguments and a connection to the server", func() {
+		server.CLI.GetAppReturns(plugin_models.GetAppModel{Name: "my-app", Guid: "app-guid"}, nil)
+
+		var (
+			receivedArgs []string
+			app          plugin_models.GetAppModel
+			err          error
+		)
+		server.Run(&recordingPlugin{run: func(cliConnection plugin.CliConnection, args []string) {
+			receivedArgs = args
+			app, err = cliConnection.GetApp("my-app")
+		}}, "my-command", "my-app")
+
+		Expect(receivedArgs).To(Equal([]string{"my-command", "my-app"}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(app).To(Equal(plugin_models.GetAppModel{Name: "my-app", Guid: "app-guid"}))
+		Expect(server.CLI.GetAppCallCount()).To(Equal(1))
+		Expect(server.CLI.GetAppArgsForCall(0)).To(Equal("my-app"))
+	})
+
+	It("returns scripted errors to the plugin", func() {
+		server.CLI.ApiEndpointReturns("", errors.New("no api endpoint"))
+
+		_, err := server.Connection().ApiEndpoint()
+		Expect(err).To(MatchError("no api endpoint"))
+	})
+
+	It("answers methods with more than one argument", func() {
+		server.CLI.GetOrgUsersReturns([]plugin_models.GetOrgUsers_Model{{Username: "admin"}}, nil)
+		server.CLI.CloudControllerRequestReturns(plugin_models.APIResponse{StatusCode: 201}, nil)
+
+		connection := server.Connection()
+
+		users, err := connection.GetOrgUsers("my-org", "-a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(users).To(Equal([]plugin_models.GetOrgUsers_Model{{Username: "admin"}}))
+		orgName, args := server.CLI.GetOrgUsersArgsForCall(0)
+		Expect(orgName).To(Equal("my-org"))
+		Expect(args).To(Equal([]string{"-a"}))
+
+		response, err := connection.CloudControllerRequest("POST", "/v2/apps", []byte(`{"name":"my-app"}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(response.StatusCode).To(Equal(201))
+		method, path, body := server.CLI.CloudControllerRequestArgsForCall(0)
+		Expect(method).To(Equal("POST"))
+		Expect(path).To(Equal("/v2/apps"))
+		Expect(string(body)).To(Equal(`{"name":"my-app"}`))
+	})
+
+	It("reports the current plugin API version", func() {
+		version, err := server.Connection().PluginAPIVersion()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(version).To(Equal(plugin.APIVersion))
+	})
+
+	Describe("CLI commands", func() {
+		It("answers CliCommand with the output of the CliCommand fake", func() {
+			server.CLI.CliCommandReturns([]string{"Getting apps", "OK"}, nil)
+
+			output, err := server.Connection().CliCommand("apps")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).To(Equal([]string{"Getting apps", "OK"}))
+			Expect(server.CLI.CliCommandArgsForCall(0)).To(Equal([]string{"apps"}))
+			Expect(server.CLI.CliCommandWithoutTerminalOutputCallCount()).To(Equal(0))
+		})
+
+		It("answers CliCommandWithoutTerminalOutput with the fake of the same name", func() {
+			server.CLI.CliCommandWithoutTerminalOutputReturns([]string{"OK"}, nil)
+
+			output, err := server.Connection().CliCommandWithoutTerminalOutput("target", "-o", "my-org")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(output).To(Equal([]string{"OK"}))
+			Expect(server.CLI.CliCommandWithoutTerminalOutputArgsForCall(0)).To(Equal([]string{"target", "-o", "my-org"}))
+			Expect(server.CLI.CliCommandCallCount()).To(Equal(0))
+		})
+
+		It("streams the lines the CliCommandStream fake prints and returns its result", func() {
+			server.CLI.CliCommandStreamStub = func(onOutput func(string), args ...string) (plugin_models.CommandResult, error) {
+				onOutput("Pushing app my-app...")
+				onOutput("FAILED")
+				return plugin_models.CommandResult{ExitCode: 1, Warnings: []string{"old stack"}, Error: "push failed"}, nil
+			}
+
+			lines := []string{}
+			result, err := server.Connection().CliCommandStream(func(line string) {
+				lines = append(lines, line)
+			}, "push", "my-app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(lines).To(Equal([]string{"Pushing app my-app...", "FAILED"}))
+			Expect(result).To(Equal(plugin_models.CommandResult{ExitCode: 1, Warnings: []string{"old stack"}, Error: "push failed"}))
+
+			_, args := server.CLI.CliCommandStreamArgsForCall(0)
+			Expect(args).To(Equal([]string{"push", "my-app"}))
+		})
+
+		It("returns the error of a failed command", func() {
+			server.CLI.CliCommandReturns(nil, errors.New("App my-app not found"))
+
+			_, err := server.Connection().CliCommand("app", "my-app")
+			Expect(err).To(MatchError("App my-app not found"))
+		})
+	})
+
+	Describe("plugin binaries", func() {
+		var pluginPath string
+
+		BeforeEach(func() {
+			pluginPath = filepath.Join("..", "..", "fixtures", "plugins", "test_1.exe")
+		})
+
+		It("records the metadata sent by the plugin", func() {
+			_, sent := server.Metadata()
+			Expect(sent).To(BeFalse())
+
+			session, err := gexec.Start(exec.Command(pluginPath, server.Port(), "SendMetadata"), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(session).Should(gexec.Exit(0))
+
+			metadata, sent := server.Metadata()
+			Expect(sent).To(BeTrue())
+			Expect(metadata.Name).To(Equal("Test1"))
+		})
+
+		It("sends the completion request and records the values sent by the plugin", func() {
+			server.CompletionRequest = plugin.CompletionRequest{
+				Command: "deploy",
+				Flag:    "app",
+				Args:    []string{"--env", "prod"},
+			}
+
+			session, err := gexec.Start(exec.Command(filepath.Join("..", "..", "fixtures", "plugins", "completions.exe"), server.Port(), "CompleteFlag"), GinkgoWriter, GinkgoWriter)
+			Expect(err).ToNot(HaveOccurred())
+			Eventually(session).Should(gexec.Exit(0))
+
+			values, sent := server.Completion()
+			Expect(sent).To(BeTrue())
+			Expect(values).To(ContainElement("app-prod"))
+		})
+	})
+})
@@ -1,12 +1,16 @@
 package rpc
 
 import (
+	"errors"
 	"os"
 	"strings"
 
 	"code.cloudfoundry.org/cli/cf/api"
 	"code.cloudfoundry.org/cli/cf/commandregistry"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/cf/models"
+	"code.cloudfoundry.org/cli/cf/requirements"
 	"code.cloudfoundry.org/cli/cf/terminal"
 	"code.cloudfoundry.org/cli/plugin"
 	"code.cloudfoundry.org/cli/plugin/models"
@@ -39,12 +43,18 @@ type CliRpcService struct {
 type CliRpcCmd struct {
 	PluginMetadata       *plugin.PluginMetadata
 	MetadataMutex        *sync.RWMutex
+	HookEvent            plugin.HookEvent
+	HookResult           plugin.HookResult
+	CompletionRequest    plugin.CompletionRequest
+	Completion           []string
 	outputCapture        OutputCapture
 	terminalOutputSwitch TerminalOutputSwitch
 	cliConfig            coreconfig.Repository
 	repoLocator          api.RepositoryLocator
 	newCmdRunner         CommandRunner
 	outputBucket         *bytes.Buffer
+	stream               *commandStream
+	streamMutex          *sync.Mutex
 	logger               trace.Printer
 	stdout               io.Writer
 }
@@ -83,6 +93,7 @@ func NewRpcService(
 			newCmdRunner:         newCmdRunner,
 			logger:               logger,
 			outputBucket:         &bytes.Buffer{},
+			streamMutex:          &sync.Mutex{},
 			stdout:               w,
 		},
 	}
@@ -163,6 +174,40 @@ func (cmd *CliRpcCmd) SetPluginMetadata(pluginMetadata plugin.PluginMetadata, re
 	return nil
 }
 
+func (cmd *CliRpcCmd) GetHookEvent(_ string, retVal *plugin.HookEvent) error {
+	cmd.MetadataMutex.RLock()
+	defer cmd.MetadataMutex.RUnlock()
+
+	*retVal = cmd.HookEvent
+	return nil
+}
+
+func (cmd *CliRpcCmd) SetHookResult(result plugin.HookResult, retVal *bool) error {
+	cmd.MetadataMutex.Lock()
+	defer cmd.MetadataMutex.Unlock()
+
+	cmd.HookResult = result
+	*retVal = true
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetCompletionRequest(_ string, retVal *plugin.CompletionRequest) error {
+	cmd.MetadataMutex.RLock()
+	defer cmd.MetadataMutex.RUnlock()
+
+	*retVal = cmd.CompletionRequest
+	return nil
+}
+
+func (cmd *CliRpcCmd) SetCompletion(values []string, retVal *bool) error {
+	cmd.MetadataMutex.Lock()
+	defer cmd.MetadataMutex.Unlock()
+
+	cmd.Completion = values
+	*retVal = true
+	return nil
+}
+
 func (cmd *CliRpcCmd) DisableTerminalOutput(disable bool, retVal *bool) error {
 	cmd.terminalOutputSwitch.DisableTerminalOutput(disable)
 	*retVal = true
@@ -202,6 +247,64 @@ func (cmd *CliRpcCmd) CallCoreCommand(args []string, retVal *bool) error {
 	return nil
 }
 
+// StartCoreCommand runs a core command in the background without terminal
+// output. The output is read with ReadCoreCommandOutput while the command
+// runs. Only one command is streamed at a time; starting another one stops
+// the output of the previous one from being read.
+func (cmd *CliRpcCmd) StartCoreCommand(args []string, retVal *bool) error {
+	if len(args) == 0 {
+		*retVal = false
+		return errors.New(T("No command given"))
+	}
+
+	stream := newCommandStream()
+	cmd.streamMutex.Lock()
+	cmd.stream = stream
+	cmd.streamMutex.Unlock()
+
+	cmd.terminalOutputSwitch.DisableTerminalOutput(true)
+	cmd.outputCapture.SetOutputBucket(stream)
+
+	go func() {
+		ui := &warningRecorder{
+			UI: terminal.NewUI(os.Stdin, cmd.stdout, cmd.outputCapture.(*terminal.TeePrinter), cmd.logger),
+		}
+
+		var err error
+		if commandregistry.Commands.CommandExists(args[0]) {
+			deps := commandregistry.NewDependency(cmd.stdout, cmd.logger, dialTimeout)
+			deps.Config = cmd.cliConfig
+			deps.RepoLocator = cmd.repoLocator
+			deps.UI = ui
+
+			err = cmd.newCmdRunner.Command(args, deps, false)
+		} else {
+			err = errors.New("'" + args[0] + T("' is not a registered command. See 'cf help'"))
+		}
+
+		stream.finish(ui.warnings, err)
+	}()
+
+	*retVal = true
+	return nil
+}
+
+// ReadCoreCommandOutput waits for the command started by StartCoreCommand to
+// print more output or to finish. Once Done is set, Result holds the exit code
+// and warnings of the command.
+func (cmd *CliRpcCmd) ReadCoreCommandOutput(_ string, retVal *plugin_models.CommandOutput) error {
+	cmd.streamMutex.Lock()
+	stream := cmd.stream
+	cmd.streamMutex.Unlock()
+
+	if stream == nil {
+		return errors.New(T("No command has been started"))
+	}
+
+	*retVal = stream.read()
+	return nil
+}
+
 func (cmd *CliRpcCmd) GetOutputAndReset(args bool, retVal *[]string) error {
 	v := strings.TrimSuffix(cmd.outputBucket.String(), "\n")
 	*retVal = strings.Split(v, "\n")
@@ -444,3 +547,241 @@ func (cmd *CliRpcCmd) GetService(serviceInstance string, retVal *plugin_models.G
 
 	return cmd.newCmdRunner.Command([]string{"service", serviceInstance}, deps, true)
 }
+
+// GetPluginAPIVersion returns the version of the plugin RPC API that this CLI
+// implements, so that plugins can check for methods newer than themselves.
+func (cmd *CliRpcCmd) GetPluginAPIVersion(_ string, retVal *int) error {
+	*retVal = plugin.APIVersion
+
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetRoutes(_ string, retVal *[]plugin_models.GetRoutes_Model) error {
+	err := requirements.NewTargetedSpaceRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	routes := []plugin_models.GetRoutes_Model{}
+	err = cmd.repoLocator.GetRouteRepository().ListRoutes(func(route models.Route) bool {
+		model := plugin_models.GetRoutes_Model{
+			Guid: route.GUID,
+			Host: route.Host,
+			Domain: plugin_models.GetRoutes_Domain{
+				Guid: route.Domain.GUID,
+				Name: route.Domain.Name,
+			},
+			Path: route.Path,
+			Port: route.Port,
+			Space: plugin_models.GetRoutes_Space{
+				Guid: route.Space.GUID,
+				Name: route.Space.Name,
+			},
+			ServiceInstance: plugin_models.GetRoutes_ServiceInstance{
+				Guid: route.ServiceInstance.GUID,
+				Name: route.ServiceInstance.Name,
+			},
+		}
+		for _, app := range route.Apps {
+			model.Apps = append(model.Apps, plugin_models.GetRoutes_App{
+				Guid: app.GUID,
+				Name: app.Name,
+			})
+		}
+		routes = append(routes, model)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	*retVal = routes
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetDomains(_ string, retVal *[]plugin_models.GetDomains_Model) error {
+	err := requirements.NewTargetedOrgRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	domains := []plugin_models.GetDomains_Model{}
+	err = cmd.repoLocator.GetDomainRepository().ListDomainsForOrg(cmd.cliConfig.OrganizationFields().GUID, func(domain models.DomainFields) bool {
+		domains = append(domains, plugin_models.GetDomains_Model{
+			Guid:                   domain.GUID,
+			Name:                   domain.Name,
+			OwningOrganizationGuid: domain.OwningOrganizationGUID,
+			RouterGroupType:        domain.RouterGroupType,
+			Shared:                 domain.Shared,
+		})
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	*retVal = domains
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetServiceKeys(serviceInstance string, retVal *[]plugin_models.GetServiceKeys_Model) error {
+	err := requirements.NewTargetedSpaceRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	instance, err := cmd.repoLocator.GetServiceRepository().FindInstanceByName(serviceInstance)
+	if err != nil {
+		return err
+	}
+
+	serviceKeys, err := cmd.repoLocator.GetServiceKeyRepository().ListServiceKeys(instance.GUID)
+	if err != nil {
+		return err
+	}
+
+	keys := []plugin_models.GetServiceKeys_Model{}
+	for _, serviceKey := range serviceKeys {
+		keys = append(keys, plugin_models.GetServiceKeys_Model{
+			Guid:        serviceKey.Fields.GUID,
+			Name:        serviceKey.Fields.Name,
+			Credentials: serviceKey.Credentials,
+		})
+	}
+
+	*retVal = keys
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetSecurityGroups(_ string, retVal *[]plugin_models.GetSecurityGroups_Model) error {
+	securityGroups, err := cmd.repoLocator.GetSecurityGroupRepository().FindAll()
+	if err != nil {
+		return err
+	}
+
+	groups := []plugin_models.GetSecurityGroups_Model{}
+	for _, securityGroup := range securityGroups {
+		group := plugin_models.GetSecurityGroups_Model{
+			Guid:  securityGroup.GUID,
+			Name:  securityGroup.Name,
+			Rules: securityGroup.Rules,
+		}
+		for _, space := range securityGroup.Spaces {
+			group.Spaces = append(group.Spaces, plugin_models.GetSecurityGroups_Space{
+				Guid: space.GUID,
+				Name: space.Name,
+			})
+		}
+		groups = append(groups, group)
+	}
+
+	*retVal = groups
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetBuildpacks(_ string, retVal *[]plugin_models.GetBuildpacks_Model) error {
+	buildpacks := []plugin_models.GetBuildpacks_Model{}
+	err := cmd.repoLocator.GetBuildpackRepository().ListBuildpacks(func(buildpack models.Buildpack) bool {
+		model := plugin_models.GetBuildpacks_Model{
+			Guid:     buildpack.GUID,
+			Name:     buildpack.Name,
+			Filename: buildpack.Filename,
+		}
+		if buildpack.Position != nil {
+			model.Position = *buildpack.Position
+		}
+		if buildpack.Enabled != nil {
+			model.Enabled = *buildpack.Enabled
+		}
+		if buildpack.Locked != nil {
+			model.Locked = *buildpack.Locked
+		}
+		buildpacks = append(buildpacks, model)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	*retVal = buildpacks
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetStacks(_ string, retVal *[]plugin_models.GetStacks_Model) error {
+	stacks, err := cmd.repoLocator.GetStackRepository().FindAll()
+	if err != nil {
+		return err
+	}
+
+	stackModels := []plugin_models.GetStacks_Model{}
+	for _, stack := range stacks {
+		stackModels = append(stackModels, plugin_models.GetStacks_Model{
+			Guid:        stack.GUID,
+			Name:        stack.Name,
+			Description: stack.Description,
+		})
+	}
+
+	*retVal = stackModels
+	return nil
+}
+
+func (cmd *CliRpcCmd) GetTasks(appName string, retVal *[]plugin_models.GetTasks_Model) error {
+	err := requirements.NewTargetedSpaceRequirement(cmd.cliConfig).Execute()
+	if err != nil {
+		return err
+	}
+
+	app, err := cmd.repoLocator.GetApplicationRepository().Read(appName)
+	if err != nil {
+		return err
+	}
+
+	v3Tasks, err := cmd.repoLocator.GetV3Repository().GetTasks(fmt.Sprintf("/v3/apps/%s/tasks", app.GUID))
+	if err != nil {
+		return err
+	}
+
+	tasks := []plugin_models.GetTasks_Model{}
+	for _, task := range v3Tasks {
+		tasks = append(tasks, plugin_models.GetTasks_Model{
+			Guid:       task.GUID,
+			SequenceId: task.SequenceID,
+			Name:       task.Name,
+			Command:    task.Command,
+			State:      task.State,
+			MemoryInMB: task.MemoryInMB,
+			DiskInMB:   task.DiskInMB,
+			CreatedAt:  task.CreatedAt,
+		})
+	}
+
+	*retVal = tasks
+	return nil
+}
+
+// CloudControllerRequest sends a plugin's request to the Cloud Controller. Error
+// statuses are returned in the response; only failures to get a response are
+// returned as errors.
+func (cmd *CliRpcCmd) CloudControllerRequest(request plugin_models.APIRequest, retVal *plugin_models.APIResponse) error {
+	return rawRequest(cmd.repoLocator.GetCloudControllerRawRequestRepository(), request, retVal)
+}
+
+// UAARequest sends a plugin's request to UAA, like CloudControllerRequest.
+func (cmd *CliRpcCmd) UAARequest(request plugin_models.APIRequest, retVal *plugin_models.APIResponse) error {
+	return rawRequest(cmd.repoLocator.GetUAARawRequestRepository(), request, retVal)
+}
+
+func rawRequest(repo api.RawRequestRepository, request plugin_models.APIRequest, retVal *plugin_models.APIResponse) error {
+	response, err := repo.Request(request.Method, request.Path, request.Body)
+	if err != nil {
+		return err
+	}
+
+	*retVal = plugin_models.APIResponse{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       response.Body,
+	}
+	return nil
+}
@@ -3,15 +3,20 @@ package rpc_test
 import (
 	"errors"
 	"net"
+	"net/http"
 	"net/rpc"
 	"os"
 	"time"
 
 	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/api/apifakes"
+	"code.cloudfoundry.org/cli/cf/api/applications/applicationsfakes"
 	"code.cloudfoundry.org/cli/cf/api/authentication/authenticationfakes"
 	"code.cloudfoundry.org/cli/cf/configuration/coreconfig"
 	"code.cloudfoundry.org/cli/cf/models"
 	"code.cloudfoundry.org/cli/cf/terminal"
+	v3models "code.cloudfoundry.org/cli/cf/v3/models"
+	"code.cloudfoundry.org/cli/cf/v3/repository/repositoryfakes"
 	"code.cloudfoundry.org/cli/plugin"
 	"code.cloudfoundry.org/cli/plugin/models"
 	. "code.cloudfoundry.org/cli/plugin/rpc"
@@ -246,6 +251,90 @@ var _ = Describe("Server", func() {
 		})
 	})
 
+	Describe(".StartCoreCommand", func() {
+		var terminalOutputSwitch *rpcfakes.FakeTerminalOutputSwitch
+
+		BeforeEach(func() {
+			outputCapture := terminal.NewTeePrinter(os.Stdout)
+			terminalOutputSwitch = new(rpcfakes.FakeTerminalOutputSwitch)
+			rpcService, err = NewRpcService(outputCapture, terminalOutputSwitch, nil, api.RepositoryLocator{}, cmdRunner.NewCommandRunner(), nil, nil, rpc.DefaultServer)
+			Expect(err).ToNot(HaveOccurred())
+
+			err := rpcService.Start()
+			Expect(err).ToNot(HaveOccurred())
+
+			pingCli(rpcService.Port())
+
+			client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			rpcService.Stop()
+
+			//give time for server to stop
+			time.Sleep(50 * time.Millisecond)
+		})
+
+		readAll := func() ([]string, plugin_models.CommandResult) {
+			lines := []string{}
+			for {
+				var output plugin_models.CommandOutput
+				err := client.Call("CliRpcCmd.ReadCoreCommandOutput", "", &output)
+				Expect(err).ToNot(HaveOccurred())
+
+				lines = append(lines, output.Lines...)
+				if output.Done {
+					return lines, output.Result
+				}
+			}
+		}
+
+		It("streams the output of a command without terminal output", func() {
+			var success bool
+			err = client.Call("CliRpcCmd.StartCoreCommand", []string{"fake-command"}, &success)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(success).To(BeTrue())
+
+			lines, result := readAll()
+			Expect(lines).To(Equal([]string{"Requirement executed", "Command Executed"}))
+			Expect(result).To(Equal(plugin_models.CommandResult{}))
+
+			Expect(terminalOutputSwitch.DisableTerminalOutputCallCount()).To(Equal(1))
+			Expect(terminalOutputSwitch.DisableTerminalOutputArgsForCall(0)).To(BeTrue())
+		})
+
+		It("returns the exit code, warnings and error of a failed command", func() {
+			var success bool
+			err = client.Call("CliRpcCmd.StartCoreCommand", []string{"fake-command5"}, &success)
+			Expect(err).ToNot(HaveOccurred())
+
+			lines, result := readAll()
+			Expect(lines).To(Equal([]string{"Starting", "Something to watch", "Stopping"}))
+			Expect(result).To(Equal(plugin_models.CommandResult{
+				ExitCode: 1,
+				Warnings: []string{"Something to watch"},
+				Error:    "fake-command5 failed",
+			}))
+		})
+
+		It("fails the command when it is not registered", func() {
+			var success bool
+			err = client.Call("CliRpcCmd.StartCoreCommand", []string{"not_a_cmd"}, &success)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, result := readAll()
+			Expect(result.ExitCode).To(Equal(1))
+			Expect(result.Error).To(ContainSubstring("'not_a_cmd' is not a registered command"))
+		})
+
+		It("returns an error when no command has been started", func() {
+			var output plugin_models.CommandOutput
+			err = client.Call("CliRpcCmd.ReadCoreCommandOutput", "", &output)
+			Expect(err).To(MatchError("No command has been started"))
+		})
+	})
+
 	Describe("disabling terminal output", func() {
 		var terminalOutputSwitch *rpcfakes.FakeTerminalOutputSwitch
 
@@ -742,6 +831,385 @@ var _ = Describe("Server", func() {
 				})
 			})
 
+			Context(".GetPluginAPIVersion", func() {
+				BeforeEach(func() {
+					rpcService, err = NewRpcService(nil, nil, config, api.RepositoryLocator{}, nil, nil, nil, rpc.DefaultServer)
+					err := rpcService.Start()
+					Expect(err).ToNot(HaveOccurred())
+
+					pingCli(rpcService.Port())
+				})
+
+				It("returns the plugin API version", func() {
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result int
+					err = client.Call("CliRpcCmd.GetPluginAPIVersion", "", &result)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(Equal(plugin.APIVersion))
+				})
+			})
+
+			Context(".GetRoutes", func() {
+				var routeRepo *apifakes.FakeRouteRepository
+
+				BeforeEach(func() {
+					routeRepo = new(apifakes.FakeRouteRepository)
+					locator := api.RepositoryLocator{}
+					locator = locator.SetRouteRepository(routeRepo)
+
+					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil, rpc.DefaultServer)
+					err := rpcService.Start()
+					Expect(err).ToNot(HaveOccurred())
+
+					pingCli(rpcService.Port())
+				})
+
+				It("returns the routes in the current space", func() {
+					routeRepo.ListRoutesStub = func(cb func(models.Route) bool) error {
+						cb(models.Route{
+							GUID:   "route-guid",
+							Host:   "some-host",
+							Path:   "/some-path",
+							Domain: models.DomainFields{GUID: "domain-guid", Name: "example.com"},
+							Apps:   []models.ApplicationFields{{GUID: "app-guid", Name: "some-app"}},
+						})
+						return nil
+					}
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result []plugin_models.GetRoutes_Model
+					err = client.Call("CliRpcCmd.GetRoutes", "", &result)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(HaveLen(1))
+					Expect(result[0].Guid).To(Equal("route-guid"))
+					Expect(result[0].Host).To(Equal("some-host"))
+					Expect(result[0].Path).To(Equal("/some-path"))
+					Expect(result[0].Domain.Name).To(Equal("example.com"))
+					Expect(result[0].Apps).To(ConsistOf(plugin_models.GetRoutes_App{Guid: "app-guid", Name: "some-app"}))
+				})
+
+				It("returns the error from listing routes", func() {
+					routeRepo.ListRoutesReturns(errors.New("list error"))
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result []plugin_models.GetRoutes_Model
+					err = client.Call("CliRpcCmd.GetRoutes", "", &result)
+					Expect(err).To(MatchError("list error"))
+				})
+
+				It("returns an error when no space is targeted", func() {
+					config.SetSpaceFields(models.SpaceFields{})
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result []plugin_models.GetRoutes_Model
+					err = client.Call("CliRpcCmd.GetRoutes", "", &result)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("No space targeted"))
+					Expect(routeRepo.ListRoutesCallCount()).To(BeZero())
+				})
+			})
+
+			Context(".GetDomains", func() {
+				var domainRepo *apifakes.FakeDomainRepository
+
+				BeforeEach(func() {
+					domainRepo = new(apifakes.FakeDomainRepository)
+					locator := api.RepositoryLocator{}
+					locator = locator.SetDomainRepository(domainRepo)
+
+					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil, rpc.DefaultServer)
+					err := rpcService.Start()
+					Expect(err).ToNot(HaveOccurred())
+
+					pingCli(rpcService.Port())
+				})
+
+				It("returns the domains of the current org", func() {
+					domainRepo.ListDomainsForOrgStub = func(orgGUID string, cb func(models.DomainFields) bool) error {
+						cb(models.DomainFields{GUID: "domain-guid", Name: "example.com", Shared: true})
+						return nil
+					}
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result []plugin_models.GetDomains_Model
+					err = client.Call("CliRpcCmd.GetDomains", "", &result)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(ConsistOf(plugin_models.GetDomains_Model{Guid: "domain-guid", Name: "example.com", Shared: true}))
+
+					orgGUID, _ := domainRepo.ListDomainsForOrgArgsForCall(0)
+					Expect(orgGUID).To(Equal("my-org-guid"))
+				})
+
+				It("returns an error when no org is targeted", func() {
+					config.SetOrganizationFields(models.OrganizationFields{})
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result []plugin_models.GetDomains_Model
+					err = client.Call("CliRpcCmd.GetDomains", "", &result)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("No org targeted"))
+					Expect(domainRepo.ListDomainsForOrgCallCount()).To(BeZero())
+				})
+			})
+
+			Context(".GetServiceKeys", func() {
+				var (
+					serviceRepo    *apifakes.FakeServiceRepository
+					serviceKeyRepo *apifakes.FakeServiceKeyRepository
+				)
+
+				BeforeEach(func() {
+					serviceRepo = new(apifakes.FakeServiceRepository)
+					serviceKeyRepo = new(apifakes.FakeServiceKeyRepository)
+					locator := api.RepositoryLocator{}
+					locator = locator.SetServiceRepository(serviceRepo)
+					locator = locator.SetServiceKeyRepository(serviceKeyRepo)
+
+					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil, rpc.DefaultServer)
+					err := rpcService.Start()
+					Expect(err).ToNot(HaveOccurred())
+
+					pingCli(rpcService.Port())
+				})
+
+				It("returns the keys of the named service instance with their credentials", func() {
+					serviceRepo.FindInstanceByNameReturns(models.ServiceInstance{
+						ServiceInstanceFields: models.ServiceInstanceFields{GUID: "instance-guid"},
+					}, nil)
+					serviceKeyRepo.ListServiceKeysReturns([]models.ServiceKey{
+						{
+							Fields:      models.ServiceKeyFields{GUID: "key-guid", Name: "some-key"},
+							Credentials: map[string]interface{}{"username": "admin"},
+						},
+					}, nil)
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result []plugin_models.GetServiceKeys_Model
+					err = client.Call("CliRpcCmd.GetServiceKeys", "some-instance", &result)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(serviceRepo.FindInstanceByNameArgsForCall(0)).To(Equal("some-instance"))
+					Expect(serviceKeyRepo.ListServiceKeysArgsForCall(0)).To(Equal("instance-guid"))
+					Expect(result).To(Equal([]plugin_models.GetServiceKeys_Model{
+						{
+							Guid:        "key-guid",
+							Name:        "some-key",
+							Credentials: map[string]interface{}{"username": "admin"},
+						},
+					}))
+				})
+
+				It("returns the error from finding the service instance", func() {
+					serviceRepo.FindInstanceByNameReturns(models.ServiceInstance{}, errors.New("not found"))
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result []plugin_models.GetServiceKeys_Model
+					err = client.Call("CliRpcCmd.GetServiceKeys", "some-instance", &result)
+					Expect(err).To(MatchError("not found"))
+					Expect(serviceKeyRepo.ListServiceKeysCallCount()).To(Equal(0))
+				})
+
+				It("returns an error when no space is targeted", func() {
+					config.SetSpaceFields(models.SpaceFields{})
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result []plugin_models.GetServiceKeys_Model
+					err = client.Call("CliRpcCmd.GetServiceKeys", "some-instance", &result)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("No space targeted"))
+					Expect(serviceRepo.FindInstanceByNameCallCount()).To(BeZero())
+				})
+			})
+
+			Context(".GetBuildpacks", func() {
+				var buildpackRepo *apifakes.FakeBuildpackRepository
+
+				BeforeEach(func() {
+					buildpackRepo = new(apifakes.FakeBuildpackRepository)
+					locator := api.RepositoryLocator{}
+					locator = locator.SetBuildpackRepository(buildpackRepo)
+
+					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil, rpc.DefaultServer)
+					err := rpcService.Start()
+					Expect(err).ToNot(HaveOccurred())
+
+					pingCli(rpcService.Port())
+				})
+
+				It("returns the buildpacks, defaulting fields the API left out", func() {
+					position := 2
+					enabled := true
+					buildpackRepo.ListBuildpacksStub = func(cb func(models.Buildpack) bool) error {
+						cb(models.Buildpack{GUID: "bp-guid-1", Name: "bp-1", Position: &position, Enabled: &enabled, Filename: "bp-1.zip"})
+						cb(models.Buildpack{GUID: "bp-guid-2", Name: "bp-2"})
+						return nil
+					}
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result []plugin_models.GetBuildpacks_Model
+					err = client.Call("CliRpcCmd.GetBuildpacks", "", &result)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result).To(Equal([]plugin_models.GetBuildpacks_Model{
+						{Guid: "bp-guid-1", Name: "bp-1", Position: 2, Enabled: true, Filename: "bp-1.zip"},
+						{Guid: "bp-guid-2", Name: "bp-2"},
+					}))
+				})
+			})
+
+			Context(".CloudControllerRequest and .UAARequest", func() {
+				var (
+					ccRawRequestRepo  *apifakes.FakeRawRequestRepository
+					uaaRawRequestRepo *apifakes.FakeRawRequestRepository
+				)
+
+				BeforeEach(func() {
+					ccRawRequestRepo = new(apifakes.FakeRawRequestRepository)
+					uaaRawRequestRepo = new(apifakes.FakeRawRequestRepository)
+					locator := api.RepositoryLocator{}
+					locator = locator.SetCloudControllerRawRequestRepository(ccRawRequestRepo)
+					locator = locator.SetUAARawRequestRepository(uaaRawRequestRepo)
+
+					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil, rpc.DefaultServer)
+					err := rpcService.Start()
+					Expect(err).ToNot(HaveOccurred())
+
+					pingCli(rpcService.Port())
+				})
+
+				It("sends the request to the Cloud Controller and returns the response", func() {
+					ccRawRequestRepo.RequestReturns(api.RawResponse{
+						StatusCode: 201,
+						Header:     http.Header{"Content-Type": {"application/json"}},
+						Body:       []byte(`{"guid":"some-guid"}`),
+					}, nil)
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result plugin_models.APIResponse
+					err = client.Call("CliRpcCmd.CloudControllerRequest", plugin_models.APIRequest{
+						Method: "POST",
+						Path:   "/v3/apps",
+						Body:   []byte(`{"name":"some-app"}`),
+					}, &result)
+					Expect(err).ToNot(HaveOccurred())
+
+					method, path, body := ccRawRequestRepo.RequestArgsForCall(0)
+					Expect(method).To(Equal("POST"))
+					Expect(path).To(Equal("/v3/apps"))
+					Expect(body).To(Equal([]byte(`{"name":"some-app"}`)))
+
+					Expect(result.StatusCode).To(Equal(201))
+					Expect(result.Header).To(HaveKeyWithValue("Content-Type", []string{"application/json"}))
+					Expect(result.Body).To(Equal([]byte(`{"guid":"some-guid"}`)))
+					Expect(uaaRawRequestRepo.RequestCallCount()).To(Equal(0))
+				})
+
+				It("sends the request to UAA and returns the response", func() {
+					uaaRawRequestRepo.RequestReturns(api.RawResponse{StatusCode: 200, Body: []byte(`{}`)}, nil)
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result plugin_models.APIResponse
+					err = client.Call("CliRpcCmd.UAARequest", plugin_models.APIRequest{Method: "GET", Path: "/Users"}, &result)
+					Expect(err).ToNot(HaveOccurred())
+
+					method, path, _ := uaaRawRequestRepo.RequestArgsForCall(0)
+					Expect(method).To(Equal("GET"))
+					Expect(path).To(Equal("/Users"))
+					Expect(result.StatusCode).To(Equal(200))
+					Expect(ccRawRequestRepo.RequestCallCount()).To(Equal(0))
+				})
+
+				It("returns the error when no response was received", func() {
+					ccRawRequestRepo.RequestReturns(api.RawResponse{}, errors.New("connection refused"))
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result plugin_models.APIResponse
+					err = client.Call("CliRpcCmd.CloudControllerRequest", plugin_models.APIRequest{Path: "/v2/info"}, &result)
+					Expect(err).To(MatchError("connection refused"))
+				})
+			})
+
+			Context(".GetTasks", func() {
+				var (
+					appRepo *applicationsfakes.FakeRepository
+					v3Repo  *repositoryfakes.FakeRepository
+				)
+
+				BeforeEach(func() {
+					appRepo = new(applicationsfakes.FakeRepository)
+					v3Repo = new(repositoryfakes.FakeRepository)
+					locator := api.RepositoryLocator{}
+					locator = locator.SetApplicationRepository(appRepo)
+					locator = locator.SetV3Repository(v3Repo)
+
+					rpcService, err = NewRpcService(nil, nil, config, locator, nil, nil, nil, rpc.DefaultServer)
+					err := rpcService.Start()
+					Expect(err).ToNot(HaveOccurred())
+
+					pingCli(rpcService.Port())
+				})
+
+				It("returns the tasks of the named app", func() {
+					appRepo.ReadReturns(models.Application{
+						ApplicationFields: models.ApplicationFields{GUID: "app-guid"},
+					}, nil)
+					v3Repo.GetTasksReturns([]v3models.V3Task{
+						{GUID: "task-guid", SequenceID: 3, Name: "migrate", Command: "rake db:migrate", State: "SUCCEEDED", MemoryInMB: 256},
+					}, nil)
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result []plugin_models.GetTasks_Model
+					err = client.Call("CliRpcCmd.GetTasks", "some-app", &result)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(appRepo.ReadArgsForCall(0)).To(Equal("some-app"))
+					Expect(v3Repo.GetTasksArgsForCall(0)).To(Equal("/v3/apps/app-guid/tasks"))
+					Expect(result).To(Equal([]plugin_models.GetTasks_Model{
+						{Guid: "task-guid", SequenceId: 3, Name: "migrate", Command: "rake db:migrate", State: "SUCCEEDED", MemoryInMB: 256},
+					}))
+				})
+
+				It("returns an error when no space is targeted", func() {
+					config.SetSpaceFields(models.SpaceFields{})
+
+					client, err = rpc.Dial("tcp", "127.0.0.1:"+rpcService.Port())
+					Expect(err).ToNot(HaveOccurred())
+
+					var result []plugin_models.GetTasks_Model
+					err = client.Call("CliRpcCmd.GetTasks", "some-app", &result)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("No space targeted"))
+					Expect(appRepo.ReadCallCount()).To(BeZero())
+				})
+			})
+
 		})
 
 		Context("fail", func() {
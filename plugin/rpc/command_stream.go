@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/cli/cf/terminal"
+	"code.cloudfoundry.org/cli/plugin/models"
+)
+
+// commandStream collects the output of a core command started with
+// StartCoreCommand and hands it out a line at a time as it is printed.
+type commandStream struct {
+	mutex   *sync.Mutex
+	cond    *sync.Cond
+	lines   []string
+	partial string
+	done    bool
+	result  plugin_models.CommandResult
+}
+
+func newCommandStream() *commandStream {
+	mutex := &sync.Mutex{}
+	return &commandStream{
+		mutex: mutex,
+		cond:  sync.NewCond(mutex),
+	}
+}
+
+func (s *commandStream) Write(p []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	lines := strings.Split(s.partial+string(p), "\n")
+	s.lines = append(s.lines, lines[:len(lines)-1]...)
+	s.partial = lines[len(lines)-1]
+	s.cond.Broadcast()
+
+	return len(p), nil
+}
+
+func (s *commandStream) finish(warnings []string, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.partial != "" {
+		s.lines = append(s.lines, s.partial)
+		s.partial = ""
+	}
+
+	s.result.Warnings = warnings
+	if err != nil {
+		s.result.ExitCode = 1
+		s.result.Error = err.Error()
+	}
+	s.done = true
+	s.cond.Broadcast()
+}
+
+// read waits for lines that have not been read yet, or for the command to
+// finish, and returns them.
+func (s *commandStream) read() plugin_models.CommandOutput {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for len(s.lines) == 0 && !s.done {
+		s.cond.Wait()
+	}
+
+	output := plugin_models.CommandOutput{
+		Lines: s.lines,
+		Done:  s.done,
+	}
+	if s.done {
+		output.Result = s.result
+	}
+	s.lines = nil
+
+	return output
+}
+
+// warningRecorder keeps the warnings a command shows so that they can be
+// returned separately from its output.
+type warningRecorder struct {
+	terminal.UI
+	warnings []string
+}
+
+func (ui *warningRecorder) Warn(message string, args ...interface{}) {
+	ui.warnings = append(ui.warnings, fmt.Sprintf(message, args...))
+	ui.UI.Warn(message, args...)
+}
@@ -0,0 +1,45 @@
+package fakecommand
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/cli/cf/commandregistry"
+	"code.cloudfoundry.org/cli/cf/flags"
+	"code.cloudfoundry.org/cli/cf/requirements"
+	"code.cloudfoundry.org/cli/cf/terminal"
+)
+
+type FakeCommand5 struct {
+	ui terminal.UI
+}
+
+func init() {
+	commandregistry.Register(FakeCommand5{})
+}
+
+func (cmd FakeCommand5) MetaData() commandregistry.CommandMetadata {
+	return commandregistry.CommandMetadata{
+		Name:        "fake-command5",
+		Description: "Description for fake-command5",
+		Usage: []string{
+			"Usage of fake-command5",
+		},
+	}
+}
+
+func (cmd FakeCommand5) Requirements(_ requirements.Factory, _ flags.FlagContext) ([]requirements.Requirement, error) {
+	reqs := []requirements.Requirement{}
+	return reqs, nil
+}
+
+func (cmd FakeCommand5) SetDependency(deps commandregistry.Dependency, pluginCall bool) commandregistry.Command {
+	cmd.ui = deps.UI
+	return cmd
+}
+
+func (cmd FakeCommand5) Execute(c flags.FlagContext) error {
+	cmd.ui.Say("Starting")
+	cmd.ui.Warn("Something to watch")
+	cmd.ui.Say("Stopping")
+	return errors.New("fake-command5 failed")
+}
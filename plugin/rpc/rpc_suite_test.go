@@ -1,7 +1,10 @@
 package rpc_test
 
 import (
+	"path/filepath"
+
 	"code.cloudfoundry.org/cli/plugin/rpc"
+	"code.cloudfoundry.org/cli/util/testhelpers/pluginbuilder"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -12,5 +15,9 @@ var rpcService *rpc.CliRpcService
 
 func TestRpc(t *testing.T) {
 	RegisterFailHandler(Fail)
+
+	pluginbuilder.BuildTestBinary(filepath.Join("..", "..", "fixtures", "plugins"), "hooks")
+	pluginbuilder.BuildTestBinary(filepath.Join("..", "..", "fixtures", "plugins"), "completions")
+
 	RunSpecs(t, "Rpc Suite")
 }
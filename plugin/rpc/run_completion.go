@@ -0,0 +1,49 @@
+package rpc
+
+import (
+	"os/exec"
+	"strings"
+
+	"code.cloudfoundry.org/cli/plugin"
+)
+
+// CompleteFlagValues returns the values of flag that start with
+// request.Prefix. The values listed in the flag's metadata are offered first;
+// when the flag is marked Complete, the plugin at location is run to offer
+// more. The plugin's output is discarded so that it does not end up in the
+// shell's completions.
+func CompleteFlagValues(rpcService *CliRpcService, location string, flag plugin.Flag, request plugin.CompletionRequest) ([]string, error) {
+	values := append([]string{}, flag.Values...)
+
+	if flag.Complete {
+		err := rpcService.Start()
+		if err != nil {
+			return nil, err
+		}
+		defer rpcService.Stop()
+
+		rpcCmd := rpcService.RpcCmd
+		rpcCmd.MetadataMutex.Lock()
+		rpcCmd.CompletionRequest = request
+		rpcCmd.Completion = nil
+		rpcCmd.MetadataMutex.Unlock()
+
+		err = exec.Command(location, rpcService.Port(), "CompleteFlag").Run()
+		if err != nil {
+			return nil, err
+		}
+
+		rpcCmd.MetadataMutex.RLock()
+		values = append(values, rpcCmd.Completion...)
+		rpcCmd.MetadataMutex.RUnlock()
+	}
+
+	matches := []string{}
+	for _, value := range values {
+		if strings.HasPrefix(value, request.Prefix) {
+			matches = append(matches, value)
+		}
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,71 @@
+package rpc_test
+
+import (
+	"net/rpc"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/plugin"
+	. "code.cloudfoundry.org/cli/plugin/rpc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CompleteFlagValues", func() {
+	var location string
+
+	BeforeEach(func() {
+		var err error
+		location, err = filepath.Abs(filepath.Join("..", "..", "fixtures", "plugins", "completions.exe"))
+		Expect(err).ToNot(HaveOccurred())
+
+		rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil, rpc.NewServer())
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		//give time for server to stop
+		time.Sleep(50 * time.Millisecond)
+	})
+
+	It("offers the flag's listed values that match the prefix without running the plugin", func() {
+		values, err := CompleteFlagValues(rpcService, "/does/not/exist", plugin.Flag{
+			Name:   "env",
+			Values: []string{"dev", "staging", "prod"},
+		}, plugin.CompletionRequest{Command: "deploy", Flag: "env", Prefix: "st"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(values).To(Equal([]string{"staging"}))
+	})
+
+	It("asks the plugin to complete flags marked Complete", func() {
+		values, err := CompleteFlagValues(rpcService, location, plugin.Flag{
+			Name:     "app",
+			Complete: true,
+		}, plugin.CompletionRequest{Command: "deploy", Flag: "app", Prefix: "app-"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(values).To(Equal([]string{"app-one", "app-two"}))
+	})
+
+	It("passes the request to the plugin", func() {
+		values, err := CompleteFlagValues(rpcService, location, plugin.Flag{
+			Name:     "app",
+			Complete: true,
+		}, plugin.CompletionRequest{
+			Command: "deploy",
+			Flag:    "app",
+			Args:    []string{"--env", "prod"},
+			Prefix:  "app-p",
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(values).To(Equal([]string{"app-prod"}))
+	})
+
+	It("returns an error when the plugin cannot be run", func() {
+		_, err := CompleteFlagValues(rpcService, "/does/not/exist", plugin.Flag{
+			Name:     "app",
+			Complete: true,
+		}, plugin.CompletionRequest{Command: "deploy", Flag: "app"})
+		Expect(err).To(HaveOccurred())
+	})
+})
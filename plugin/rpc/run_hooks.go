@@ -0,0 +1,106 @@
+package rpc
+
+import (
+	"os"
+	"os/exec"
+	"sort"
+
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	. "code.cloudfoundry.org/cli/cf/i18n"
+	"code.cloudfoundry.org/cli/plugin"
+)
+
+// HookVetoError is returned by RunHooks when a pre hook stops a command.
+type HookVetoError struct {
+	PluginName string
+	Command    string
+	Message    string
+}
+
+func (e HookVetoError) Error() string {
+	return T("Plugin {{.PluginName}} stopped {{.Command}} from running: {{.Message}}", map[string]interface{}{
+		"PluginName": e.PluginName,
+		"Command":    e.Command,
+		"Message":    e.Message,
+	})
+}
+
+// HookFailedError is returned by RunHooks when a plugin exits with an error
+// while running a hook.
+type HookFailedError struct {
+	PluginName string
+	Err        error
+}
+
+func (e HookFailedError) Error() string {
+	return T("Plugin {{.PluginName}} failed to run its hook: {{.Error}}", map[string]interface{}{
+		"PluginName": e.PluginName,
+		"Error":      e.Err.Error(),
+	})
+}
+
+// PluginsWithHook returns the names, in order, of the plugins that registered
+// a hook for the stage of command.
+func PluginsWithHook(pluginList map[string]pluginconfig.PluginMetadata, stage plugin.HookStage, command string) []string {
+	names := []string{}
+	for name, metadata := range pluginList {
+		for _, hook := range metadata.Hooks {
+			if hook.Stage == stage && hook.Command == command {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// RunHooks runs the hooks registered for event's stage and command, one
+// plugin at a time in name order. A pre hook veto stops the remaining hooks
+// and is returned as a HookVetoError. A plugin that fails stops the remaining
+// hooks with a HookFailedError.
+func RunHooks(rpcService *CliRpcService, event plugin.HookEvent, pluginList map[string]pluginconfig.PluginMetadata) error {
+	names := PluginsWithHook(pluginList, event.Stage, event.Command)
+	if len(names) == 0 {
+		return nil
+	}
+
+	err := rpcService.Start()
+	if err != nil {
+		return err
+	}
+	defer rpcService.Stop()
+
+	rpcCmd := rpcService.RpcCmd
+	for _, name := range names {
+		rpcCmd.MetadataMutex.Lock()
+		rpcCmd.HookEvent = event
+		rpcCmd.HookResult = plugin.HookResult{}
+		rpcCmd.MetadataMutex.Unlock()
+
+		cmd := exec.Command(pluginList[name].Location, rpcService.Port(), "RunHook")
+		cmd.Stdout = os.Stdout
+		cmd.Stdin = os.Stdin
+		cmd.Stderr = os.Stderr
+
+		err = cmd.Run()
+		if err != nil {
+			return HookFailedError{PluginName: name, Err: err}
+		}
+
+		rpcCmd.MetadataMutex.RLock()
+		result := rpcCmd.HookResult
+		rpcCmd.MetadataMutex.RUnlock()
+
+		if event.Stage == plugin.PreHook && result.Veto {
+			return HookVetoError{
+				PluginName: name,
+				Command:    event.Command,
+				Message:    result.Message,
+			}
+		}
+	}
+
+	return nil
+}
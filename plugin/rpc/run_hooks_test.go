@@ -0,0 +1,141 @@
+package rpc_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/rpc"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/cli/cf/api"
+	"code.cloudfoundry.org/cli/cf/configuration/pluginconfig"
+	"code.cloudfoundry.org/cli/plugin"
+	. "code.cloudfoundry.org/cli/plugin/rpc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RunHooks", func() {
+	var (
+		pluginList map[string]pluginconfig.PluginMetadata
+		eventFile  string
+		server     *rpc.Server
+	)
+
+	BeforeEach(func() {
+		location, err := filepath.Abs(filepath.Join("..", "..", "fixtures", "plugins", "hooks.exe"))
+		Expect(err).ToNot(HaveOccurred())
+
+		pluginList = map[string]pluginconfig.PluginMetadata{
+			"Hooks": {
+				Location: location,
+				Hooks: []plugin.Hook{
+					{Command: "push", Stage: plugin.PreHook},
+					{Command: "push", Stage: plugin.PostHook},
+				},
+			},
+			"Other": {
+				Location: "/does/not/exist",
+				Hooks: []plugin.Hook{
+					{Command: "delete", Stage: plugin.PreHook},
+				},
+			},
+		}
+
+		tmpFile, err := ioutil.TempFile("", "hook-event")
+		Expect(err).ToNot(HaveOccurred())
+		eventFile = tmpFile.Name()
+		tmpFile.Close()
+		os.Setenv("HOOKS_EVENT_FILE", eventFile)
+
+		server = rpc.NewServer()
+		rpcService, err = NewRpcService(nil, nil, nil, api.RepositoryLocator{}, nil, nil, nil, server)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("HOOKS_EVENT_FILE")
+		os.Remove(eventFile)
+
+		//give time for server to stop
+		time.Sleep(50 * time.Millisecond)
+	})
+
+	readEvent := func() plugin.HookEvent {
+		contents, err := ioutil.ReadFile(eventFile)
+		Expect(err).ToNot(HaveOccurred())
+
+		var event plugin.HookEvent
+		Expect(json.Unmarshal(contents, &event)).To(Succeed())
+		return event
+	}
+
+	Describe("PluginsWithHook", func() {
+		It("returns the plugins that registered a hook for the stage of the command", func() {
+			Expect(PluginsWithHook(pluginList, plugin.PreHook, "push")).To(Equal([]string{"Hooks"}))
+			Expect(PluginsWithHook(pluginList, plugin.PreHook, "delete")).To(Equal([]string{"Other"}))
+			Expect(PluginsWithHook(pluginList, plugin.PostHook, "delete")).To(BeEmpty())
+		})
+	})
+
+	It("passes the event to the plugin", func() {
+		err := RunHooks(rpcService, plugin.HookEvent{
+			Stage:   plugin.PreHook,
+			Command: "push",
+			Args:    []string{"some-app", "-i", "2"},
+		}, pluginList)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(readEvent()).To(Equal(plugin.HookEvent{
+			Stage:   plugin.PreHook,
+			Command: "push",
+			Args:    []string{"some-app", "-i", "2"},
+		}))
+	})
+
+	It("returns a HookVetoError when a pre hook vetoes the command", func() {
+		err := RunHooks(rpcService, plugin.HookEvent{
+			Stage:   plugin.PreHook,
+			Command: "push",
+			Args:    []string{"forbidden-app"},
+		}, pluginList)
+		Expect(err).To(Equal(HookVetoError{
+			PluginName: "Hooks",
+			Command:    "push",
+			Message:    "app names must not be forbidden",
+		}))
+	})
+
+	It("passes the command's result to post hooks", func() {
+		err := RunHooks(rpcService, plugin.HookEvent{
+			Stage:   plugin.PostHook,
+			Command: "push",
+			Args:    []string{"forbidden-app"},
+			Error:   "push failed",
+		}, pluginList)
+		Expect(err).ToNot(HaveOccurred())
+
+		event := readEvent()
+		Expect(event.Stage).To(Equal(plugin.PostHook))
+		Expect(event.Succeeded).To(BeFalse())
+		Expect(event.Error).To(Equal("push failed"))
+	})
+
+	It("returns a HookFailedError when the plugin cannot be run", func() {
+		err := RunHooks(rpcService, plugin.HookEvent{
+			Stage:   plugin.PreHook,
+			Command: "delete",
+		}, pluginList)
+		Expect(err).To(BeAssignableToTypeOf(HookFailedError{}))
+		Expect(err.(HookFailedError).PluginName).To(Equal("Other"))
+	})
+
+	It("does nothing when no plugin registered a hook for the command", func() {
+		err := RunHooks(rpcService, plugin.HookEvent{
+			Stage:   plugin.PreHook,
+			Command: "bind-service",
+		}, pluginList)
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,42 @@
+// Package browser opens URLs in the user's web browser.
+package browser
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ErrNoBrowser is returned when there is no browser to open a URL in, for
+// example in an SSH session without a display.
+var ErrNoBrowser = errors.New("no browser available")
+
+// Open opens url in the browser named by the BROWSER environment variable or,
+// if it is unset, the system's default browser. Setting BROWSER to "none"
+// disables opening a browser.
+func Open(url string) error {
+	command, args := browserCommand()
+	if command == "" {
+		return ErrNoBrowser
+	}
+
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return ErrNoBrowser
+	}
+
+	cmd := exec.Command(path, append(args, url)...)
+	return cmd.Start()
+}
+
+func browserCommand() (string, []string) {
+	if value, ok := os.LookupEnv("BROWSER"); ok {
+		fields := strings.Fields(value)
+		if len(fields) == 0 || fields[0] == "none" {
+			return "", nil
+		}
+		return fields[0], fields[1:]
+	}
+	return defaultCommand()
+}
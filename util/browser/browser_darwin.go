@@ -0,0 +1,5 @@
+package browser
+
+func defaultCommand() (string, []string) {
+	return "open", nil
+}
@@ -0,0 +1,87 @@
+package browser_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	. "code.cloudfoundry.org/cli/util/browser"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Open", func() {
+	var (
+		oldBrowser string
+		hadBrowser bool
+	)
+
+	BeforeEach(func() {
+		oldBrowser, hadBrowser = os.LookupEnv("BROWSER")
+	})
+
+	AfterEach(func() {
+		if hadBrowser {
+			os.Setenv("BROWSER", oldBrowser)
+		} else {
+			os.Unsetenv("BROWSER")
+		}
+	})
+
+	Context("when BROWSER is none", func() {
+		BeforeEach(func() {
+			os.Setenv("BROWSER", "none")
+		})
+
+		It("returns ErrNoBrowser", func() {
+			Expect(Open("https://example.com")).To(MatchError(ErrNoBrowser))
+		})
+	})
+
+	Context("when the BROWSER command does not exist", func() {
+		BeforeEach(func() {
+			os.Setenv("BROWSER", "some-browser-that-does-not-exist")
+		})
+
+		It("returns ErrNoBrowser", func() {
+			Expect(Open("https://example.com")).To(MatchError(ErrNoBrowser))
+		})
+	})
+
+	Context("when BROWSER is a command", func() {
+		var (
+			dir    string
+			output string
+		)
+
+		BeforeEach(func() {
+			if runtime.GOOS == "windows" {
+				Skip("uses a shell script")
+			}
+
+			var err error
+			dir, err = ioutil.TempDir("", "browser")
+			Expect(err).ToNot(HaveOccurred())
+
+			output = filepath.Join(dir, "url")
+			script := filepath.Join(dir, "fake-browser")
+			err = ioutil.WriteFile(script, []byte("#!/bin/sh\necho \"$@\" > "+output+"\n"), 0755)
+			Expect(err).ToNot(HaveOccurred())
+
+			os.Setenv("BROWSER", script+" --new-window")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		It("runs it with the URL", func() {
+			Expect(Open("https://example.com")).To(Succeed())
+			Eventually(func() string {
+				contents, _ := ioutil.ReadFile(output)
+				return string(contents)
+			}).Should(Equal("--new-window https://example.com\n"))
+		})
+	})
+})
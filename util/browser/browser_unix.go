@@ -0,0 +1,12 @@
+// +build !darwin,!windows
+
+package browser
+
+import "os"
+
+func defaultCommand() (string, []string) {
+	if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return "", nil
+	}
+	return "xdg-open", nil
+}
@@ -0,0 +1,5 @@
+package browser
+
+func defaultCommand() (string, []string) {
+	return "rundll32", []string{"url.dll,FileProtocolHandler"}
+}
@@ -2,32 +2,43 @@ package util
 
 import (
 	"crypto/sha1"
+	"crypto/sha256"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 )
 
-//go:generate counterfeiter . Sha1Checksum
+//go:generate counterfeiter . Checksum
 
-type Sha1Checksum interface {
+// Checksum computes and checks the SHA-1 and SHA-256 digests of a file.
+type Checksum interface {
 	ComputeFileSha1() ([]byte, error)
 	CheckSha1(string) bool
+	ComputeFileSha256() ([]byte, error)
+	CheckSha256(string) bool
 	SetFilePath(string)
 }
 
-type sha1Checksum struct {
+type fileChecksum struct {
 	filepath string
 }
 
-func NewSha1Checksum(filepath string) Sha1Checksum {
-	return &sha1Checksum{
+func NewChecksum(filepath string) Checksum {
+	return &fileChecksum{
 		filepath: filepath,
 	}
 }
 
-func (c *sha1Checksum) ComputeFileSha1() ([]byte, error) {
-	hash := sha1.New()
+func (c *fileChecksum) ComputeFileSha1() ([]byte, error) {
+	return c.computeFileHash(sha1.New())
+}
+
+func (c *fileChecksum) ComputeFileSha256() ([]byte, error) {
+	return c.computeFileHash(sha256.New())
+}
 
+func (c *fileChecksum) computeFileHash(hash hash.Hash) ([]byte, error) {
 	f, err := os.Open(c.filepath)
 	if err != nil {
 		return []byte{}, err
@@ -41,7 +52,7 @@ func (c *sha1Checksum) ComputeFileSha1() ([]byte, error) {
 	return hash.Sum(nil), nil
 }
 
-func (c *sha1Checksum) CheckSha1(targetSha1 string) bool {
+func (c *fileChecksum) CheckSha1(targetSha1 string) bool {
 	sha1, err := c.ComputeFileSha1()
 	if err != nil {
 		return false
@@ -53,6 +64,15 @@ func (c *sha1Checksum) CheckSha1(targetSha1 string) bool {
 	return false
 }
 
-func (c *sha1Checksum) SetFilePath(filepath string) {
+func (c *fileChecksum) CheckSha256(targetSha256 string) bool {
+	sha256, err := c.ComputeFileSha256()
+	if err != nil {
+		return false
+	}
+
+	return fmt.Sprintf("%x", sha256) == targetSha256
+}
+
+func (c *fileChecksum) SetFilePath(filepath string) {
 	c.filepath = filepath
 }
@@ -11,16 +11,16 @@ import (
 	. "github.com/onsi/gomega"
 )
 
-var _ = Describe("Sha1Checksum", func() {
+var _ = Describe("Checksum", func() {
 
 	var (
-		checksum Sha1Checksum
+		checksum Checksum
 	)
 
 	Describe("ComputeFileSha1", func() {
 		Context("If file does not exist", func() {
 			It("returns error", func() {
-				checksum = NewSha1Checksum("file/path/to/no/where")
+				checksum = NewChecksum("file/path/to/no/where")
 
 				sha1, err := checksum.ComputeFileSha1()
 				Expect(len(sha1)).To(Equal(0))
@@ -46,7 +46,7 @@ var _ = Describe("Sha1Checksum", func() {
 			})
 
 			It("returns the sha1 of a file", func() {
-				checksum = NewSha1Checksum(f.Name())
+				checksum = NewChecksum(f.Name())
 
 				sha1, err := checksum.ComputeFileSha1()
 				Expect(err).NotTo(HaveOccurred())
@@ -60,7 +60,7 @@ var _ = Describe("Sha1Checksum", func() {
 	Describe("CheckSha1", func() {
 		Context("file doesn't exist", func() {
 			It("returns false", func() {
-				checksum = NewSha1Checksum("file/path/to/no/where")
+				checksum = NewChecksum("file/path/to/no/where")
 
 				sha1, err := checksum.ComputeFileSha1()
 				Expect(len(sha1)).To(Equal(0))
@@ -86,17 +86,51 @@ var _ = Describe("Sha1Checksum", func() {
 			})
 
 			It("returns false if sha1 doesn't match", func() {
-				checksum = NewSha1Checksum(f.Name())
+				checksum = NewChecksum(f.Name())
 
 				Expect(checksum.CheckSha1("skj33933dabs2292391223aa393fjs92")).To(BeFalse())
 			})
 
 			It("returns true if sha1 matches", func() {
-				checksum = NewSha1Checksum(f.Name())
+				checksum = NewChecksum(f.Name())
 
 				Expect(checksum.CheckSha1("a9993e364706816aba3e25717850c26c9cd0d89d")).To(BeTrue())
 			})
 		})
 
 	})
+
+	Describe("CheckSha256", func() {
+		var f *os.File
+
+		BeforeEach(func() {
+			var err error
+			f, err = ioutil.TempFile("", "sha256_test_")
+			Expect(err).NotTo(HaveOccurred())
+			defer f.Close()
+			f.Write([]byte("abc"))
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(f.Name())
+		})
+
+		It("returns true if sha256 matches", func() {
+			checksum = NewChecksum(f.Name())
+
+			Expect(checksum.CheckSha256("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad")).To(BeTrue())
+		})
+
+		It("returns false if sha256 doesn't match", func() {
+			checksum = NewChecksum(f.Name())
+
+			Expect(checksum.CheckSha256("a9993e364706816aba3e25717850c26c9cd0d89d")).To(BeFalse())
+		})
+
+		It("returns false if the file doesn't exist", func() {
+			checksum = NewChecksum("file/path/to/no/where")
+
+			Expect(checksum.CheckSha256("ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad")).To(BeFalse())
+		})
+	})
 })
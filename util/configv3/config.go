@@ -7,8 +7,15 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
+	"code.cloudfoundry.org/cli/api/retry"
+	"code.cloudfoundry.org/cli/util/contexts"
+	"code.cloudfoundry.org/cli/util/credentials"
+	"code.cloudfoundry.org/cli/util/httpcache"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
+	"code.cloudfoundry.org/cli/util/workpool"
 	"code.cloudfoundry.org/cli/version"
 )
 
@@ -30,6 +37,10 @@ const (
 	// Developer note about constant above ^^^ do not replace with math.MaxInt64
 	// This will require the math package which is a dynamically linked library.
 
+	// TraceFormatHAR is the CF_TRACE_FORMAT value that writes trace files as
+	// HTTP Archives instead of text.
+	TraceFormatHAR = "har"
+
 	// DefaultTarget is the default CFConfig value for Target.
 	DefaultTarget = ""
 
@@ -92,6 +103,16 @@ func LoadConfig(flags ...FlagOverride) (*Config, error) {
 		}
 	}
 
+	err := config.loadCredentials(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.loadSelectedContext()
+	if err != nil {
+		return nil, err
+	}
+
 	config.ENV = EnvOverride{
 		BinaryName:       filepath.Base(os.Args[0]),
 		CFColor:          os.Getenv("CF_COLOR"),
@@ -99,11 +120,17 @@ func LoadConfig(flags ...FlagOverride) (*Config, error) {
 		CFStagingTimeout: os.Getenv("CF_STAGING_TIMEOUT"),
 		CFStartupTimeout: os.Getenv("CF_STARTUP_TIMEOUT"),
 		CFTrace:          os.Getenv("CF_TRACE"),
+		CFTraceFormat:    os.Getenv("CF_TRACE_FORMAT"),
 		HTTPSProxy:       os.Getenv("https_proxy"),
 		Lang:             os.Getenv("LANG"),
 		LCAll:            os.Getenv("LC_ALL"),
 		Experimental:     os.Getenv("CF_CLI_EXPERIMENTAL"),
 		CFDialTimeout:    os.Getenv("CF_DIAL_TIMEOUT"),
+		CFRetryMax:       os.Getenv("CF_RETRY_MAX"),
+		CFRetryBaseDelay: os.Getenv("CF_RETRY_BASE_DELAY"),
+		CFRetryMaxDelay:  os.Getenv("CF_RETRY_MAX_DELAY"),
+		CFCacheTTL:       os.Getenv(httpcache.TTLEnvVar),
+		CFConcurrency:    os.Getenv("CF_CONCURRENCY"),
 	}
 
 	pluginFilePath := filepath.Join(config.PluginHome(), "config.json")
@@ -131,8 +158,35 @@ func LoadConfig(flags ...FlagOverride) (*Config, error) {
 // WriteConfig creates the .cf directory and then writes the config.json. The
 // location of .cf directory is written in the same way LoadConfig reads .cf
 // directory.
+//
+// When a credential store is configured, the access and refresh tokens are
+// saved to the store and left out of config.json. When a context was selected
+// for this command, its target information is saved to the context instead.
 func WriteConfig(c *Config) error {
-	rawConfig, err := json.MarshalIndent(c.ConfigFile, "", "  ")
+	configFile := c.ConfigFile
+	if c.selectedContext != "" {
+		var err error
+		configFile, err = c.saveSelectedContext()
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.credentialStore != nil {
+		err := c.saveCredentials(ConfigFilePath(), configFile)
+		if err != nil {
+			return err
+		}
+		configFile.AccessToken = ""
+		configFile.RefreshToken = ""
+		configFile.UAAOAuthClientSecret = ""
+	} else if configFile.UAAGrantType == clientCredentialsGrantType {
+		// A secret given to auth --client-credentials is only ever saved in a
+		// credential store.
+		configFile.UAAOAuthClientSecret = ""
+	}
+
+	rawConfig, err := json.MarshalIndent(configFile, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -158,6 +212,20 @@ type Config struct {
 	Flags FlagOverride
 
 	pluginConfig PluginsConfig
+
+	// credentialStore holds the tokens when CF_CREDENTIAL_STORE is set
+	credentialStore credentials.Store
+
+	// storedCredentials are the tokens last read from or written to the
+	// credentialStore
+	storedCredentials credentials.Credentials
+
+	// selectedContext is the context chosen with CF_CONTEXT for this command
+	selectedContext string
+
+	// unselectedContext holds the target information from config.json that
+	// the selectedContext replaced
+	unselectedContext contexts.Context
 }
 
 // CFConfig represents .cf/config.json
@@ -173,6 +241,7 @@ type CFConfig struct {
 	SSHOAuthClient           string        `json:"SSHOAuthClient"`
 	UAAOAuthClient           string        `json:"UAAOAuthClient"`
 	UAAOAuthClientSecret     string        `json:"UAAOAuthClientSecret"`
+	UAAGrantType             string        `json:"UAAGrantType"`
 	RefreshToken             string        `json:"RefreshToken"`
 	TargetedOrganization     Organization  `json:"OrganizationFields"`
 	TargetedSpace            Space         `json:"SpaceFields"`
@@ -184,6 +253,9 @@ type CFConfig struct {
 	PluginRepos              []PluginRepos `json:"PluginRepos"`
 	MinCLIVersion            string        `json:"MinCLIVersion"`
 	MinRecommendedCLIVersion string        `json:"MinRecommendedCLIVersion"`
+	CACertFile               string        `json:"CACertFile,omitempty"`
+	ClientCertFile           string        `json:"ClientCertFile,omitempty"`
+	ClientKeyFile            string        `json:"ClientKeyFile,omitempty"`
 }
 
 // Organization contains basic information about the targeted organization
@@ -222,11 +294,17 @@ type EnvOverride struct {
 	CFStagingTimeout string
 	CFStartupTimeout string
 	CFTrace          string
+	CFTraceFormat    string
 	HTTPSProxy       string
 	Lang             string
 	LCAll            string
 	Experimental     string
 	CFDialTimeout    string
+	CFRetryMax       string
+	CFRetryBaseDelay string
+	CFRetryMaxDelay  string
+	CFCacheTTL       string
+	CFConcurrency    string
 }
 
 // FlagOverride represents all the global flags passed to the CF CLI
@@ -261,6 +339,16 @@ func (config *Config) SkipSSLValidation() bool {
 	return config.ConfigFile.SkipSSLValidation
 }
 
+// TLSFiles returns the paths of the CA bundle and client certificate set with
+// 'cf config'. Use tlsconfig.Load to apply the environment overrides.
+func (config *Config) TLSFiles() tlsconfig.Files {
+	return tlsconfig.Files{
+		CACert:     config.ConfigFile.CACertFile,
+		ClientCert: config.ConfigFile.ClientCertFile,
+		ClientKey:  config.ConfigFile.ClientKeyFile,
+	}
+}
+
 // AccessToken returns the access token for making authenticated API calls
 func (config *Config) AccessToken() string {
 	return config.ConfigFile.AccessToken
@@ -281,6 +369,11 @@ func (config *Config) UAAOAuthClientSecret() string {
 	return config.ConfigFile.UAAOAuthClientSecret
 }
 
+// UAAGrantType returns the grant type the current tokens were obtained with
+func (config *Config) UAAGrantType() string {
+	return config.ConfigFile.UAAGrantType
+}
+
 // APIVersion returns the CC API Version
 func (config *Config) APIVersion() string {
 	return config.ConfigFile.APIVersion
@@ -398,6 +491,13 @@ func (config *Config) Verbose() (bool, []string) {
 	return verbose, filePath
 }
 
+// TraceFormat returns the format of the trace files returned by Verbose. This
+// is based off of the $CF_TRACE_FORMAT environment variable, which is either
+// empty for text or TraceFormatHAR.
+func (config *Config) TraceFormat() string {
+	return strings.ToLower(config.ENV.CFTraceFormat)
+}
+
 // DialTimeout returns the timeout to use when dialing. This is based off of:
 //   1. The $CF_DIAL_TIMEOUT environment variable if set
 //   2. Defaults to 5 seconds
@@ -412,6 +512,43 @@ func (config *Config) DialTimeout() time.Duration {
 	return DefaultDialTimeout
 }
 
+// RetryPolicy returns how failed API requests are retried. This is based off
+// of:
+//   1. The $CF_RETRY_MAX, $CF_RETRY_BASE_DELAY and $CF_RETRY_MAX_DELAY
+//      environment variables if set. Delays are durations such as "500ms" or
+//      a whole number of seconds.
+//   2. Defaults to retry.DefaultPolicy
+func (config *Config) RetryPolicy() retry.Policy {
+	return retry.ParsePolicy(config.ENV.CFRetryMax, config.ENV.CFRetryBaseDelay, config.ENV.CFRetryMaxDelay)
+}
+
+// Concurrency returns the number of API requests made at the same time by
+// bulk operations. This is based off of:
+//   1. The $CF_CONCURRENCY environment variable if set to a positive number
+//   2. Defaults to workpool.DefaultWorkers
+func (config *Config) Concurrency() int {
+	if config.ENV.CFConcurrency != "" {
+		workers, err := strconv.Atoi(config.ENV.CFConcurrency)
+		if err == nil && workers > 0 {
+			return workers
+		}
+	}
+
+	return workpool.DefaultWorkers
+}
+
+// CacheTTL returns how long API responses are reused before they are
+// revalidated. This is based off of the $CF_CACHE_TTL environment variable;
+// when it is unset or invalid the cache is disabled and 0 is returned.
+func (config *Config) CacheTTL() time.Duration {
+	return httpcache.ParseTTL(config.ENV.CFCacheTTL)
+}
+
+// CacheDirectory returns the directory API responses are cached in.
+func (config *Config) CacheDirectory() string {
+	return filepath.Join(homeDirectory(), ".cf", "cache")
+}
+
 func (config *Config) BinaryVersion() string {
 	return version.VersionString()
 }
@@ -473,6 +610,18 @@ func (config *Config) SetRefreshToken(refreshToken string) {
 	config.ConfigFile.RefreshToken = refreshToken
 }
 
+// SetUAAGrantType sets the grant type the current tokens were obtained with
+func (config *Config) SetUAAGrantType(uaaGrantType string) {
+	config.ConfigFile.UAAGrantType = uaaGrantType
+}
+
+// SetUAAClientCredentials sets the client ID and secret the CLI uses when
+// talking to the UAA
+func (config *Config) SetUAAClientCredentials(client string, clientSecret string) {
+	config.ConfigFile.UAAOAuthClient = client
+	config.ConfigFile.UAAOAuthClientSecret = clientSecret
+}
+
 // UnsetSpaceInformation resets the space values to default
 func (config *Config) UnsetSpaceInformation() {
 	config.SetSpaceInformation("", "", false)
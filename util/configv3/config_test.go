@@ -8,7 +8,10 @@ import (
 	"path/filepath"
 	"time"
 
+	"code.cloudfoundry.org/cli/api/retry"
 	. "code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/tlsconfig"
+	"code.cloudfoundry.org/cli/util/workpool"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -204,6 +207,20 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Describe("TLSFiles", func() {
+			It("returns the configured CA bundle and client certificate", func() {
+				setConfig(homeDir, `{ "CACertFile": "ca.pem", "ClientCertFile": "cert.pem", "ClientKeyFile": "key.pem" }`)
+
+				config, err := LoadConfig()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config.TLSFiles()).To(Equal(tlsconfig.Files{
+					CACert:     "ca.pem",
+					ClientCert: "cert.pem",
+					ClientKey:  "key.pem",
+				}))
+			})
+		})
+
 		Describe("SkipSSLValidation", func() {
 			var config *Config
 
@@ -498,6 +515,163 @@ var _ = Describe("Config", func() {
 			})
 		})
 
+		Describe("TraceFormat", func() {
+			var originalTraceFormat string
+
+			BeforeEach(func() {
+				originalTraceFormat = os.Getenv("CF_TRACE_FORMAT")
+				os.Setenv("CF_TRACE_FORMAT", "HAR")
+			})
+
+			AfterEach(func() {
+				os.Setenv("CF_TRACE_FORMAT", originalTraceFormat)
+			})
+
+			It("returns the lower cased trace format", func() {
+				config, err := LoadConfig()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config.TraceFormat()).To(Equal(TraceFormatHAR))
+			})
+		})
+
+		Describe("RetryPolicy", func() {
+			var (
+				originalRetryMax       string
+				originalRetryBaseDelay string
+				originalRetryMaxDelay  string
+			)
+
+			BeforeEach(func() {
+				originalRetryMax = os.Getenv("CF_RETRY_MAX")
+				originalRetryBaseDelay = os.Getenv("CF_RETRY_BASE_DELAY")
+				originalRetryMaxDelay = os.Getenv("CF_RETRY_MAX_DELAY")
+			})
+
+			AfterEach(func() {
+				os.Setenv("CF_RETRY_MAX", originalRetryMax)
+				os.Setenv("CF_RETRY_BASE_DELAY", originalRetryBaseDelay)
+				os.Setenv("CF_RETRY_MAX_DELAY", originalRetryMaxDelay)
+			})
+
+			Context("when no CF_RETRY_* variables are set", func() {
+				BeforeEach(func() {
+					os.Unsetenv("CF_RETRY_MAX")
+					os.Unsetenv("CF_RETRY_BASE_DELAY")
+					os.Unsetenv("CF_RETRY_MAX_DELAY")
+				})
+
+				It("returns the default policy", func() {
+					config, err := LoadConfig()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(config.RetryPolicy()).To(Equal(retry.DefaultPolicy()))
+				})
+			})
+
+			Context("when the CF_RETRY_* variables are set", func() {
+				BeforeEach(func() {
+					os.Setenv("CF_RETRY_MAX", "5")
+					os.Setenv("CF_RETRY_BASE_DELAY", "250ms")
+					os.Setenv("CF_RETRY_MAX_DELAY", "60")
+				})
+
+				It("overrides the default policy", func() {
+					config, err := LoadConfig()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(config.RetryPolicy()).To(Equal(retry.Policy{
+						MaxRetries: 5,
+						BaseDelay:  250 * time.Millisecond,
+						MaxDelay:   time.Minute,
+					}))
+				})
+			})
+
+			Context("when the CF_RETRY_* variables are invalid", func() {
+				BeforeEach(func() {
+					os.Setenv("CF_RETRY_MAX", "-1")
+					os.Setenv("CF_RETRY_BASE_DELAY", "soon")
+					os.Setenv("CF_RETRY_MAX_DELAY", "-5s")
+				})
+
+				It("ignores them", func() {
+					config, err := LoadConfig()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(config.RetryPolicy()).To(Equal(retry.DefaultPolicy()))
+				})
+			})
+		})
+
+		Describe("Concurrency", func() {
+			var originalConcurrency string
+
+			BeforeEach(func() {
+				originalConcurrency = os.Getenv("CF_CONCURRENCY")
+			})
+
+			AfterEach(func() {
+				os.Setenv("CF_CONCURRENCY", originalConcurrency)
+			})
+
+			Context("when CF_CONCURRENCY is set", func() {
+				BeforeEach(func() {
+					os.Setenv("CF_CONCURRENCY", "10")
+				})
+
+				It("returns its value", func() {
+					config, err := LoadConfig()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(config.Concurrency()).To(Equal(10))
+				})
+			})
+
+			Context("when CF_CONCURRENCY is invalid", func() {
+				BeforeEach(func() {
+					os.Setenv("CF_CONCURRENCY", "0")
+				})
+
+				It("returns the default", func() {
+					config, err := LoadConfig()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(config.Concurrency()).To(Equal(workpool.DefaultWorkers))
+				})
+			})
+		})
+
+		Describe("CacheTTL", func() {
+			var originalCacheTTL string
+
+			BeforeEach(func() {
+				originalCacheTTL = os.Getenv("CF_CACHE_TTL")
+			})
+
+			AfterEach(func() {
+				os.Setenv("CF_CACHE_TTL", originalCacheTTL)
+			})
+
+			Context("when CF_CACHE_TTL is not set", func() {
+				BeforeEach(func() {
+					os.Setenv("CF_CACHE_TTL", "")
+				})
+
+				It("disables the cache", func() {
+					config, err := LoadConfig()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(config.CacheTTL()).To(BeZero())
+				})
+			})
+
+			Context("when CF_CACHE_TTL is set", func() {
+				BeforeEach(func() {
+					os.Setenv("CF_CACHE_TTL", "60")
+				})
+
+				It("returns the TTL in seconds", func() {
+					config, err := LoadConfig()
+					Expect(err).ToNot(HaveOccurred())
+					Expect(config.CacheTTL()).To(Equal(time.Minute))
+				})
+			})
+		})
+
 		Describe("BinaryVersion", func() {
 			It("returns back version.BinaryVersion", func() {
 				conf := Config{}
@@ -538,6 +712,89 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	Describe("credential store", func() {
+		BeforeEach(func() {
+			os.Setenv("CF_CREDENTIAL_STORE", "encrypted-file")
+			os.Setenv("CF_CREDENTIAL_PASSPHRASE", "open sesame")
+
+			setConfig(homeDir, `{
+				"ConfigVersion": 3,
+				"AccessToken": "bearer plaintext-token",
+				"RefreshToken": "plaintext-refresh-token"
+			}`)
+		})
+
+		AfterEach(func() {
+			os.Unsetenv("CF_CREDENTIAL_STORE")
+			os.Unsetenv("CF_CREDENTIAL_PASSPHRASE")
+		})
+
+		It("moves the tokens out of config.json and into the store", func() {
+			config, err := LoadConfig()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.AccessToken()).To(Equal("bearer plaintext-token"))
+
+			Expect(WriteConfig(config)).To(Succeed())
+
+			file, err := ioutil.ReadFile(filepath.Join(homeDir, ".cf", "config.json"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(file)).ToNot(ContainSubstring("plaintext"))
+			Expect(filepath.Join(homeDir, ".cf", "credentials.enc")).To(BeARegularFile())
+
+			config, err = LoadConfig()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.AccessToken()).To(Equal("bearer plaintext-token"))
+			Expect(config.RefreshToken()).To(Equal("plaintext-refresh-token"))
+		})
+
+		It("keeps the client secret in the store", func() {
+			config, err := LoadConfig()
+			Expect(err).ToNot(HaveOccurred())
+			config.SetUAAClientCredentials("ci-client", "ci-secret")
+			config.SetUAAGrantType("client_credentials")
+			Expect(WriteConfig(config)).To(Succeed())
+
+			file, err := ioutil.ReadFile(filepath.Join(homeDir, ".cf", "config.json"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(file)).ToNot(ContainSubstring("ci-secret"))
+
+			config, err = LoadConfig()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.UAAOAuthClientSecret()).To(Equal("ci-secret"))
+		})
+
+		Context("when no store is selected", func() {
+			BeforeEach(func() {
+				os.Unsetenv("CF_CREDENTIAL_STORE")
+			})
+
+			It("does not save a client credentials secret", func() {
+				config, err := LoadConfig()
+				Expect(err).ToNot(HaveOccurred())
+				config.SetUAAClientCredentials("ci-client", "ci-secret")
+				config.SetUAAGrantType("client_credentials")
+				Expect(WriteConfig(config)).To(Succeed())
+
+				file, err := ioutil.ReadFile(filepath.Join(homeDir, ".cf", "config.json"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(file)).To(ContainSubstring("ci-client"))
+				Expect(string(file)).ToNot(ContainSubstring("ci-secret"))
+				Expect(config.UAAOAuthClientSecret()).To(Equal("ci-secret"))
+			})
+		})
+
+		Context("when the store cannot be set up", func() {
+			BeforeEach(func() {
+				os.Unsetenv("CF_CREDENTIAL_PASSPHRASE")
+			})
+
+			It("returns an error", func() {
+				_, err := LoadConfig()
+				Expect(err).To(MatchError(ContainSubstring("CF_CREDENTIAL_PASSPHRASE is not set")))
+			})
+		})
+	})
+
 	Describe("setter functions", func() {
 		Describe("SetTargetInformation", func() {
 			It("sets the api target and other related endpoints", func() {
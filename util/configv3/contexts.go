@@ -0,0 +1,192 @@
+package configv3
+
+import (
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/util/contexts"
+)
+
+// ContextAlreadyExistsError is returned when adding a context with a name
+// that is already in use.
+type ContextAlreadyExistsError struct {
+	Name string
+}
+
+func (e ContextAlreadyExistsError) Error() string {
+	return "Context '" + e.Name + "' already exists."
+}
+
+// Contexts returns the saved contexts and the name of the one in use.
+func (config *Config) Contexts() ([]contexts.Context, string, error) {
+	store, err := config.loadContextStore()
+	if err != nil {
+		return nil, "", err
+	}
+	return store.List(), store.Current(), nil
+}
+
+// AddContext saves the current target information under name.
+func (config *Config) AddContext(name string) error {
+	store, err := config.loadContextStore()
+	if err != nil {
+		return err
+	}
+
+	if _, err = store.Get(name); err == nil {
+		return ContextAlreadyExistsError{Name: name}
+	}
+
+	store.Set(name, contextFromConfigFile(config.ConfigFile))
+	return store.Save()
+}
+
+// UseContext replaces the current target information with the named
+// context. The current target information is first saved to the context
+// in use, so tokens refreshed since switching to it are kept.
+func (config *Config) UseContext(name string) error {
+	store, err := config.loadContextStore()
+	if err != nil {
+		return err
+	}
+
+	if config.selectedContext != "" {
+		store.Set(config.selectedContext, contextFromConfigFile(config.ConfigFile))
+		applyContext(&config.ConfigFile, config.unselectedContext)
+		config.selectedContext = ""
+	}
+
+	if current := store.Current(); current != "" {
+		if _, err = store.Get(current); err == nil {
+			store.Set(current, contextFromConfigFile(config.ConfigFile))
+		}
+	}
+
+	context, err := store.Get(name)
+	if err != nil {
+		return err
+	}
+
+	applyContext(&config.ConfigFile, context)
+	store.SetCurrent(name)
+	return store.Save()
+}
+
+// DeleteContext removes the named context. The current target information is
+// left unchanged.
+func (config *Config) DeleteContext(name string) error {
+	store, err := config.loadContextStore()
+	if err != nil {
+		return err
+	}
+
+	err = store.Delete(name)
+	if err != nil {
+		return err
+	}
+	return store.Save()
+}
+
+// loadSelectedContext replaces the target information from config.json with
+// the context selected with CF_CONTEXT for the duration of the command.
+func (config *Config) loadSelectedContext() error {
+	name := contexts.Selected()
+	if name == "" {
+		return nil
+	}
+
+	store, err := config.loadContextStore()
+	if err != nil {
+		return err
+	}
+
+	if store.Current() == name {
+		return nil
+	}
+
+	context, err := store.Get(name)
+	if err != nil {
+		return err
+	}
+
+	config.selectedContext = name
+	config.unselectedContext = contextFromConfigFile(config.ConfigFile)
+	applyContext(&config.ConfigFile, context)
+	return nil
+}
+
+// saveSelectedContext saves the current target information to the selected
+// context and returns the config file with its own target information
+// restored.
+func (config *Config) saveSelectedContext() (CFConfig, error) {
+	store, err := config.loadContextStore()
+	if err != nil {
+		return CFConfig{}, err
+	}
+
+	store.Set(config.selectedContext, contextFromConfigFile(config.ConfigFile))
+	err = store.Save()
+	if err != nil {
+		return CFConfig{}, err
+	}
+
+	configFile := config.ConfigFile
+	applyContext(&configFile, config.unselectedContext)
+	return configFile, nil
+}
+
+func (config *Config) loadContextStore() (*contexts.Store, error) {
+	store := contexts.NewStore(contexts.FilePath(filepath.Join(homeDirectory(), ".cf")), config.credentialStore)
+	return store, store.Load()
+}
+
+func contextFromConfigFile(configFile CFConfig) contexts.Context {
+	return contexts.Context{
+		Target:                   configFile.Target,
+		APIVersion:               configFile.APIVersion,
+		AuthorizationEndpoint:    configFile.AuthorizationEndpoint,
+		DopplerEndpoint:          configFile.DopplerEndpoint,
+		UAAEndpoint:              configFile.UAAEndpoint,
+		RoutingEndpoint:          configFile.RoutingEndpoint,
+		SkipSSLValidation:        configFile.SkipSSLValidation,
+		MinCLIVersion:            configFile.MinCLIVersion,
+		MinRecommendedCLIVersion: configFile.MinRecommendedCLIVersion,
+		AccessToken:              configFile.AccessToken,
+		RefreshToken:             configFile.RefreshToken,
+		SSHOAuthClient:           configFile.SSHOAuthClient,
+		UAAOAuthClient:           configFile.UAAOAuthClient,
+		UAAOAuthClientSecret:     configFile.UAAOAuthClientSecret,
+		UAAGrantType:             configFile.UAAGrantType,
+		OrganizationGUID:         configFile.TargetedOrganization.GUID,
+		OrganizationName:         configFile.TargetedOrganization.Name,
+		SpaceGUID:                configFile.TargetedSpace.GUID,
+		SpaceName:                configFile.TargetedSpace.Name,
+		SpaceAllowSSH:            configFile.TargetedSpace.AllowSSH,
+	}
+}
+
+func applyContext(configFile *CFConfig, context contexts.Context) {
+	configFile.Target = context.Target
+	configFile.APIVersion = context.APIVersion
+	configFile.AuthorizationEndpoint = context.AuthorizationEndpoint
+	configFile.DopplerEndpoint = context.DopplerEndpoint
+	configFile.UAAEndpoint = context.UAAEndpoint
+	configFile.RoutingEndpoint = context.RoutingEndpoint
+	configFile.SkipSSLValidation = context.SkipSSLValidation
+	configFile.MinCLIVersion = context.MinCLIVersion
+	configFile.MinRecommendedCLIVersion = context.MinRecommendedCLIVersion
+	configFile.AccessToken = context.AccessToken
+	configFile.RefreshToken = context.RefreshToken
+	configFile.SSHOAuthClient = context.SSHOAuthClient
+	configFile.UAAOAuthClient = context.UAAOAuthClient
+	configFile.UAAOAuthClientSecret = context.UAAOAuthClientSecret
+	configFile.UAAGrantType = context.UAAGrantType
+	configFile.TargetedOrganization = Organization{
+		GUID: context.OrganizationGUID,
+		Name: context.OrganizationName,
+	}
+	configFile.TargetedSpace = Space{
+		GUID:     context.SpaceGUID,
+		Name:     context.SpaceName,
+		AllowSSH: context.SpaceAllowSSH,
+	}
+}
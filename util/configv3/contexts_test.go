@@ -0,0 +1,147 @@
+package configv3_test
+
+import (
+	"os"
+
+	. "code.cloudfoundry.org/cli/util/configv3"
+	"code.cloudfoundry.org/cli/util/contexts"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Contexts", func() {
+	var homeDir string
+
+	BeforeEach(func() {
+		homeDir = setup()
+		setConfig(homeDir, `{
+			"Target": "https://api.dev.example.com",
+			"AccessToken": "bearer dev",
+			"OrganizationFields": {"GUID": "dev-org-guid", "Name": "dev-org"},
+			"SpaceFields": {"GUID": "dev-space-guid", "Name": "dev-space"}
+		}`)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv(contexts.EnvVar)
+		teardown(homeDir)
+	})
+
+	loadConfig := func() *Config {
+		config, err := LoadConfig()
+		Expect(err).ToNot(HaveOccurred())
+		return config
+	}
+
+	addProdContext := func() {
+		config := loadConfig()
+		config.SetTargetInformation("https://api.prod.example.com", "2.75.0", "", "", "", "", "", false)
+		config.SetAccessToken("bearer prod")
+		config.SetOrganizationInformation("prod-org-guid", "prod-org")
+		Expect(config.AddContext("prod")).To(Succeed())
+	}
+
+	Describe("AddContext", func() {
+		It("saves the current target information under the name", func() {
+			Expect(loadConfig().AddContext("dev")).To(Succeed())
+
+			saved, current, err := loadConfig().Contexts()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(current).To(BeEmpty())
+			Expect(saved).To(HaveLen(1))
+			Expect(saved[0].Name).To(Equal("dev"))
+			Expect(saved[0].Target).To(Equal("https://api.dev.example.com"))
+			Expect(saved[0].AccessToken).To(Equal("bearer dev"))
+			Expect(saved[0].SpaceName).To(Equal("dev-space"))
+		})
+
+		It("returns an error when the name is in use", func() {
+			Expect(loadConfig().AddContext("dev")).To(Succeed())
+			Expect(loadConfig().AddContext("dev")).To(MatchError(ContextAlreadyExistsError{Name: "dev"}))
+		})
+	})
+
+	Describe("UseContext", func() {
+		BeforeEach(func() {
+			Expect(loadConfig().AddContext("dev")).To(Succeed())
+			addProdContext()
+		})
+
+		It("replaces the target information and records the context in use", func() {
+			config := loadConfig()
+			Expect(config.UseContext("prod")).To(Succeed())
+			Expect(WriteConfig(config)).To(Succeed())
+
+			config = loadConfig()
+			Expect(config.Target()).To(Equal("https://api.prod.example.com"))
+			Expect(config.AccessToken()).To(Equal("bearer prod"))
+			Expect(config.TargetedOrganization().Name).To(Equal("prod-org"))
+			Expect(config.HasTargetedSpace()).To(BeFalse())
+
+			_, current, err := config.Contexts()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(current).To(Equal("prod"))
+		})
+
+		It("keeps changes made while a context was in use", func() {
+			config := loadConfig()
+			Expect(config.UseContext("prod")).To(Succeed())
+			config.SetAccessToken("bearer refreshed")
+			Expect(config.UseContext("dev")).To(Succeed())
+
+			saved, _, err := config.Contexts()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved[1].Name).To(Equal("prod"))
+			Expect(saved[1].AccessToken).To(Equal("bearer refreshed"))
+		})
+
+		It("returns an error for an unknown context", func() {
+			Expect(loadConfig().UseContext("missing")).To(MatchError(contexts.NotFoundError{Name: "missing"}))
+		})
+	})
+
+	Describe("DeleteContext", func() {
+		It("removes the context without changing the target", func() {
+			addProdContext()
+
+			config := loadConfig()
+			Expect(config.DeleteContext("prod")).To(Succeed())
+
+			saved, _, err := config.Contexts()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved).To(BeEmpty())
+			Expect(config.Target()).To(Equal("https://api.dev.example.com"))
+		})
+	})
+
+	Context("when a context is selected with CF_CONTEXT", func() {
+		BeforeEach(func() {
+			addProdContext()
+			os.Setenv(contexts.EnvVar, "prod")
+		})
+
+		It("uses the selected context for the command only", func() {
+			config := loadConfig()
+			Expect(config.Target()).To(Equal("https://api.prod.example.com"))
+
+			config.SetAccessToken("bearer refreshed")
+			Expect(WriteConfig(config)).To(Succeed())
+
+			os.Unsetenv(contexts.EnvVar)
+			config = loadConfig()
+			Expect(config.Target()).To(Equal("https://api.dev.example.com"))
+			Expect(config.AccessToken()).To(Equal("bearer dev"))
+
+			saved, _, err := config.Contexts()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(saved[0].AccessToken).To(Equal("bearer refreshed"))
+		})
+
+		It("returns an error when the context does not exist", func() {
+			os.Setenv(contexts.EnvVar, "missing")
+			_, err := LoadConfig()
+			Expect(err).To(MatchError(contexts.NotFoundError{Name: "missing"}))
+		})
+	})
+})
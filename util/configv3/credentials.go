@@ -0,0 +1,55 @@
+package configv3
+
+import (
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/util/credentials"
+)
+
+// clientCredentialsGrantType is the UAAGrantType of tokens obtained with
+// auth --client-credentials.
+const clientCredentialsGrantType = "client_credentials"
+
+// loadCredentials sets up the credential store selected by
+// CF_CREDENTIAL_STORE and reads the tokens and client secret from it. Values
+// still present in config.json are kept when the store has none, so they are
+// moved into the store on the next write.
+func (config *Config) loadCredentials(filePath string) error {
+	store, err := credentials.NewStoreFromEnv(filepath.Dir(filePath))
+	if err != nil || store == nil {
+		return err
+	}
+
+	creds, err := store.Get(filePath)
+	if err != nil {
+		return err
+	}
+
+	config.credentialStore = store
+	config.storedCredentials = creds
+	if !creds.IsEmpty() {
+		config.ConfigFile.AccessToken = creds.AccessToken
+		config.ConfigFile.RefreshToken = creds.RefreshToken
+	}
+	if creds.ClientSecret != "" {
+		config.ConfigFile.UAAOAuthClientSecret = creds.ClientSecret
+	}
+	return nil
+}
+
+// saveCredentials writes the tokens and client secret in configFile to the
+// credential store if they have changed since they were loaded.
+func (config *Config) saveCredentials(filePath string, configFile CFConfig) error {
+	creds := credentials.Credentials{
+		AccessToken:  configFile.AccessToken,
+		RefreshToken: configFile.RefreshToken,
+		ClientSecret: configFile.UAAOAuthClientSecret,
+	}
+	err := credentials.Save(config.credentialStore, filePath, creds, config.storedCredentials)
+	if err != nil {
+		return err
+	}
+
+	config.storedCredentials = creds
+	return nil
+}
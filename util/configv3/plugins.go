@@ -58,6 +58,18 @@ type PluginCommand struct {
 type PluginUsageDetails struct {
 	Usage   string            `json:"Usage"`
 	Options map[string]string `json:"Options"`
+	Flags   []PluginFlag      `json:"Flags"`
+}
+
+// PluginFlag is a flag of a plugin command, as declared by the plugin
+type PluginFlag struct {
+	Name      string   `json:"Name"`
+	ShortName string   `json:"ShortName"`
+	Type      string   `json:"Type"`
+	Default   string   `json:"Default"`
+	Usage     string   `json:"Usage"`
+	Values    []string `json:"Values"`
+	Complete  bool     `json:"Complete"`
 }
 
 // PluginHome returns the plugin configuration directory based off:
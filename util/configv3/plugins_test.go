@@ -56,7 +56,18 @@ var _ = Describe("Config", func() {
           "HelpText": "disable Diego support for an app",
           "UsageDetails": {
             "Usage": "cf disable-diego APP_NAME",
-            "Options": null
+            "Options": null,
+            "Flags": [
+              {
+                "Name": "force",
+                "ShortName": "f",
+                "Type": "bool",
+                "Default": "",
+                "Usage": "Disable without confirmation",
+                "Values": null,
+                "Complete": false
+              }
+            ]
           }
         }
 			]
@@ -85,6 +96,19 @@ var _ = Describe("Config", func() {
 					},
 				},
 			))
+			Expect(plugin.Commands).To(ContainElement(
+				PluginCommand{
+					Name:     "disable-diego",
+					Alias:    "",
+					HelpText: "disable Diego support for an app",
+					UsageDetails: PluginUsageDetails{
+						Usage: "cf disable-diego APP_NAME",
+						Flags: []PluginFlag{
+							{Name: "force", ShortName: "f", Type: "bool", Usage: "Disable without confirmation"},
+						},
+					},
+				},
+			))
 		},
 
 		Entry("standard location", func() (string, string) {
@@ -0,0 +1,245 @@
+// Package contexts stores named sets of target information (API endpoint,
+// tokens, org and space) so users can switch between foundations without
+// copying CF_HOME directories around.
+package contexts
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"code.cloudfoundry.org/cli/util/credentials"
+)
+
+// EnvVar selects a context for a single command, like the --context flag.
+const EnvVar = "CF_CONTEXT"
+
+// clientCredentialsGrantType is the UAAGrantType of tokens obtained with
+// auth --client-credentials.
+const clientCredentialsGrantType = "client_credentials"
+
+// Context is the target information saved under a name.
+type Context struct {
+	Name                     string `json:"-"`
+	Target                   string `json:"Target"`
+	APIVersion               string `json:"APIVersion"`
+	AuthorizationEndpoint    string `json:"AuthorizationEndpoint"`
+	DopplerEndpoint          string `json:"DopplerEndPoint"`
+	UAAEndpoint              string `json:"UaaEndpoint"`
+	RoutingEndpoint          string `json:"RoutingAPIEndpoint"`
+	SkipSSLValidation        bool   `json:"SSLDisabled"`
+	MinCLIVersion            string `json:"MinCLIVersion"`
+	MinRecommendedCLIVersion string `json:"MinRecommendedCLIVersion"`
+	AccessToken              string `json:"AccessToken"`
+	RefreshToken             string `json:"RefreshToken"`
+	SSHOAuthClient           string `json:"SSHOAuthClient"`
+	UAAOAuthClient           string `json:"UAAOAuthClient"`
+	UAAOAuthClientSecret     string `json:"UAAOAuthClientSecret"`
+	UAAGrantType             string `json:"UAAGrantType"`
+	OrganizationGUID         string `json:"OrganizationGUID"`
+	OrganizationName         string `json:"OrganizationName"`
+	SpaceGUID                string `json:"SpaceGUID"`
+	SpaceName                string `json:"SpaceName"`
+	SpaceAllowSSH            bool   `json:"SpaceAllowSSH"`
+}
+
+// NotFoundError is returned when a context does not exist.
+type NotFoundError struct {
+	Name string
+}
+
+func (e NotFoundError) Error() string {
+	return "Context '" + e.Name + "' not found."
+}
+
+// Selected returns the name of the context selected with CF_CONTEXT, if any.
+func Selected() string {
+	return os.Getenv(EnvVar)
+}
+
+// FilePath returns the path of the contexts file in the given .cf directory.
+func FilePath(configDir string) string {
+	return filepath.Join(configDir, "contexts.json")
+}
+
+// Store reads and writes the contexts file. When a credential store is
+// given, tokens and client secrets are kept there instead of in the file.
+type Store struct {
+	path            string
+	credentialStore credentials.Store
+
+	file   contextsFile
+	stored map[string]credentials.Credentials
+}
+
+type contextsFile struct {
+	Current  string             `json:"Current"`
+	Contexts map[string]Context `json:"Contexts"`
+}
+
+func NewStore(path string, credentialStore credentials.Store) *Store {
+	return &Store{
+		path:            path,
+		credentialStore: credentialStore,
+		file:            contextsFile{Contexts: map[string]Context{}},
+		stored:          map[string]credentials.Credentials{},
+	}
+}
+
+// Load reads the contexts file. A missing file is the same as an empty one.
+func (store *Store) Load() error {
+	raw, err := ioutil.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(raw, &store.file)
+	if err != nil {
+		return err
+	}
+	if store.file.Contexts == nil {
+		store.file.Contexts = map[string]Context{}
+	}
+
+	if store.credentialStore == nil {
+		return nil
+	}
+
+	for name, context := range store.file.Contexts {
+		creds, err := store.credentialStore.Get(store.credentialsKey(name))
+		if err != nil {
+			return err
+		}
+		store.stored[name] = creds
+		if !creds.IsEmpty() {
+			context.AccessToken = creds.AccessToken
+			context.RefreshToken = creds.RefreshToken
+		}
+		if creds.ClientSecret != "" {
+			context.UAAOAuthClientSecret = creds.ClientSecret
+		}
+		store.file.Contexts[name] = context
+	}
+	return nil
+}
+
+// Save writes the contexts file, readable only by the current user.
+func (store *Store) Save() error {
+	file := contextsFile{
+		Current:  store.file.Current,
+		Contexts: make(map[string]Context, len(store.file.Contexts)),
+	}
+
+	for name, context := range store.file.Contexts {
+		if store.credentialStore != nil {
+			err := store.saveCredentials(name, context)
+			if err != nil {
+				return err
+			}
+			context.AccessToken = ""
+			context.RefreshToken = ""
+			context.UAAOAuthClientSecret = ""
+		} else if context.UAAGrantType == clientCredentialsGrantType {
+			// A secret given to auth --client-credentials is only ever saved in
+			// a credential store.
+			context.UAAOAuthClientSecret = ""
+		}
+		file.Contexts[name] = context
+	}
+
+	raw, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(store.path), 0700)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(store.path, raw, 0600)
+}
+
+// Get returns the named context.
+func (store *Store) Get(name string) (Context, error) {
+	context, ok := store.file.Contexts[name]
+	if !ok {
+		return Context{}, NotFoundError{Name: name}
+	}
+	context.Name = name
+	return context, nil
+}
+
+// Set adds or replaces the named context.
+func (store *Store) Set(name string, context Context) {
+	context.Name = ""
+	store.file.Contexts[name] = context
+}
+
+// Delete removes the named context. Deleting the current context unsets it.
+func (store *Store) Delete(name string) error {
+	if _, ok := store.file.Contexts[name]; !ok {
+		return NotFoundError{Name: name}
+	}
+
+	delete(store.file.Contexts, name)
+	if store.file.Current == name {
+		store.file.Current = ""
+	}
+
+	if store.credentialStore != nil {
+		delete(store.stored, name)
+		return store.credentialStore.Erase(store.credentialsKey(name))
+	}
+	return nil
+}
+
+// List returns all contexts sorted by name.
+func (store *Store) List() []Context {
+	names := make([]string, 0, len(store.file.Contexts))
+	for name := range store.file.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]Context, 0, len(names))
+	for _, name := range names {
+		context, _ := store.Get(name)
+		list = append(list, context)
+	}
+	return list
+}
+
+// Current returns the name of the context last switched to with Use.
+func (store *Store) Current() string {
+	return store.file.Current
+}
+
+// SetCurrent records the name of the context in use.
+func (store *Store) SetCurrent(name string) {
+	store.file.Current = name
+}
+
+func (store *Store) saveCredentials(name string, context Context) error {
+	creds := credentials.Credentials{
+		AccessToken:  context.AccessToken,
+		RefreshToken: context.RefreshToken,
+		ClientSecret: context.UAAOAuthClientSecret,
+	}
+	err := credentials.Save(store.credentialStore, store.credentialsKey(name), creds, store.stored[name])
+	if err != nil {
+		return err
+	}
+
+	store.stored[name] = creds
+	return nil
+}
+
+func (store *Store) credentialsKey(name string) string {
+	return store.path + "#" + name
+}
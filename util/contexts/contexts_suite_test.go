@@ -0,0 +1,13 @@
+package contexts_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestContexts(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Contexts Suite")
+}
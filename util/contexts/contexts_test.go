@@ -0,0 +1,156 @@
+package contexts_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/cli/util/contexts"
+	"code.cloudfoundry.org/cli/util/credentials"
+	"code.cloudfoundry.org/cli/util/credentials/credentialsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Store", func() {
+	var (
+		dir   string
+		path  string
+		store *contexts.Store
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "contexts")
+		Expect(err).NotTo(HaveOccurred())
+		path = contexts.FilePath(filepath.Join(dir, ".cf"))
+		store = contexts.NewStore(path, nil)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("loads nothing when the file does not exist", func() {
+		Expect(store.Load()).To(Succeed())
+		Expect(store.List()).To(BeEmpty())
+		Expect(store.Current()).To(BeEmpty())
+	})
+
+	It("round trips contexts and the current context name", func() {
+		store.Set("prod", contexts.Context{Target: "https://api.prod.example.com", AccessToken: "bearer prod"})
+		store.Set("dev", contexts.Context{Target: "https://api.dev.example.com", SpaceName: "some-space"})
+		store.SetCurrent("prod")
+		Expect(store.Save()).To(Succeed())
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+
+		loaded := contexts.NewStore(path, nil)
+		Expect(loaded.Load()).To(Succeed())
+		Expect(loaded.Current()).To(Equal("prod"))
+		Expect(loaded.List()).To(Equal([]contexts.Context{
+			{Name: "dev", Target: "https://api.dev.example.com", SpaceName: "some-space"},
+			{Name: "prod", Target: "https://api.prod.example.com", AccessToken: "bearer prod"},
+		}))
+	})
+
+	It("does not save client credentials secrets without a credential store", func() {
+		store.Set("ci", contexts.Context{UAAOAuthClient: "ci-client", UAAOAuthClientSecret: "ci-secret", UAAGrantType: "client_credentials"})
+		Expect(store.Save()).To(Succeed())
+
+		raw, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(raw)).To(ContainSubstring("ci-client"))
+		Expect(string(raw)).NotTo(ContainSubstring("ci-secret"))
+	})
+
+	Describe("Get", func() {
+		It("returns a NotFoundError for an unknown context", func() {
+			_, err := store.Get("missing")
+			Expect(err).To(MatchError(contexts.NotFoundError{Name: "missing"}))
+		})
+	})
+
+	Describe("Delete", func() {
+		BeforeEach(func() {
+			store.Set("prod", contexts.Context{Target: "https://api.prod.example.com"})
+			store.SetCurrent("prod")
+		})
+
+		It("removes the context and unsets it as current", func() {
+			Expect(store.Delete("prod")).To(Succeed())
+			Expect(store.List()).To(BeEmpty())
+			Expect(store.Current()).To(BeEmpty())
+		})
+
+		It("returns a NotFoundError for an unknown context", func() {
+			Expect(store.Delete("missing")).To(MatchError(contexts.NotFoundError{Name: "missing"}))
+		})
+	})
+
+	Context("when a credential store is given", func() {
+		var fakeCredentialStore *credentialsfakes.FakeStore
+
+		BeforeEach(func() {
+			fakeCredentialStore = new(credentialsfakes.FakeStore)
+			store = contexts.NewStore(path, fakeCredentialStore)
+		})
+
+		It("keeps tokens out of the contexts file", func() {
+			store.Set("prod", contexts.Context{Target: "https://api.prod.example.com", AccessToken: "bearer prod", RefreshToken: "refresh-prod"})
+			Expect(store.Save()).To(Succeed())
+
+			Expect(fakeCredentialStore.SetCallCount()).To(Equal(1))
+			key, creds := fakeCredentialStore.SetArgsForCall(0)
+			Expect(key).To(Equal(path + "#prod"))
+			Expect(creds).To(Equal(credentials.Credentials{AccessToken: "bearer prod", RefreshToken: "refresh-prod"}))
+
+			raw, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(raw)).NotTo(ContainSubstring("refresh-prod"))
+		})
+
+		It("keeps the client secret out of the contexts file", func() {
+			store.Set("ci", contexts.Context{UAAOAuthClient: "ci-client", UAAOAuthClientSecret: "ci-secret"})
+			Expect(store.Save()).To(Succeed())
+
+			_, creds := fakeCredentialStore.SetArgsForCall(0)
+			Expect(creds).To(Equal(credentials.Credentials{ClientSecret: "ci-secret"}))
+
+			raw, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(raw)).NotTo(ContainSubstring("ci-secret"))
+
+			fakeCredentialStore.GetReturns(creds, nil)
+			loaded := contexts.NewStore(path, fakeCredentialStore)
+			Expect(loaded.Load()).To(Succeed())
+
+			context, err := loaded.Get("ci")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(context.UAAOAuthClientSecret).To(Equal("ci-secret"))
+		})
+
+		It("reads tokens back from the credential store", func() {
+			store.Set("prod", contexts.Context{Target: "https://api.prod.example.com"})
+			Expect(store.Save()).To(Succeed())
+
+			fakeCredentialStore.GetReturns(credentials.Credentials{AccessToken: "bearer prod"}, nil)
+			loaded := contexts.NewStore(path, fakeCredentialStore)
+			Expect(loaded.Load()).To(Succeed())
+
+			context, err := loaded.Get("prod")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(context.AccessToken).To(Equal("bearer prod"))
+			Expect(fakeCredentialStore.GetArgsForCall(0)).To(Equal(path + "#prod"))
+		})
+
+		It("erases the tokens of deleted contexts", func() {
+			store.Set("prod", contexts.Context{Target: "https://api.prod.example.com"})
+			Expect(store.Delete("prod")).To(Succeed())
+			Expect(fakeCredentialStore.EraseCallCount()).To(Equal(1))
+			Expect(fakeCredentialStore.EraseArgsForCall(0)).To(Equal(path + "#prod"))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+// Package credentials stores the CLI's OAuth tokens, and the client secret
+// given to cf auth --client-credentials, outside of config.json.
+//
+// A Store is selected with the CF_CREDENTIAL_STORE environment variable:
+//
+//   secret-service  the Linux secret service (via secret-tool)
+//   encrypted-file  a file encrypted with CF_CREDENTIAL_PASSPHRASE
+//   NAME            the credential helper binary cf-credential-NAME
+//   /path/to/bin    the credential helper binary at the given path
+//
+// When CF_CREDENTIAL_STORE is empty the tokens remain in config.json and the
+// client secret is not saved at all.
+package credentials
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// SecretServiceStoreName selects the SecretServiceStore.
+	SecretServiceStoreName = "secret-service"
+
+	// EncryptedFileStoreName selects the EncryptedFileStore.
+	EncryptedFileStoreName = "encrypted-file"
+
+	// EncryptedFileName is the name of the EncryptedFileStore's file inside the
+	// .cf directory.
+	EncryptedFileName = "credentials.enc"
+
+	// HelperPrefix is prepended to a credential helper name to find its binary
+	// on the PATH.
+	HelperPrefix = "cf-credential-"
+)
+
+// Credentials are the tokens the CLI authenticates with, and the client
+// secret it gets new tokens with when it authenticated as a client.
+type Credentials struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// IsEmpty returns true when neither token nor the client secret is set.
+func (c Credentials) IsEmpty() bool {
+	return c.AccessToken == "" && c.RefreshToken == "" && c.ClientSecret == ""
+}
+
+//go:generate counterfeiter . Store
+
+// Store saves credentials under a key. The CLI uses the path of the config
+// file as the key, so every CF_HOME gets its own credentials.
+type Store interface {
+	// Get returns the credentials saved under key. Empty credentials are
+	// returned when nothing has been saved.
+	Get(key string) (Credentials, error)
+
+	// Set saves credentials under key, replacing any already there.
+	Set(key string, creds Credentials) error
+
+	// Erase removes the credentials saved under key.
+	Erase(key string) error
+}
+
+// Save writes creds to store under key, erasing the key when creds are
+// empty. Nothing is written when creds equal saved, the credentials last read
+// from or written to key.
+func Save(store Store, key string, creds Credentials, saved Credentials) error {
+	if creds == saved {
+		return nil
+	}
+
+	if creds.IsEmpty() {
+		return store.Erase(key)
+	}
+	return store.Set(key, creds)
+}
+
+// StoreConfigurationError is returned when CF_CREDENTIAL_STORE names a store
+// that cannot be set up.
+type StoreConfigurationError struct {
+	Name   string
+	Reason string
+}
+
+func (e StoreConfigurationError) Error() string {
+	return fmt.Sprintf("Unable to use credential store %q: %s", e.Name, e.Reason)
+}
+
+// NewStore returns the store selected by name. configDir is the .cf
+// directory, used by stores that keep files next to config.json. A nil store
+// is returned for an empty name.
+func NewStore(name string, configDir string) (Store, error) {
+	switch name {
+	case "":
+		return nil, nil
+	case SecretServiceStoreName:
+		return NewSecretServiceStore(), nil
+	case EncryptedFileStoreName:
+		passphrase := os.Getenv("CF_CREDENTIAL_PASSPHRASE")
+		if passphrase == "" {
+			return nil, StoreConfigurationError{Name: name, Reason: "CF_CREDENTIAL_PASSPHRASE is not set"}
+		}
+		return NewEncryptedFileStore(filepath.Join(configDir, EncryptedFileName), passphrase), nil
+	}
+
+	if strings.ContainsRune(name, os.PathSeparator) || strings.ContainsRune(name, '/') {
+		return NewHelperStore(name), nil
+	}
+	return NewHelperStore(HelperPrefix + name), nil
+}
+
+// NewStoreFromEnv returns the store selected by CF_CREDENTIAL_STORE.
+func NewStoreFromEnv(configDir string) (Store, error) {
+	return NewStore(os.Getenv("CF_CREDENTIAL_STORE"), configDir)
+}
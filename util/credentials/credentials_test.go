@@ -0,0 +1,99 @@
+package credentials_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/util/credentials"
+	"code.cloudfoundry.org/cli/util/credentials/credentialsfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewStore", func() {
+	It("returns no store when no name is given", func() {
+		store, err := NewStore("", "/home/user/.cf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(BeNil())
+	})
+
+	It("returns the secret service store", func() {
+		store, err := NewStore("secret-service", "/home/user/.cf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(Equal(NewSecretServiceStore()))
+	})
+
+	Context("when the encrypted file store is selected", func() {
+		AfterEach(func() {
+			os.Unsetenv("CF_CREDENTIAL_PASSPHRASE")
+		})
+
+		It("uses the credentials file in the config directory", func() {
+			os.Setenv("CF_CREDENTIAL_PASSPHRASE", "open sesame")
+			store, err := NewStore("encrypted-file", "/home/user/.cf")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(store).To(Equal(NewEncryptedFileStore(filepath.Join("/home/user/.cf", "credentials.enc"), "open sesame")))
+		})
+
+		It("returns an error when no passphrase is set", func() {
+			_, err := NewStore("encrypted-file", "/home/user/.cf")
+			Expect(err).To(MatchError(StoreConfigurationError{Name: "encrypted-file", Reason: "CF_CREDENTIAL_PASSPHRASE is not set"}))
+		})
+	})
+
+	It("returns a helper store for any other name", func() {
+		store, err := NewStore("vault", "/home/user/.cf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(Equal(NewHelperStore("cf-credential-vault")))
+	})
+
+	It("uses a helper path as is", func() {
+		store, err := NewStore("/usr/local/bin/my-helper", "/home/user/.cf")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(store).To(Equal(NewHelperStore("/usr/local/bin/my-helper")))
+	})
+})
+
+var _ = Describe("Save", func() {
+	var store *credentialsfakes.FakeStore
+
+	BeforeEach(func() {
+		store = new(credentialsfakes.FakeStore)
+	})
+
+	It("sets changed credentials", func() {
+		creds := Credentials{AccessToken: "new-access-token", RefreshToken: "some-refresh-token"}
+		err := Save(store, "some-key", creds, Credentials{AccessToken: "old-access-token", RefreshToken: "some-refresh-token"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(store.SetCallCount()).To(Equal(1))
+		key, setCreds := store.SetArgsForCall(0)
+		Expect(key).To(Equal("some-key"))
+		Expect(setCreds).To(Equal(creds))
+	})
+
+	It("erases empty credentials", func() {
+		err := Save(store, "some-key", Credentials{}, Credentials{AccessToken: "some-access-token"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(store.SetCallCount()).To(Equal(0))
+		Expect(store.EraseCallCount()).To(Equal(1))
+		Expect(store.EraseArgsForCall(0)).To(Equal("some-key"))
+	})
+
+	It("does not write unchanged credentials", func() {
+		creds := Credentials{AccessToken: "some-access-token"}
+		err := Save(store, "some-key", creds, creds)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(store.SetCallCount()).To(Equal(0))
+		Expect(store.EraseCallCount()).To(Equal(0))
+	})
+
+	It("returns the error from the store", func() {
+		store.SetReturns(errors.New("some-error"))
+		err := Save(store, "some-key", Credentials{AccessToken: "some-access-token"}, Credentials{})
+		Expect(err).To(MatchError("some-error"))
+	})
+})
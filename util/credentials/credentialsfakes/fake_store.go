@@ -0,0 +1,166 @@
+// This file was generated by counterfeiter
+package credentialsfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/cli/util/credentials"
+)
+
+type FakeStore struct {
+	GetStub        func(key string) (credentials.Credentials, error)
+	getMutex       sync.RWMutex
+	getArgsForCall []struct {
+		key string
+	}
+	getReturns struct {
+		result1 credentials.Credentials
+		result2 error
+	}
+	SetStub        func(key string, creds credentials.Credentials) error
+	setMutex       sync.RWMutex
+	setArgsForCall []struct {
+		key   string
+		creds credentials.Credentials
+	}
+	setReturns struct {
+		result1 error
+	}
+	EraseStub        func(key string) error
+	eraseMutex       sync.RWMutex
+	eraseArgsForCall []struct {
+		key string
+	}
+	eraseReturns struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStore) Get(key string) (credentials.Credentials, error) {
+	fake.getMutex.Lock()
+	fake.getArgsForCall = append(fake.getArgsForCall, struct {
+		key string
+	}{key})
+	fake.recordInvocation("Get", []interface{}{key})
+	fake.getMutex.Unlock()
+	if fake.GetStub != nil {
+		return fake.GetStub(key)
+	} else {
+		return fake.getReturns.result1, fake.getReturns.result2
+	}
+}
+
+func (fake *FakeStore) GetCallCount() int {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return len(fake.getArgsForCall)
+}
+
+func (fake *FakeStore) GetArgsForCall(i int) string {
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	return fake.getArgsForCall[i].key
+}
+
+func (fake *FakeStore) GetReturns(result1 credentials.Credentials, result2 error) {
+	fake.GetStub = nil
+	fake.getReturns = struct {
+		result1 credentials.Credentials
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) Set(key string, creds credentials.Credentials) error {
+	fake.setMutex.Lock()
+	fake.setArgsForCall = append(fake.setArgsForCall, struct {
+		key   string
+		creds credentials.Credentials
+	}{key, creds})
+	fake.recordInvocation("Set", []interface{}{key, creds})
+	fake.setMutex.Unlock()
+	if fake.SetStub != nil {
+		return fake.SetStub(key, creds)
+	} else {
+		return fake.setReturns.result1
+	}
+}
+
+func (fake *FakeStore) SetCallCount() int {
+	fake.setMutex.RLock()
+	defer fake.setMutex.RUnlock()
+	return len(fake.setArgsForCall)
+}
+
+func (fake *FakeStore) SetArgsForCall(i int) (string, credentials.Credentials) {
+	fake.setMutex.RLock()
+	defer fake.setMutex.RUnlock()
+	return fake.setArgsForCall[i].key, fake.setArgsForCall[i].creds
+}
+
+func (fake *FakeStore) SetReturns(result1 error) {
+	fake.SetStub = nil
+	fake.setReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) Erase(key string) error {
+	fake.eraseMutex.Lock()
+	fake.eraseArgsForCall = append(fake.eraseArgsForCall, struct {
+		key string
+	}{key})
+	fake.recordInvocation("Erase", []interface{}{key})
+	fake.eraseMutex.Unlock()
+	if fake.EraseStub != nil {
+		return fake.EraseStub(key)
+	} else {
+		return fake.eraseReturns.result1
+	}
+}
+
+func (fake *FakeStore) EraseCallCount() int {
+	fake.eraseMutex.RLock()
+	defer fake.eraseMutex.RUnlock()
+	return len(fake.eraseArgsForCall)
+}
+
+func (fake *FakeStore) EraseArgsForCall(i int) string {
+	fake.eraseMutex.RLock()
+	defer fake.eraseMutex.RUnlock()
+	return fake.eraseArgsForCall[i].key
+}
+
+func (fake *FakeStore) EraseReturns(result1 error) {
+	fake.EraseStub = nil
+	fake.eraseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getMutex.RLock()
+	defer fake.getMutex.RUnlock()
+	fake.setMutex.RLock()
+	defer fake.setMutex.RUnlock()
+	fake.eraseMutex.RLock()
+	defer fake.eraseMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ credentials.Store = new(FakeStore)
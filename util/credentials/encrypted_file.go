@@ -0,0 +1,197 @@
+package credentials
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const (
+	encryptedFileVersion    = 1
+	encryptedFileIterations = 100000
+	encryptedFileKeyLength  = 32
+	encryptedFileSaltLength = 16
+)
+
+// ErrIncorrectPassphrase is returned when the encrypted credentials file
+// cannot be decrypted with the configured passphrase.
+var ErrIncorrectPassphrase = errors.New("Unable to decrypt the credentials file. Check that CF_CREDENTIAL_PASSPHRASE is correct.")
+
+// EncryptedFileStore keeps credentials in a file encrypted with AES-256-GCM.
+// The key is derived from a passphrase with PBKDF2-HMAC-SHA256 and a random
+// salt that is regenerated on every write.
+type EncryptedFileStore struct {
+	path       string
+	passphrase string
+}
+
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func NewEncryptedFileStore(path string, passphrase string) *EncryptedFileStore {
+	return &EncryptedFileStore{
+		path:       path,
+		passphrase: passphrase,
+	}
+}
+
+func (store *EncryptedFileStore) Get(key string) (Credentials, error) {
+	all, err := store.read()
+	if err != nil {
+		return Credentials{}, err
+	}
+	return all[key], nil
+}
+
+func (store *EncryptedFileStore) Set(key string, creds Credentials) error {
+	all, err := store.read()
+	if err != nil {
+		return err
+	}
+
+	all[key] = creds
+	return store.write(all)
+}
+
+func (store *EncryptedFileStore) Erase(key string) error {
+	all, err := store.read()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := all[key]; !ok {
+		return nil
+	}
+
+	delete(all, key)
+	return store.write(all)
+}
+
+func (store *EncryptedFileStore) read() (map[string]Credentials, error) {
+	all := map[string]Credentials{}
+
+	raw, err := ioutil.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file encryptedFile
+	err = json.Unmarshal(raw, &file)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(store.passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(file.Nonce) != gcm.NonceSize() {
+		return nil, ErrIncorrectPassphrase
+	}
+
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrIncorrectPassphrase
+	}
+
+	err = json.Unmarshal(plaintext, &all)
+	return all, err
+}
+
+func (store *EncryptedFileStore) write(all map[string]Credentials) error {
+	plaintext, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+
+	file := encryptedFile{
+		Version:    encryptedFileVersion,
+		Iterations: encryptedFileIterations,
+		Salt:       make([]byte, encryptedFileSaltLength),
+	}
+	_, err = io.ReadFull(rand.Reader, file.Salt)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := newGCM(store.passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return err
+	}
+
+	file.Nonce = make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, file.Nonce)
+	if err != nil {
+		return err
+	}
+	file.Ciphertext = gcm.Seal(nil, file.Nonce, plaintext, nil)
+
+	raw, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(store.path), 0700)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(store.path, raw, 0600)
+}
+
+func newGCM(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2SHA256([]byte(passphrase), salt, iterations, encryptedFileKeyLength))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2SHA256 derives a key as described in RFC 8018 section 5.2.
+func pbkdf2SHA256(password []byte, salt []byte, iterations int, keyLength int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLength := prf.Size()
+	blocks := (keyLength + hashLength - 1) / hashLength
+
+	key := make([]byte, 0, blocks*hashLength)
+	counter := make([]byte, 4)
+	for block := 1; block <= blocks; block++ {
+		binary.BigEndian.PutUint32(counter, uint32(block))
+
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(counter)
+		u := prf.Sum(nil)
+
+		t := make([]byte, len(u))
+		copy(t, u)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLength]
+}
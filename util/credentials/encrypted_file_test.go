@@ -0,0 +1,74 @@
+package credentials_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/util/credentials"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EncryptedFileStore", func() {
+	var (
+		dir   string
+		path  string
+		store *EncryptedFileStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "encrypted-file-store")
+		Expect(err).NotTo(HaveOccurred())
+		path = filepath.Join(dir, ".cf", "credentials.enc")
+		store = NewEncryptedFileStore(path, "open sesame")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("returns empty credentials when the file does not exist", func() {
+		creds, err := store.Get("some-key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds.IsEmpty()).To(BeTrue())
+	})
+
+	It("round trips credentials per key without storing them in plaintext", func() {
+		Expect(store.Set("key-1", Credentials{AccessToken: "bearer one", RefreshToken: "refresh-one"})).To(Succeed())
+		Expect(store.Set("key-2", Credentials{AccessToken: "bearer two"})).To(Succeed())
+
+		creds, err := NewEncryptedFileStore(path, "open sesame").Get("key-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds).To(Equal(Credentials{AccessToken: "bearer one", RefreshToken: "refresh-one"}))
+
+		raw, err := ioutil.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(raw)).NotTo(ContainSubstring("refresh-one"))
+
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("erases credentials", func() {
+		Expect(store.Set("key-1", Credentials{AccessToken: "bearer one"})).To(Succeed())
+		Expect(store.Erase("key-1")).To(Succeed())
+
+		creds, err := store.Get("key-1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds.IsEmpty()).To(BeTrue())
+	})
+
+	Context("when the passphrase is wrong", func() {
+		BeforeEach(func() {
+			Expect(store.Set("key-1", Credentials{AccessToken: "bearer one"})).To(Succeed())
+		})
+
+		It("returns ErrIncorrectPassphrase", func() {
+			_, err := NewEncryptedFileStore(path, "guess").Get("key-1")
+			Expect(err).To(Equal(ErrIncorrectPassphrase))
+		})
+	})
+})
@@ -0,0 +1,122 @@
+package credentials
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// HelperStore delegates to an external credential helper binary, in the
+// spirit of git credential helpers. The helper is invoked with one of the
+// actions "get", "store" or "erase" and reads lines of the form
+//
+//   key=<config file path>
+//   access_token=<token>
+//   refresh_token=<token>
+//   client_secret=<secret>
+//
+// from stdin, terminated by a blank line. Only "store" is sent the tokens,
+// and client_secret only when the CLI authenticated as a client. For "get"
+// the helper writes back the access_token, refresh_token and client_secret
+// lines it was sent to stdout, or nothing when it has no credentials for the
+// key.
+type HelperStore struct {
+	Command string
+}
+
+// HelperError is returned when a credential helper exits unsuccessfully.
+type HelperError struct {
+	Command string
+	Action  string
+	Stderr  string
+	Err     error
+}
+
+func (e HelperError) Error() string {
+	message := fmt.Sprintf("Credential helper %s %s failed: %s", e.Command, e.Action, e.Err.Error())
+	if e.Stderr != "" {
+		message = fmt.Sprintf("%s\n%s", message, e.Stderr)
+	}
+	return message
+}
+
+func NewHelperStore(command string) *HelperStore {
+	return &HelperStore{Command: command}
+}
+
+func (store *HelperStore) Get(key string) (Credentials, error) {
+	output, err := store.run("get", map[string]string{"key": key})
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	var creds Credentials
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		switch parts[0] {
+		case "access_token":
+			creds.AccessToken = parts[1]
+		case "refresh_token":
+			creds.RefreshToken = parts[1]
+		case "client_secret":
+			creds.ClientSecret = parts[1]
+		}
+	}
+	return creds, scanner.Err()
+}
+
+func (store *HelperStore) Set(key string, creds Credentials) error {
+	attributes := map[string]string{
+		"key":           key,
+		"access_token":  creds.AccessToken,
+		"refresh_token": creds.RefreshToken,
+	}
+	if creds.ClientSecret != "" {
+		attributes["client_secret"] = creds.ClientSecret
+	}
+	_, err := store.run("store", attributes)
+	return err
+}
+
+func (store *HelperStore) Erase(key string) error {
+	_, err := store.run("erase", map[string]string{"key": key})
+	return err
+}
+
+func (store *HelperStore) run(action string, attributes map[string]string) ([]byte, error) {
+	input := &bytes.Buffer{}
+	for _, name := range []string{"key", "access_token", "refresh_token", "client_secret"} {
+		if value, ok := attributes[name]; ok {
+			fmt.Fprintf(input, "%s=%s\n", name, value)
+		}
+	}
+	fmt.Fprintln(input)
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.Command(store.Command, action)
+	cmd.Stdin = input
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = os.Environ()
+
+	err := cmd.Run()
+	if err != nil {
+		return nil, HelperError{
+			Command: store.Command,
+			Action:  action,
+			Stderr:  strings.TrimSpace(stderr.String()),
+			Err:     err,
+		}
+	}
+	return stdout.Bytes(), nil
+}
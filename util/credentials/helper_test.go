@@ -0,0 +1,111 @@
+// +build !windows
+
+package credentials_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/util/credentials"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const fakeHelperScript = `#!/bin/sh
+input=$(cat)
+echo "$1" >> "$FAKE_HELPER_DIR/calls"
+echo "$input" > "$FAKE_HELPER_DIR/last-input"
+case "$1" in
+get)
+	cat "$FAKE_HELPER_DIR/stored" 2>/dev/null || true
+	;;
+store)
+	echo "$input" | grep "_token=\|client_secret=" > "$FAKE_HELPER_DIR/stored"
+	;;
+erase)
+	rm -f "$FAKE_HELPER_DIR/stored"
+	;;
+esac
+`
+
+var _ = Describe("HelperStore", func() {
+	var (
+		dir   string
+		store *HelperStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "helper-store")
+		Expect(err).NotTo(HaveOccurred())
+
+		helperPath := filepath.Join(dir, "cf-credential-fake")
+		err = ioutil.WriteFile(helperPath, []byte(fakeHelperScript), 0700)
+		Expect(err).NotTo(HaveOccurred())
+
+		os.Setenv("FAKE_HELPER_DIR", dir)
+		store = NewHelperStore(helperPath)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("FAKE_HELPER_DIR")
+		os.RemoveAll(dir)
+	})
+
+	It("stores and gets credentials through the helper", func() {
+		Expect(store.Set("/home/user/.cf/config.json", Credentials{AccessToken: "bearer token", RefreshToken: "refresh"})).To(Succeed())
+
+		input, err := ioutil.ReadFile(filepath.Join(dir, "last-input"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(input)).To(Equal("key=/home/user/.cf/config.json\naccess_token=bearer token\nrefresh_token=refresh\n"))
+
+		creds, err := store.Get("/home/user/.cf/config.json")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds).To(Equal(Credentials{AccessToken: "bearer token", RefreshToken: "refresh"}))
+
+		input, err = ioutil.ReadFile(filepath.Join(dir, "last-input"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(input)).To(Equal("key=/home/user/.cf/config.json\n"))
+	})
+
+	It("sends and gets the client secret when there is one", func() {
+		Expect(store.Set("key", Credentials{AccessToken: "bearer token", ClientSecret: "some-secret"})).To(Succeed())
+
+		input, err := ioutil.ReadFile(filepath.Join(dir, "last-input"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(input)).To(Equal("key=key\naccess_token=bearer token\nrefresh_token=\nclient_secret=some-secret\n"))
+
+		creds, err := store.Get("key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds).To(Equal(Credentials{AccessToken: "bearer token", ClientSecret: "some-secret"}))
+	})
+
+	It("erases credentials through the helper", func() {
+		Expect(store.Set("key", Credentials{AccessToken: "bearer token"})).To(Succeed())
+		Expect(store.Erase("key")).To(Succeed())
+
+		creds, err := store.Get("key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds.IsEmpty()).To(BeTrue())
+
+		calls, err := ioutil.ReadFile(filepath.Join(dir, "calls"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(calls)).To(Equal("store\nerase\nget\n"))
+	})
+
+	Context("when the helper fails", func() {
+		BeforeEach(func() {
+			err := ioutil.WriteFile(filepath.Join(dir, "failing-helper"), []byte("#!/bin/sh\necho 'vault is sealed' >&2\nexit 3\n"), 0700)
+			Expect(err).NotTo(HaveOccurred())
+			store = NewHelperStore(filepath.Join(dir, "failing-helper"))
+		})
+
+		It("returns a HelperError with the helper's stderr", func() {
+			_, err := store.Get("key")
+			Expect(err).To(BeAssignableToTypeOf(HelperError{}))
+			Expect(err.Error()).To(ContainSubstring("failing-helper get failed"))
+			Expect(err.Error()).To(ContainSubstring("vault is sealed"))
+		})
+	})
+})
@@ -0,0 +1,36 @@
+package credentials
+
+import (
+	"encoding/hex"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("pbkdf2SHA256", func() {
+	DescribeTable("derives the published PBKDF2-HMAC-SHA256 test vectors",
+		func(password string, salt string, iterations int, expectedKey string) {
+			key := pbkdf2SHA256([]byte(password), []byte(salt), iterations, len(expectedKey)/2)
+			Expect(hex.EncodeToString(key)).To(Equal(expectedKey))
+		},
+
+		// RFC 7914 section 11
+		Entry("passwd, 1 iteration", "passwd", "salt", 1,
+			"55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783"),
+		Entry("Password, 80000 iterations", "Password", "NaCl", 80000,
+			"4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d"),
+
+		// The RFC 6070 inputs with HMAC-SHA256
+		Entry("1 iteration", "password", "salt", 1,
+			"120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"),
+		Entry("2 iterations", "password", "salt", 2,
+			"ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"),
+		Entry("4096 iterations", "password", "salt", 4096,
+			"c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"),
+		Entry("a key longer than the hash", "passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096,
+			"348c89dbcbd32b2f32d814b8116e84cf2b17347ebc1800181c4e2a1fb8dd53e1c635518c7dac47e9"),
+		Entry("NUL bytes and a truncated key", "pass\x00word", "sa\x00lt", 4096,
+			"89b69d0516f829893c696226650a8687"),
+	)
+})
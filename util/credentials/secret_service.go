@@ -0,0 +1,104 @@
+package credentials
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const (
+	secretServiceApplication = "cf-cli"
+	secretServiceLabel       = "Cloud Foundry CLI credentials"
+)
+
+// SecretServiceStore keeps credentials in the freedesktop.org secret service
+// (GNOME Keyring, KWallet, KeePassXC, ...) over D-Bus using the secret-tool
+// command from libsecret. Both tokens are kept in a single secret so they are
+// unlocked together.
+type SecretServiceStore struct {
+	Command string
+}
+
+func NewSecretServiceStore() *SecretServiceStore {
+	return &SecretServiceStore{Command: "secret-tool"}
+}
+
+func (store *SecretServiceStore) Get(key string) (Credentials, error) {
+	output, err := store.run(nil, append([]string{"lookup"}, store.attributes(key)...)...)
+	if err != nil {
+		// secret-tool exits 1 without output when there is no matching secret.
+		if exitErr, ok := err.(HelperError); ok && exitErr.Stderr == "" {
+			if _, isExit := exitErr.Err.(*exec.ExitError); isExit {
+				return Credentials{}, nil
+			}
+		}
+		return Credentials{}, err
+	}
+
+	return decodeSecret(output), nil
+}
+
+func (store *SecretServiceStore) Set(key string, creds Credentials) error {
+	args := append([]string{"store", "--label", secretServiceLabel}, store.attributes(key)...)
+	_, err := store.run(encodeSecret(creds), args...)
+	return err
+}
+
+func (store *SecretServiceStore) Erase(key string) error {
+	_, err := store.run(nil, append([]string{"clear"}, store.attributes(key)...)...)
+	return err
+}
+
+func (store *SecretServiceStore) attributes(key string) []string {
+	return []string{"application", secretServiceApplication, "config", key}
+}
+
+func (store *SecretServiceStore) run(stdin []byte, args ...string) ([]byte, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.Command(store.Command, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if execErr, ok := err.(*exec.Error); ok && execErr.Err == exec.ErrNotFound {
+		return nil, StoreConfigurationError{
+			Name:   SecretServiceStoreName,
+			Reason: fmt.Sprintf("the %s command was not found; install libsecret (the libsecret-tools package on Debian and Ubuntu) or choose another store", store.Command),
+		}
+	}
+	if err != nil {
+		return nil, HelperError{
+			Command: store.Command,
+			Action:  args[0],
+			Stderr:  strings.TrimSpace(stderr.String()),
+			Err:     err,
+		}
+	}
+	return stdout.Bytes(), nil
+}
+
+// The secret is stored as "<access token>\n<refresh token>", followed by
+// "\n<client secret>" when there is one. None of them can contain a newline.
+func encodeSecret(creds Credentials) []byte {
+	secret := creds.AccessToken + "\n" + creds.RefreshToken
+	if creds.ClientSecret != "" {
+		secret += "\n" + creds.ClientSecret
+	}
+	return []byte(secret)
+}
+
+func decodeSecret(secret []byte) Credentials {
+	parts := strings.SplitN(strings.TrimRight(string(secret), "\n"), "\n", 3)
+	creds := Credentials{AccessToken: parts[0]}
+	if len(parts) > 1 {
+		creds.RefreshToken = parts[1]
+	}
+	if len(parts) > 2 {
+		creds.ClientSecret = parts[2]
+	}
+	return creds
+}
@@ -0,0 +1,94 @@
+// +build !windows
+
+package credentials_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/util/credentials"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const fakeSecretToolScript = `#!/bin/sh
+echo "$@" >> "$FAKE_SECRET_TOOL_DIR/calls"
+case "$1" in
+lookup)
+	[ -f "$FAKE_SECRET_TOOL_DIR/secret" ] || exit 1
+	cat "$FAKE_SECRET_TOOL_DIR/secret"
+	;;
+store)
+	cat > "$FAKE_SECRET_TOOL_DIR/secret"
+	;;
+clear)
+	rm -f "$FAKE_SECRET_TOOL_DIR/secret"
+	;;
+esac
+`
+
+var _ = Describe("SecretServiceStore", func() {
+	var (
+		dir   string
+		store *SecretServiceStore
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "secret-service-store")
+		Expect(err).NotTo(HaveOccurred())
+
+		secretToolPath := filepath.Join(dir, "secret-tool")
+		err = ioutil.WriteFile(secretToolPath, []byte(fakeSecretToolScript), 0700)
+		Expect(err).NotTo(HaveOccurred())
+
+		os.Setenv("FAKE_SECRET_TOOL_DIR", dir)
+		store = &SecretServiceStore{Command: secretToolPath}
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("FAKE_SECRET_TOOL_DIR")
+		os.RemoveAll(dir)
+	})
+
+	It("explains that secret-tool is needed when it is not installed", func() {
+		store = &SecretServiceStore{Command: "cf-test-missing-secret-tool"}
+
+		_, err := store.Get("some-key")
+		Expect(err).To(BeAssignableToTypeOf(StoreConfigurationError{}))
+		Expect(err.Error()).To(ContainSubstring("the cf-test-missing-secret-tool command was not found"))
+	})
+
+	It("returns empty credentials when there is no secret", func() {
+		creds, err := store.Get("key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds.IsEmpty()).To(BeTrue())
+	})
+
+	It("stores, looks up and clears the secret", func() {
+		Expect(store.Set("key", Credentials{AccessToken: "bearer token", RefreshToken: "refresh"})).To(Succeed())
+
+		creds, err := store.Get("key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds).To(Equal(Credentials{AccessToken: "bearer token", RefreshToken: "refresh"}))
+
+		Expect(store.Erase("key")).To(Succeed())
+
+		calls, err := ioutil.ReadFile(filepath.Join(dir, "calls"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(calls)).To(Equal(
+			"store --label Cloud Foundry CLI credentials application cf-cli config key\n" +
+				"lookup application cf-cli config key\n" +
+				"clear application cf-cli config key\n",
+		))
+	})
+
+	It("stores and looks up the client secret with the tokens", func() {
+		Expect(store.Set("key", Credentials{AccessToken: "bearer token", ClientSecret: "some-secret"})).To(Succeed())
+
+		creds, err := store.Get("key")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(creds).To(Equal(Credentials{AccessToken: "bearer token", ClientSecret: "some-secret"}))
+	})
+})
@@ -1,6 +1,7 @@
 package downloader
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,12 +20,16 @@ type downloader struct {
 	saveDir    string
 	filename   string
 	downloaded bool
+	tlsConfig  *tls.Config
 }
 
-func NewDownloader(saveDir string) Downloader {
+// NewDownloader returns a Downloader that saves files into saveDir. If
+// tlsConfig is nil the default TLS configuration is used.
+func NewDownloader(saveDir string, tlsConfig *tls.Config) Downloader {
 	return &downloader{
 		saveDir:    saveDir,
 		downloaded: false,
+		tlsConfig:  tlsConfig,
 	}
 }
 
@@ -41,6 +46,10 @@ func (d *downloader) DownloadFile(url string) (int64, string, error) {
 
 			return nil
 		},
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: d.tlsConfig,
+		},
 	}
 
 	r, err := c.Get(url)
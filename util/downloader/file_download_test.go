@@ -24,7 +24,7 @@ var _ = Describe("Downloader", func() {
 		var err error
 		tempDir, err = ioutil.TempDir("", "file-download-test")
 		Expect(err).NotTo(HaveOccurred())
-		d = downloader.NewDownloader(tempDir)
+		d = downloader.NewDownloader(tempDir, nil)
 	})
 
 	AfterEach(func() {
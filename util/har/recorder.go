@@ -0,0 +1,329 @@
+package har
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Version is the HAR specification version written by Recorder.
+const Version = "1.2"
+
+// RedactedValue replaces headers and bodies that may contain secrets.
+const RedactedValue = "[PRIVATE DATA HIDDEN]"
+
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+// redactedFields are the form and query fields redacted in addition to the
+// ones sanitizeJSON redacts: client secrets, and the authorization codes and
+// PKCE verifiers that are exchanged for tokens.
+var redactedFields = regexp.MustCompile(`(?i)^(?:.*secret.*|code|code_verifier)$`)
+
+// SanitizeJSONFunc parses a JSON object and redacts its secrets.
+type SanitizeJSONFunc func(raw []byte) (map[string]interface{}, error)
+
+// Recorder appends entries to a HAR file. The first entry recorded to a file
+// by the process replaces whatever the file held before; every entry after it
+// is written over the closing brackets of the archive, so the file is a valid
+// archive after each request without being read back.
+type Recorder struct {
+	path         string
+	creator      Creator
+	sanitizeJSON SanitizeJSONFunc
+
+	file *archiveFile
+}
+
+// archiveFile is a HAR file being recorded to by this process. Recorders for
+// the same path share one, since the legacy commands and plugin RPC calls
+// create their own recorders alongside the ones of the command being run.
+type archiveFile struct {
+	path    string
+	started bool
+	offset  int64
+
+	mutex sync.Mutex
+}
+
+// archiveTrailer closes the entries, the log and the archive.
+const archiveTrailer = "\n]}}\n"
+
+var (
+	archiveFilesLock sync.Mutex
+	archiveFiles     = map[string]*archiveFile{}
+)
+
+// NewRecorder returns a Recorder that writes to path. Request and response
+// bodies are redacted with sanitizeJSON; bodies that are neither JSON nor
+// form encoded are replaced with RedactedValue.
+func NewRecorder(path string, creator Creator, sanitizeJSON SanitizeJSONFunc) *Recorder {
+	archiveFilesLock.Lock()
+	defer archiveFilesLock.Unlock()
+
+	file, ok := archiveFiles[path]
+	if !ok {
+		file = &archiveFile{path: path}
+		archiveFiles[path] = file
+	}
+
+	return &Recorder{
+		path:         path,
+		creator:      creator,
+		sanitizeJSON: sanitizeJSON,
+		file:         file,
+	}
+}
+
+// Path returns the path of the HAR file.
+func (recorder *Recorder) Path() string {
+	return recorder.path
+}
+
+// Record adds the request and its response to the HAR file. The bodies are
+// passed separately because they have usually been consumed by the time the
+// response is received. started is when the request was sent.
+func (recorder *Recorder) Record(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte, started time.Time) error {
+	entry, err := json.Marshal(recorder.newEntry(request, requestBody, response, responseBody, started, time.Now()))
+	if err != nil {
+		return err
+	}
+
+	recorder.file.mutex.Lock()
+	defer recorder.file.mutex.Unlock()
+
+	return recorder.file.append(recorder.creator, entry)
+}
+
+func (file *archiveFile) append(creator Creator, entry []byte) error {
+	var (
+		data []byte
+		flag = os.O_WRONLY
+	)
+
+	if file.started {
+		data = []byte(",\n")
+	} else {
+		err := os.MkdirAll(filepath.Dir(file.path), os.ModeDir|os.ModePerm)
+		if err != nil {
+			return err
+		}
+
+		data, err = archiveHeader(creator)
+		if err != nil {
+			return err
+		}
+		flag |= os.O_CREATE | os.O_TRUNC
+	}
+	data = append(data, entry...)
+
+	f, err := os.OpenFile(file.path, flag, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteAt(append(data, archiveTrailer...), file.offset)
+	if err != nil {
+		return err
+	}
+
+	file.started = true
+	file.offset += int64(len(data))
+	return f.Close()
+}
+
+// archiveHeader opens the archive, the log and its entries.
+func archiveHeader(creator Creator) ([]byte, error) {
+	version, err := json.Marshal(Version)
+	if err != nil {
+		return nil, err
+	}
+	creatorJSON, err := json.Marshal(creator)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(`{"log":{"version":` + string(version) + `,"creator":` + string(creatorJSON) + `,"entries":[` + "\n"), nil
+}
+
+func (recorder *Recorder) newEntry(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte, started time.Time, finished time.Time) Entry {
+	elapsed := milliseconds(finished.Sub(started))
+
+	requestURL := *request.URL
+	query, redacted := recorder.redactValues(requestURL.Query())
+	if redacted {
+		requestURL.RawQuery = query.Encode()
+	}
+
+	entry := Entry{
+		StartedDateTime: started.Format("2006-01-02T15:04:05.000Z07:00"),
+		Time:            elapsed,
+		Request: Request{
+			Method:      request.Method,
+			URL:         requestURL.String(),
+			HTTPVersion: request.Proto,
+			Cookies:     []NameValue{},
+			Headers:     headers(request.Header),
+			QueryString: queryString(query),
+			HeadersSize: -1,
+			BodySize:    len(requestBody),
+		},
+		Timings: Timings{
+			Wait: elapsed,
+		},
+	}
+	if entry.Request.HTTPVersion == "" {
+		entry.Request.HTTPVersion = "HTTP/1.1"
+	}
+
+	if len(requestBody) > 0 {
+		mimeType := request.Header.Get("Content-Type")
+		entry.Request.PostData = &PostData{
+			MimeType: mimeType,
+			Text:     recorder.redactBody(mimeType, requestBody),
+		}
+	}
+
+	if response != nil {
+		mimeType := response.Header.Get("Content-Type")
+		entry.Response = Response{
+			Status:      response.StatusCode,
+			StatusText:  http.StatusText(response.StatusCode),
+			HTTPVersion: response.Proto,
+			Cookies:     []NameValue{},
+			Headers:     headers(response.Header),
+			Content: Content{
+				Size:     len(responseBody),
+				MimeType: mimeType,
+			},
+			RedirectURL: response.Header.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    len(responseBody),
+		}
+		if len(responseBody) > 0 {
+			entry.Response.Content.Text = recorder.redactBody(mimeType, responseBody)
+		}
+	}
+
+	return entry
+}
+
+func (recorder *Recorder) redactBody(contentType string, body []byte) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case strings.Contains(mediaType, "json"):
+		sanitized, err := recorder.sanitizeJSON(body)
+		if err != nil {
+			return RedactedValue
+		}
+		return encodeJSON(sanitized)
+	case mediaType == "application/x-www-form-urlencoded":
+		return recorder.redactForm(body)
+	default:
+		return RedactedValue
+	}
+}
+
+// redactForm applies the rules of redactValues to a form encoded body.
+func (recorder *Recorder) redactForm(body []byte) string {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return RedactedValue
+	}
+
+	redacted, _ := recorder.redactValues(values)
+	return redacted.Encode()
+}
+
+// redactValues redacts the form or query fields matching redactedFields and
+// the ones sanitizeJSON redacts when they are converted to a JSON object. It
+// returns whether any value was redacted.
+func (recorder *Recorder) redactValues(values url.Values) (url.Values, bool) {
+	fields := map[string]interface{}{}
+	for key := range values {
+		fields[key] = values.Get(key)
+	}
+
+	var sanitized map[string]interface{}
+	raw, err := json.Marshal(fields)
+	if err == nil {
+		sanitized, err = recorder.sanitizeJSON(raw)
+	}
+
+	redacted := url.Values{}
+	anyRedacted := false
+	for key, keyValues := range values {
+		hide := err != nil || redactedFields.MatchString(key) || sanitized[key] != fields[key]
+		for _, value := range keyValues {
+			if hide {
+				value = RedactedValue
+				anyRedacted = true
+			}
+			redacted.Add(key, value)
+		}
+	}
+	return redacted, anyRedacted
+}
+
+func encodeJSON(value interface{}) string {
+	buffer := new(bytes.Buffer)
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(value)
+	if err != nil {
+		return RedactedValue
+	}
+	return strings.TrimSpace(buffer.String())
+}
+
+func headers(header http.Header) []NameValue {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := []NameValue{}
+	for _, key := range keys {
+		for _, value := range header[key] {
+			if redactedHeaders[http.CanonicalHeaderKey(key)] {
+				value = RedactedValue
+			}
+			values = append(values, NameValue{Name: key, Value: value})
+		}
+	}
+	return values
+}
+
+func queryString(query url.Values) []NameValue {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := []NameValue{}
+	for _, key := range keys {
+		for _, value := range query[key] {
+			values = append(values, NameValue{Name: key, Value: value})
+		}
+	}
+	return values
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
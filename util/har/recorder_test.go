@@ -0,0 +1,179 @@
+package har_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "code.cloudfoundry.org/cli/util/har"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Recorder", func() {
+	var (
+		tempDir  string
+		harPath  string
+		recorder *Recorder
+
+		request  *http.Request
+		response *http.Response
+	)
+
+	sanitizeJSON := func(raw []byte) (map[string]interface{}, error) {
+		var result map[string]interface{}
+		err := json.Unmarshal(raw, &result)
+		if err != nil {
+			return nil, err
+		}
+		for key := range result {
+			if key == "password" || key == "access_token" {
+				result[key] = "[PRIVATE DATA HIDDEN]"
+			}
+		}
+		return result, nil
+	}
+
+	readArchive := func() Archive {
+		raw, err := ioutil.ReadFile(harPath)
+		Expect(err).ToNot(HaveOccurred())
+
+		var archive Archive
+		Expect(json.Unmarshal(raw, &archive)).To(Succeed())
+		return archive
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = ioutil.TempDir("", "har-recorder")
+		Expect(err).ToNot(HaveOccurred())
+		harPath = filepath.Join(tempDir, "traces", "cf.har")
+
+		recorder = NewRecorder(harPath, Creator{Name: "cf", Version: "1.2.3"}, sanitizeJSON)
+
+		request, err = http.NewRequest(http.MethodPost, "https://uaa.example.com/oauth/token?scope=openid", bytes.NewBufferString("unused"))
+		Expect(err).ToNot(HaveOccurred())
+		request.Header.Set("Authorization", "Basic Y2Y6")
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		response = &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Proto:      "HTTP/1.1",
+			Header: http.Header{
+				"Content-Type": {"application/json;charset=UTF-8"},
+				"Set-Cookie":   {"JSESSIONID=secret"},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tempDir)).To(Succeed())
+	})
+
+	It("writes a HAR archive with the request and response", func() {
+		started := time.Now().Add(-50 * time.Millisecond)
+		err := recorder.Record(request, []byte("grant_type=password&password=hunter2&username=admin"), response, []byte(`{"access_token":"secret","token_type":"bearer"}`), started)
+		Expect(err).ToNot(HaveOccurred())
+
+		archive := readArchive()
+		Expect(archive.Log.Version).To(Equal("1.2"))
+		Expect(archive.Log.Creator).To(Equal(Creator{Name: "cf", Version: "1.2.3"}))
+		Expect(archive.Log.Entries).To(HaveLen(1))
+
+		entry := archive.Log.Entries[0]
+		Expect(entry.Time).To(BeNumerically(">=", 50))
+		Expect(entry.Request.Method).To(Equal(http.MethodPost))
+		Expect(entry.Request.URL).To(Equal("https://uaa.example.com/oauth/token?scope=openid"))
+		Expect(entry.Request.QueryString).To(ConsistOf(NameValue{Name: "scope", Value: "openid"}))
+		Expect(entry.Request.Headers).To(ContainElement(NameValue{Name: "Authorization", Value: RedactedValue}))
+		Expect(entry.Request.PostData.Text).To(Equal("grant_type=password&password=%5BPRIVATE+DATA+HIDDEN%5D&username=admin"))
+
+		Expect(entry.Response.Status).To(Equal(http.StatusOK))
+		Expect(entry.Response.StatusText).To(Equal("OK"))
+		Expect(entry.Response.Headers).To(ContainElement(NameValue{Name: "Set-Cookie", Value: RedactedValue}))
+		Expect(entry.Response.Content.MimeType).To(Equal("application/json;charset=UTF-8"))
+		Expect(entry.Response.Content.Text).To(MatchJSON(`{"access_token":"[PRIVATE DATA HIDDEN]","token_type":"bearer"}`))
+	})
+
+	It("hides client secrets in forms", func() {
+		err := recorder.Record(request, []byte("client_id=ci&client_secret=s3cr3t&grant_type=client_credentials"), response, nil, time.Now())
+		Expect(err).ToNot(HaveOccurred())
+
+		entry := readArchive().Log.Entries[0]
+		Expect(entry.Request.PostData.Text).To(Equal("client_id=ci&client_secret=%5BPRIVATE+DATA+HIDDEN%5D&grant_type=client_credentials"))
+	})
+
+	It("hides authorization codes and verifiers in forms and query strings", func() {
+		var err error
+		request, err = http.NewRequest(http.MethodPost, "https://uaa.example.com/oauth/token?code=abc123&state=xyz", nil)
+		Expect(err).ToNot(HaveOccurred())
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		err = recorder.Record(request, []byte("code=abc123&code_verifier=v3r1f13r&grant_type=authorization_code&secret=s3cr3t"), response, nil, time.Now())
+		Expect(err).ToNot(HaveOccurred())
+
+		entry := readArchive().Log.Entries[0]
+		Expect(entry.Request.URL).To(Equal("https://uaa.example.com/oauth/token?code=%5BPRIVATE+DATA+HIDDEN%5D&state=xyz"))
+		Expect(entry.Request.QueryString).To(ConsistOf(
+			NameValue{Name: "code", Value: RedactedValue},
+			NameValue{Name: "state", Value: "xyz"},
+		))
+		Expect(entry.Request.PostData.Text).To(Equal("code=%5BPRIVATE+DATA+HIDDEN%5D&code_verifier=%5BPRIVATE+DATA+HIDDEN%5D&grant_type=authorization_code&secret=%5BPRIVATE+DATA+HIDDEN%5D"))
+	})
+
+	It("hides query values that sanitizeJSON would hide", func() {
+		var err error
+		request, err = http.NewRequest(http.MethodGet, "https://api.example.com/v2/info?access_token=abc", nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = recorder.Record(request, nil, response, nil, time.Now())
+		Expect(err).ToNot(HaveOccurred())
+
+		entry := readArchive().Log.Entries[0]
+		Expect(entry.Request.URL).ToNot(ContainSubstring("abc"))
+		Expect(entry.Request.QueryString).To(ConsistOf(NameValue{Name: "access_token", Value: RedactedValue}))
+	})
+
+	It("hides bodies that are neither JSON nor form encoded", func() {
+		request.Header.Set("Content-Type", "application/zip")
+		err := recorder.Record(request, []byte("PK..."), response, nil, time.Now())
+		Expect(err).ToNot(HaveOccurred())
+
+		entry := readArchive().Log.Entries[0]
+		Expect(entry.Request.PostData.Text).To(Equal(RedactedValue))
+		Expect(entry.Response.Content.Text).To(BeEmpty())
+	})
+
+	It("appends to an existing archive", func() {
+		Expect(recorder.Record(request, nil, response, nil, time.Now())).To(Succeed())
+		Expect(recorder.Record(request, nil, response, nil, time.Now())).To(Succeed())
+
+		Expect(readArchive().Log.Entries).To(HaveLen(2))
+	})
+
+	It("appends the entries of every recorder for the same file", func() {
+		otherRecorder := NewRecorder(harPath, Creator{Name: "cf", Version: "1.2.3"}, sanitizeJSON)
+		Expect(recorder.Record(request, nil, response, nil, time.Now())).To(Succeed())
+		Expect(otherRecorder.Record(request, nil, response, nil, time.Now())).To(Succeed())
+		Expect(recorder.Record(request, nil, response, nil, time.Now())).To(Succeed())
+
+		Expect(readArchive().Log.Entries).To(HaveLen(3))
+	})
+
+	Context("when the file was written before", func() {
+		BeforeEach(func() {
+			Expect(os.MkdirAll(filepath.Dir(harPath), 0700)).To(Succeed())
+			Expect(ioutil.WriteFile(harPath, []byte("REQUEST: [2017-03-01T12:00:00Z]"), 0600)).To(Succeed())
+		})
+
+		It("replaces it with a new archive", func() {
+			Expect(recorder.Record(request, nil, response, nil, time.Now())).To(Succeed())
+			Expect(readArchive().Log.Entries).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,256 @@
+// Package httpcache stores API responses on disk so that they can be reused
+// until they expire and revalidated with conditional requests afterwards.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TTLEnvVar is the environment variable that enables the cache. Its value is
+// how long responses are reused without revalidation, as a duration such as
+// "30s" or a whole number of seconds.
+const TTLEnvVar = "CF_CACHE_TTL"
+
+// ParseTTL parses the value of TTLEnvVar. Invalid and negative values
+// disable the cache by returning 0.
+func ParseTTL(value string) time.Duration {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if ttl, err := time.ParseDuration(value); err == nil && ttl > 0 {
+		return ttl
+	}
+	return 0
+}
+
+// cacheablePaths are the lists that are read often and change rarely. Only
+// these are stored: anything that is polled, such as jobs, instances and
+// packages, must always be fetched, and responses with credentials, such as
+// app environments and service keys, must never be written to disk. A "*"
+// segment matches any GUID.
+var cacheablePaths = []string{
+	"/v2/buildpacks",
+	"/v2/domains",
+	"/v2/organizations",
+	"/v2/organizations/*/private_domains",
+	"/v2/private_domains",
+	"/v2/service_plan_visibilities",
+	"/v2/service_plans",
+	"/v2/services",
+	"/v2/services/*/service_plans",
+	"/v2/shared_domains",
+	"/v2/spaces/*/services",
+	"/v2/stacks",
+	"/v3/organizations",
+}
+
+// Cacheable returns true if the response to request may be stored.
+func Cacheable(request *http.Request) bool {
+	if request.Method != http.MethodGet || request.Header.Get("Range") != "" {
+		return false
+	}
+
+	path := strings.Split(strings.TrimSuffix(request.URL.Path, "/"), "/")
+	for _, cacheablePath := range cacheablePaths {
+		if matchPath(strings.Split(cacheablePath, "/"), path) {
+			return true
+		}
+	}
+	return false
+}
+
+// Invalidates returns true if request may change resources, in which case no
+// stored response can be trusted anymore.
+func Invalidates(request *http.Request) bool {
+	return request.Method != http.MethodGet && request.Method != http.MethodHead
+}
+
+func matchPath(pattern []string, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Entry is a stored response.
+type Entry struct {
+	URL        string      `json:"url"`
+	StoredAt   time.Time   `json:"stored_at"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// NewEntry returns an entry for a response to url and its body.
+func NewEntry(url string, response *http.Response, body []byte, now time.Time) Entry {
+	return Entry{
+		URL:        url,
+		StoredAt:   now,
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Body:       body,
+	}
+}
+
+// Fresh returns true if the entry can be used without revalidation.
+func (entry Entry) Fresh(ttl time.Duration, now time.Time) bool {
+	return now.Sub(entry.StoredAt) < ttl
+}
+
+// SetConditionalHeaders adds the headers that ask the server to respond with
+// 304 Not Modified if the entry is still valid.
+func (entry Entry) SetConditionalHeaders(header http.Header) {
+	if etag := entry.Header.Get("ETag"); etag != "" {
+		header.Set("If-None-Match", etag)
+	}
+	if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+		header.Set("If-Modified-Since", lastModified)
+	}
+}
+
+// Response returns the entry as an HTTP response.
+func (entry Entry) Response() *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(entry.StatusCode) + " " + http.StatusText(entry.StatusCode),
+		StatusCode:    entry.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.Header,
+		Body:          ioutil.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+	}
+}
+
+// Cache stores entries in a directory that is specific to an API target and
+// the identity used to access it, so that responses are never shared between
+// targets or users.
+type Cache struct {
+	dir      string
+	identity string
+	ttl      time.Duration
+}
+
+// New returns a Cache in a subdirectory of baseDir for target and identity,
+// as returned by Identity.
+func New(baseDir string, target string, identity string, ttl time.Duration) *Cache {
+	return &Cache{
+		dir:      filepath.Join(baseDir, hash(target+"\n"+identity)),
+		identity: identity,
+		ttl:      ttl,
+	}
+}
+
+// Identity returns who the access token was issued to: the user_id claim for
+// user tokens and the client_id claim for client tokens. It stays the same
+// when the token is refreshed. The token is not verified. An empty string is
+// returned when the token has neither claim, in which case the cache must not
+// be used.
+func Identity(accessToken string) string {
+	if fields := strings.Fields(accessToken); len(fields) == 2 && strings.EqualFold(fields[0], "bearer") {
+		accessToken = fields[1]
+	}
+
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+
+	encoded := strings.TrimRight(parts[1], "=")
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		payload, err = base64.RawStdEncoding.DecodeString(encoded)
+		if err != nil {
+			return ""
+		}
+	}
+
+	var claims struct {
+		UserID   string `json:"user_id"`
+		ClientID string `json:"client_id"`
+	}
+	err = json.Unmarshal(payload, &claims)
+	switch {
+	case err != nil:
+		return ""
+	case claims.UserID != "":
+		return "user:" + claims.UserID
+	case claims.ClientID != "":
+		return "client:" + claims.ClientID
+	default:
+		return ""
+	}
+}
+
+// Identity returns the identity the cache was created for.
+func (cache *Cache) Identity() string {
+	return cache.identity
+}
+
+// TTL returns how long entries are used without revalidation.
+func (cache *Cache) TTL() time.Duration {
+	return cache.ttl
+}
+
+// Get returns the entry for url, if there is one.
+func (cache *Cache) Get(url string) (Entry, bool) {
+	raw, err := ioutil.ReadFile(cache.path(url))
+	if err != nil {
+		return Entry{}, false
+	}
+
+	var entry Entry
+	err = json.Unmarshal(raw, &entry)
+	if err != nil || entry.URL != url {
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+// Put stores the entry, replacing any previous entry for its URL.
+func (cache *Cache) Put(entry Entry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(cache.dir, 0700)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(cache.path(entry.URL), raw, 0600)
+}
+
+// Clear removes every entry. It is called whenever a request could have
+// changed the resources that are cached.
+func (cache *Cache) Clear() error {
+	return os.RemoveAll(cache.dir)
+}
+
+func (cache *Cache) path(url string) string {
+	return filepath.Join(cache.dir, hash(url)+".json")
+}
+
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
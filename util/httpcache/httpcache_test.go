@@ -0,0 +1,194 @@
+package httpcache_test
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	. "code.cloudfoundry.org/cli/util/httpcache"
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("httpcache", func() {
+	table.DescribeTable("ParseTTL",
+		func(value string, expected time.Duration) {
+			Expect(ParseTTL(value)).To(Equal(expected))
+		},
+
+		table.Entry("unset", "", time.Duration(0)),
+		table.Entry("seconds", "30", 30*time.Second),
+		table.Entry("duration", "2m", 2*time.Minute),
+		table.Entry("negative", "-5", time.Duration(0)),
+		table.Entry("invalid", "forever", time.Duration(0)),
+	)
+
+	Describe("Cacheable", func() {
+		It("only allows GET requests without a range", func() {
+			get, err := http.NewRequest(http.MethodGet, "https://api.example.com/v2/stacks", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(Cacheable(get)).To(BeTrue())
+
+			get.Header.Set("Range", "bytes=0-10")
+			Expect(Cacheable(get)).To(BeFalse())
+
+			post, err := http.NewRequest(http.MethodPost, "https://api.example.com/v2/stacks", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(Cacheable(post)).To(BeFalse())
+		})
+
+		table.DescribeTable("only allows the lists that rarely change",
+			func(url string, cacheable bool) {
+				request, err := http.NewRequest(http.MethodGet, url, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(Cacheable(request)).To(Equal(cacheable))
+			},
+			table.Entry("services", "https://api.example.com/v2/services?results-per-page=100", true),
+			table.Entry("plans of a service", "https://api.example.com/v2/services/some-guid/service_plans", true),
+			table.Entry("services of a space", "https://api.example.com/v2/spaces/some-guid/services", true),
+			table.Entry("private domains of an org", "https://api.example.com/v2/organizations/some-guid/private_domains", true),
+			table.Entry("orgs", "https://api.example.com/v2/organizations?q=name:some-org", true),
+			table.Entry("a job", "https://api.example.com/v2/jobs/some-guid", false),
+			table.Entry("app instances", "https://api.example.com/v2/apps/some-guid/instances", false),
+			table.Entry("an app environment", "https://api.example.com/v2/apps/some-guid/env", false),
+			table.Entry("service keys", "https://api.example.com/v2/service_keys", false),
+			table.Entry("service keys of an instance", "https://api.example.com/v2/service_instances/some-guid/service_keys", false),
+			table.Entry("a package", "https://api.example.com/v3/packages/some-guid", false),
+			table.Entry("a droplet", "https://api.example.com/v3/droplets/some-guid", false),
+		)
+	})
+
+	Describe("Invalidates", func() {
+		It("is true for requests that may change resources", func() {
+			for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+				request, err := http.NewRequest(method, "https://api.example.com/v2/apps", nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(Invalidates(request)).To(BeTrue())
+			}
+
+			request, err := http.NewRequest(http.MethodGet, "https://api.example.com/v2/jobs/some-guid", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(Invalidates(request)).To(BeFalse())
+		})
+	})
+
+	Describe("Entry", func() {
+		var (
+			now   time.Time
+			entry Entry
+		)
+
+		BeforeEach(func() {
+			now = time.Date(2017, time.March, 1, 12, 0, 0, 0, time.UTC)
+			entry = NewEntry("https://api.example.com/v2/stacks", &http.Response{
+				StatusCode: http.StatusOK,
+				Header: http.Header{
+					"Etag":          {`"some-etag"`},
+					"Last-Modified": {"Wed, 01 Mar 2017 11:00:00 GMT"},
+				},
+			}, []byte(`{"resources":[]}`), now)
+		})
+
+		It("is fresh until the TTL has passed", func() {
+			Expect(entry.Fresh(time.Minute, now.Add(59*time.Second))).To(BeTrue())
+			Expect(entry.Fresh(time.Minute, now.Add(time.Minute))).To(BeFalse())
+		})
+
+		It("sets the conditional request headers", func() {
+			header := http.Header{}
+			entry.SetConditionalHeaders(header)
+			Expect(header.Get("If-None-Match")).To(Equal(`"some-etag"`))
+			Expect(header.Get("If-Modified-Since")).To(Equal("Wed, 01 Mar 2017 11:00:00 GMT"))
+		})
+
+		It("converts back into a response", func() {
+			response := entry.Response()
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+			Expect(response.Status).To(Equal("200 OK"))
+			body, err := ioutil.ReadAll(response.Body)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(body)).To(Equal(`{"resources":[]}`))
+		})
+	})
+
+	Describe("Identity", func() {
+		encodeToken := func(claims string) string {
+			return "bearer header." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+		}
+
+		It("returns the user of user tokens", func() {
+			Expect(Identity(encodeToken(`{"user_id":"some-user-guid","client_id":"cf"}`))).To(Equal("user:some-user-guid"))
+		})
+
+		It("returns the client of client tokens", func() {
+			Expect(Identity(encodeToken(`{"client_id":"some-client"}`))).To(Equal("client:some-client"))
+		})
+
+		It("returns nothing when the token identifies no one", func() {
+			Expect(Identity(encodeToken(`{"scope":["openid"]}`))).To(BeEmpty())
+			Expect(Identity("bearer not-a-jwt")).To(BeEmpty())
+			Expect(Identity("")).To(BeEmpty())
+		})
+	})
+
+	Describe("Cache", func() {
+		var (
+			baseDir string
+			cache   *Cache
+			entry   Entry
+		)
+
+		BeforeEach(func() {
+			var err error
+			baseDir, err = ioutil.TempDir("", "httpcache")
+			Expect(err).ToNot(HaveOccurred())
+
+			cache = New(baseDir, "https://api.example.com", "user:some-user-guid", time.Minute)
+			entry = Entry{
+				URL:        "https://api.example.com/v2/stacks",
+				StoredAt:   time.Now(),
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Etag": {`"some-etag"`}},
+				Body:       []byte(`{"resources":[]}`),
+			}
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(baseDir)).To(Succeed())
+		})
+
+		It("returns stored entries", func() {
+			Expect(cache.Put(entry)).To(Succeed())
+
+			stored, ok := cache.Get(entry.URL)
+			Expect(ok).To(BeTrue())
+			Expect(stored.Body).To(Equal(entry.Body))
+			Expect(stored.Header).To(Equal(entry.Header))
+		})
+
+		It("returns false for missing entries", func() {
+			_, ok := cache.Get(entry.URL)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("isolates entries by target and identity", func() {
+			Expect(cache.Put(entry)).To(Succeed())
+
+			_, ok := New(baseDir, "https://api.other.com", "user:some-user-guid", time.Minute).Get(entry.URL)
+			Expect(ok).To(BeFalse())
+			_, ok = New(baseDir, "https://api.example.com", "user:other-user-guid", time.Minute).Get(entry.URL)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("removes every entry on Clear", func() {
+			Expect(cache.Put(entry)).To(Succeed())
+			Expect(cache.Clear()).To(Succeed())
+
+			_, ok := cache.Get(entry.URL)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package pluginreposerver_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestPluginRepoServer(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Plugin Repo Server Suite")
+}
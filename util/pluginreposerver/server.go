@@ -0,0 +1,249 @@
+// Package pluginreposerver serves a directory of plugin binaries as a plugin
+// repository that the CLI can add with add-plugin-repo.
+//
+// Binaries are indexed by file name, which must have the form
+// NAME_VERSION_PLATFORM, optionally followed by .exe. PLATFORM is either a
+// repository platform (osx, linux32, linux64, win32 or win64) or a Go
+// GOOS_GOARCH pair such as linux_amd64. Signature files, ending in .sig, are
+// served next to the binaries they sign.
+package pluginreposerver
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
+)
+
+const (
+	binariesPath    = "/binaries/"
+	signatureSuffix = ".sig"
+)
+
+// platformAliases maps file name platform suffixes to repository platforms.
+var platformAliases = []struct {
+	suffix   string
+	platform string
+}{
+	{"darwin_amd64", "osx"},
+	{"linux_386", "linux32"},
+	{"linux_amd64", "linux64"},
+	{"windows_386", "win32"},
+	{"windows_amd64", "win64"},
+	{"osx", "osx"},
+	{"linux32", "linux32"},
+	{"linux64", "linux64"},
+	{"win32", "win32"},
+	{"win64", "win64"},
+}
+
+// Server serves the plugin binaries in Dir. It answers /list with the
+// repository's plugins and /binaries/FILE with the binaries in that list and
+// their signatures; no other file in Dir is served. Dir is indexed again on
+// every request, so binaries can be added while the server runs.
+type Server struct {
+	Dir string
+}
+
+// NewServer returns a Server for the plugin binaries in dir.
+func NewServer(dir string) *Server {
+	return &Server{Dir: dir}
+}
+
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/list":
+		server.serveList(w, r)
+	case strings.HasPrefix(r.URL.Path, binariesPath):
+		server.serveBinary(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// Index returns the latest version of each plugin in Dir, sorted by name,
+// with binary URLs under baseURL. It also returns the names of the files it
+// could not index.
+func (server *Server) Index(baseURL string) ([]clipr.Plugin, []string, error) {
+	plugins, skipped, err := server.index()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range plugins {
+		for j := range plugins[i].Binaries {
+			binary := &plugins[i].Binaries[j]
+			checksum, err := fileSha256(filepath.Join(server.Dir, binary.Url))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			binary.Url = strings.TrimRight(baseURL, "/") + binariesPath + binary.Url
+			binary.Checksum = checksum
+		}
+	}
+
+	return plugins, skipped, nil
+}
+
+// index is Index without the checksums. The URL of each binary is its file
+// name.
+func (server *Server) index() ([]clipr.Plugin, []string, error) {
+	files, err := ioutil.ReadDir(server.Dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plugins := map[string]*clipr.Plugin{}
+	versions := map[string]semver.Version{}
+	skipped := []string{}
+
+	for _, file := range files {
+		if !file.Mode().IsRegular() || strings.HasPrefix(file.Name(), ".") || strings.HasSuffix(file.Name(), signatureSuffix) {
+			continue
+		}
+
+		name, version, platform, ok := parseFileName(file.Name())
+		if !ok {
+			skipped = append(skipped, file.Name())
+			continue
+		}
+
+		if current, found := versions[name]; found && version.LT(current) {
+			continue
+		} else if !found || version.GT(current) {
+			versions[name] = version
+			plugins[name] = &clipr.Plugin{
+				Name:    name,
+				Version: version.String(),
+				Created: file.ModTime(),
+			}
+		}
+
+		plugin := plugins[name]
+		if file.ModTime().After(plugin.Updated) {
+			plugin.Updated = file.ModTime()
+		}
+		if file.ModTime().Before(plugin.Created) {
+			plugin.Created = file.ModTime()
+		}
+		plugin.Binaries = append(plugin.Binaries, clipr.Binary{
+			Platform: platform,
+			Url:      file.Name(),
+		})
+	}
+
+	names := []string{}
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := []clipr.Plugin{}
+	for _, name := range names {
+		list = append(list, *plugins[name])
+	}
+
+	return list, skipped, nil
+}
+
+// serveBinary serves a binary listed in the index, or its signature.
+func (server *Server) serveBinary(w http.ResponseWriter, r *http.Request) {
+	fileName := strings.TrimPrefix(r.URL.Path, binariesPath)
+	binaryName := strings.TrimSuffix(fileName, signatureSuffix)
+
+	plugins, _, err := server.index()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for _, plugin := range plugins {
+		for _, binary := range plugin.Binaries {
+			if binary.Url == binaryName {
+				server.serveFile(w, r, fileName)
+				return
+			}
+		}
+	}
+	http.NotFound(w, r)
+}
+
+func (server *Server) serveFile(w http.ResponseWriter, r *http.Request, fileName string) {
+	path := filepath.Join(server.Dir, fileName)
+	info, err := os.Lstat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		http.NotFound(w, r)
+		return
+	}
+
+	http.ServeFile(w, r, path)
+}
+
+func (server *Server) serveList(w http.ResponseWriter, r *http.Request) {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	plugins, _, err := server.Index(fmt.Sprintf("%s://%s", scheme, r.Host))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(clipr.PluginsJson{Plugins: plugins})
+}
+
+func parseFileName(fileName string) (string, semver.Version, string, bool) {
+	base := strings.TrimSuffix(fileName, ".exe")
+
+	platform := ""
+	for _, alias := range platformAliases {
+		if strings.HasSuffix(base, "_"+alias.suffix) {
+			platform = alias.platform
+			base = strings.TrimSuffix(base, "_"+alias.suffix)
+			break
+		}
+	}
+	if platform == "" {
+		return "", semver.Version{}, "", false
+	}
+
+	separator := strings.LastIndex(base, "_")
+	if separator <= 0 {
+		return "", semver.Version{}, "", false
+	}
+
+	version, err := semver.ParseTolerant(base[separator+1:])
+	if err != nil {
+		return "", semver.Version{}, "", false
+	}
+
+	return base[:separator], version, platform, true
+}
+
+func fileSha256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
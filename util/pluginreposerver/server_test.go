@@ -0,0 +1,144 @@
+package pluginreposerver_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "code.cloudfoundry.org/cli/util/pluginreposerver"
+	clipr "github.com/cloudfoundry-incubator/cli-plugin-repo/web"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		dir    string
+		server *Server
+	)
+
+	writeFile := func(name string, contents string) {
+		Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0700)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "plugin-repo")
+		Expect(err).ToNot(HaveOccurred())
+
+		writeFile("echo_1.0.0_linux64", "echo linux 1.0.0")
+		writeFile("echo_1.1.0_linux64", "echo linux")
+		writeFile("echo_1.1.0_osx", "echo osx")
+		writeFile("echo_1.1.0_windows_amd64.exe", "echo windows")
+		writeFile("echo_1.1.0_linux64.sig", "signature")
+		writeFile("my_plugin_v2.0_darwin_amd64", "my plugin")
+		writeFile("README.md", "not a plugin")
+		writeFile(".hidden", "not a plugin")
+
+		server = NewServer(dir)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Describe("Index", func() {
+		It("lists the latest version of each plugin with a binary per platform", func() {
+			plugins, skipped, err := server.Index("http://repo.example.com/")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(skipped).To(Equal([]string{"README.md"}))
+
+			Expect(plugins).To(HaveLen(2))
+			Expect(plugins[0].Name).To(Equal("echo"))
+			Expect(plugins[0].Version).To(Equal("1.1.0"))
+			Expect(plugins[0].Binaries).To(ConsistOf(
+				clipr.Binary{Platform: "linux64", Url: "http://repo.example.com/binaries/echo_1.1.0_linux64", Checksum: "b23e2f66bc2859270fadedf6bbe346f3359156503b1c20f04ecdf9620e970df5"},
+				clipr.Binary{Platform: "osx", Url: "http://repo.example.com/binaries/echo_1.1.0_osx", Checksum: "7457b47ca925666336c848e3315661377482c3583287f650c506b96e5c95d385"},
+				clipr.Binary{Platform: "win64", Url: "http://repo.example.com/binaries/echo_1.1.0_windows_amd64.exe", Checksum: "1f3ce3c58f7940b09616b63d24aabce89149c5b65c62e41e6cc4c3d091e9fd78"},
+			))
+
+			Expect(plugins[1].Name).To(Equal("my_plugin"))
+			Expect(plugins[1].Version).To(Equal("2.0.0"))
+			Expect(plugins[1].Binaries).To(HaveLen(1))
+			Expect(plugins[1].Binaries[0].Platform).To(Equal("osx"))
+		})
+
+		It("returns an error when the directory cannot be read", func() {
+			server = NewServer(filepath.Join(dir, "missing"))
+
+			_, _, err := server.Index("http://repo.example.com")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ServeHTTP", func() {
+		var testServer *httptest.Server
+
+		BeforeEach(func() {
+			testServer = httptest.NewServer(server)
+		})
+
+		AfterEach(func() {
+			testServer.Close()
+		})
+
+		It("serves the plugin list in the plugin repository format", func() {
+			response, err := http.Get(testServer.URL + "/list")
+			Expect(err).ToNot(HaveOccurred())
+			defer response.Body.Close()
+			Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+			var list clipr.PluginsJson
+			Expect(json.NewDecoder(response.Body).Decode(&list)).To(Succeed())
+			Expect(list.Plugins).To(HaveLen(2))
+			Expect(list.Plugins[0].Binaries[0].Url).To(HavePrefix(testServer.URL + "/binaries/"))
+		})
+
+		It("serves the binaries and their signatures", func() {
+			for file, contents := range map[string]string{
+				"echo_1.1.0_linux64":     "echo linux",
+				"echo_1.1.0_linux64.sig": "signature",
+			} {
+				response, err := http.Get(testServer.URL + "/binaries/" + file)
+				Expect(err).ToNot(HaveOccurred())
+				body, err := ioutil.ReadAll(response.Body)
+				response.Body.Close()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(body)).To(Equal(contents))
+			}
+		})
+
+		It("does not serve files that are not in the index", func() {
+			Expect(os.Mkdir(filepath.Join(dir, "subdir"), 0700)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "subdir", "echo_1.1.0_linux64"), []byte("nested"), 0600)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(dir, "echo_1.1.0_osx.sig"), 0700)).To(Succeed())
+
+			for _, file := range []string{
+				"",
+				"README.md",
+				".hidden",
+				"echo_1.0.0_linux64",
+				"echo_1.0.0_linux64.sig",
+				"subdir/",
+				"subdir/echo_1.1.0_linux64",
+				"echo_1.1.0_osx.sig",
+			} {
+				response, err := http.Get(testServer.URL + "/binaries/" + file)
+				Expect(err).ToNot(HaveOccurred())
+				response.Body.Close()
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound), file)
+			}
+		})
+
+		It("does not serve files outside the directory", func() {
+			response, err := http.Get(testServer.URL + "/binaries/../../etc/passwd")
+			Expect(err).ToNot(HaveOccurred())
+			response.Body.Close()
+			Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+		})
+	})
+})
@@ -0,0 +1,134 @@
+// Package tlsconfig loads the CA bundle and client certificate used to
+// secure every connection the CLI makes: the Cloud Controller, UAA, routing
+// API, Doppler and plugin downloads.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+)
+
+const (
+	// CACertEnvVar overrides the configured CA bundle.
+	CACertEnvVar = "CF_CA_CERT"
+	// ClientCertEnvVar overrides the configured client certificate.
+	ClientCertEnvVar = "CF_CLIENT_CERT"
+	// ClientKeyEnvVar overrides the configured client key.
+	ClientKeyEnvVar = "CF_CLIENT_KEY"
+)
+
+// Files are the paths of the PEM encoded files to load. Empty paths are
+// ignored.
+type Files struct {
+	CACert     string
+	ClientCert string
+	ClientKey  string
+}
+
+// Certificates are the loaded contents of Files.
+type Certificates struct {
+	// RootCAs contains the system roots and the CA bundle. It is nil when no
+	// CA bundle is configured.
+	RootCAs *x509.CertPool
+
+	// ClientCertificates are presented to servers that ask for a client
+	// certificate.
+	ClientCertificates []tls.Certificate
+}
+
+// FileError is returned when a certificate file cannot be read or parsed.
+type FileError struct {
+	Path string
+	Err  error
+}
+
+func (e FileError) Error() string {
+	return "Unable to load certificate file " + e.Path + ": " + e.Err.Error()
+}
+
+// NoCertificatesError is returned when a CA bundle contains no PEM encoded
+// certificates.
+type NoCertificatesError struct {
+	Path string
+}
+
+func (e NoCertificatesError) Error() string {
+	return "No PEM encoded certificates found in " + e.Path
+}
+
+// IncompleteClientCertificateError is returned when only one of the client
+// certificate and key is provided.
+type IncompleteClientCertificateError struct{}
+
+func (e IncompleteClientCertificateError) Error() string {
+	return "A client certificate and key must be provided together"
+}
+
+// Load reads the configured files. CF_CA_CERT, CF_CLIENT_CERT and
+// CF_CLIENT_KEY take precedence over the configured paths.
+func Load(configured Files) (Certificates, error) {
+	files := Files{
+		CACert:     override(configured.CACert, CACertEnvVar),
+		ClientCert: override(configured.ClientCert, ClientCertEnvVar),
+		ClientKey:  override(configured.ClientKey, ClientKeyEnvVar),
+	}
+
+	var certificates Certificates
+
+	if files.CACert != "" {
+		pool, err := loadCertPool(files.CACert)
+		if err != nil {
+			return Certificates{}, err
+		}
+		certificates.RootCAs = pool
+	}
+
+	if files.ClientCert != "" || files.ClientKey != "" {
+		if files.ClientCert == "" || files.ClientKey == "" {
+			return Certificates{}, IncompleteClientCertificateError{}
+		}
+
+		cert, err := tls.LoadX509KeyPair(files.ClientCert, files.ClientKey)
+		if err != nil {
+			return Certificates{}, FileError{Path: files.ClientCert, Err: err}
+		}
+		certificates.ClientCertificates = []tls.Certificate{cert}
+	}
+
+	return certificates, nil
+}
+
+// TLSConfig returns a new tls.Config that uses the certificates.
+func (certificates Certificates) TLSConfig(skipSSLValidation bool) *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: skipSSLValidation,
+		RootCAs:            certificates.RootCAs,
+		Certificates:       certificates.ClientCertificates,
+	}
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, FileError{Path: path, Err: err}
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(raw) {
+		return nil, NoCertificatesError{Path: path}
+	}
+	return pool, nil
+}
+
+func override(configured string, envVar string) string {
+	if value := os.Getenv(envVar); value != "" {
+		return value
+	}
+	return configured
+}
@@ -0,0 +1,134 @@
+package tlsconfig_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "code.cloudfoundry.org/cli/util/tlsconfig"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Load", func() {
+	var (
+		dir      string
+		certPath string
+		keyPath  string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "tlsconfig")
+		Expect(err).ToNot(HaveOccurred())
+
+		certPath = filepath.Join(dir, "cert.pem")
+		keyPath = filepath.Join(dir, "key.pem")
+		writeCertificate(certPath, keyPath)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv(CACertEnvVar)
+		os.Unsetenv(ClientCertEnvVar)
+		os.Unsetenv(ClientKeyEnvVar)
+		os.RemoveAll(dir)
+	})
+
+	Context("when no files are configured", func() {
+		It("returns no certificates", func() {
+			certificates, err := Load(Files{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificates).To(Equal(Certificates{}))
+
+			config := certificates.TLSConfig(true)
+			Expect(config.InsecureSkipVerify).To(BeTrue())
+			Expect(config.RootCAs).To(BeNil())
+		})
+	})
+
+	Context("when a CA bundle is configured", func() {
+		It("adds it to the root CAs", func() {
+			certificates, err := Load(Files{CACert: certPath})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificates.RootCAs).ToNot(BeNil())
+			Expect(certificates.TLSConfig(false).RootCAs).To(Equal(certificates.RootCAs))
+		})
+
+		It("returns an error when the file does not exist", func() {
+			_, err := Load(Files{CACert: filepath.Join(dir, "missing.pem")})
+			Expect(err).To(BeAssignableToTypeOf(FileError{}))
+		})
+
+		It("returns an error when the file contains no certificates", func() {
+			_, err := Load(Files{CACert: keyPath})
+			Expect(err).To(MatchError(NoCertificatesError{Path: keyPath}))
+		})
+	})
+
+	Context("when a client certificate is configured", func() {
+		It("loads the key pair", func() {
+			certificates, err := Load(Files{ClientCert: certPath, ClientKey: keyPath})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificates.ClientCertificates).To(HaveLen(1))
+			Expect(certificates.TLSConfig(false).Certificates).To(HaveLen(1))
+		})
+
+		It("returns an error when the key is missing", func() {
+			_, err := Load(Files{ClientCert: certPath})
+			Expect(err).To(MatchError(IncompleteClientCertificateError{}))
+		})
+
+		It("returns an error when the key does not match", func() {
+			_, err := Load(Files{ClientCert: certPath, ClientKey: certPath})
+			Expect(err).To(BeAssignableToTypeOf(FileError{}))
+		})
+	})
+
+	Context("when the environment overrides the configured files", func() {
+		BeforeEach(func() {
+			os.Setenv(CACertEnvVar, certPath)
+			os.Setenv(ClientCertEnvVar, certPath)
+			os.Setenv(ClientKeyEnvVar, keyPath)
+		})
+
+		It("loads the files from the environment", func() {
+			certificates, err := Load(Files{CACert: filepath.Join(dir, "missing.pem")})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(certificates.RootCAs).ToNot(BeNil())
+			Expect(certificates.ClientCertificates).To(HaveLen(1))
+		})
+	})
+})
+
+func writeCertificate(certPath string, keyPath string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "some-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	Expect(err).ToNot(HaveOccurred())
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = ioutil.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	Expect(err).ToNot(HaveOccurred())
+	err = ioutil.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600)
+	Expect(err).ToNot(HaveOccurred())
+}
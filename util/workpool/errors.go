@@ -0,0 +1,21 @@
+package workpool
+
+import "fmt"
+
+// Failure is a job that failed, identified by its position in the list passed
+// to Run.
+type Failure struct {
+	Index int
+	Err   error
+}
+
+// PartialFailureError is returned when some of the jobs passed to Run failed.
+// The other jobs completed.
+type PartialFailureError struct {
+	Total    int
+	Failures []Failure
+}
+
+func (e PartialFailureError) Error() string {
+	return fmt.Sprintf("%d of %d operations failed: %s", len(e.Failures), e.Total, e.Failures[0].Err)
+}
@@ -0,0 +1,94 @@
+// Package workpool runs independent API operations concurrently with a bounded
+// number of workers, keeping their results in the order they were queued.
+package workpool
+
+import "sync"
+
+// DefaultWorkers is the number of jobs run at the same time when no worker
+// count is configured.
+const DefaultWorkers = 4
+
+// Job is a single operation. It returns the warnings from the API and whether
+// the operation failed.
+type Job func() ([]string, error)
+
+// Result is the outcome of a Job.
+type Result struct {
+	Warnings []string
+	Err      error
+}
+
+// Results are the outcomes of a list of jobs, in the order the jobs were
+// passed to Run.
+type Results []Result
+
+// Run runs jobs with at most workers of them at the same time and waits for
+// all of them to finish. A failing job does not stop the others. A worker
+// count below 1 runs the jobs one at a time.
+func Run(workers int, jobs []Job) Results {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	results := make(Results, len(jobs))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				warnings, err := jobs[index]()
+				results[index] = Result{Warnings: warnings, Err: err}
+			}
+		}()
+	}
+
+	for index := range jobs {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// Warnings returns the warnings of every job, in job order.
+func (results Results) Warnings() []string {
+	var warnings []string
+	for _, result := range results {
+		warnings = append(warnings, result.Warnings...)
+	}
+	return warnings
+}
+
+// FirstError returns the error of the first failed job, or nil if all jobs
+// succeeded.
+func (results Results) FirstError() error {
+	for _, result := range results {
+		if result.Err != nil {
+			return result.Err
+		}
+	}
+	return nil
+}
+
+// Err returns a PartialFailureError listing every failed job, or nil if all
+// jobs succeeded.
+func (results Results) Err() error {
+	var failures []Failure
+	for index, result := range results {
+		if result.Err != nil {
+			failures = append(failures, Failure{Index: index, Err: result.Err})
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil
+	}
+	return PartialFailureError{Total: len(results), Failures: failures}
+}
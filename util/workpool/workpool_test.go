@@ -0,0 +1,108 @@
+package workpool_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	. "code.cloudfoundry.org/cli/util/workpool"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Run", func() {
+	var jobs []Job
+
+	BeforeEach(func() {
+		jobs = nil
+		for i := 0; i < 10; i++ {
+			index := i
+			jobs = append(jobs, func() ([]string, error) {
+				// later jobs finish first to make sure results stay in order
+				time.Sleep(time.Duration(10-index) * time.Millisecond)
+				return []string{fmt.Sprintf("warning-%d", index)}, nil
+			})
+		}
+	})
+
+	It("returns the results in job order", func() {
+		results := Run(4, jobs)
+		Expect(results).To(HaveLen(10))
+		for i, result := range results {
+			Expect(result.Warnings).To(Equal([]string{fmt.Sprintf("warning-%d", i)}))
+			Expect(result.Err).ToNot(HaveOccurred())
+		}
+		Expect(results.Warnings()).To(HaveLen(10))
+		Expect(results.Warnings()[0]).To(Equal("warning-0"))
+		Expect(results.Err()).ToNot(HaveOccurred())
+		Expect(results.FirstError()).ToNot(HaveOccurred())
+	})
+
+	It("runs at most the given number of jobs at the same time", func() {
+		var (
+			mutex      sync.Mutex
+			running    int
+			maxRunning int
+		)
+		for i := range jobs {
+			jobs[i] = func() ([]string, error) {
+				mutex.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mutex.Unlock()
+
+				time.Sleep(5 * time.Millisecond)
+
+				mutex.Lock()
+				running--
+				mutex.Unlock()
+				return nil, nil
+			}
+		}
+
+		Run(3, jobs)
+		Expect(maxRunning).To(BeNumerically(">", 1))
+		Expect(maxRunning).To(BeNumerically("<=", 3))
+	})
+
+	Context("when the worker count is less than 1", func() {
+		It("runs the jobs one at a time", func() {
+			results := Run(0, jobs)
+			Expect(results.Warnings()).To(HaveLen(10))
+		})
+	})
+
+	Context("when there are no jobs", func() {
+		It("returns no results", func() {
+			Expect(Run(4, nil)).To(BeEmpty())
+		})
+	})
+
+	Context("when some jobs fail", func() {
+		BeforeEach(func() {
+			jobs[2] = func() ([]string, error) {
+				return []string{"warning-2"}, errors.New("job-2-error")
+			}
+			jobs[7] = func() ([]string, error) {
+				return nil, errors.New("job-7-error")
+			}
+		})
+
+		It("runs the remaining jobs and reports every failure", func() {
+			results := Run(4, jobs)
+			Expect(results.Warnings()).To(HaveLen(9))
+			Expect(results.FirstError()).To(MatchError("job-2-error"))
+
+			err := results.Err()
+			Expect(err).To(MatchError("2 of 10 operations failed: job-2-error"))
+			Expect(err.(PartialFailureError).Failures).To(Equal([]Failure{
+				{Index: 2, Err: errors.New("job-2-error")},
+				{Index: 7, Err: errors.New("job-7-error")},
+			}))
+		})
+	})
+})